@@ -0,0 +1,68 @@
+package ledger
+
+import "fmt"
+
+// AccountType is the kind of account in a chart of accounts, which decides its normal balance side
+type AccountType string
+
+const (
+	AccountAsset     AccountType = "asset"
+	AccountLiability AccountType = "liability"
+	AccountEquity    AccountType = "equity"
+	AccountIncome    AccountType = "income"
+	AccountExpense   AccountType = "expense"
+)
+
+// DefaultAccountType is the type of a wallet that hasn't been given an account, customer funds the ledger holds are owed back to them
+const DefaultAccountType = AccountLiability
+
+// NormalSide returns the side postings that increase the account's balance go on, TransactionDebit or TransactionCredit,
+// or an empty string if the type isn't a known one
+func (t AccountType) NormalSide() string {
+	switch t {
+	case AccountAsset, AccountExpense:
+		return TransactionDebit
+	case AccountLiability, AccountEquity, AccountIncome:
+		return TransactionCredit
+	default:
+		return ""
+	}
+}
+
+// Account is an entry in the chart of accounts, the wallet with the same code is posted to it
+type Account struct {
+	Code   string      `json:"code"`
+	Name   string      `json:"name"`
+	Type   AccountType `json:"type"`
+	Parent string      `json:"parent,omitempty"` // code of the account this one rolls up into, which must be of the same type
+}
+
+// Validate returns an error if the account has no code or isn't of a known type
+func (a Account) Validate() error {
+	if a.Code == "" {
+		return fmt.Errorf("account needs a code")
+	}
+
+	if a.Type.NormalSide() == "" {
+		return fmt.Errorf("account '%s' has unknown type '%s'", a.Code, a.Type)
+	}
+
+	if a.Parent == a.Code {
+		return fmt.Errorf("account '%s' can't be its own parent", a.Code)
+	}
+
+	return nil
+}
+
+// Side returns the side of an account a transaction of the type is posted to, TransactionDebit or TransactionCredit,
+// cash in is posted as a credit and cash out as a debit, holds and releases aren't posted to either side so they return an empty string
+func Side(transactionType string) string {
+	switch transactionType {
+	case TransactionCredit, TransactionCashIn:
+		return TransactionCredit
+	case TransactionDebit, TransactionCashOut:
+		return TransactionDebit
+	default:
+		return ""
+	}
+}
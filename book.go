@@ -1,21 +1,78 @@
 package ledger
 
-import ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+import (
+	"time"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
 
 const (
 	TransactionCredit  = "credit"
 	TransactionDebit   = "debit"
 	TransactionCashIn  = "cash in"
 	TransactionCashOut = "cash out"
+	// holds set funds aside in a wallet until they're released, they change its available balance but not its current balance
+	TransactionHold    = "hold"
+	TransactionRelease = "release"
 )
 
+// EffectiveDateLayout is the layout of a transaction's effective date, the calendar day in UTC it takes effect on
+const EffectiveDateLayout = "2006-01-02"
+
+// Book is a ledger of transactions against wallets
+// every command takes an optional idempotency key, a command retried with the same key returns the result of the first attempt
+// instead of running again, and a key reused for a different command fails with an IdempotencyConflictError
+// commands that take funds out of a wallet fail with an InsufficientFundsError when its overdraft policy doesn't cover them
+// commands posting to a frozen or closed wallet fail with a WalletStatusError, and ones that would take a wallet
+// past its limits fail with a LimitExceededError
+// transactions, transfers, deposits and withdrawals with an unknown type, an amount that isn't positive or a missing ID
+// fail with a ValidationError before anything is looked up
+// transfers and withdrawals are charged the fees of their fee schedule, posted in the same aggregate as the funds they move
+// wallets with an interest policy earn interest on their daily balance, posted once each of its periods ends
 type Book interface {
-	TransferWalletFunds(source string, destination string, amount int32) (string, error)
-	DepositWalletFunds(wallet string, deposit int32) (string, error)
-	WithdrawWalletFunds(wallet string, withdraw int32) (string, error)
-	AddTransaction(transactionType string, wallet string, amount int32, aggregate string) error
+	OpenWallet(wallet Wallet, idempotencyKey string) (string, error)
+	SetWalletStatus(wallet string, status WalletStatus, idempotencyKey string) error
+	TransferWalletFunds(source string, destination string, amount Money, idempotencyKey string) (string, error)
+	ExchangeWalletFunds(source string, destination string, amount Money, currency string, idempotencyKey string) (string, error)
+	Post(entries []Entry, idempotencyKey string) (string, error)
+	DepositWalletFunds(wallet string, deposit Money, idempotencyKey string) (string, error)
+	WithdrawWalletFunds(wallet string, withdraw Money, idempotencyKey string) (string, error)
+	ReverseAggregate(aggregate string, reason string, idempotencyKey string) (string, error)
+	RefundAggregate(aggregate string, amount Money, idempotencyKey string) (string, error)
+	AuthorizeHold(wallet string, amount Money, expiresAt time.Time, idempotencyKey string) (string, error)
+	CaptureHold(hold string, destination string, amount Money, idempotencyKey string) (string, error)
+	VoidHold(hold string, idempotencyKey string) (string, error)
+	SetOverdraftPolicy(wallet string, policy OverdraftPolicy, idempotencyKey string) error
+	OverdraftPolicy(wallet string) OverdraftPolicy
+	SetWalletLimits(wallet string, limits Limits, idempotencyKey string) error
+	SetTierLimits(tier string, limits Limits, idempotencyKey string) error
+	SetFeeSchedule(schedule FeeSchedule, idempotencyKey string) error
+	QuoteFee(command FeeCommand, wallet string, amount Money) (FeeQuote, error)
+	SetInterestPolicy(wallet string, policy InterestPolicy, idempotencyKey string) error
+	AccrueInterest(through time.Time, idempotencyKey string) (string, error)
+	AddTransaction(transactionType string, wallet string, amount Money, aggregate string, idempotencyKey string) error
+	DefineAccount(account Account, idempotencyKey string) error
 	Transactions() []ledgerpb.Transaction
-	WalletBalance(wallet string) (int32, error)
+	Wallet(wallet string) (Wallet, error)
+	Accounts() []Account
+	WalletAccountType(wallet string) AccountType
+	AccountBalance(code string) ([]Money, error)
+	WalletBalance(wallet string) ([]Balance, error)
+	WalletBalanceAt(wallet string, at Point) ([]Money, error)
 	WalletTransactions(wallet string) ([]*ledgerpb.Transaction, error)
+	WalletTransactionsBetween(wallet string, from Point, to Point) ([]*ledgerpb.Transaction, error)
 	AggregateTransactions(aggregate string) ([]*ledgerpb.Transaction, error)
 }
+
+// Entry is one leg of a posting, an amount debited from or credited to a wallet
+type Entry struct {
+	Type   string `json:"type"` // TransactionDebit or TransactionCredit
+	Wallet string `json:"wallet"`
+	Amount Money  `json:"amount"`
+}
+
+// Balance is a wallet's balance in one currency
+type Balance struct {
+	Current   Money `json:"current"`   // the sum of every transaction posted to the wallet
+	Available Money `json:"available"` // what the wallet can spend, its current balance less the funds on hold
+}
@@ -1,16 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"time"
 
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
 	ledgergrpc "gitlab.com/patchwell/ledger/pkg/api/server/grpc"
 	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	"gitlab.com/patchwell/ledger/pkg/event"
+	ratefile "gitlab.com/patchwell/ledger/pkg/rate/file"
+	"gitlab.com/patchwell/ledger/pkg/webhook"
 
 	"google.golang.org/grpc"
 )
 
+const (
+	ratesFileName = "rates.json"
+	cashAccount   = "cash"
+	// webhookInterval is how often the outbox is checked for events to deliver to webhooks
+	webhookInterval = time.Second
+)
+
 func main() {
 	l, err := net.Listen("tcp", "0.0.0.0:50051")
 	if err != nil {
@@ -18,10 +30,38 @@ func main() {
 	}
 
 	s := grpc.NewServer()
-	book := memory.NewInMemoryBook()
+	// every command the book commits is published on the bus, subscribers are handed its events in the order they happened
+	bus := event.NewBus()
+	defer bus.Close()
+
+	bus.Subscribe(logEvent)
+
+	options := []memory.Option{memory.WithCashAccount(cashAccount), memory.WithPublisher(bus), memory.WithOutbox()}
+
+	rates, err := ratefile.NewFileSystemRates(ratesFileName)
+	if err != nil {
+		log.Printf("exchanging funds between currencies is disabled, %v", err)
+	} else {
+		options = append(options, memory.WithRateProvider(rates))
+	}
+
+	book := memory.NewInMemoryBook(options...)
 	ledgerpb.RegisterLedgerServiceServer(s, ledgergrpc.NewGRPCServer(book))
+	ledgerpb.RegisterWalletServiceServer(s, ledgergrpc.NewWalletServer(book))
+	ledgerpb.RegisterAdminServiceServer(s, ledgergrpc.NewAdminServer(book))
+	ledgerpb.RegisterFeedServiceServer(s, ledgergrpc.NewFeedServer(book))
+
+	dispatcher := webhook.NewDispatcher(book, webhook.NewMemoryStore())
+	go dispatcher.Run(context.Background(), webhookInterval)
+
+	ledgerpb.RegisterWebhookServiceServer(s, ledgergrpc.NewWebhookServer(dispatcher))
 
 	if err := s.Serve(l); err != nil {
 		log.Fatalf("failed to serve, %v", err)
 	}
 }
+
+func logEvent(e event.Envelope) error {
+	log.Printf("event %d %s %+v", e.Sequence, e.Name, e.Event)
+	return nil
+}
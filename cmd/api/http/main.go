@@ -1,15 +1,31 @@
 package main
 
 import (
+	"context"
 	"gitlab.com/patchwell/ledger/pkg/book/file"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	ledgerhttp "gitlab.com/patchwell/ledger/pkg/api/server/http"
+	"gitlab.com/patchwell/ledger/pkg/event"
+	"gitlab.com/patchwell/ledger/pkg/invariant"
+	ratefile "gitlab.com/patchwell/ledger/pkg/rate/file"
+	"gitlab.com/patchwell/ledger/pkg/webhook"
+	webhookfile "gitlab.com/patchwell/ledger/pkg/webhook/file"
 )
 
-const dbFileName = "transactions.db.json"
+const (
+	dbFileName         = "transactions.db.json"
+	ratesFileName      = "rates.json"
+	webhooksFileName   = "webhooks.json"
+	snapshotInterval   = time.Minute
+	holdExpiryInterval = time.Minute
+	invariantInterval  = time.Hour
+	webhookInterval    = time.Second
+	cashAccount        = "cash"
+)
 
 func main() {
 	f, err := os.OpenFile(dbFileName, os.O_RDWR|os.O_CREATE, 0666)
@@ -17,13 +33,57 @@ func main() {
 		log.Fatalf("unable to open file %s, %v", dbFileName, err)
 	}
 
-	book, err := file.NewFileSystemBook(f)
+	// every command the book commits is published on the bus, subscribers are handed its events in the order they happened
+	bus := event.NewBus()
+	defer bus.Close()
+
+	bus.Subscribe(logEvent)
+
+	options := []file.Option{file.WithSnapshotInterval(snapshotInterval), file.WithHoldExpiryInterval(holdExpiryInterval), file.WithCashAccount(cashAccount), file.WithPublisher(bus), file.WithOutbox()}
+
+	rates, err := ratefile.NewFileSystemRates(ratesFileName)
+	if err != nil {
+		log.Printf("exchanging funds between currencies is disabled, %v", err)
+	} else {
+		options = append(options, file.WithRateProvider(rates))
+	}
+
+	book, err := file.NewFileSystemBook(f, options...)
 	if err != nil {
 		log.Fatalf("problem when creating file system book, %v", err)
 	}
 
-	server := ledgerhttp.NewServer(book)
-	if err := http.ListenAndServe(":5000", server); err != nil {
+	// the book is checked in the background, a violation means something committed a transaction it shouldn't have
+	go invariant.Watch(context.Background(), book, invariantInterval, func(r invariant.Report) {
+		for _, v := range r.Violations {
+			log.Printf("ALERT ledger invariant broken, %v", v)
+		}
+	})
+
+	// events are written to the book's outbox along with its commands, and delivered from there to the registered webhooks
+	webhooks, err := webhookfile.NewFileSystemStore(webhooksFileName)
+	if err != nil {
+		log.Fatalf("problem when loading webhooks, %v", err)
+	}
+
+	dispatcher := webhook.NewDispatcher(book, webhooks)
+	go dispatcher.Run(context.Background(), webhookInterval)
+
+	webhookServer := ledgerhttp.NewWebhookServer(dispatcher)
+
+	router := http.NewServeMux()
+	router.Handle("/webhooks", webhookServer)
+	router.Handle("/webhooks/", webhookServer)
+	// consumers tail the book's transactions from a cursor, by long polling or as an event stream
+	router.Handle("/feed", ledgerhttp.NewFeedServer(book))
+	router.Handle("/", ledgerhttp.NewServer(book))
+
+	if err := http.ListenAndServe(":5000", router); err != nil {
 		log.Fatalf("could not listen on port 5000 %v", err)
 	}
 }
+
+func logEvent(e event.Envelope) error {
+	log.Printf("event %d %s %+v", e.Sequence, e.Name, e.Event)
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitlab.com/patchwell/ledger/pkg/book/file"
+	"gitlab.com/patchwell/ledger/pkg/reconcile"
+	reconcilefile "gitlab.com/patchwell/ledger/pkg/reconcile/file"
+)
+
+// reconcile matches a bank or processor statement against the ledger's cash in and cash out, printing what matched,
+// what didn't and the lines suspected of being duplicates, matches are recorded so running it again picks up where it left off
+func main() {
+	dbFileName := flag.String("file", "transactions.db.json", "journal of the book to reconcile")
+	cashAccount := flag.String("cash-account", "cash", "account deposits and withdrawals are posted against")
+	statementName := flag.String("statement", "", "statement to reconcile, a CSV file or a camt.053 XML file")
+	source := flag.String("source", "", "bank account or processor the statement is from, lines are told apart per source, the statement's file name if left out")
+	format := flag.String("format", "", "format of the statement, csv or camt053, worked out from its extension if left out")
+	decisionsName := flag.String("decisions", "reconciliation.json", "file the runs and their matches are recorded in")
+	window := flag.Int("window", reconcile.DefaultDateWindow, "days a line and a transaction can be booked apart and still match by amount")
+	flag.Parse()
+
+	if *statementName == "" {
+		log.Fatal("no statement given to reconcile, use -statement")
+	}
+
+	if *format == "" {
+		*format = "csv"
+		if strings.EqualFold(filepath.Ext(*statementName), ".xml") {
+			*format = "camt053"
+		}
+	}
+
+	s, err := os.Open(*statementName)
+	if err != nil {
+		log.Fatalf("unable to open statement %s, %v", *statementName, err)
+	}
+	defer s.Close()
+
+	var lines []reconcile.Line
+
+	switch *format {
+	case "csv":
+		lines, err = reconcile.ParseCSV(s)
+	case "camt053":
+		lines, err = reconcile.ParseCamt053(s)
+	default:
+		log.Fatalf("unknown statement format %s", *format)
+	}
+	if err != nil {
+		log.Fatalf("problem reading statement %s, %v", *statementName, err)
+	}
+
+	f, err := os.OpenFile(*dbFileName, os.O_RDWR, 0666)
+	if err != nil {
+		log.Fatalf("unable to open file %s, %v", *dbFileName, err)
+	}
+	defer f.Close()
+
+	book, err := file.NewFileSystemBook(f, file.WithCashAccount(*cashAccount))
+	if err != nil {
+		log.Fatalf("problem when creating file system book, %v", err)
+	}
+	defer book.Close()
+
+	store, err := reconcilefile.NewFileSystemStore(*decisionsName)
+	if err != nil {
+		log.Fatalf("problem loading reconciliation decisions, %v", err)
+	}
+
+	if *source == "" {
+		*source = filepath.Base(*statementName)
+	}
+
+	result, err := reconcile.NewReconciler(book, store, reconcile.WithDateWindow(*window)).Reconcile(*source, filepath.Base(*statementName), lines)
+	if err != nil {
+		log.Fatalf("problem reconciling statement %s, %v", *statementName, err)
+	}
+
+	fmt.Printf("run %d of %s: %d lines, %d already matched, %d matched, %d unmatched, %d suspected duplicates\n",
+		result.Run.ID, result.Run.Statement, result.Run.Lines, result.Run.Skipped, len(result.Matched), len(result.Unmatched), len(result.Duplicates))
+
+	for _, m := range result.Matched {
+		fmt.Printf("matched    %s %s %v by %s to transaction %s of wallet %s\n", m.Line.Date, m.Line.ID, m.Line.Amount, m.Rule, m.Transaction, m.Wallet)
+	}
+	for _, l := range result.Unmatched {
+		fmt.Printf("unmatched  %s %s %s %v %s\n", l.Date, l.ID, l.Direction, l.Amount, l.Reference)
+	}
+	for _, l := range result.Duplicates {
+		fmt.Printf("duplicate  %s %s %s %v %s\n", l.Date, l.ID, l.Direction, l.Amount, l.Reference)
+	}
+	for _, t := range result.UnmatchedTransactions {
+		fmt.Printf("not on statement  %s %s %s %d %s of wallet %s\n", t.EffectiveDate, t.Id, t.Type, t.Amount, t.Currency, t.Wallet)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"gitlab.com/patchwell/ledger/pkg/book/file"
+	"gitlab.com/patchwell/ledger/pkg/invariant"
+)
+
+// verify checks the ledger's journal is internally consistent, printing its trial balance and every broken invariant
+// it exits with status 1 when an invariant is broken, so it can be run from cron or a deploy pipeline
+// the journal is only read, so it's safe to check one a running server has open
+func main() {
+	dbFileName := flag.String("file", "transactions.db.json", "journal of the book to check")
+	cashAccount := flag.String("cash-account", "cash", "account deposits and withdrawals are posted against")
+	trialBalance := flag.Bool("trial-balance", true, "print the trial balance")
+	flag.Parse()
+
+	f, err := os.Open(*dbFileName)
+	if err != nil {
+		log.Fatalf("unable to open file %s, %v", *dbFileName, err)
+	}
+	defer f.Close()
+
+	book, err := file.NewFileSystemBook(f, file.WithReadOnly(), file.WithCashAccount(*cashAccount))
+	if err != nil {
+		log.Fatalf("problem when creating file system book, %v", err)
+	}
+	defer book.Close()
+
+	if *trialBalance {
+		tb := invariant.NewTrialBalance(book)
+
+		fmt.Printf("%-40s %-8s %16s %16s\n", "WALLET", "CURRENCY", "DEBIT", "CREDIT")
+		for _, l := range tb.Lines {
+			fmt.Printf("%-40s %-8s %16d %16d\n", l.Wallet, l.Currency, l.Debit, l.Credit)
+		}
+		for _, t := range tb.Totals {
+			fmt.Printf("%-40s %-8s %16d %16d balanced: %t\n", "TOTAL", t.Currency, t.Debit, t.Credit, t.Balanced())
+		}
+		fmt.Println()
+	}
+
+	r := invariant.Check(book)
+
+	fmt.Printf("checked %d transactions, %d violations\n", r.Transactions, len(r.Violations))
+	for _, v := range r.Violations {
+		fmt.Println(v)
+	}
+
+	if !r.OK() {
+		book.Close()
+		f.Close()
+		os.Exit(1)
+	}
+}
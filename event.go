@@ -1,44 +1,246 @@
 package ledger
 
+import "time"
+
+// Event is something a book did, published once the command that did it has been committed
+// its name tells subscribers which of the types below it is
+type Event interface {
+	EventName() string
+}
+
+// Publisher is handed the events of every commit, in the order they were committed, along with the wallets the commit touched
+// it's called while the book holds its write lock, so it must hand the event off rather than deliver it
+type Publisher interface {
+	Publish(event Event, wallets ...string)
+}
+
+// the names of the events, as returned by their EventName
+const (
+	EventWalletFundsTransferred  = "wallet_funds_transferred"
+	EventWalletFundsExchanged    = "wallet_funds_exchanged"
+	EventEntriesPosted           = "entries_posted"
+	EventWalletFundsDeposited    = "wallet_funds_deposited"
+	EventWalletFundsWithdrawn    = "wallet_funds_withdrawn"
+	EventAggregateReversed       = "aggregate_reversed"
+	EventAggregateRefunded       = "aggregate_refunded"
+	EventHoldAuthorized          = "hold_authorized"
+	EventHoldCaptured            = "hold_captured"
+	EventHoldVoided              = "hold_voided"
+	EventHoldExpired             = "hold_expired"
+	EventOverdraftPolicySet      = "overdraft_policy_set"
+	EventWalletOpened            = "wallet_opened"
+	EventWalletStatusSet         = "wallet_status_set"
+	EventWalletLimitsSet         = "wallet_limits_set"
+	EventTierLimitsSet           = "tier_limits_set"
+	EventFeeScheduleSet          = "fee_schedule_set"
+	EventInterestPolicySet       = "interest_policy_set"
+	EventInterestAccrued         = "interest_accrued"
+	EventAccountDefined          = "account_defined"
+	EventCreditTransactionAdded  = "credit_transaction_added"
+	EventDebitTransactionAdded   = "debit_transaction_added"
+	EventCashInTransactionAdded  = "cash_in_transaction_added"
+	EventCashOutTransactionAdded = "cash_out_transaction_added"
+)
+
+// KnownEvent reports whether the name is that of one of the events above
+func KnownEvent(name string) bool {
+	switch name {
+	case EventWalletFundsTransferred, EventWalletFundsExchanged, EventEntriesPosted, EventWalletFundsDeposited,
+		EventWalletFundsWithdrawn, EventAggregateReversed, EventAggregateRefunded, EventHoldAuthorized,
+		EventHoldCaptured, EventHoldVoided, EventHoldExpired, EventOverdraftPolicySet,
+		EventWalletOpened, EventWalletStatusSet, EventWalletLimitsSet, EventTierLimitsSet,
+		EventFeeScheduleSet, EventInterestPolicySet, EventInterestAccrued, EventAccountDefined,
+		EventCreditTransactionAdded, EventDebitTransactionAdded, EventCashInTransactionAdded, EventCashOutTransactionAdded:
+		return true
+	}
+
+	return false
+}
+
 type WalletFundsTransferred struct {
 	Source      string `json:"source"`
 	Destination string `json:"destination"`
-	Amount      int32  `json:"amount"`
+	Amount      Money  `json:"amount"`
+	Aggregate   string `json:"aggregate"`
+}
+
+type WalletFundsExchanged struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Debit       Money  `json:"debit"`
+	Credit      Money  `json:"credit"`
+	Rate        string `json:"rate"`
 	Aggregate   string `json:"aggregate"`
 }
 
+type EntriesPosted struct {
+	Entries   []Entry `json:"entries"`
+	Aggregate string  `json:"aggregate"`
+}
+
 type WalletFundsDeposited struct {
 	Wallet    string `json:"wallet"`
-	Deposit   int32  `json:"deposit"`
+	Deposit   Money  `json:"deposit"`
 	Aggregate string `json:"aggregate"`
 }
 
 type WalletFundsWithdrawn struct {
 	Wallet    string `json:"wallet"`
-	Withdraw  int32  `json:"withdraw"`
+	Withdraw  Money  `json:"withdraw"`
 	Aggregate string `json:"aggregate"`
 }
 
+type AggregateReversed struct {
+	Aggregate string `json:"aggregate"`
+	Reversal  string `json:"reversal"`
+	Reason    string `json:"reason"`
+}
+
+type AggregateRefunded struct {
+	Aggregate string `json:"aggregate"`
+	Refund    string `json:"refund"`
+	Amount    Money  `json:"amount"`
+}
+
+type HoldAuthorized struct {
+	Wallet    string    `json:"wallet"`
+	Amount    Money     `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Hold      string    `json:"hold"`
+}
+
+type HoldCaptured struct {
+	Hold        string `json:"hold"`
+	Destination string `json:"destination"`
+	Amount      Money  `json:"amount"`
+	Aggregate   string `json:"aggregate"`
+}
+
+type HoldVoided struct {
+	Hold      string `json:"hold"`
+	Aggregate string `json:"aggregate"`
+}
+
+// HoldExpired is a hold released because it lapsed, before it was captured or voided
+type HoldExpired struct {
+	Hold      string `json:"hold"`
+	Wallet    string `json:"wallet"`
+	Amount    Money  `json:"amount"`
+	Aggregate string `json:"aggregate"`
+}
+
+type OverdraftPolicySet struct {
+	Wallet string          `json:"wallet"`
+	Policy OverdraftPolicy `json:"policy"`
+}
+
+type WalletOpened struct {
+	Wallet Wallet `json:"wallet"`
+}
+
+type WalletStatusSet struct {
+	Wallet string       `json:"wallet"`
+	Status WalletStatus `json:"status"`
+}
+
+type WalletLimitsSet struct {
+	Wallet string `json:"wallet"`
+	Limits Limits `json:"limits"`
+}
+
+type TierLimitsSet struct {
+	Tier   string `json:"tier"`
+	Limits Limits `json:"limits"`
+}
+
+type FeeScheduleSet struct {
+	Schedule FeeSchedule `json:"schedule"`
+}
+
+type InterestPolicySet struct {
+	Wallet string         `json:"wallet"`
+	Policy InterestPolicy `json:"policy"`
+}
+
+// InterestAccrued is empty of an aggregate when the run posted no interest
+type InterestAccrued struct {
+	Through   string   `json:"through"`
+	Aggregate string   `json:"aggregate,omitempty"`
+	Skipped   []string `json:"skipped,omitempty"` // wallets whose interest couldn't be accrued, they're tried again on the next run
+}
+
+type AccountDefined struct {
+	Account Account `json:"account"`
+}
+
 type CreditTransactionAdded struct {
 	Wallet    string `json:"wallet"`
-	Credit    int32  `json:"credit"`
+	Credit    Money  `json:"credit"`
 	Aggregate string `json:"aggregate"`
 }
 
 type DebitTransactionAdded struct {
 	Wallet    string `json:"wallet"`
-	Debit     int32  `json:"debit"`
+	Debit     Money  `json:"debit"`
 	Aggregate string `json:"aggregate"`
 }
 
 type CashInTransactionAdded struct {
 	Wallet    string `json:"wallet"`
-	Credit    int32  `json:"credit"`
+	Credit    Money  `json:"credit"`
 	Aggregate string `json:"aggregate"`
 }
 
 type CashOutTransactionAdded struct {
 	Wallet    string `json:"wallet"`
-	Debit     int32  `json:"debit"`
+	Debit     Money  `json:"debit"`
 	Aggregate string `json:"aggregate"`
 }
+
+func (WalletFundsTransferred) EventName() string { return EventWalletFundsTransferred }
+
+func (WalletFundsExchanged) EventName() string { return EventWalletFundsExchanged }
+
+func (EntriesPosted) EventName() string { return EventEntriesPosted }
+
+func (WalletFundsDeposited) EventName() string { return EventWalletFundsDeposited }
+
+func (WalletFundsWithdrawn) EventName() string { return EventWalletFundsWithdrawn }
+
+func (AggregateReversed) EventName() string { return EventAggregateReversed }
+
+func (AggregateRefunded) EventName() string { return EventAggregateRefunded }
+
+func (HoldAuthorized) EventName() string { return EventHoldAuthorized }
+
+func (HoldCaptured) EventName() string { return EventHoldCaptured }
+
+func (HoldVoided) EventName() string { return EventHoldVoided }
+
+func (HoldExpired) EventName() string { return EventHoldExpired }
+
+func (OverdraftPolicySet) EventName() string { return EventOverdraftPolicySet }
+
+func (WalletOpened) EventName() string { return EventWalletOpened }
+
+func (WalletStatusSet) EventName() string { return EventWalletStatusSet }
+
+func (WalletLimitsSet) EventName() string { return EventWalletLimitsSet }
+
+func (TierLimitsSet) EventName() string { return EventTierLimitsSet }
+
+func (FeeScheduleSet) EventName() string { return EventFeeScheduleSet }
+
+func (InterestPolicySet) EventName() string { return EventInterestPolicySet }
+
+func (InterestAccrued) EventName() string { return EventInterestAccrued }
+
+func (AccountDefined) EventName() string { return EventAccountDefined }
+
+func (CreditTransactionAdded) EventName() string { return EventCreditTransactionAdded }
+
+func (DebitTransactionAdded) EventName() string { return EventDebitTransactionAdded }
+
+func (CashInTransactionAdded) EventName() string { return EventCashInTransactionAdded }
+
+func (CashOutTransactionAdded) EventName() string { return EventCashOutTransactionAdded }
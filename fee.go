@@ -0,0 +1,162 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// FeeCommand names a command fees can be charged on
+type FeeCommand string
+
+const (
+	FeeTransfer   FeeCommand = "transfer"
+	FeeWithdrawal FeeCommand = "withdrawal"
+)
+
+// basisPoints is how many basis points make up the whole of an amount
+const basisPoints = 10000
+
+// FeeBand is what's charged on amounts up to and including UpTo, a flat amount plus basis points of the amount
+type FeeBand struct {
+	UpTo        int64 `json:"up_to"` // zero in the last band of a schedule covers every amount above the band before it
+	Flat        int64 `json:"flat"`
+	BasisPoints int64 `json:"basis_points"`
+}
+
+// FeeSchedule is what's charged on a command taking an amount in its currency out of a wallet in its tier
+// the fee is a flat amount plus basis points of the amount, or that of the first band covering the amount when it has bands
+// then raised to Min and capped at Max, every amount is in the schedule's currency
+type FeeSchedule struct {
+	Command     FeeCommand `json:"command"`
+	Tier        string     `json:"tier,omitempty"` // empty for wallets whose tier has no schedule of its own
+	Currency    string     `json:"currency"`
+	Wallet      string     `json:"wallet"` // the fee income wallet fees are credited to
+	Flat        int64      `json:"flat,omitempty"`
+	BasisPoints int64      `json:"basis_points,omitempty"`
+	Bands       []FeeBand  `json:"bands,omitempty"`
+	Min         int64      `json:"min,omitempty"` // zero for no minimum
+	Max         int64      `json:"max,omitempty"` // zero for no maximum
+}
+
+// Validate returns an error if the schedule is for an unknown command, has no currency or fee income wallet
+// or has negative amounts, more basis points than make up the whole amount, bands out of order or a minimum above its maximum
+func (s FeeSchedule) Validate() error {
+	if s.Command != FeeTransfer && s.Command != FeeWithdrawal {
+		return fmt.Errorf("fees can't be charged on command '%s'", s.Command)
+	}
+
+	if s.Currency == "" {
+		return errors.New("fee schedule has no currency")
+	}
+
+	if s.Wallet == "" && !s.IsZero() {
+		return errors.New("fee schedule has no wallet to credit fees to")
+	}
+
+	if s.Flat < 0 || s.BasisPoints < 0 || s.Min < 0 || s.Max < 0 {
+		return errors.New("fee schedule amounts can't be negative")
+	}
+
+	if s.BasisPoints > basisPoints {
+		return fmt.Errorf("fee schedule basis points of %d are more than the %d making up the whole amount", s.BasisPoints, basisPoints)
+	}
+
+	if s.Max > 0 && s.Min > s.Max {
+		return fmt.Errorf("fee schedule minimum of %d is above its maximum of %d", s.Min, s.Max)
+	}
+
+	var upTo int64
+
+	for i, band := range s.Bands {
+		if band.Flat < 0 || band.BasisPoints < 0 {
+			return fmt.Errorf("fee band %d has negative amounts", i)
+		}
+
+		if band.BasisPoints > basisPoints {
+			return fmt.Errorf("fee band %d basis points of %d are more than the %d making up the whole amount", i, band.BasisPoints, basisPoints)
+		}
+
+		if band.UpTo == 0 && i != len(s.Bands)-1 {
+			return fmt.Errorf("fee band %d has no upper bound, only the last band can cover every amount above the one before it", i)
+		}
+
+		if band.UpTo != 0 && band.UpTo <= upTo {
+			return fmt.Errorf("fee band %d up to %d doesn't come after the band before it", i, band.UpTo)
+		}
+
+		upTo = band.UpTo
+	}
+
+	return nil
+}
+
+// IsZero reports whether the schedule charges nothing on any amount
+func (s FeeSchedule) IsZero() bool {
+	return s.Flat == 0 && s.BasisPoints == 0 && len(s.Bands) == 0 && s.Min == 0
+}
+
+// Fee returns what the schedule charges on the amount, basis points are rounded to the nearest minor unit, halves up
+// an amount above every band is charged nothing beyond the schedule's minimum, ErrAmountOverflow is returned for a fee that doesn't fit in 64 bits
+func (s FeeSchedule) Fee(amount int64) (int64, error) {
+	flat, points := s.Flat, s.BasisPoints
+
+	if len(s.Bands) > 0 {
+		flat, points = 0, 0
+
+		for _, band := range s.Bands {
+			if band.UpTo == 0 || amount <= band.UpTo {
+				flat, points = band.Flat, band.BasisPoints
+				break
+			}
+		}
+	}
+
+	p, err := percentage(amount, points)
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := Money{Amount: flat}.Add(Money{Amount: p})
+	if err != nil {
+		return 0, err
+	}
+
+	fee := total.Amount
+
+	if fee < s.Min {
+		fee = s.Min
+	}
+
+	if s.Max > 0 && fee > s.Max {
+		fee = s.Max
+	}
+
+	return fee, nil
+}
+
+// percentage returns the basis points of the amount rounded to the nearest minor unit, halves up
+// or ErrAmountOverflow when that doesn't fit in 64 bits
+func percentage(amount int64, points int64) (int64, error) {
+	if points == 0 {
+		return 0, nil
+	}
+
+	n := new(big.Int).Mul(big.NewInt(amount), big.NewInt(points))
+	n.Add(n, big.NewInt(basisPoints/2))
+	n.Quo(n, big.NewInt(basisPoints))
+
+	if !n.IsInt64() {
+		return 0, ErrAmountOverflow
+	}
+
+	return n.Int64(), nil
+}
+
+// FeeQuote is what a command would charge on an amount before running it
+type FeeQuote struct {
+	Amount Money  `json:"amount"`
+	Fee    Money  `json:"fee"`
+	Total  Money  `json:"total"`            // what would be taken out of the wallet, the amount and its fee
+	Wallet string `json:"wallet,omitempty"` // the fee income wallet the fee would be credited to, empty when there's no fee
+}
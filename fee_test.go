@@ -0,0 +1,81 @@
+package ledger
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeeSchedule_Fee(t *testing.T) {
+	bands := []FeeBand{{UpTo: 10000, Flat: 50}, {UpTo: 100000, BasisPoints: 100}, {BasisPoints: 50}}
+
+	cases := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   int64
+		want     int64
+	}{
+		{"a flat fee", FeeSchedule{Flat: 25}, 5000, 25},
+		{"a percentage rounded to the nearest minor unit", FeeSchedule{BasisPoints: 150}, 1033, 15},
+		{"a percentage rounding halves up", FeeSchedule{BasisPoints: 50}, 1100, 6},
+		{"a flat fee plus a percentage", FeeSchedule{Flat: 30, BasisPoints: 290}, 10000, 320},
+		{"a percentage raised to the minimum", FeeSchedule{BasisPoints: 100, Min: 50}, 1000, 50},
+		{"a percentage capped at the maximum", FeeSchedule{BasisPoints: 100, Max: 500}, 100000, 500},
+		{"the first band covering the amount", FeeSchedule{Bands: bands}, 10000, 50},
+		{"a band further up", FeeSchedule{Bands: bands}, 50000, 500},
+		{"the open-ended last band", FeeSchedule{Bands: bands}, 200000, 1000},
+		{"an amount above every band", FeeSchedule{Bands: bands[:2], Min: 10}, 200000, 10},
+	}
+
+	for _, c := range cases {
+		t.Run("should work out "+c.name, func(t *testing.T) {
+			got, err := c.schedule.Fee(c.amount)
+			if err != nil || got != c.want {
+				t.Errorf("got fee of %d and error %v on %d, wanted %d", got, err, c.amount, c.want)
+			}
+		})
+	}
+
+	t.Run("should return an error for a fee that doesn't fit in 64 bits", func(t *testing.T) {
+		for _, s := range []FeeSchedule{{Flat: math.MaxInt64, BasisPoints: 100}, {BasisPoints: 20000}} {
+			_, err := s.Fee(math.MaxInt64)
+			if err != ErrAmountOverflow {
+				t.Errorf("got error %v for %+v, wanted %v", err, s, ErrAmountOverflow)
+			}
+		}
+	})
+}
+
+func TestFeeSchedule_Validate(t *testing.T) {
+	valid := FeeSchedule{Command: FeeTransfer, Currency: "USD", Wallet: "fees", Flat: 25}
+
+	cases := []struct {
+		name   string
+		change func(s *FeeSchedule)
+		valid  bool
+	}{
+		{"a flat fee", func(s *FeeSchedule) {}, true},
+		{"a schedule charging nothing with no wallet", func(s *FeeSchedule) { s.Flat, s.Wallet = 0, "" }, true},
+		{"an unknown command", func(s *FeeSchedule) { s.Command = "deposit" }, false},
+		{"no currency", func(s *FeeSchedule) { s.Currency = "" }, false},
+		{"no wallet", func(s *FeeSchedule) { s.Wallet = "" }, false},
+		{"a negative fee", func(s *FeeSchedule) { s.BasisPoints = -1 }, false},
+		{"the whole amount in basis points", func(s *FeeSchedule) { s.BasisPoints = 10000 }, true},
+		{"more basis points than the whole amount", func(s *FeeSchedule) { s.BasisPoints = 10001 }, false},
+		{"a band with more basis points than the whole amount", func(s *FeeSchedule) { s.Bands = []FeeBand{{BasisPoints: 10001}} }, false},
+		{"a minimum above the maximum", func(s *FeeSchedule) { s.Min, s.Max = 100, 50 }, false},
+		{"bands out of order", func(s *FeeSchedule) { s.Bands = []FeeBand{{UpTo: 100}, {UpTo: 50}} }, false},
+		{"an open-ended band before the last", func(s *FeeSchedule) { s.Bands = []FeeBand{{Flat: 1}, {UpTo: 50}} }, false},
+	}
+
+	for _, c := range cases {
+		t.Run("should check "+c.name, func(t *testing.T) {
+			s := valid
+			c.change(&s)
+
+			err := s.Validate()
+			if (err == nil) != c.valid {
+				t.Errorf("got error %v validating %+v, wanted valid %t", err, s, c.valid)
+			}
+		})
+	}
+}
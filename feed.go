@@ -0,0 +1,18 @@
+package ledger
+
+import (
+	"context"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// Feed is kept by books that let consumers tail their transactions in the order they were committed
+// a consumer's cursor is the sequence number of the last transaction it has handled, zero before it has handled any
+// so a consumer that keeps its cursor along with what it did with the transactions resumes where it left off after a restart
+// without missing or seeing any of them twice
+type Feed interface {
+	// TransactionsAfter returns up to limit transactions with a sequence number after the cursor, in commit order, or all of them when limit is zero
+	TransactionsAfter(cursor uint64, limit int) []ledgerpb.Transaction
+	// WaitForTransactions blocks until there's a transaction after the cursor, or returns the context's error once it's done
+	WaitForTransactions(ctx context.Context, cursor uint64) error
+}
@@ -27,8 +27,21 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type Transaction struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Wallet               string   `protobuf:"bytes,2,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Amount               int32    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	Aggregate            string   `protobuf:"bytes,4,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Currency             string   `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rate                 string   `protobuf:"bytes,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	Id                   string   `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	Sequence             uint64   `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	RecordedAt           int64    `protobuf:"varint,9,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	EffectiveDate        string   `protobuf:"bytes,10,opt,name=effective_date,json=effectiveDate,proto3" json:"effective_date,omitempty"`
+	ReversalOf           string   `protobuf:"bytes,11,opt,name=reversal_of,json=reversalOf,proto3" json:"reversal_of,omitempty"`
+	RefundOf             string   `protobuf:"bytes,12,opt,name=refund_of,json=refundOf,proto3" json:"refund_of,omitempty"`
+	Reason               string   `protobuf:"bytes,13,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Hold                 string   `protobuf:"bytes,15,opt,name=hold,proto3" json:"hold,omitempty"`
+	Fee                  bool     `protobuf:"varint,16,opt,name=fee,proto3" json:"fee,omitempty"`
+	Interest             bool     `protobuf:"varint,17,opt,name=interest,proto3" json:"interest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -73,7 +86,7 @@ func (m *Transaction) GetWallet() string {
 	return ""
 }
 
-func (m *Transaction) GetAmount() int32 {
+func (m *Transaction) GetAmount() int64 {
 	if m != nil {
 		return m.Amount
 	}
@@ -87,10 +100,102 @@ func (m *Transaction) GetAggregate() string {
 	return ""
 }
 
+func (m *Transaction) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Transaction) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+func (m *Transaction) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Transaction) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Transaction) GetRecordedAt() int64 {
+	if m != nil {
+		return m.RecordedAt
+	}
+	return 0
+}
+
+func (m *Transaction) GetEffectiveDate() string {
+	if m != nil {
+		return m.EffectiveDate
+	}
+	return ""
+}
+
+func (m *Transaction) GetReversalOf() string {
+	if m != nil {
+		return m.ReversalOf
+	}
+	return ""
+}
+
+func (m *Transaction) GetRefundOf() string {
+	if m != nil {
+		return m.RefundOf
+	}
+	return ""
+}
+
+func (m *Transaction) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Transaction) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *Transaction) GetHold() string {
+	if m != nil {
+		return m.Hold
+	}
+	return ""
+}
+
+func (m *Transaction) GetFee() bool {
+	if m != nil {
+		return m.Fee
+	}
+	return false
+}
+
+func (m *Transaction) GetInterest() bool {
+	if m != nil {
+		return m.Interest
+	}
+	return false
+}
+
 type CreditTransaction struct {
 	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Credit               int32    `protobuf:"varint,2,opt,name=credit,proto3" json:"credit,omitempty"`
+	Credit               int64    `protobuf:"varint,2,opt,name=credit,proto3" json:"credit,omitempty"`
 	Aggregate            string   `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -128,7 +233,7 @@ func (m *CreditTransaction) GetWallet() string {
 	return ""
 }
 
-func (m *CreditTransaction) GetCredit() int32 {
+func (m *CreditTransaction) GetCredit() int64 {
 	if m != nil {
 		return m.Credit
 	}
@@ -142,8 +247,16 @@ func (m *CreditTransaction) GetAggregate() string {
 	return ""
 }
 
+func (m *CreditTransaction) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
 type AddCreditTransactionRequest struct {
 	Transaction          *CreditTransaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	IdempotencyKey       string             `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -181,6 +294,13 @@ func (m *AddCreditTransactionRequest) GetTransaction() *CreditTransaction {
 	return nil
 }
 
+func (m *AddCreditTransactionRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type AddCreditTransactionResponse struct {
 	Response             string   `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -222,8 +342,9 @@ func (m *AddCreditTransactionResponse) GetResponse() string {
 
 type DebitTransaction struct {
 	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Debit                int32    `protobuf:"varint,2,opt,name=debit,proto3" json:"debit,omitempty"`
+	Debit                int64    `protobuf:"varint,2,opt,name=debit,proto3" json:"debit,omitempty"`
 	Aggregate            string   `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -261,7 +382,7 @@ func (m *DebitTransaction) GetWallet() string {
 	return ""
 }
 
-func (m *DebitTransaction) GetDebit() int32 {
+func (m *DebitTransaction) GetDebit() int64 {
 	if m != nil {
 		return m.Debit
 	}
@@ -275,8 +396,16 @@ func (m *DebitTransaction) GetAggregate() string {
 	return ""
 }
 
+func (m *DebitTransaction) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
 type AddDebitTransactionRequest struct {
 	Transaction          *DebitTransaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	IdempotencyKey       string            `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -314,6 +443,13 @@ func (m *AddDebitTransactionRequest) GetTransaction() *DebitTransaction {
 	return nil
 }
 
+func (m *AddDebitTransactionRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type AddDebitTransactionResponse struct {
 	Response             string   `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -355,8 +491,9 @@ func (m *AddDebitTransactionResponse) GetResponse() string {
 
 type CashInTransaction struct {
 	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Credit               int32    `protobuf:"varint,2,opt,name=credit,proto3" json:"credit,omitempty"`
+	Credit               int64    `protobuf:"varint,2,opt,name=credit,proto3" json:"credit,omitempty"`
 	Aggregate            string   `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -394,7 +531,7 @@ func (m *CashInTransaction) GetWallet() string {
 	return ""
 }
 
-func (m *CashInTransaction) GetCredit() int32 {
+func (m *CashInTransaction) GetCredit() int64 {
 	if m != nil {
 		return m.Credit
 	}
@@ -408,8 +545,16 @@ func (m *CashInTransaction) GetAggregate() string {
 	return ""
 }
 
+func (m *CashInTransaction) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
 type AddCashInTransactionRequest struct {
 	Transaction          *CashInTransaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	IdempotencyKey       string             `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -447,6 +592,13 @@ func (m *AddCashInTransactionRequest) GetTransaction() *CashInTransaction {
 	return nil
 }
 
+func (m *AddCashInTransactionRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type AddCashInTransactionResponse struct {
 	Response             string   `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -488,8 +640,9 @@ func (m *AddCashInTransactionResponse) GetResponse() string {
 
 type CashOutTransaction struct {
 	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Debit                int32    `protobuf:"varint,2,opt,name=debit,proto3" json:"debit,omitempty"`
+	Debit                int64    `protobuf:"varint,2,opt,name=debit,proto3" json:"debit,omitempty"`
 	Aggregate            string   `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -527,7 +680,7 @@ func (m *CashOutTransaction) GetWallet() string {
 	return ""
 }
 
-func (m *CashOutTransaction) GetDebit() int32 {
+func (m *CashOutTransaction) GetDebit() int64 {
 	if m != nil {
 		return m.Debit
 	}
@@ -541,8 +694,16 @@ func (m *CashOutTransaction) GetAggregate() string {
 	return ""
 }
 
+func (m *CashOutTransaction) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
 type AddCashOutTransactionRequest struct {
 	Transaction          *CashOutTransaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	IdempotencyKey       string              `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
 	XXX_sizecache        int32               `json:"-"`
@@ -580,6 +741,13 @@ func (m *AddCashOutTransactionRequest) GetTransaction() *CashOutTransaction {
 	return nil
 }
 
+func (m *AddCashOutTransactionRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type AddCashOutTransactionResponse struct {
 	Response             string   `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -658,18 +826,73 @@ func (m *WalletBalanceRequest) GetWallet() string {
 	return ""
 }
 
-type WalletBalanceResponse struct {
-	Balance              int32    `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+type Balance struct {
+	Amount               int64    `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Available            int64    `protobuf:"varint,3,opt,name=available,proto3" json:"available,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *Balance) Reset()         { *m = Balance{} }
+func (m *Balance) String() string { return proto.CompactTextString(m) }
+func (*Balance) ProtoMessage()    {}
+func (*Balance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{14}
+}
+
+func (m *Balance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Balance.Unmarshal(m, b)
+}
+func (m *Balance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Balance.Marshal(b, m, deterministic)
+}
+func (m *Balance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Balance.Merge(m, src)
+}
+func (m *Balance) XXX_Size() int {
+	return xxx_messageInfo_Balance.Size(m)
+}
+func (m *Balance) XXX_DiscardUnknown() {
+	xxx_messageInfo_Balance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Balance proto.InternalMessageInfo
+
+func (m *Balance) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Balance) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Balance) GetAvailable() int64 {
+	if m != nil {
+		return m.Available
+	}
+	return 0
+}
+
+type WalletBalanceResponse struct {
+	Balances             []*Balance `protobuf:"bytes,3,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
 func (m *WalletBalanceResponse) Reset()         { *m = WalletBalanceResponse{} }
 func (m *WalletBalanceResponse) String() string { return proto.CompactTextString(m) }
 func (*WalletBalanceResponse) ProtoMessage()    {}
 func (*WalletBalanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{14}
+	return fileDescriptor_42f128396e519916, []int{15}
 }
 
 func (m *WalletBalanceResponse) XXX_Unmarshal(b []byte) error {
@@ -690,11 +913,11 @@ func (m *WalletBalanceResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_WalletBalanceResponse proto.InternalMessageInfo
 
-func (m *WalletBalanceResponse) GetBalance() int32 {
+func (m *WalletBalanceResponse) GetBalances() []*Balance {
 	if m != nil {
-		return m.Balance
+		return m.Balances
 	}
-	return 0
+	return nil
 }
 
 type WalletTransactionsRequest struct {
@@ -708,7 +931,7 @@ func (m *WalletTransactionsRequest) Reset()         { *m = WalletTransactionsReq
 func (m *WalletTransactionsRequest) String() string { return proto.CompactTextString(m) }
 func (*WalletTransactionsRequest) ProtoMessage()    {}
 func (*WalletTransactionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{15}
+	return fileDescriptor_42f128396e519916, []int{16}
 }
 
 func (m *WalletTransactionsRequest) XXX_Unmarshal(b []byte) error {
@@ -747,7 +970,7 @@ func (m *WalletTransactionsResponse) Reset()         { *m = WalletTransactionsRe
 func (m *WalletTransactionsResponse) String() string { return proto.CompactTextString(m) }
 func (*WalletTransactionsResponse) ProtoMessage()    {}
 func (*WalletTransactionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{16}
+	return fileDescriptor_42f128396e519916, []int{17}
 }
 
 func (m *WalletTransactionsResponse) XXX_Unmarshal(b []byte) error {
@@ -775,830 +998,5823 @@ func (m *WalletTransactionsResponse) GetTransactions() []*Transaction {
 	return nil
 }
 
-type AggregateTransactionsRequest struct {
-	Aggregate            string   `protobuf:"bytes,1,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+// points in the book's history are a unix time in nanoseconds or a sequence number, whichever is set, neither is now
+type WalletBalanceAtRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	At                   int64    `protobuf:"varint,2,opt,name=at,proto3" json:"at,omitempty"`
+	Sequence             uint64   `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AggregateTransactionsRequest) Reset()         { *m = AggregateTransactionsRequest{} }
-func (m *AggregateTransactionsRequest) String() string { return proto.CompactTextString(m) }
-func (*AggregateTransactionsRequest) ProtoMessage()    {}
-func (*AggregateTransactionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{17}
+func (m *WalletBalanceAtRequest) Reset()         { *m = WalletBalanceAtRequest{} }
+func (m *WalletBalanceAtRequest) String() string { return proto.CompactTextString(m) }
+func (*WalletBalanceAtRequest) ProtoMessage()    {}
+func (*WalletBalanceAtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{18}
 }
 
-func (m *AggregateTransactionsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AggregateTransactionsRequest.Unmarshal(m, b)
+func (m *WalletBalanceAtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletBalanceAtRequest.Unmarshal(m, b)
 }
-func (m *AggregateTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AggregateTransactionsRequest.Marshal(b, m, deterministic)
+func (m *WalletBalanceAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletBalanceAtRequest.Marshal(b, m, deterministic)
 }
-func (m *AggregateTransactionsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AggregateTransactionsRequest.Merge(m, src)
+func (m *WalletBalanceAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletBalanceAtRequest.Merge(m, src)
 }
-func (m *AggregateTransactionsRequest) XXX_Size() int {
-	return xxx_messageInfo_AggregateTransactionsRequest.Size(m)
+func (m *WalletBalanceAtRequest) XXX_Size() int {
+	return xxx_messageInfo_WalletBalanceAtRequest.Size(m)
 }
-func (m *AggregateTransactionsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AggregateTransactionsRequest.DiscardUnknown(m)
+func (m *WalletBalanceAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletBalanceAtRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AggregateTransactionsRequest proto.InternalMessageInfo
+var xxx_messageInfo_WalletBalanceAtRequest proto.InternalMessageInfo
 
-func (m *AggregateTransactionsRequest) GetAggregate() string {
+func (m *WalletBalanceAtRequest) GetWallet() string {
 	if m != nil {
-		return m.Aggregate
+		return m.Wallet
 	}
 	return ""
 }
 
-type AggregateTransactionsResponse struct {
-	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *WalletBalanceAtRequest) GetAt() int64 {
+	if m != nil {
+		return m.At
+	}
+	return 0
 }
 
-func (m *AggregateTransactionsResponse) Reset()         { *m = AggregateTransactionsResponse{} }
-func (m *AggregateTransactionsResponse) String() string { return proto.CompactTextString(m) }
-func (*AggregateTransactionsResponse) ProtoMessage()    {}
-func (*AggregateTransactionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{18}
+func (m *WalletBalanceAtRequest) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
 }
 
-func (m *AggregateTransactionsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AggregateTransactionsResponse.Unmarshal(m, b)
+type WalletBalanceAtResponse struct {
+	Balances             []*Balance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
-func (m *AggregateTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AggregateTransactionsResponse.Marshal(b, m, deterministic)
+
+func (m *WalletBalanceAtResponse) Reset()         { *m = WalletBalanceAtResponse{} }
+func (m *WalletBalanceAtResponse) String() string { return proto.CompactTextString(m) }
+func (*WalletBalanceAtResponse) ProtoMessage()    {}
+func (*WalletBalanceAtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{19}
 }
-func (m *AggregateTransactionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AggregateTransactionsResponse.Merge(m, src)
+
+func (m *WalletBalanceAtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletBalanceAtResponse.Unmarshal(m, b)
 }
-func (m *AggregateTransactionsResponse) XXX_Size() int {
-	return xxx_messageInfo_AggregateTransactionsResponse.Size(m)
+func (m *WalletBalanceAtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletBalanceAtResponse.Marshal(b, m, deterministic)
 }
-func (m *AggregateTransactionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AggregateTransactionsResponse.DiscardUnknown(m)
+func (m *WalletBalanceAtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletBalanceAtResponse.Merge(m, src)
+}
+func (m *WalletBalanceAtResponse) XXX_Size() int {
+	return xxx_messageInfo_WalletBalanceAtResponse.Size(m)
+}
+func (m *WalletBalanceAtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletBalanceAtResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AggregateTransactionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_WalletBalanceAtResponse proto.InternalMessageInfo
 
-func (m *AggregateTransactionsResponse) GetTransactions() []*Transaction {
+func (m *WalletBalanceAtResponse) GetBalances() []*Balance {
 	if m != nil {
-		return m.Transactions
+		return m.Balances
 	}
 	return nil
 }
 
-type TransferWalletFundsRequest struct {
-	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
-	Amount               int32    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+type WalletTransactionsBetweenRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	From                 int64    `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	FromSequence         uint64   `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	To                   int64    `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	ToSequence           uint64   `protobuf:"varint,5,opt,name=to_sequence,json=toSequence,proto3" json:"to_sequence,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *TransferWalletFundsRequest) Reset()         { *m = TransferWalletFundsRequest{} }
-func (m *TransferWalletFundsRequest) String() string { return proto.CompactTextString(m) }
-func (*TransferWalletFundsRequest) ProtoMessage()    {}
-func (*TransferWalletFundsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{19}
+func (m *WalletTransactionsBetweenRequest) Reset()         { *m = WalletTransactionsBetweenRequest{} }
+func (m *WalletTransactionsBetweenRequest) String() string { return proto.CompactTextString(m) }
+func (*WalletTransactionsBetweenRequest) ProtoMessage()    {}
+func (*WalletTransactionsBetweenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{20}
 }
 
-func (m *TransferWalletFundsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TransferWalletFundsRequest.Unmarshal(m, b)
+func (m *WalletTransactionsBetweenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletTransactionsBetweenRequest.Unmarshal(m, b)
 }
-func (m *TransferWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TransferWalletFundsRequest.Marshal(b, m, deterministic)
+func (m *WalletTransactionsBetweenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletTransactionsBetweenRequest.Marshal(b, m, deterministic)
 }
-func (m *TransferWalletFundsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransferWalletFundsRequest.Merge(m, src)
+func (m *WalletTransactionsBetweenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletTransactionsBetweenRequest.Merge(m, src)
 }
-func (m *TransferWalletFundsRequest) XXX_Size() int {
-	return xxx_messageInfo_TransferWalletFundsRequest.Size(m)
+func (m *WalletTransactionsBetweenRequest) XXX_Size() int {
+	return xxx_messageInfo_WalletTransactionsBetweenRequest.Size(m)
 }
-func (m *TransferWalletFundsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransferWalletFundsRequest.DiscardUnknown(m)
+func (m *WalletTransactionsBetweenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletTransactionsBetweenRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransferWalletFundsRequest proto.InternalMessageInfo
+var xxx_messageInfo_WalletTransactionsBetweenRequest proto.InternalMessageInfo
 
-func (m *TransferWalletFundsRequest) GetSource() string {
+func (m *WalletTransactionsBetweenRequest) GetWallet() string {
 	if m != nil {
-		return m.Source
+		return m.Wallet
 	}
 	return ""
 }
 
-func (m *TransferWalletFundsRequest) GetDestination() string {
+func (m *WalletTransactionsBetweenRequest) GetFrom() int64 {
 	if m != nil {
-		return m.Destination
+		return m.From
 	}
-	return ""
+	return 0
 }
 
-func (m *TransferWalletFundsRequest) GetAmount() int32 {
+func (m *WalletTransactionsBetweenRequest) GetFromSequence() uint64 {
 	if m != nil {
-		return m.Amount
+		return m.FromSequence
 	}
 	return 0
 }
 
-type TransferWalletFundsResponse struct {
-	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *WalletTransactionsBetweenRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
 }
 
-func (m *TransferWalletFundsResponse) Reset()         { *m = TransferWalletFundsResponse{} }
-func (m *TransferWalletFundsResponse) String() string { return proto.CompactTextString(m) }
-func (*TransferWalletFundsResponse) ProtoMessage()    {}
-func (*TransferWalletFundsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{20}
+func (m *WalletTransactionsBetweenRequest) GetToSequence() uint64 {
+	if m != nil {
+		return m.ToSequence
+	}
+	return 0
 }
 
-func (m *TransferWalletFundsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TransferWalletFundsResponse.Unmarshal(m, b)
+type WalletTransactionsBetweenResponse struct {
+	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
-func (m *TransferWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TransferWalletFundsResponse.Marshal(b, m, deterministic)
+
+func (m *WalletTransactionsBetweenResponse) Reset()         { *m = WalletTransactionsBetweenResponse{} }
+func (m *WalletTransactionsBetweenResponse) String() string { return proto.CompactTextString(m) }
+func (*WalletTransactionsBetweenResponse) ProtoMessage()    {}
+func (*WalletTransactionsBetweenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{21}
 }
-func (m *TransferWalletFundsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TransferWalletFundsResponse.Merge(m, src)
+
+func (m *WalletTransactionsBetweenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WalletTransactionsBetweenResponse.Unmarshal(m, b)
 }
-func (m *TransferWalletFundsResponse) XXX_Size() int {
-	return xxx_messageInfo_TransferWalletFundsResponse.Size(m)
+func (m *WalletTransactionsBetweenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WalletTransactionsBetweenResponse.Marshal(b, m, deterministic)
 }
-func (m *TransferWalletFundsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_TransferWalletFundsResponse.DiscardUnknown(m)
+func (m *WalletTransactionsBetweenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WalletTransactionsBetweenResponse.Merge(m, src)
+}
+func (m *WalletTransactionsBetweenResponse) XXX_Size() int {
+	return xxx_messageInfo_WalletTransactionsBetweenResponse.Size(m)
+}
+func (m *WalletTransactionsBetweenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WalletTransactionsBetweenResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TransferWalletFundsResponse proto.InternalMessageInfo
+var xxx_messageInfo_WalletTransactionsBetweenResponse proto.InternalMessageInfo
 
-func (m *TransferWalletFundsResponse) GetResult() string {
+func (m *WalletTransactionsBetweenResponse) GetTransactions() []*Transaction {
 	if m != nil {
-		return m.Result
+		return m.Transactions
+	}
+	return nil
+}
+
+type AggregateTransactionsRequest struct {
+	Aggregate            string   `protobuf:"bytes,1,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AggregateTransactionsRequest) Reset()         { *m = AggregateTransactionsRequest{} }
+func (m *AggregateTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*AggregateTransactionsRequest) ProtoMessage()    {}
+func (*AggregateTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{22}
+}
+
+func (m *AggregateTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AggregateTransactionsRequest.Unmarshal(m, b)
+}
+func (m *AggregateTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AggregateTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *AggregateTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregateTransactionsRequest.Merge(m, src)
+}
+func (m *AggregateTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_AggregateTransactionsRequest.Size(m)
+}
+func (m *AggregateTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregateTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregateTransactionsRequest proto.InternalMessageInfo
+
+func (m *AggregateTransactionsRequest) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
 	}
 	return ""
 }
 
-type DepositWalletFundsRequest struct {
-	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Deposit              int32    `protobuf:"varint,2,opt,name=deposit,proto3" json:"deposit,omitempty"`
+type AggregateTransactionsResponse struct {
+	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *AggregateTransactionsResponse) Reset()         { *m = AggregateTransactionsResponse{} }
+func (m *AggregateTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*AggregateTransactionsResponse) ProtoMessage()    {}
+func (*AggregateTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{23}
+}
+
+func (m *AggregateTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AggregateTransactionsResponse.Unmarshal(m, b)
+}
+func (m *AggregateTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AggregateTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *AggregateTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AggregateTransactionsResponse.Merge(m, src)
+}
+func (m *AggregateTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_AggregateTransactionsResponse.Size(m)
+}
+func (m *AggregateTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AggregateTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AggregateTransactionsResponse proto.InternalMessageInfo
+
+func (m *AggregateTransactionsResponse) GetTransactions() []*Transaction {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+type TransferWalletFundsRequest struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DepositWalletFundsRequest) Reset()         { *m = DepositWalletFundsRequest{} }
-func (m *DepositWalletFundsRequest) String() string { return proto.CompactTextString(m) }
-func (*DepositWalletFundsRequest) ProtoMessage()    {}
-func (*DepositWalletFundsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{21}
+func (m *TransferWalletFundsRequest) Reset()         { *m = TransferWalletFundsRequest{} }
+func (m *TransferWalletFundsRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferWalletFundsRequest) ProtoMessage()    {}
+func (*TransferWalletFundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{24}
 }
 
-func (m *DepositWalletFundsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DepositWalletFundsRequest.Unmarshal(m, b)
+func (m *TransferWalletFundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferWalletFundsRequest.Unmarshal(m, b)
 }
-func (m *DepositWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DepositWalletFundsRequest.Marshal(b, m, deterministic)
+func (m *TransferWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferWalletFundsRequest.Marshal(b, m, deterministic)
 }
-func (m *DepositWalletFundsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DepositWalletFundsRequest.Merge(m, src)
+func (m *TransferWalletFundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferWalletFundsRequest.Merge(m, src)
 }
-func (m *DepositWalletFundsRequest) XXX_Size() int {
-	return xxx_messageInfo_DepositWalletFundsRequest.Size(m)
+func (m *TransferWalletFundsRequest) XXX_Size() int {
+	return xxx_messageInfo_TransferWalletFundsRequest.Size(m)
 }
-func (m *DepositWalletFundsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DepositWalletFundsRequest.DiscardUnknown(m)
+func (m *TransferWalletFundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferWalletFundsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DepositWalletFundsRequest proto.InternalMessageInfo
+var xxx_messageInfo_TransferWalletFundsRequest proto.InternalMessageInfo
 
-func (m *DepositWalletFundsRequest) GetWallet() string {
+func (m *TransferWalletFundsRequest) GetSource() string {
 	if m != nil {
-		return m.Wallet
+		return m.Source
 	}
 	return ""
 }
 
-func (m *DepositWalletFundsRequest) GetDeposit() int32 {
+func (m *TransferWalletFundsRequest) GetDestination() string {
 	if m != nil {
-		return m.Deposit
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *TransferWalletFundsRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
 	}
 	return 0
 }
 
-type DepositWalletFundsResponse struct {
+func (m *TransferWalletFundsRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *TransferWalletFundsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type TransferWalletFundsResponse struct {
 	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DepositWalletFundsResponse) Reset()         { *m = DepositWalletFundsResponse{} }
-func (m *DepositWalletFundsResponse) String() string { return proto.CompactTextString(m) }
-func (*DepositWalletFundsResponse) ProtoMessage()    {}
-func (*DepositWalletFundsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{22}
+func (m *TransferWalletFundsResponse) Reset()         { *m = TransferWalletFundsResponse{} }
+func (m *TransferWalletFundsResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferWalletFundsResponse) ProtoMessage()    {}
+func (*TransferWalletFundsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{25}
 }
 
-func (m *DepositWalletFundsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DepositWalletFundsResponse.Unmarshal(m, b)
+func (m *TransferWalletFundsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferWalletFundsResponse.Unmarshal(m, b)
 }
-func (m *DepositWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DepositWalletFundsResponse.Marshal(b, m, deterministic)
+func (m *TransferWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferWalletFundsResponse.Marshal(b, m, deterministic)
 }
-func (m *DepositWalletFundsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DepositWalletFundsResponse.Merge(m, src)
+func (m *TransferWalletFundsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferWalletFundsResponse.Merge(m, src)
 }
-func (m *DepositWalletFundsResponse) XXX_Size() int {
-	return xxx_messageInfo_DepositWalletFundsResponse.Size(m)
+func (m *TransferWalletFundsResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferWalletFundsResponse.Size(m)
 }
-func (m *DepositWalletFundsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DepositWalletFundsResponse.DiscardUnknown(m)
+func (m *TransferWalletFundsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferWalletFundsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DepositWalletFundsResponse proto.InternalMessageInfo
+var xxx_messageInfo_TransferWalletFundsResponse proto.InternalMessageInfo
 
-func (m *DepositWalletFundsResponse) GetResult() string {
+func (m *TransferWalletFundsResponse) GetResult() string {
 	if m != nil {
 		return m.Result
 	}
 	return ""
 }
 
-type WithdrawWalletFundsRequest struct {
-	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
-	Withdraw             int32    `protobuf:"varint,2,opt,name=withdraw,proto3" json:"withdraw,omitempty"`
+type ExchangeWalletFundsRequest struct {
+	Source               string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	TargetCurrency       string   `protobuf:"bytes,5,opt,name=target_currency,json=targetCurrency,proto3" json:"target_currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WithdrawWalletFundsRequest) Reset()         { *m = WithdrawWalletFundsRequest{} }
-func (m *WithdrawWalletFundsRequest) String() string { return proto.CompactTextString(m) }
-func (*WithdrawWalletFundsRequest) ProtoMessage()    {}
-func (*WithdrawWalletFundsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{23}
+func (m *ExchangeWalletFundsRequest) Reset()         { *m = ExchangeWalletFundsRequest{} }
+func (m *ExchangeWalletFundsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExchangeWalletFundsRequest) ProtoMessage()    {}
+func (*ExchangeWalletFundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{26}
 }
 
-func (m *WithdrawWalletFundsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WithdrawWalletFundsRequest.Unmarshal(m, b)
+func (m *ExchangeWalletFundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeWalletFundsRequest.Unmarshal(m, b)
 }
-func (m *WithdrawWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WithdrawWalletFundsRequest.Marshal(b, m, deterministic)
+func (m *ExchangeWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeWalletFundsRequest.Marshal(b, m, deterministic)
 }
-func (m *WithdrawWalletFundsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WithdrawWalletFundsRequest.Merge(m, src)
+func (m *ExchangeWalletFundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeWalletFundsRequest.Merge(m, src)
 }
-func (m *WithdrawWalletFundsRequest) XXX_Size() int {
-	return xxx_messageInfo_WithdrawWalletFundsRequest.Size(m)
+func (m *ExchangeWalletFundsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExchangeWalletFundsRequest.Size(m)
 }
-func (m *WithdrawWalletFundsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WithdrawWalletFundsRequest.DiscardUnknown(m)
+func (m *ExchangeWalletFundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeWalletFundsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WithdrawWalletFundsRequest proto.InternalMessageInfo
+var xxx_messageInfo_ExchangeWalletFundsRequest proto.InternalMessageInfo
 
-func (m *WithdrawWalletFundsRequest) GetWallet() string {
+func (m *ExchangeWalletFundsRequest) GetSource() string {
 	if m != nil {
-		return m.Wallet
+		return m.Source
 	}
 	return ""
 }
 
-func (m *WithdrawWalletFundsRequest) GetWithdraw() int32 {
+func (m *ExchangeWalletFundsRequest) GetDestination() string {
 	if m != nil {
-		return m.Withdraw
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *ExchangeWalletFundsRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
 	}
 	return 0
 }
 
-type WithdrawWalletFundsResponse struct {
+func (m *ExchangeWalletFundsRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *ExchangeWalletFundsRequest) GetTargetCurrency() string {
+	if m != nil {
+		return m.TargetCurrency
+	}
+	return ""
+}
+
+func (m *ExchangeWalletFundsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type ExchangeWalletFundsResponse struct {
 	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *WithdrawWalletFundsResponse) Reset()         { *m = WithdrawWalletFundsResponse{} }
-func (m *WithdrawWalletFundsResponse) String() string { return proto.CompactTextString(m) }
-func (*WithdrawWalletFundsResponse) ProtoMessage()    {}
-func (*WithdrawWalletFundsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_42f128396e519916, []int{24}
+func (m *ExchangeWalletFundsResponse) Reset()         { *m = ExchangeWalletFundsResponse{} }
+func (m *ExchangeWalletFundsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExchangeWalletFundsResponse) ProtoMessage()    {}
+func (*ExchangeWalletFundsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{27}
 }
 
-func (m *WithdrawWalletFundsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_WithdrawWalletFundsResponse.Unmarshal(m, b)
+func (m *ExchangeWalletFundsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeWalletFundsResponse.Unmarshal(m, b)
 }
-func (m *WithdrawWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_WithdrawWalletFundsResponse.Marshal(b, m, deterministic)
+func (m *ExchangeWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeWalletFundsResponse.Marshal(b, m, deterministic)
 }
-func (m *WithdrawWalletFundsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WithdrawWalletFundsResponse.Merge(m, src)
+func (m *ExchangeWalletFundsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeWalletFundsResponse.Merge(m, src)
 }
-func (m *WithdrawWalletFundsResponse) XXX_Size() int {
-	return xxx_messageInfo_WithdrawWalletFundsResponse.Size(m)
+func (m *ExchangeWalletFundsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExchangeWalletFundsResponse.Size(m)
 }
-func (m *WithdrawWalletFundsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_WithdrawWalletFundsResponse.DiscardUnknown(m)
+func (m *ExchangeWalletFundsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeWalletFundsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WithdrawWalletFundsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExchangeWalletFundsResponse proto.InternalMessageInfo
 
-func (m *WithdrawWalletFundsResponse) GetResult() string {
+func (m *ExchangeWalletFundsResponse) GetResult() string {
 	if m != nil {
 		return m.Result
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Transaction)(nil), "ledger.Transaction")
-	proto.RegisterType((*CreditTransaction)(nil), "ledger.CreditTransaction")
-	proto.RegisterType((*AddCreditTransactionRequest)(nil), "ledger.AddCreditTransactionRequest")
-	proto.RegisterType((*AddCreditTransactionResponse)(nil), "ledger.AddCreditTransactionResponse")
-	proto.RegisterType((*DebitTransaction)(nil), "ledger.DebitTransaction")
-	proto.RegisterType((*AddDebitTransactionRequest)(nil), "ledger.AddDebitTransactionRequest")
-	proto.RegisterType((*AddDebitTransactionResponse)(nil), "ledger.AddDebitTransactionResponse")
-	proto.RegisterType((*CashInTransaction)(nil), "ledger.CashInTransaction")
+type Entry struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Wallet               string   `protobuf:"bytes,2,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Entry) Reset()         { *m = Entry{} }
+func (m *Entry) String() string { return proto.CompactTextString(m) }
+func (*Entry) ProtoMessage()    {}
+func (*Entry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{28}
+}
+
+func (m *Entry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Entry.Unmarshal(m, b)
+}
+func (m *Entry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Entry.Marshal(b, m, deterministic)
+}
+func (m *Entry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Entry.Merge(m, src)
+}
+func (m *Entry) XXX_Size() int {
+	return xxx_messageInfo_Entry.Size(m)
+}
+func (m *Entry) XXX_DiscardUnknown() {
+	xxx_messageInfo_Entry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Entry proto.InternalMessageInfo
+
+func (m *Entry) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Entry) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *Entry) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Entry) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+type PostRequest struct {
+	Entries              []*Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PostRequest) Reset()         { *m = PostRequest{} }
+func (m *PostRequest) String() string { return proto.CompactTextString(m) }
+func (*PostRequest) ProtoMessage()    {}
+func (*PostRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{29}
+}
+
+func (m *PostRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostRequest.Unmarshal(m, b)
+}
+func (m *PostRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostRequest.Marshal(b, m, deterministic)
+}
+func (m *PostRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostRequest.Merge(m, src)
+}
+func (m *PostRequest) XXX_Size() int {
+	return xxx_messageInfo_PostRequest.Size(m)
+}
+func (m *PostRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostRequest proto.InternalMessageInfo
+
+func (m *PostRequest) GetEntries() []*Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *PostRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type PostResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PostResponse) Reset()         { *m = PostResponse{} }
+func (m *PostResponse) String() string { return proto.CompactTextString(m) }
+func (*PostResponse) ProtoMessage()    {}
+func (*PostResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{30}
+}
+
+func (m *PostResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PostResponse.Unmarshal(m, b)
+}
+func (m *PostResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PostResponse.Marshal(b, m, deterministic)
+}
+func (m *PostResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PostResponse.Merge(m, src)
+}
+func (m *PostResponse) XXX_Size() int {
+	return xxx_messageInfo_PostResponse.Size(m)
+}
+func (m *PostResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PostResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PostResponse proto.InternalMessageInfo
+
+func (m *PostResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type ReverseAggregateRequest struct {
+	Aggregate            string   `protobuf:"bytes,1,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReverseAggregateRequest) Reset()         { *m = ReverseAggregateRequest{} }
+func (m *ReverseAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*ReverseAggregateRequest) ProtoMessage()    {}
+func (*ReverseAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{31}
+}
+
+func (m *ReverseAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReverseAggregateRequest.Unmarshal(m, b)
+}
+func (m *ReverseAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReverseAggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *ReverseAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReverseAggregateRequest.Merge(m, src)
+}
+func (m *ReverseAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_ReverseAggregateRequest.Size(m)
+}
+func (m *ReverseAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReverseAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReverseAggregateRequest proto.InternalMessageInfo
+
+func (m *ReverseAggregateRequest) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
+	}
+	return ""
+}
+
+func (m *ReverseAggregateRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ReverseAggregateRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type ReverseAggregateResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReverseAggregateResponse) Reset()         { *m = ReverseAggregateResponse{} }
+func (m *ReverseAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*ReverseAggregateResponse) ProtoMessage()    {}
+func (*ReverseAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{32}
+}
+
+func (m *ReverseAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReverseAggregateResponse.Unmarshal(m, b)
+}
+func (m *ReverseAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReverseAggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *ReverseAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReverseAggregateResponse.Merge(m, src)
+}
+func (m *ReverseAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_ReverseAggregateResponse.Size(m)
+}
+func (m *ReverseAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReverseAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReverseAggregateResponse proto.InternalMessageInfo
+
+func (m *ReverseAggregateResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type RefundAggregateRequest struct {
+	Aggregate            string   `protobuf:"bytes,1,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundAggregateRequest) Reset()         { *m = RefundAggregateRequest{} }
+func (m *RefundAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*RefundAggregateRequest) ProtoMessage()    {}
+func (*RefundAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{33}
+}
+
+func (m *RefundAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundAggregateRequest.Unmarshal(m, b)
+}
+func (m *RefundAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundAggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *RefundAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundAggregateRequest.Merge(m, src)
+}
+func (m *RefundAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_RefundAggregateRequest.Size(m)
+}
+func (m *RefundAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundAggregateRequest proto.InternalMessageInfo
+
+func (m *RefundAggregateRequest) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
+	}
+	return ""
+}
+
+func (m *RefundAggregateRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *RefundAggregateRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *RefundAggregateRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type RefundAggregateResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RefundAggregateResponse) Reset()         { *m = RefundAggregateResponse{} }
+func (m *RefundAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*RefundAggregateResponse) ProtoMessage()    {}
+func (*RefundAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{34}
+}
+
+func (m *RefundAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefundAggregateResponse.Unmarshal(m, b)
+}
+func (m *RefundAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefundAggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *RefundAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefundAggregateResponse.Merge(m, src)
+}
+func (m *RefundAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_RefundAggregateResponse.Size(m)
+}
+func (m *RefundAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefundAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefundAggregateResponse proto.InternalMessageInfo
+
+func (m *RefundAggregateResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type AuthorizeHoldRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	ExpiresAt            int64    `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorizeHoldRequest) Reset()         { *m = AuthorizeHoldRequest{} }
+func (m *AuthorizeHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*AuthorizeHoldRequest) ProtoMessage()    {}
+func (*AuthorizeHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{35}
+}
+
+func (m *AuthorizeHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorizeHoldRequest.Unmarshal(m, b)
+}
+func (m *AuthorizeHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorizeHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *AuthorizeHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorizeHoldRequest.Merge(m, src)
+}
+func (m *AuthorizeHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_AuthorizeHoldRequest.Size(m)
+}
+func (m *AuthorizeHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorizeHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorizeHoldRequest proto.InternalMessageInfo
+
+func (m *AuthorizeHoldRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *AuthorizeHoldRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *AuthorizeHoldRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *AuthorizeHoldRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *AuthorizeHoldRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type AuthorizeHoldResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorizeHoldResponse) Reset()         { *m = AuthorizeHoldResponse{} }
+func (m *AuthorizeHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*AuthorizeHoldResponse) ProtoMessage()    {}
+func (*AuthorizeHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{36}
+}
+
+func (m *AuthorizeHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorizeHoldResponse.Unmarshal(m, b)
+}
+func (m *AuthorizeHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorizeHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *AuthorizeHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorizeHoldResponse.Merge(m, src)
+}
+func (m *AuthorizeHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_AuthorizeHoldResponse.Size(m)
+}
+func (m *AuthorizeHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorizeHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorizeHoldResponse proto.InternalMessageInfo
+
+func (m *AuthorizeHoldResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type CaptureHoldRequest struct {
+	Hold                 string   `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	Destination          string   `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CaptureHoldRequest) Reset()         { *m = CaptureHoldRequest{} }
+func (m *CaptureHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*CaptureHoldRequest) ProtoMessage()    {}
+func (*CaptureHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{37}
+}
+
+func (m *CaptureHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureHoldRequest.Unmarshal(m, b)
+}
+func (m *CaptureHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *CaptureHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureHoldRequest.Merge(m, src)
+}
+func (m *CaptureHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_CaptureHoldRequest.Size(m)
+}
+func (m *CaptureHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CaptureHoldRequest proto.InternalMessageInfo
+
+func (m *CaptureHoldRequest) GetHold() string {
+	if m != nil {
+		return m.Hold
+	}
+	return ""
+}
+
+func (m *CaptureHoldRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *CaptureHoldRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *CaptureHoldRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *CaptureHoldRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type CaptureHoldResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CaptureHoldResponse) Reset()         { *m = CaptureHoldResponse{} }
+func (m *CaptureHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*CaptureHoldResponse) ProtoMessage()    {}
+func (*CaptureHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{38}
+}
+
+func (m *CaptureHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CaptureHoldResponse.Unmarshal(m, b)
+}
+func (m *CaptureHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CaptureHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *CaptureHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CaptureHoldResponse.Merge(m, src)
+}
+func (m *CaptureHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_CaptureHoldResponse.Size(m)
+}
+func (m *CaptureHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CaptureHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CaptureHoldResponse proto.InternalMessageInfo
+
+func (m *CaptureHoldResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type VoidHoldRequest struct {
+	Hold                 string   `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VoidHoldRequest) Reset()         { *m = VoidHoldRequest{} }
+func (m *VoidHoldRequest) String() string { return proto.CompactTextString(m) }
+func (*VoidHoldRequest) ProtoMessage()    {}
+func (*VoidHoldRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{39}
+}
+
+func (m *VoidHoldRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VoidHoldRequest.Unmarshal(m, b)
+}
+func (m *VoidHoldRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VoidHoldRequest.Marshal(b, m, deterministic)
+}
+func (m *VoidHoldRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoidHoldRequest.Merge(m, src)
+}
+func (m *VoidHoldRequest) XXX_Size() int {
+	return xxx_messageInfo_VoidHoldRequest.Size(m)
+}
+func (m *VoidHoldRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoidHoldRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoidHoldRequest proto.InternalMessageInfo
+
+func (m *VoidHoldRequest) GetHold() string {
+	if m != nil {
+		return m.Hold
+	}
+	return ""
+}
+
+func (m *VoidHoldRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type VoidHoldResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VoidHoldResponse) Reset()         { *m = VoidHoldResponse{} }
+func (m *VoidHoldResponse) String() string { return proto.CompactTextString(m) }
+func (*VoidHoldResponse) ProtoMessage()    {}
+func (*VoidHoldResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{40}
+}
+
+func (m *VoidHoldResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VoidHoldResponse.Unmarshal(m, b)
+}
+func (m *VoidHoldResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VoidHoldResponse.Marshal(b, m, deterministic)
+}
+func (m *VoidHoldResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoidHoldResponse.Merge(m, src)
+}
+func (m *VoidHoldResponse) XXX_Size() int {
+	return xxx_messageInfo_VoidHoldResponse.Size(m)
+}
+func (m *VoidHoldResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoidHoldResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoidHoldResponse proto.InternalMessageInfo
+
+func (m *VoidHoldResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type SetOverdraftPolicyRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Unlimited            bool     `protobuf:"varint,2,opt,name=unlimited,proto3" json:"unlimited,omitempty"`
+	Limit                int64    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetOverdraftPolicyRequest) Reset()         { *m = SetOverdraftPolicyRequest{} }
+func (m *SetOverdraftPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetOverdraftPolicyRequest) ProtoMessage()    {}
+func (*SetOverdraftPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{41}
+}
+
+func (m *SetOverdraftPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOverdraftPolicyRequest.Unmarshal(m, b)
+}
+func (m *SetOverdraftPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOverdraftPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *SetOverdraftPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOverdraftPolicyRequest.Merge(m, src)
+}
+func (m *SetOverdraftPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetOverdraftPolicyRequest.Size(m)
+}
+func (m *SetOverdraftPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOverdraftPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOverdraftPolicyRequest proto.InternalMessageInfo
+
+func (m *SetOverdraftPolicyRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *SetOverdraftPolicyRequest) GetUnlimited() bool {
+	if m != nil {
+		return m.Unlimited
+	}
+	return false
+}
+
+func (m *SetOverdraftPolicyRequest) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *SetOverdraftPolicyRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *SetOverdraftPolicyRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type SetOverdraftPolicyResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetOverdraftPolicyResponse) Reset()         { *m = SetOverdraftPolicyResponse{} }
+func (m *SetOverdraftPolicyResponse) String() string { return proto.CompactTextString(m) }
+func (*SetOverdraftPolicyResponse) ProtoMessage()    {}
+func (*SetOverdraftPolicyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{42}
+}
+
+func (m *SetOverdraftPolicyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetOverdraftPolicyResponse.Unmarshal(m, b)
+}
+func (m *SetOverdraftPolicyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetOverdraftPolicyResponse.Marshal(b, m, deterministic)
+}
+func (m *SetOverdraftPolicyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetOverdraftPolicyResponse.Merge(m, src)
+}
+func (m *SetOverdraftPolicyResponse) XXX_Size() int {
+	return xxx_messageInfo_SetOverdraftPolicyResponse.Size(m)
+}
+func (m *SetOverdraftPolicyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetOverdraftPolicyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetOverdraftPolicyResponse proto.InternalMessageInfo
+
+func (m *SetOverdraftPolicyResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type FeeBand struct {
+	UpTo                 int64    `protobuf:"varint,1,opt,name=up_to,json=upTo,proto3" json:"up_to,omitempty"`
+	Flat                 int64    `protobuf:"varint,2,opt,name=flat,proto3" json:"flat,omitempty"`
+	BasisPoints          int64    `protobuf:"varint,3,opt,name=basis_points,json=basisPoints,proto3" json:"basis_points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FeeBand) Reset()         { *m = FeeBand{} }
+func (m *FeeBand) String() string { return proto.CompactTextString(m) }
+func (*FeeBand) ProtoMessage()    {}
+func (*FeeBand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{43}
+}
+
+func (m *FeeBand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeeBand.Unmarshal(m, b)
+}
+func (m *FeeBand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeeBand.Marshal(b, m, deterministic)
+}
+func (m *FeeBand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeeBand.Merge(m, src)
+}
+func (m *FeeBand) XXX_Size() int {
+	return xxx_messageInfo_FeeBand.Size(m)
+}
+func (m *FeeBand) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeeBand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeeBand proto.InternalMessageInfo
+
+func (m *FeeBand) GetUpTo() int64 {
+	if m != nil {
+		return m.UpTo
+	}
+	return 0
+}
+
+func (m *FeeBand) GetFlat() int64 {
+	if m != nil {
+		return m.Flat
+	}
+	return 0
+}
+
+func (m *FeeBand) GetBasisPoints() int64 {
+	if m != nil {
+		return m.BasisPoints
+	}
+	return 0
+}
+
+type FeeSchedule struct {
+	Command              string     `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Tier                 string     `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	Currency             string     `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Wallet               string     `protobuf:"bytes,4,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Flat                 int64      `protobuf:"varint,5,opt,name=flat,proto3" json:"flat,omitempty"`
+	BasisPoints          int64      `protobuf:"varint,6,opt,name=basis_points,json=basisPoints,proto3" json:"basis_points,omitempty"`
+	Bands                []*FeeBand `protobuf:"bytes,7,rep,name=bands,proto3" json:"bands,omitempty"`
+	Min                  int64      `protobuf:"varint,8,opt,name=min,proto3" json:"min,omitempty"`
+	Max                  int64      `protobuf:"varint,9,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FeeSchedule) Reset()         { *m = FeeSchedule{} }
+func (m *FeeSchedule) String() string { return proto.CompactTextString(m) }
+func (*FeeSchedule) ProtoMessage()    {}
+func (*FeeSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{44}
+}
+
+func (m *FeeSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeeSchedule.Unmarshal(m, b)
+}
+func (m *FeeSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeeSchedule.Marshal(b, m, deterministic)
+}
+func (m *FeeSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeeSchedule.Merge(m, src)
+}
+func (m *FeeSchedule) XXX_Size() int {
+	return xxx_messageInfo_FeeSchedule.Size(m)
+}
+func (m *FeeSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeeSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeeSchedule proto.InternalMessageInfo
+
+func (m *FeeSchedule) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *FeeSchedule) GetTier() string {
+	if m != nil {
+		return m.Tier
+	}
+	return ""
+}
+
+func (m *FeeSchedule) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *FeeSchedule) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *FeeSchedule) GetFlat() int64 {
+	if m != nil {
+		return m.Flat
+	}
+	return 0
+}
+
+func (m *FeeSchedule) GetBasisPoints() int64 {
+	if m != nil {
+		return m.BasisPoints
+	}
+	return 0
+}
+
+func (m *FeeSchedule) GetBands() []*FeeBand {
+	if m != nil {
+		return m.Bands
+	}
+	return nil
+}
+
+func (m *FeeSchedule) GetMin() int64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *FeeSchedule) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type SetFeeScheduleRequest struct {
+	Schedule             *FeeSchedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	IdempotencyKey       string       `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetFeeScheduleRequest) Reset()         { *m = SetFeeScheduleRequest{} }
+func (m *SetFeeScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetFeeScheduleRequest) ProtoMessage()    {}
+func (*SetFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{45}
+}
+
+func (m *SetFeeScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetFeeScheduleRequest.Unmarshal(m, b)
+}
+func (m *SetFeeScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetFeeScheduleRequest.Marshal(b, m, deterministic)
+}
+func (m *SetFeeScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFeeScheduleRequest.Merge(m, src)
+}
+func (m *SetFeeScheduleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetFeeScheduleRequest.Size(m)
+}
+func (m *SetFeeScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFeeScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFeeScheduleRequest proto.InternalMessageInfo
+
+func (m *SetFeeScheduleRequest) GetSchedule() *FeeSchedule {
+	if m != nil {
+		return m.Schedule
+	}
+	return nil
+}
+
+func (m *SetFeeScheduleRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type SetFeeScheduleResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetFeeScheduleResponse) Reset()         { *m = SetFeeScheduleResponse{} }
+func (m *SetFeeScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*SetFeeScheduleResponse) ProtoMessage()    {}
+func (*SetFeeScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{46}
+}
+
+func (m *SetFeeScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetFeeScheduleResponse.Unmarshal(m, b)
+}
+func (m *SetFeeScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetFeeScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *SetFeeScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFeeScheduleResponse.Merge(m, src)
+}
+func (m *SetFeeScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_SetFeeScheduleResponse.Size(m)
+}
+func (m *SetFeeScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFeeScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFeeScheduleResponse proto.InternalMessageInfo
+
+func (m *SetFeeScheduleResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type QuoteFeeRequest struct {
+	Command              string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Wallet               string   `protobuf:"bytes,2,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteFeeRequest) Reset()         { *m = QuoteFeeRequest{} }
+func (m *QuoteFeeRequest) String() string { return proto.CompactTextString(m) }
+func (*QuoteFeeRequest) ProtoMessage()    {}
+func (*QuoteFeeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{47}
+}
+
+func (m *QuoteFeeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteFeeRequest.Unmarshal(m, b)
+}
+func (m *QuoteFeeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteFeeRequest.Marshal(b, m, deterministic)
+}
+func (m *QuoteFeeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteFeeRequest.Merge(m, src)
+}
+func (m *QuoteFeeRequest) XXX_Size() int {
+	return xxx_messageInfo_QuoteFeeRequest.Size(m)
+}
+func (m *QuoteFeeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteFeeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteFeeRequest proto.InternalMessageInfo
+
+func (m *QuoteFeeRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *QuoteFeeRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *QuoteFeeRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *QuoteFeeRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+// total is what would be taken out of the wallet, the amount and its fee
+type QuoteFeeResponse struct {
+	Fee                  int64    `protobuf:"varint,1,opt,name=fee,proto3" json:"fee,omitempty"`
+	Total                int64    `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Currency             string   `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Wallet               string   `protobuf:"bytes,4,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteFeeResponse) Reset()         { *m = QuoteFeeResponse{} }
+func (m *QuoteFeeResponse) String() string { return proto.CompactTextString(m) }
+func (*QuoteFeeResponse) ProtoMessage()    {}
+func (*QuoteFeeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{48}
+}
+
+func (m *QuoteFeeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteFeeResponse.Unmarshal(m, b)
+}
+func (m *QuoteFeeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteFeeResponse.Marshal(b, m, deterministic)
+}
+func (m *QuoteFeeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteFeeResponse.Merge(m, src)
+}
+func (m *QuoteFeeResponse) XXX_Size() int {
+	return xxx_messageInfo_QuoteFeeResponse.Size(m)
+}
+func (m *QuoteFeeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteFeeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteFeeResponse proto.InternalMessageInfo
+
+func (m *QuoteFeeResponse) GetFee() int64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *QuoteFeeResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *QuoteFeeResponse) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *QuoteFeeResponse) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+// rate is the annual rate as a decimal, such as "0.025" for 2.5%
+type InterestPolicy struct {
+	Rate                 string   `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	DayCount             string   `protobuf:"bytes,3,opt,name=day_count,json=dayCount,proto3" json:"day_count,omitempty"`
+	Rounding             string   `protobuf:"bytes,4,opt,name=rounding,proto3" json:"rounding,omitempty"`
+	Posting              string   `protobuf:"bytes,5,opt,name=posting,proto3" json:"posting,omitempty"`
+	Source               string   `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InterestPolicy) Reset()         { *m = InterestPolicy{} }
+func (m *InterestPolicy) String() string { return proto.CompactTextString(m) }
+func (*InterestPolicy) ProtoMessage()    {}
+func (*InterestPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{49}
+}
+
+func (m *InterestPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InterestPolicy.Unmarshal(m, b)
+}
+func (m *InterestPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InterestPolicy.Marshal(b, m, deterministic)
+}
+func (m *InterestPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InterestPolicy.Merge(m, src)
+}
+func (m *InterestPolicy) XXX_Size() int {
+	return xxx_messageInfo_InterestPolicy.Size(m)
+}
+func (m *InterestPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_InterestPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InterestPolicy proto.InternalMessageInfo
+
+func (m *InterestPolicy) GetRate() string {
+	if m != nil {
+		return m.Rate
+	}
+	return ""
+}
+
+func (m *InterestPolicy) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *InterestPolicy) GetDayCount() string {
+	if m != nil {
+		return m.DayCount
+	}
+	return ""
+}
+
+func (m *InterestPolicy) GetRounding() string {
+	if m != nil {
+		return m.Rounding
+	}
+	return ""
+}
+
+func (m *InterestPolicy) GetPosting() string {
+	if m != nil {
+		return m.Posting
+	}
+	return ""
+}
+
+func (m *InterestPolicy) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+type SetInterestPolicyRequest struct {
+	Wallet               string          `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Policy               *InterestPolicy `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	IdempotencyKey       string          `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SetInterestPolicyRequest) Reset()         { *m = SetInterestPolicyRequest{} }
+func (m *SetInterestPolicyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetInterestPolicyRequest) ProtoMessage()    {}
+func (*SetInterestPolicyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{50}
+}
+
+func (m *SetInterestPolicyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetInterestPolicyRequest.Unmarshal(m, b)
+}
+func (m *SetInterestPolicyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetInterestPolicyRequest.Marshal(b, m, deterministic)
+}
+func (m *SetInterestPolicyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetInterestPolicyRequest.Merge(m, src)
+}
+func (m *SetInterestPolicyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetInterestPolicyRequest.Size(m)
+}
+func (m *SetInterestPolicyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetInterestPolicyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetInterestPolicyRequest proto.InternalMessageInfo
+
+func (m *SetInterestPolicyRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *SetInterestPolicyRequest) GetPolicy() *InterestPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *SetInterestPolicyRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type SetInterestPolicyResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetInterestPolicyResponse) Reset()         { *m = SetInterestPolicyResponse{} }
+func (m *SetInterestPolicyResponse) String() string { return proto.CompactTextString(m) }
+func (*SetInterestPolicyResponse) ProtoMessage()    {}
+func (*SetInterestPolicyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{51}
+}
+
+func (m *SetInterestPolicyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetInterestPolicyResponse.Unmarshal(m, b)
+}
+func (m *SetInterestPolicyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetInterestPolicyResponse.Marshal(b, m, deterministic)
+}
+func (m *SetInterestPolicyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetInterestPolicyResponse.Merge(m, src)
+}
+func (m *SetInterestPolicyResponse) XXX_Size() int {
+	return xxx_messageInfo_SetInterestPolicyResponse.Size(m)
+}
+func (m *SetInterestPolicyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetInterestPolicyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetInterestPolicyResponse proto.InternalMessageInfo
+
+func (m *SetInterestPolicyResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+// through is the last day to accrue interest for, laid out as 2006-01-02
+type AccrueInterestRequest struct {
+	Through              string   `protobuf:"bytes,1,opt,name=through,proto3" json:"through,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccrueInterestRequest) Reset()         { *m = AccrueInterestRequest{} }
+func (m *AccrueInterestRequest) String() string { return proto.CompactTextString(m) }
+func (*AccrueInterestRequest) ProtoMessage()    {}
+func (*AccrueInterestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{52}
+}
+
+func (m *AccrueInterestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccrueInterestRequest.Unmarshal(m, b)
+}
+func (m *AccrueInterestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccrueInterestRequest.Marshal(b, m, deterministic)
+}
+func (m *AccrueInterestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccrueInterestRequest.Merge(m, src)
+}
+func (m *AccrueInterestRequest) XXX_Size() int {
+	return xxx_messageInfo_AccrueInterestRequest.Size(m)
+}
+func (m *AccrueInterestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccrueInterestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccrueInterestRequest proto.InternalMessageInfo
+
+func (m *AccrueInterestRequest) GetThrough() string {
+	if m != nil {
+		return m.Through
+	}
+	return ""
+}
+
+func (m *AccrueInterestRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+// aggregate is empty when no interest was posted
+// skipped are the wallets whose interest couldn't be accrued, they're tried again on the next run
+type AccrueInterestResponse struct {
+	Aggregate            string   `protobuf:"bytes,1,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Skipped              []string `protobuf:"bytes,2,rep,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccrueInterestResponse) Reset()         { *m = AccrueInterestResponse{} }
+func (m *AccrueInterestResponse) String() string { return proto.CompactTextString(m) }
+func (*AccrueInterestResponse) ProtoMessage()    {}
+func (*AccrueInterestResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{53}
+}
+
+func (m *AccrueInterestResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccrueInterestResponse.Unmarshal(m, b)
+}
+func (m *AccrueInterestResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccrueInterestResponse.Marshal(b, m, deterministic)
+}
+func (m *AccrueInterestResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccrueInterestResponse.Merge(m, src)
+}
+func (m *AccrueInterestResponse) XXX_Size() int {
+	return xxx_messageInfo_AccrueInterestResponse.Size(m)
+}
+func (m *AccrueInterestResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccrueInterestResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccrueInterestResponse proto.InternalMessageInfo
+
+func (m *AccrueInterestResponse) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
+	}
+	return ""
+}
+
+func (m *AccrueInterestResponse) GetSkipped() []string {
+	if m != nil {
+		return m.Skipped
+	}
+	return nil
+}
+
+type DefineAccountRequest struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Parent               string   `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DefineAccountRequest) Reset()         { *m = DefineAccountRequest{} }
+func (m *DefineAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*DefineAccountRequest) ProtoMessage()    {}
+func (*DefineAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{54}
+}
+
+func (m *DefineAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DefineAccountRequest.Unmarshal(m, b)
+}
+func (m *DefineAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DefineAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *DefineAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DefineAccountRequest.Merge(m, src)
+}
+func (m *DefineAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_DefineAccountRequest.Size(m)
+}
+func (m *DefineAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DefineAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DefineAccountRequest proto.InternalMessageInfo
+
+func (m *DefineAccountRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *DefineAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DefineAccountRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DefineAccountRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *DefineAccountRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type DefineAccountResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DefineAccountResponse) Reset()         { *m = DefineAccountResponse{} }
+func (m *DefineAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*DefineAccountResponse) ProtoMessage()    {}
+func (*DefineAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{55}
+}
+
+func (m *DefineAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DefineAccountResponse.Unmarshal(m, b)
+}
+func (m *DefineAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DefineAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *DefineAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DefineAccountResponse.Merge(m, src)
+}
+func (m *DefineAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_DefineAccountResponse.Size(m)
+}
+func (m *DefineAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DefineAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DefineAccountResponse proto.InternalMessageInfo
+
+func (m *DefineAccountResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type AccountBalanceRequest struct {
+	Code                 string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountBalanceRequest) Reset()         { *m = AccountBalanceRequest{} }
+func (m *AccountBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*AccountBalanceRequest) ProtoMessage()    {}
+func (*AccountBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{56}
+}
+
+func (m *AccountBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountBalanceRequest.Unmarshal(m, b)
+}
+func (m *AccountBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *AccountBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountBalanceRequest.Merge(m, src)
+}
+func (m *AccountBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_AccountBalanceRequest.Size(m)
+}
+func (m *AccountBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountBalanceRequest proto.InternalMessageInfo
+
+func (m *AccountBalanceRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type AccountBalanceResponse struct {
+	Balances             []*Balance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AccountBalanceResponse) Reset()         { *m = AccountBalanceResponse{} }
+func (m *AccountBalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountBalanceResponse) ProtoMessage()    {}
+func (*AccountBalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{57}
+}
+
+func (m *AccountBalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountBalanceResponse.Unmarshal(m, b)
+}
+func (m *AccountBalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountBalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountBalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountBalanceResponse.Merge(m, src)
+}
+func (m *AccountBalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountBalanceResponse.Size(m)
+}
+func (m *AccountBalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountBalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountBalanceResponse proto.InternalMessageInfo
+
+func (m *AccountBalanceResponse) GetBalances() []*Balance {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+type DepositWalletFundsRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Deposit              int64    `protobuf:"varint,2,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	Currency             string   `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DepositWalletFundsRequest) Reset()         { *m = DepositWalletFundsRequest{} }
+func (m *DepositWalletFundsRequest) String() string { return proto.CompactTextString(m) }
+func (*DepositWalletFundsRequest) ProtoMessage()    {}
+func (*DepositWalletFundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{58}
+}
+
+func (m *DepositWalletFundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepositWalletFundsRequest.Unmarshal(m, b)
+}
+func (m *DepositWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepositWalletFundsRequest.Marshal(b, m, deterministic)
+}
+func (m *DepositWalletFundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepositWalletFundsRequest.Merge(m, src)
+}
+func (m *DepositWalletFundsRequest) XXX_Size() int {
+	return xxx_messageInfo_DepositWalletFundsRequest.Size(m)
+}
+func (m *DepositWalletFundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepositWalletFundsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepositWalletFundsRequest proto.InternalMessageInfo
+
+func (m *DepositWalletFundsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *DepositWalletFundsRequest) GetDeposit() int64 {
+	if m != nil {
+		return m.Deposit
+	}
+	return 0
+}
+
+func (m *DepositWalletFundsRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *DepositWalletFundsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type DepositWalletFundsResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DepositWalletFundsResponse) Reset()         { *m = DepositWalletFundsResponse{} }
+func (m *DepositWalletFundsResponse) String() string { return proto.CompactTextString(m) }
+func (*DepositWalletFundsResponse) ProtoMessage()    {}
+func (*DepositWalletFundsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{59}
+}
+
+func (m *DepositWalletFundsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepositWalletFundsResponse.Unmarshal(m, b)
+}
+func (m *DepositWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepositWalletFundsResponse.Marshal(b, m, deterministic)
+}
+func (m *DepositWalletFundsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepositWalletFundsResponse.Merge(m, src)
+}
+func (m *DepositWalletFundsResponse) XXX_Size() int {
+	return xxx_messageInfo_DepositWalletFundsResponse.Size(m)
+}
+func (m *DepositWalletFundsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepositWalletFundsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepositWalletFundsResponse proto.InternalMessageInfo
+
+func (m *DepositWalletFundsResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type WithdrawWalletFundsRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Withdraw             int64    `protobuf:"varint,2,opt,name=withdraw,proto3" json:"withdraw,omitempty"`
+	Currency             string   `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WithdrawWalletFundsRequest) Reset()         { *m = WithdrawWalletFundsRequest{} }
+func (m *WithdrawWalletFundsRequest) String() string { return proto.CompactTextString(m) }
+func (*WithdrawWalletFundsRequest) ProtoMessage()    {}
+func (*WithdrawWalletFundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{60}
+}
+
+func (m *WithdrawWalletFundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WithdrawWalletFundsRequest.Unmarshal(m, b)
+}
+func (m *WithdrawWalletFundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WithdrawWalletFundsRequest.Marshal(b, m, deterministic)
+}
+func (m *WithdrawWalletFundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WithdrawWalletFundsRequest.Merge(m, src)
+}
+func (m *WithdrawWalletFundsRequest) XXX_Size() int {
+	return xxx_messageInfo_WithdrawWalletFundsRequest.Size(m)
+}
+func (m *WithdrawWalletFundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WithdrawWalletFundsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WithdrawWalletFundsRequest proto.InternalMessageInfo
+
+func (m *WithdrawWalletFundsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *WithdrawWalletFundsRequest) GetWithdraw() int64 {
+	if m != nil {
+		return m.Withdraw
+	}
+	return 0
+}
+
+func (m *WithdrawWalletFundsRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *WithdrawWalletFundsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type WithdrawWalletFundsResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WithdrawWalletFundsResponse) Reset()         { *m = WithdrawWalletFundsResponse{} }
+func (m *WithdrawWalletFundsResponse) String() string { return proto.CompactTextString(m) }
+func (*WithdrawWalletFundsResponse) ProtoMessage()    {}
+func (*WithdrawWalletFundsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{61}
+}
+
+func (m *WithdrawWalletFundsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WithdrawWalletFundsResponse.Unmarshal(m, b)
+}
+func (m *WithdrawWalletFundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WithdrawWalletFundsResponse.Marshal(b, m, deterministic)
+}
+func (m *WithdrawWalletFundsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WithdrawWalletFundsResponse.Merge(m, src)
+}
+func (m *WithdrawWalletFundsResponse) XXX_Size() int {
+	return xxx_messageInfo_WithdrawWalletFundsResponse.Size(m)
+}
+func (m *WithdrawWalletFundsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WithdrawWalletFundsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WithdrawWalletFundsResponse proto.InternalMessageInfo
+
+func (m *WithdrawWalletFundsResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type Wallet struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner                string            `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Status               string            `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Tier                 string            `protobuf:"bytes,5,opt,name=tier,proto3" json:"tier,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Wallet) Reset()         { *m = Wallet{} }
+func (m *Wallet) String() string { return proto.CompactTextString(m) }
+func (*Wallet) ProtoMessage()    {}
+func (*Wallet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{62}
+}
+
+func (m *Wallet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Wallet.Unmarshal(m, b)
+}
+func (m *Wallet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Wallet.Marshal(b, m, deterministic)
+}
+func (m *Wallet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Wallet.Merge(m, src)
+}
+func (m *Wallet) XXX_Size() int {
+	return xxx_messageInfo_Wallet.Size(m)
+}
+func (m *Wallet) XXX_DiscardUnknown() {
+	xxx_messageInfo_Wallet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Wallet proto.InternalMessageInfo
+
+func (m *Wallet) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Wallet) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Wallet) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Wallet) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Wallet) GetTier() string {
+	if m != nil {
+		return m.Tier
+	}
+	return ""
+}
+
+type OpenWalletRequest struct {
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner                string            `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IdempotencyKey       string            `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Tier                 string            `protobuf:"bytes,5,opt,name=tier,proto3" json:"tier,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *OpenWalletRequest) Reset()         { *m = OpenWalletRequest{} }
+func (m *OpenWalletRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenWalletRequest) ProtoMessage()    {}
+func (*OpenWalletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{63}
+}
+
+func (m *OpenWalletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenWalletRequest.Unmarshal(m, b)
+}
+func (m *OpenWalletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenWalletRequest.Marshal(b, m, deterministic)
+}
+func (m *OpenWalletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenWalletRequest.Merge(m, src)
+}
+func (m *OpenWalletRequest) XXX_Size() int {
+	return xxx_messageInfo_OpenWalletRequest.Size(m)
+}
+func (m *OpenWalletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenWalletRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenWalletRequest proto.InternalMessageInfo
+
+func (m *OpenWalletRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *OpenWalletRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *OpenWalletRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *OpenWalletRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+func (m *OpenWalletRequest) GetTier() string {
+	if m != nil {
+		return m.Tier
+	}
+	return ""
+}
+
+type OpenWalletResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenWalletResponse) Reset()         { *m = OpenWalletResponse{} }
+func (m *OpenWalletResponse) String() string { return proto.CompactTextString(m) }
+func (*OpenWalletResponse) ProtoMessage()    {}
+func (*OpenWalletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{64}
+}
+
+func (m *OpenWalletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenWalletResponse.Unmarshal(m, b)
+}
+func (m *OpenWalletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenWalletResponse.Marshal(b, m, deterministic)
+}
+func (m *OpenWalletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenWalletResponse.Merge(m, src)
+}
+func (m *OpenWalletResponse) XXX_Size() int {
+	return xxx_messageInfo_OpenWalletResponse.Size(m)
+}
+func (m *OpenWalletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenWalletResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenWalletResponse proto.InternalMessageInfo
+
+func (m *OpenWalletResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+func (m *OpenWalletResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type SetWalletStatusRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWalletStatusRequest) Reset()         { *m = SetWalletStatusRequest{} }
+func (m *SetWalletStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SetWalletStatusRequest) ProtoMessage()    {}
+func (*SetWalletStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{65}
+}
+
+func (m *SetWalletStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetWalletStatusRequest.Unmarshal(m, b)
+}
+func (m *SetWalletStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetWalletStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *SetWalletStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWalletStatusRequest.Merge(m, src)
+}
+func (m *SetWalletStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SetWalletStatusRequest.Size(m)
+}
+func (m *SetWalletStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWalletStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWalletStatusRequest proto.InternalMessageInfo
+
+func (m *SetWalletStatusRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *SetWalletStatusRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SetWalletStatusRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type SetWalletStatusResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWalletStatusResponse) Reset()         { *m = SetWalletStatusResponse{} }
+func (m *SetWalletStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*SetWalletStatusResponse) ProtoMessage()    {}
+func (*SetWalletStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{66}
+}
+
+func (m *SetWalletStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetWalletStatusResponse.Unmarshal(m, b)
+}
+func (m *SetWalletStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetWalletStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *SetWalletStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWalletStatusResponse.Merge(m, src)
+}
+func (m *SetWalletStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_SetWalletStatusResponse.Size(m)
+}
+func (m *SetWalletStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWalletStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWalletStatusResponse proto.InternalMessageInfo
+
+func (m *SetWalletStatusResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+// amounts are all in the given currency, zero leaves a limit unset
+type Limits struct {
+	MaxSingleOutflow     int64    `protobuf:"varint,1,opt,name=max_single_outflow,json=maxSingleOutflow,proto3" json:"max_single_outflow,omitempty"`
+	MaxDailyOutflow      int64    `protobuf:"varint,2,opt,name=max_daily_outflow,json=maxDailyOutflow,proto3" json:"max_daily_outflow,omitempty"`
+	MaxTransfersPerHour  int32    `protobuf:"varint,3,opt,name=max_transfers_per_hour,json=maxTransfersPerHour,proto3" json:"max_transfers_per_hour,omitempty"`
+	MaxBalance           int64    `protobuf:"varint,4,opt,name=max_balance,json=maxBalance,proto3" json:"max_balance,omitempty"`
+	Currency             string   `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Limits) Reset()         { *m = Limits{} }
+func (m *Limits) String() string { return proto.CompactTextString(m) }
+func (*Limits) ProtoMessage()    {}
+func (*Limits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{67}
+}
+
+func (m *Limits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Limits.Unmarshal(m, b)
+}
+func (m *Limits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Limits.Marshal(b, m, deterministic)
+}
+func (m *Limits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Limits.Merge(m, src)
+}
+func (m *Limits) XXX_Size() int {
+	return xxx_messageInfo_Limits.Size(m)
+}
+func (m *Limits) XXX_DiscardUnknown() {
+	xxx_messageInfo_Limits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Limits proto.InternalMessageInfo
+
+func (m *Limits) GetMaxSingleOutflow() int64 {
+	if m != nil {
+		return m.MaxSingleOutflow
+	}
+	return 0
+}
+
+func (m *Limits) GetMaxDailyOutflow() int64 {
+	if m != nil {
+		return m.MaxDailyOutflow
+	}
+	return 0
+}
+
+func (m *Limits) GetMaxTransfersPerHour() int32 {
+	if m != nil {
+		return m.MaxTransfersPerHour
+	}
+	return 0
+}
+
+func (m *Limits) GetMaxBalance() int64 {
+	if m != nil {
+		return m.MaxBalance
+	}
+	return 0
+}
+
+func (m *Limits) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+type SetWalletLimitsRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Limits               *Limits  `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWalletLimitsRequest) Reset()         { *m = SetWalletLimitsRequest{} }
+func (m *SetWalletLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetWalletLimitsRequest) ProtoMessage()    {}
+func (*SetWalletLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{68}
+}
+
+func (m *SetWalletLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetWalletLimitsRequest.Unmarshal(m, b)
+}
+func (m *SetWalletLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetWalletLimitsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetWalletLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWalletLimitsRequest.Merge(m, src)
+}
+func (m *SetWalletLimitsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetWalletLimitsRequest.Size(m)
+}
+func (m *SetWalletLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWalletLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWalletLimitsRequest proto.InternalMessageInfo
+
+func (m *SetWalletLimitsRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *SetWalletLimitsRequest) GetLimits() *Limits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+func (m *SetWalletLimitsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type SetWalletLimitsResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWalletLimitsResponse) Reset()         { *m = SetWalletLimitsResponse{} }
+func (m *SetWalletLimitsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetWalletLimitsResponse) ProtoMessage()    {}
+func (*SetWalletLimitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{69}
+}
+
+func (m *SetWalletLimitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetWalletLimitsResponse.Unmarshal(m, b)
+}
+func (m *SetWalletLimitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetWalletLimitsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetWalletLimitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWalletLimitsResponse.Merge(m, src)
+}
+func (m *SetWalletLimitsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetWalletLimitsResponse.Size(m)
+}
+func (m *SetWalletLimitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWalletLimitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWalletLimitsResponse proto.InternalMessageInfo
+
+func (m *SetWalletLimitsResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type SetTierLimitsRequest struct {
+	Tier                 string   `protobuf:"bytes,1,opt,name=tier,proto3" json:"tier,omitempty"`
+	Limits               *Limits  `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	IdempotencyKey       string   `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTierLimitsRequest) Reset()         { *m = SetTierLimitsRequest{} }
+func (m *SetTierLimitsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTierLimitsRequest) ProtoMessage()    {}
+func (*SetTierLimitsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{70}
+}
+
+func (m *SetTierLimitsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTierLimitsRequest.Unmarshal(m, b)
+}
+func (m *SetTierLimitsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTierLimitsRequest.Marshal(b, m, deterministic)
+}
+func (m *SetTierLimitsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTierLimitsRequest.Merge(m, src)
+}
+func (m *SetTierLimitsRequest) XXX_Size() int {
+	return xxx_messageInfo_SetTierLimitsRequest.Size(m)
+}
+func (m *SetTierLimitsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTierLimitsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTierLimitsRequest proto.InternalMessageInfo
+
+func (m *SetTierLimitsRequest) GetTier() string {
+	if m != nil {
+		return m.Tier
+	}
+	return ""
+}
+
+func (m *SetTierLimitsRequest) GetLimits() *Limits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+func (m *SetTierLimitsRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type SetTierLimitsResponse struct {
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetTierLimitsResponse) Reset()         { *m = SetTierLimitsResponse{} }
+func (m *SetTierLimitsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetTierLimitsResponse) ProtoMessage()    {}
+func (*SetTierLimitsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{71}
+}
+
+func (m *SetTierLimitsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetTierLimitsResponse.Unmarshal(m, b)
+}
+func (m *SetTierLimitsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetTierLimitsResponse.Marshal(b, m, deterministic)
+}
+func (m *SetTierLimitsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTierLimitsResponse.Merge(m, src)
+}
+func (m *SetTierLimitsResponse) XXX_Size() int {
+	return xxx_messageInfo_SetTierLimitsResponse.Size(m)
+}
+func (m *SetTierLimitsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTierLimitsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetTierLimitsResponse proto.InternalMessageInfo
+
+func (m *SetTierLimitsResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type GetWalletRequest struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletRequest) Reset()         { *m = GetWalletRequest{} }
+func (m *GetWalletRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWalletRequest) ProtoMessage()    {}
+func (*GetWalletRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{72}
+}
+
+func (m *GetWalletRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletRequest.Unmarshal(m, b)
+}
+func (m *GetWalletRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWalletRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletRequest.Merge(m, src)
+}
+func (m *GetWalletRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWalletRequest.Size(m)
+}
+func (m *GetWalletRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletRequest proto.InternalMessageInfo
+
+func (m *GetWalletRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+type GetWalletResponse struct {
+	Wallet               *Wallet  `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWalletResponse) Reset()         { *m = GetWalletResponse{} }
+func (m *GetWalletResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWalletResponse) ProtoMessage()    {}
+func (*GetWalletResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{73}
+}
+
+func (m *GetWalletResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWalletResponse.Unmarshal(m, b)
+}
+func (m *GetWalletResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWalletResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWalletResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWalletResponse.Merge(m, src)
+}
+func (m *GetWalletResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWalletResponse.Size(m)
+}
+func (m *GetWalletResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWalletResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWalletResponse proto.InternalMessageInfo
+
+func (m *GetWalletResponse) GetWallet() *Wallet {
+	if m != nil {
+		return m.Wallet
+	}
+	return nil
+}
+
+type Violation struct {
+	Invariant            string   `protobuf:"bytes,1,opt,name=invariant,proto3" json:"invariant,omitempty"`
+	Wallet               string   `protobuf:"bytes,2,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Aggregate            string   `protobuf:"bytes,3,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	Detail               string   `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Violation) Reset()         { *m = Violation{} }
+func (m *Violation) String() string { return proto.CompactTextString(m) }
+func (*Violation) ProtoMessage()    {}
+func (*Violation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{74}
+}
+
+func (m *Violation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Violation.Unmarshal(m, b)
+}
+func (m *Violation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Violation.Marshal(b, m, deterministic)
+}
+func (m *Violation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Violation.Merge(m, src)
+}
+func (m *Violation) XXX_Size() int {
+	return xxx_messageInfo_Violation.Size(m)
+}
+func (m *Violation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Violation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Violation proto.InternalMessageInfo
+
+func (m *Violation) GetInvariant() string {
+	if m != nil {
+		return m.Invariant
+	}
+	return ""
+}
+
+func (m *Violation) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *Violation) GetAggregate() string {
+	if m != nil {
+		return m.Aggregate
+	}
+	return ""
+}
+
+func (m *Violation) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type CheckInvariantsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckInvariantsRequest) Reset()         { *m = CheckInvariantsRequest{} }
+func (m *CheckInvariantsRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckInvariantsRequest) ProtoMessage()    {}
+func (*CheckInvariantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{75}
+}
+
+func (m *CheckInvariantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInvariantsRequest.Unmarshal(m, b)
+}
+func (m *CheckInvariantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInvariantsRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckInvariantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInvariantsRequest.Merge(m, src)
+}
+func (m *CheckInvariantsRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckInvariantsRequest.Size(m)
+}
+func (m *CheckInvariantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInvariantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInvariantsRequest proto.InternalMessageInfo
+
+// transactions is how many transactions were checked
+type CheckInvariantsResponse struct {
+	Transactions         int64        `protobuf:"varint,1,opt,name=transactions,proto3" json:"transactions,omitempty"`
+	Violations           []*Violation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CheckInvariantsResponse) Reset()         { *m = CheckInvariantsResponse{} }
+func (m *CheckInvariantsResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckInvariantsResponse) ProtoMessage()    {}
+func (*CheckInvariantsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{76}
+}
+
+func (m *CheckInvariantsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInvariantsResponse.Unmarshal(m, b)
+}
+func (m *CheckInvariantsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInvariantsResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckInvariantsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInvariantsResponse.Merge(m, src)
+}
+func (m *CheckInvariantsResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckInvariantsResponse.Size(m)
+}
+func (m *CheckInvariantsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInvariantsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInvariantsResponse proto.InternalMessageInfo
+
+func (m *CheckInvariantsResponse) GetTransactions() int64 {
+	if m != nil {
+		return m.Transactions
+	}
+	return 0
+}
+
+func (m *CheckInvariantsResponse) GetViolations() []*Violation {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+type TrialBalanceRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrialBalanceRequest) Reset()         { *m = TrialBalanceRequest{} }
+func (m *TrialBalanceRequest) String() string { return proto.CompactTextString(m) }
+func (*TrialBalanceRequest) ProtoMessage()    {}
+func (*TrialBalanceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{77}
+}
+
+func (m *TrialBalanceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrialBalanceRequest.Unmarshal(m, b)
+}
+func (m *TrialBalanceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrialBalanceRequest.Marshal(b, m, deterministic)
+}
+func (m *TrialBalanceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrialBalanceRequest.Merge(m, src)
+}
+func (m *TrialBalanceRequest) XXX_Size() int {
+	return xxx_messageInfo_TrialBalanceRequest.Size(m)
+}
+func (m *TrialBalanceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrialBalanceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrialBalanceRequest proto.InternalMessageInfo
+
+type TrialBalanceLine struct {
+	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Debit                int64    `protobuf:"varint,3,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit               int64    `protobuf:"varint,4,opt,name=credit,proto3" json:"credit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrialBalanceLine) Reset()         { *m = TrialBalanceLine{} }
+func (m *TrialBalanceLine) String() string { return proto.CompactTextString(m) }
+func (*TrialBalanceLine) ProtoMessage()    {}
+func (*TrialBalanceLine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{78}
+}
+
+func (m *TrialBalanceLine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrialBalanceLine.Unmarshal(m, b)
+}
+func (m *TrialBalanceLine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrialBalanceLine.Marshal(b, m, deterministic)
+}
+func (m *TrialBalanceLine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrialBalanceLine.Merge(m, src)
+}
+func (m *TrialBalanceLine) XXX_Size() int {
+	return xxx_messageInfo_TrialBalanceLine.Size(m)
+}
+func (m *TrialBalanceLine) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrialBalanceLine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrialBalanceLine proto.InternalMessageInfo
+
+func (m *TrialBalanceLine) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *TrialBalanceLine) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *TrialBalanceLine) GetDebit() int64 {
+	if m != nil {
+		return m.Debit
+	}
+	return 0
+}
+
+func (m *TrialBalanceLine) GetCredit() int64 {
+	if m != nil {
+		return m.Credit
+	}
+	return 0
+}
+
+type TrialBalanceTotal struct {
+	Currency             string   `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Debit                int64    `protobuf:"varint,2,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit               int64    `protobuf:"varint,3,opt,name=credit,proto3" json:"credit,omitempty"`
+	Balanced             bool     `protobuf:"varint,4,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrialBalanceTotal) Reset()         { *m = TrialBalanceTotal{} }
+func (m *TrialBalanceTotal) String() string { return proto.CompactTextString(m) }
+func (*TrialBalanceTotal) ProtoMessage()    {}
+func (*TrialBalanceTotal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{79}
+}
+
+func (m *TrialBalanceTotal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrialBalanceTotal.Unmarshal(m, b)
+}
+func (m *TrialBalanceTotal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrialBalanceTotal.Marshal(b, m, deterministic)
+}
+func (m *TrialBalanceTotal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrialBalanceTotal.Merge(m, src)
+}
+func (m *TrialBalanceTotal) XXX_Size() int {
+	return xxx_messageInfo_TrialBalanceTotal.Size(m)
+}
+func (m *TrialBalanceTotal) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrialBalanceTotal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrialBalanceTotal proto.InternalMessageInfo
+
+func (m *TrialBalanceTotal) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *TrialBalanceTotal) GetDebit() int64 {
+	if m != nil {
+		return m.Debit
+	}
+	return 0
+}
+
+func (m *TrialBalanceTotal) GetCredit() int64 {
+	if m != nil {
+		return m.Credit
+	}
+	return 0
+}
+
+func (m *TrialBalanceTotal) GetBalanced() bool {
+	if m != nil {
+		return m.Balanced
+	}
+	return false
+}
+
+type TrialBalanceResponse struct {
+	Lines                []*TrialBalanceLine  `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
+	Totals               []*TrialBalanceTotal `protobuf:"bytes,2,rep,name=totals,proto3" json:"totals,omitempty"`
+	Balanced             bool                 `protobuf:"varint,3,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TrialBalanceResponse) Reset()         { *m = TrialBalanceResponse{} }
+func (m *TrialBalanceResponse) String() string { return proto.CompactTextString(m) }
+func (*TrialBalanceResponse) ProtoMessage()    {}
+func (*TrialBalanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{80}
+}
+
+func (m *TrialBalanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrialBalanceResponse.Unmarshal(m, b)
+}
+func (m *TrialBalanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrialBalanceResponse.Marshal(b, m, deterministic)
+}
+func (m *TrialBalanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrialBalanceResponse.Merge(m, src)
+}
+func (m *TrialBalanceResponse) XXX_Size() int {
+	return xxx_messageInfo_TrialBalanceResponse.Size(m)
+}
+func (m *TrialBalanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrialBalanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrialBalanceResponse proto.InternalMessageInfo
+
+func (m *TrialBalanceResponse) GetLines() []*TrialBalanceLine {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *TrialBalanceResponse) GetTotals() []*TrialBalanceTotal {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+func (m *TrialBalanceResponse) GetBalanced() bool {
+	if m != nil {
+		return m.Balanced
+	}
+	return false
+}
+
+// events are the names of the events the webhook is sent, every event when empty
+// secret is what deliveries are signed with, it's only returned when the webhook is created
+type Webhook struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Events               []string `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	Secret               string   `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{81}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+// a secret is generated for the webhook when it's given none
+type CreateWebhookRequest struct {
+	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Events               []string `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Secret               string   `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookRequest) Reset()         { *m = CreateWebhookRequest{} }
+func (m *CreateWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookRequest) ProtoMessage()    {}
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{82}
+}
+
+func (m *CreateWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookRequest.Unmarshal(m, b)
+}
+func (m *CreateWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookRequest.Merge(m, src)
+}
+func (m *CreateWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookRequest.Size(m)
+}
+func (m *CreateWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookRequest proto.InternalMessageInfo
+
+func (m *CreateWebhookRequest) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *CreateWebhookRequest) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *CreateWebhookRequest) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+type CreateWebhookResponse struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookResponse) Reset()         { *m = CreateWebhookResponse{} }
+func (m *CreateWebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookResponse) ProtoMessage()    {}
+func (*CreateWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{83}
+}
+
+func (m *CreateWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookResponse.Unmarshal(m, b)
+}
+func (m *CreateWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookResponse.Merge(m, src)
+}
+func (m *CreateWebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookResponse.Size(m)
+}
+func (m *CreateWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookResponse proto.InternalMessageInfo
+
+func (m *CreateWebhookResponse) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{84}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+type ListWebhooksResponse struct {
+	Webhooks             []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListWebhooksResponse) Reset()         { *m = ListWebhooksResponse{} }
+func (m *ListWebhooksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksResponse) ProtoMessage()    {}
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{85}
+}
+
+func (m *ListWebhooksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksResponse.Unmarshal(m, b)
+}
+func (m *ListWebhooksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResponse.Merge(m, src)
+}
+func (m *ListWebhooksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksResponse.Size(m)
+}
+func (m *ListWebhooksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResponse proto.InternalMessageInfo
+
+func (m *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+type DeleteWebhookRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookRequest) Reset()         { *m = DeleteWebhookRequest{} }
+func (m *DeleteWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookRequest) ProtoMessage()    {}
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{86}
+}
+
+func (m *DeleteWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookRequest.Unmarshal(m, b)
+}
+func (m *DeleteWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookRequest.Merge(m, src)
+}
+func (m *DeleteWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookRequest.Size(m)
+}
+func (m *DeleteWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookRequest proto.InternalMessageInfo
+
+func (m *DeleteWebhookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteWebhookResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookResponse) Reset()         { *m = DeleteWebhookResponse{} }
+func (m *DeleteWebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookResponse) ProtoMessage()    {}
+func (*DeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{87}
+}
+
+func (m *DeleteWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookResponse.Unmarshal(m, b)
+}
+func (m *DeleteWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookResponse.Merge(m, src)
+}
+func (m *DeleteWebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookResponse.Size(m)
+}
+func (m *DeleteWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookResponse proto.InternalMessageInfo
+
+// dead_at is when the delivery ran out of attempts, in unix nanoseconds
+type WebhookDelivery struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Webhook              string   `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Event                string   `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	Sequence             uint64   `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Attempts             int32    `protobuf:"varint,5,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError            string   `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	DeadAt               int64    `protobuf:"varint,7,opt,name=dead_at,json=deadAt,proto3" json:"dead_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{88}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetWebhook() string {
+	if m != nil {
+		return m.Webhook
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetDeadAt() int64 {
+	if m != nil {
+		return m.DeadAt
+	}
+	return 0
+}
+
+type ListDeadLettersRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLettersRequest) Reset()         { *m = ListDeadLettersRequest{} }
+func (m *ListDeadLettersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersRequest) ProtoMessage()    {}
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{89}
+}
+
+func (m *ListDeadLettersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersRequest.Unmarshal(m, b)
+}
+func (m *ListDeadLettersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersRequest.Merge(m, src)
+}
+func (m *ListDeadLettersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersRequest.Size(m)
+}
+func (m *ListDeadLettersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersRequest proto.InternalMessageInfo
+
+type ListDeadLettersResponse struct {
+	Deliveries           []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListDeadLettersResponse) Reset()         { *m = ListDeadLettersResponse{} }
+func (m *ListDeadLettersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLettersResponse) ProtoMessage()    {}
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{90}
+}
+
+func (m *ListDeadLettersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLettersResponse.Unmarshal(m, b)
+}
+func (m *ListDeadLettersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLettersResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLettersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLettersResponse.Merge(m, src)
+}
+func (m *ListDeadLettersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLettersResponse.Size(m)
+}
+func (m *ListDeadLettersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLettersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLettersResponse proto.InternalMessageInfo
+
+func (m *ListDeadLettersResponse) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+type RedeliverWebhookRequest struct {
+	Delivery             string   `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedeliverWebhookRequest) Reset()         { *m = RedeliverWebhookRequest{} }
+func (m *RedeliverWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*RedeliverWebhookRequest) ProtoMessage()    {}
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{91}
+}
+
+func (m *RedeliverWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedeliverWebhookRequest.Unmarshal(m, b)
+}
+func (m *RedeliverWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedeliverWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *RedeliverWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedeliverWebhookRequest.Merge(m, src)
+}
+func (m *RedeliverWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_RedeliverWebhookRequest.Size(m)
+}
+func (m *RedeliverWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedeliverWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedeliverWebhookRequest proto.InternalMessageInfo
+
+func (m *RedeliverWebhookRequest) GetDelivery() string {
+	if m != nil {
+		return m.Delivery
+	}
+	return ""
+}
+
+type RedeliverWebhookResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedeliverWebhookResponse) Reset()         { *m = RedeliverWebhookResponse{} }
+func (m *RedeliverWebhookResponse) String() string { return proto.CompactTextString(m) }
+func (*RedeliverWebhookResponse) ProtoMessage()    {}
+func (*RedeliverWebhookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{92}
+}
+
+func (m *RedeliverWebhookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedeliverWebhookResponse.Unmarshal(m, b)
+}
+func (m *RedeliverWebhookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedeliverWebhookResponse.Marshal(b, m, deterministic)
+}
+func (m *RedeliverWebhookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedeliverWebhookResponse.Merge(m, src)
+}
+func (m *RedeliverWebhookResponse) XXX_Size() int {
+	return xxx_messageInfo_RedeliverWebhookResponse.Size(m)
+}
+func (m *RedeliverWebhookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedeliverWebhookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedeliverWebhookResponse proto.InternalMessageInfo
+
+// cursor is the sequence number of the last transaction the consumer has handled, zero to start from the first
+type StreamTransactionsRequest struct {
+	Cursor               uint64   `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamTransactionsRequest) Reset()         { *m = StreamTransactionsRequest{} }
+func (m *StreamTransactionsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamTransactionsRequest) ProtoMessage()    {}
+func (*StreamTransactionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{93}
+}
+
+func (m *StreamTransactionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamTransactionsRequest.Unmarshal(m, b)
+}
+func (m *StreamTransactionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamTransactionsRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamTransactionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamTransactionsRequest.Merge(m, src)
+}
+func (m *StreamTransactionsRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamTransactionsRequest.Size(m)
+}
+func (m *StreamTransactionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamTransactionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamTransactionsRequest proto.InternalMessageInfo
+
+func (m *StreamTransactionsRequest) GetCursor() uint64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+// the transaction's sequence number is the cursor to resume from once it has been handled
+type StreamTransactionsResponse struct {
+	Transaction          *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StreamTransactionsResponse) Reset()         { *m = StreamTransactionsResponse{} }
+func (m *StreamTransactionsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamTransactionsResponse) ProtoMessage()    {}
+func (*StreamTransactionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_42f128396e519916, []int{94}
+}
+
+func (m *StreamTransactionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamTransactionsResponse.Unmarshal(m, b)
+}
+func (m *StreamTransactionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamTransactionsResponse.Marshal(b, m, deterministic)
+}
+func (m *StreamTransactionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamTransactionsResponse.Merge(m, src)
+}
+func (m *StreamTransactionsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamTransactionsResponse.Size(m)
+}
+func (m *StreamTransactionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamTransactionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamTransactionsResponse proto.InternalMessageInfo
+
+func (m *StreamTransactionsResponse) GetTransaction() *Transaction {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Transaction)(nil), "ledger.Transaction")
+	proto.RegisterType((*CreditTransaction)(nil), "ledger.CreditTransaction")
+	proto.RegisterType((*AddCreditTransactionRequest)(nil), "ledger.AddCreditTransactionRequest")
+	proto.RegisterType((*AddCreditTransactionResponse)(nil), "ledger.AddCreditTransactionResponse")
+	proto.RegisterType((*DebitTransaction)(nil), "ledger.DebitTransaction")
+	proto.RegisterType((*AddDebitTransactionRequest)(nil), "ledger.AddDebitTransactionRequest")
+	proto.RegisterType((*AddDebitTransactionResponse)(nil), "ledger.AddDebitTransactionResponse")
+	proto.RegisterType((*CashInTransaction)(nil), "ledger.CashInTransaction")
 	proto.RegisterType((*AddCashInTransactionRequest)(nil), "ledger.AddCashInTransactionRequest")
 	proto.RegisterType((*AddCashInTransactionResponse)(nil), "ledger.AddCashInTransactionResponse")
 	proto.RegisterType((*CashOutTransaction)(nil), "ledger.CashOutTransaction")
 	proto.RegisterType((*AddCashOutTransactionRequest)(nil), "ledger.AddCashOutTransactionRequest")
 	proto.RegisterType((*AddCashOutTransactionResponse)(nil), "ledger.AddCashOutTransactionResponse")
 	proto.RegisterType((*WalletBalanceRequest)(nil), "ledger.WalletBalanceRequest")
+	proto.RegisterType((*Balance)(nil), "ledger.Balance")
 	proto.RegisterType((*WalletBalanceResponse)(nil), "ledger.WalletBalanceResponse")
 	proto.RegisterType((*WalletTransactionsRequest)(nil), "ledger.WalletTransactionsRequest")
 	proto.RegisterType((*WalletTransactionsResponse)(nil), "ledger.WalletTransactionsResponse")
+	proto.RegisterType((*WalletBalanceAtRequest)(nil), "ledger.WalletBalanceAtRequest")
+	proto.RegisterType((*WalletBalanceAtResponse)(nil), "ledger.WalletBalanceAtResponse")
+	proto.RegisterType((*WalletTransactionsBetweenRequest)(nil), "ledger.WalletTransactionsBetweenRequest")
+	proto.RegisterType((*WalletTransactionsBetweenResponse)(nil), "ledger.WalletTransactionsBetweenResponse")
 	proto.RegisterType((*AggregateTransactionsRequest)(nil), "ledger.AggregateTransactionsRequest")
 	proto.RegisterType((*AggregateTransactionsResponse)(nil), "ledger.AggregateTransactionsResponse")
 	proto.RegisterType((*TransferWalletFundsRequest)(nil), "ledger.TransferWalletFundsRequest")
 	proto.RegisterType((*TransferWalletFundsResponse)(nil), "ledger.TransferWalletFundsResponse")
+	proto.RegisterType((*ExchangeWalletFundsRequest)(nil), "ledger.ExchangeWalletFundsRequest")
+	proto.RegisterType((*ExchangeWalletFundsResponse)(nil), "ledger.ExchangeWalletFundsResponse")
+	proto.RegisterType((*Entry)(nil), "ledger.Entry")
+	proto.RegisterType((*PostRequest)(nil), "ledger.PostRequest")
+	proto.RegisterType((*PostResponse)(nil), "ledger.PostResponse")
+	proto.RegisterType((*ReverseAggregateRequest)(nil), "ledger.ReverseAggregateRequest")
+	proto.RegisterType((*ReverseAggregateResponse)(nil), "ledger.ReverseAggregateResponse")
+	proto.RegisterType((*RefundAggregateRequest)(nil), "ledger.RefundAggregateRequest")
+	proto.RegisterType((*RefundAggregateResponse)(nil), "ledger.RefundAggregateResponse")
+	proto.RegisterType((*AuthorizeHoldRequest)(nil), "ledger.AuthorizeHoldRequest")
+	proto.RegisterType((*AuthorizeHoldResponse)(nil), "ledger.AuthorizeHoldResponse")
+	proto.RegisterType((*CaptureHoldRequest)(nil), "ledger.CaptureHoldRequest")
+	proto.RegisterType((*CaptureHoldResponse)(nil), "ledger.CaptureHoldResponse")
+	proto.RegisterType((*VoidHoldRequest)(nil), "ledger.VoidHoldRequest")
+	proto.RegisterType((*VoidHoldResponse)(nil), "ledger.VoidHoldResponse")
+	proto.RegisterType((*SetOverdraftPolicyRequest)(nil), "ledger.SetOverdraftPolicyRequest")
+	proto.RegisterType((*SetOverdraftPolicyResponse)(nil), "ledger.SetOverdraftPolicyResponse")
+	proto.RegisterType((*FeeBand)(nil), "ledger.FeeBand")
+	proto.RegisterType((*FeeSchedule)(nil), "ledger.FeeSchedule")
+	proto.RegisterType((*SetFeeScheduleRequest)(nil), "ledger.SetFeeScheduleRequest")
+	proto.RegisterType((*SetFeeScheduleResponse)(nil), "ledger.SetFeeScheduleResponse")
+	proto.RegisterType((*QuoteFeeRequest)(nil), "ledger.QuoteFeeRequest")
+	proto.RegisterType((*QuoteFeeResponse)(nil), "ledger.QuoteFeeResponse")
+	proto.RegisterType((*InterestPolicy)(nil), "ledger.InterestPolicy")
+	proto.RegisterType((*SetInterestPolicyRequest)(nil), "ledger.SetInterestPolicyRequest")
+	proto.RegisterType((*SetInterestPolicyResponse)(nil), "ledger.SetInterestPolicyResponse")
+	proto.RegisterType((*AccrueInterestRequest)(nil), "ledger.AccrueInterestRequest")
+	proto.RegisterType((*AccrueInterestResponse)(nil), "ledger.AccrueInterestResponse")
+	proto.RegisterType((*DefineAccountRequest)(nil), "ledger.DefineAccountRequest")
+	proto.RegisterType((*DefineAccountResponse)(nil), "ledger.DefineAccountResponse")
+	proto.RegisterType((*AccountBalanceRequest)(nil), "ledger.AccountBalanceRequest")
+	proto.RegisterType((*AccountBalanceResponse)(nil), "ledger.AccountBalanceResponse")
 	proto.RegisterType((*DepositWalletFundsRequest)(nil), "ledger.DepositWalletFundsRequest")
 	proto.RegisterType((*DepositWalletFundsResponse)(nil), "ledger.DepositWalletFundsResponse")
 	proto.RegisterType((*WithdrawWalletFundsRequest)(nil), "ledger.WithdrawWalletFundsRequest")
 	proto.RegisterType((*WithdrawWalletFundsResponse)(nil), "ledger.WithdrawWalletFundsResponse")
+	proto.RegisterType((*Wallet)(nil), "ledger.Wallet")
+	proto.RegisterMapType((map[string]string)(nil), "ledger.Wallet.MetadataEntry")
+	proto.RegisterType((*OpenWalletRequest)(nil), "ledger.OpenWalletRequest")
+	proto.RegisterMapType((map[string]string)(nil), "ledger.OpenWalletRequest.MetadataEntry")
+	proto.RegisterType((*OpenWalletResponse)(nil), "ledger.OpenWalletResponse")
+	proto.RegisterType((*SetWalletStatusRequest)(nil), "ledger.SetWalletStatusRequest")
+	proto.RegisterType((*SetWalletStatusResponse)(nil), "ledger.SetWalletStatusResponse")
+	proto.RegisterType((*Limits)(nil), "ledger.Limits")
+	proto.RegisterType((*SetWalletLimitsRequest)(nil), "ledger.SetWalletLimitsRequest")
+	proto.RegisterType((*SetWalletLimitsResponse)(nil), "ledger.SetWalletLimitsResponse")
+	proto.RegisterType((*SetTierLimitsRequest)(nil), "ledger.SetTierLimitsRequest")
+	proto.RegisterType((*SetTierLimitsResponse)(nil), "ledger.SetTierLimitsResponse")
+	proto.RegisterType((*GetWalletRequest)(nil), "ledger.GetWalletRequest")
+	proto.RegisterType((*GetWalletResponse)(nil), "ledger.GetWalletResponse")
+	proto.RegisterType((*Violation)(nil), "ledger.Violation")
+	proto.RegisterType((*CheckInvariantsRequest)(nil), "ledger.CheckInvariantsRequest")
+	proto.RegisterType((*CheckInvariantsResponse)(nil), "ledger.CheckInvariantsResponse")
+	proto.RegisterType((*TrialBalanceRequest)(nil), "ledger.TrialBalanceRequest")
+	proto.RegisterType((*TrialBalanceLine)(nil), "ledger.TrialBalanceLine")
+	proto.RegisterType((*TrialBalanceTotal)(nil), "ledger.TrialBalanceTotal")
+	proto.RegisterType((*TrialBalanceResponse)(nil), "ledger.TrialBalanceResponse")
+	proto.RegisterType((*Webhook)(nil), "ledger.Webhook")
+	proto.RegisterType((*CreateWebhookRequest)(nil), "ledger.CreateWebhookRequest")
+	proto.RegisterType((*CreateWebhookResponse)(nil), "ledger.CreateWebhookResponse")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "ledger.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResponse)(nil), "ledger.ListWebhooksResponse")
+	proto.RegisterType((*DeleteWebhookRequest)(nil), "ledger.DeleteWebhookRequest")
+	proto.RegisterType((*DeleteWebhookResponse)(nil), "ledger.DeleteWebhookResponse")
+	proto.RegisterType((*WebhookDelivery)(nil), "ledger.WebhookDelivery")
+	proto.RegisterType((*ListDeadLettersRequest)(nil), "ledger.ListDeadLettersRequest")
+	proto.RegisterType((*ListDeadLettersResponse)(nil), "ledger.ListDeadLettersResponse")
+	proto.RegisterType((*RedeliverWebhookRequest)(nil), "ledger.RedeliverWebhookRequest")
+	proto.RegisterType((*RedeliverWebhookResponse)(nil), "ledger.RedeliverWebhookResponse")
+	proto.RegisterType((*StreamTransactionsRequest)(nil), "ledger.StreamTransactionsRequest")
+	proto.RegisterType((*StreamTransactionsResponse)(nil), "ledger.StreamTransactionsResponse")
+}
+
+func init() { proto.RegisterFile("api/protobuf/ledger.proto", fileDescriptor_42f128396e519916) }
+
+var fileDescriptor_42f128396e519916 = []byte{
+	// 3178 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3b, 0x5b, 0x6f, 0x1c, 0xb7,
+	0xd5, 0xdf, 0xec, 0xae, 0xa4, 0xd5, 0xd1, 0x7d, 0x74, 0x5b, 0x51, 0x92, 0x25, 0x8f, 0x63, 0xc7,
+	0x49, 0xbe, 0xcf, 0x8e, 0xe5, 0x18, 0x5f, 0x1a, 0x07, 0x28, 0x64, 0xc9, 0x6e, 0x2e, 0x6e, 0xec,
+	0xac, 0x94, 0x38, 0x48, 0x53, 0x6c, 0xa9, 0x1d, 0xae, 0x34, 0xf0, 0xee, 0xcc, 0x66, 0x86, 0xab,
+	0x4b, 0xda, 0x3e, 0x14, 0x45, 0x9f, 0x8a, 0xbe, 0xb4, 0x68, 0x7f, 0x41, 0x81, 0x02, 0x6d, 0x5e,
+	0x8a, 0xfe, 0x82, 0xbe, 0xf6, 0xad, 0x40, 0x1f, 0xfb, 0x27, 0xfa, 0xd6, 0xc7, 0x82, 0x1c, 0x92,
+	0xc3, 0x99, 0xe1, 0xec, 0x6c, 0x6c, 0x01, 0xe9, 0xd3, 0xce, 0x39, 0x3c, 0x24, 0xcf, 0x8d, 0xe4,
+	0x39, 0x87, 0x5c, 0x58, 0xc3, 0x7d, 0xef, 0x76, 0x3f, 0x0c, 0x68, 0x70, 0x34, 0xe8, 0xdc, 0xee,
+	0x12, 0xf7, 0x98, 0x84, 0xb7, 0x38, 0x6c, 0x8f, 0xc7, 0x90, 0xf3, 0xb7, 0x2a, 0x4c, 0x1d, 0x86,
+	0xd8, 0x8f, 0x70, 0x9b, 0x7a, 0x81, 0x6f, 0xdb, 0x50, 0xa3, 0x17, 0x7d, 0xd2, 0xb0, 0xb6, 0xad,
+	0x9b, 0x93, 0x4d, 0xfe, 0x6d, 0xaf, 0xc0, 0xf8, 0x19, 0xee, 0x76, 0x09, 0x6d, 0x54, 0x38, 0x56,
+	0x40, 0x0c, 0x8f, 0x7b, 0xc1, 0xc0, 0xa7, 0x8d, 0xea, 0xb6, 0x75, 0xb3, 0xda, 0x14, 0x90, 0xbd,
+	0x01, 0x93, 0xf8, 0xf8, 0x38, 0x24, 0xc7, 0x98, 0x92, 0x46, 0x8d, 0x77, 0x49, 0x10, 0x36, 0x82,
+	0x7a, 0x7b, 0x10, 0x86, 0xc4, 0x6f, 0x5f, 0x34, 0xc6, 0x78, 0xa3, 0x82, 0xd9, 0xec, 0x21, 0xeb,
+	0x34, 0x1e, 0xcf, 0xce, 0xbe, 0xed, 0x59, 0xa8, 0x78, 0x6e, 0x63, 0x82, 0x63, 0x2a, 0x9e, 0xcb,
+	0xfa, 0x47, 0xe4, 0xcb, 0x01, 0xf1, 0xdb, 0xa4, 0x51, 0xdf, 0xb6, 0x6e, 0xd6, 0x9a, 0x0a, 0xb6,
+	0xb7, 0x60, 0x2a, 0x24, 0xed, 0x20, 0x74, 0x89, 0xdb, 0xc2, 0xb4, 0x31, 0xc9, 0xd9, 0x02, 0x89,
+	0xda, 0xa5, 0xf6, 0x75, 0x98, 0x25, 0x9d, 0x0e, 0x69, 0x53, 0xef, 0x94, 0xb4, 0x5c, 0x36, 0x15,
+	0xf0, 0x81, 0x67, 0x14, 0x76, 0x1f, 0x53, 0x31, 0xce, 0x29, 0x09, 0x23, 0xdc, 0x6d, 0x05, 0x9d,
+	0xc6, 0x14, 0xa7, 0x01, 0x89, 0x7a, 0xd2, 0xb1, 0xd7, 0x61, 0x32, 0x24, 0x9d, 0x81, 0xef, 0xb2,
+	0xe6, 0xe9, 0x58, 0x8a, 0x18, 0xf1, 0xa4, 0xc3, 0xf4, 0x12, 0x12, 0x1c, 0x05, 0x7e, 0x63, 0x26,
+	0xd6, 0x57, 0x0c, 0xd9, 0x9b, 0x00, 0xe4, 0xbc, 0xef, 0x85, 0x24, 0x62, 0xcc, 0xcd, 0x72, 0xe6,
+	0x26, 0x05, 0x66, 0x97, 0x32, 0xe1, 0x4f, 0x82, 0xae, 0xdb, 0x98, 0x8b, 0x85, 0x67, 0xdf, 0xf6,
+	0x3c, 0x54, 0x3b, 0x84, 0x34, 0xe6, 0xb7, 0xad, 0x9b, 0xf5, 0x26, 0xfb, 0x64, 0xe2, 0x7b, 0x3e,
+	0x25, 0x21, 0x89, 0x68, 0x63, 0x81, 0xa3, 0x15, 0xec, 0xfc, 0x14, 0x16, 0xf6, 0x42, 0xe2, 0x7a,
+	0x54, 0xb7, 0x68, 0x62, 0x3d, 0x2b, 0x6b, 0xbd, 0x36, 0x27, 0xe6, 0x56, 0xad, 0x36, 0x05, 0x94,
+	0xb6, 0x5e, 0x75, 0x98, 0xf5, 0x6a, 0x69, 0xeb, 0x39, 0x3f, 0xb7, 0x60, 0x7d, 0xd7, 0x75, 0x73,
+	0x2c, 0x34, 0x99, 0x79, 0x22, 0x6a, 0xdf, 0x87, 0x29, 0x9a, 0x60, 0x39, 0x3b, 0x53, 0x3b, 0x6b,
+	0xb7, 0x84, 0x5f, 0xe6, 0xbb, 0xe9, 0xd4, 0xf6, 0xab, 0x30, 0xe7, 0xb9, 0xa4, 0xd7, 0x0f, 0x28,
+	0x9b, 0xab, 0xf5, 0x9c, 0x5c, 0x08, 0x6f, 0x9c, 0xd5, 0xd0, 0x1f, 0x92, 0x0b, 0xe7, 0x1d, 0xd8,
+	0x30, 0x33, 0x11, 0xf5, 0x03, 0x3f, 0xe2, 0x12, 0x84, 0xe2, 0x5b, 0x68, 0x44, 0xc1, 0xce, 0x57,
+	0x30, 0xbf, 0x4f, 0x8e, 0x46, 0xd3, 0xdf, 0x12, 0x8c, 0xb9, 0x8c, 0x56, 0xa8, 0x2f, 0x06, 0x5e,
+	0x42, 0x7b, 0x3f, 0xb3, 0x00, 0xed, 0xba, 0x6e, 0x76, 0x7e, 0xa9, 0xbc, 0x77, 0x4c, 0xca, 0x6b,
+	0x48, 0xe5, 0xe5, 0x7a, 0xbd, 0x98, 0xee, 0xbe, 0xc3, 0x0d, 0x98, 0x67, 0x61, 0x04, 0xd5, 0x31,
+	0xdf, 0xc3, 0xd1, 0xc9, 0xfb, 0xfe, 0xb7, 0xeb, 0x7b, 0x59, 0x16, 0x46, 0xf4, 0xbd, 0x5c, 0xb7,
+	0x97, 0xf2, 0xbd, 0x3c, 0x13, 0x23, 0x28, 0xf0, 0x27, 0x60, 0xb3, 0x8e, 0x4f, 0x06, 0xdf, 0x8a,
+	0xf7, 0xfd, 0xc2, 0x52, 0xac, 0xa7, 0x39, 0x90, 0x0a, 0x7c, 0xd7, 0xa4, 0x40, 0xa4, 0x2b, 0x30,
+	0xd3, 0xef, 0xc5, 0x34, 0x78, 0x1f, 0x36, 0x0b, 0xd8, 0x18, 0x41, 0x85, 0xb7, 0x60, 0xe9, 0x19,
+	0x57, 0xcf, 0x03, 0xdc, 0xc5, 0x7e, 0x9b, 0x48, 0xde, 0x0b, 0x94, 0xe8, 0xfc, 0x00, 0x26, 0x04,
+	0xa5, 0x76, 0x96, 0x59, 0xa9, 0xb3, 0x4c, 0xd7, 0x59, 0x25, 0x73, 0x5a, 0x31, 0x6d, 0x9f, 0x62,
+	0xaf, 0x8b, 0x8f, 0xba, 0x44, 0x1c, 0x81, 0x09, 0xc2, 0x79, 0x0a, 0xcb, 0x19, 0x66, 0x84, 0x04,
+	0x6f, 0x40, 0xfd, 0x28, 0x46, 0x45, 0x8d, 0xea, 0x76, 0xf5, 0xe6, 0xd4, 0xce, 0x9c, 0x54, 0xa3,
+	0x24, 0x55, 0x04, 0x1f, 0xd4, 0xea, 0xd6, 0x7c, 0xe5, 0x83, 0x5a, 0xbd, 0x32, 0x5f, 0x75, 0xee,
+	0xc2, 0x5a, 0x3c, 0xa2, 0xa6, 0x97, 0xa8, 0x4c, 0xc6, 0x4f, 0x00, 0x99, 0x3a, 0x09, 0x5e, 0xfe,
+	0x1f, 0xa6, 0x35, 0x33, 0x45, 0x0d, 0x8b, 0xf3, 0xb3, 0x28, 0xf9, 0xd1, 0x0d, 0x90, 0x22, 0x74,
+	0xbe, 0x80, 0x95, 0x94, 0x74, 0xbb, 0xb4, 0x84, 0x11, 0x76, 0x8e, 0x63, 0xe9, 0xae, 0x15, 0x4c,
+	0x53, 0xe7, 0x78, 0x35, 0x7d, 0x8e, 0x3b, 0x8f, 0x60, 0x35, 0x37, 0xba, 0x41, 0x7b, 0x56, 0x89,
+	0xf6, 0x9c, 0xdf, 0x5b, 0xb0, 0x9d, 0x97, 0xfe, 0x01, 0xa1, 0x67, 0x84, 0xf8, 0x65, 0x0c, 0xdb,
+	0x50, 0xeb, 0x84, 0x41, 0x4f, 0xb0, 0xcc, 0xbf, 0xed, 0x6b, 0x30, 0xc3, 0x7e, 0x5b, 0x19, 0xce,
+	0xa7, 0x19, 0xf2, 0x40, 0xe0, 0x98, 0xa4, 0x34, 0xe0, 0x2b, 0xac, 0xda, 0xac, 0xd0, 0x80, 0x45,
+	0x13, 0x34, 0x48, 0xba, 0x8c, 0xf1, 0x2e, 0x40, 0x03, 0xd9, 0xc1, 0xf9, 0x02, 0xae, 0x0e, 0xe1,
+	0xf2, 0x65, 0x4d, 0xf5, 0x2e, 0x6c, 0xec, 0xca, 0x3d, 0xc0, 0xe4, 0x39, 0xa9, 0x4d, 0xc3, 0xca,
+	0x6c, 0x1a, 0xce, 0x67, 0xb0, 0x59, 0xd0, 0xfb, 0x65, 0xf9, 0xfa, 0x8b, 0x05, 0x88, 0xb7, 0x76,
+	0x48, 0x18, 0x8b, 0xff, 0x68, 0xe0, 0xbb, 0xba, 0x43, 0x47, 0xc1, 0x20, 0x6c, 0x4b, 0x9e, 0x04,
+	0x64, 0x6f, 0xc3, 0x94, 0x4b, 0x22, 0xea, 0xf9, 0x98, 0x6f, 0x44, 0xf1, 0xa2, 0xd4, 0x51, 0x85,
+	0x71, 0xe9, 0x90, 0xfd, 0xcf, 0xb4, 0x41, 0x8d, 0x19, 0x37, 0xa8, 0x7b, 0xb0, 0x6e, 0x64, 0x5a,
+	0x68, 0x83, 0xc7, 0x7e, 0xd1, 0xa0, 0xab, 0x9c, 0x29, 0x86, 0x9c, 0x7f, 0x5a, 0x80, 0x1e, 0x9e,
+	0xb7, 0x4f, 0xb0, 0x7f, 0x4c, 0xfe, 0x1b, 0x84, 0xa5, 0x38, 0x3c, 0x26, 0xb4, 0x95, 0x89, 0xc4,
+	0x67, 0x63, 0xf4, 0xde, 0x10, 0xad, 0x8c, 0x17, 0x69, 0xc5, 0x28, 0x5d, 0x89, 0x56, 0x8e, 0x61,
+	0xec, 0xa1, 0x4f, 0xc3, 0x8b, 0x4b, 0x49, 0x3b, 0x86, 0x1d, 0x6f, 0x2d, 0x98, 0x7a, 0x1a, 0x44,
+	0x6a, 0x8f, 0x7a, 0x15, 0x26, 0x88, 0x4f, 0x43, 0x4f, 0xed, 0x21, 0x33, 0xd2, 0x5d, 0x39, 0x3b,
+	0x4d, 0xd9, 0x3a, 0xfa, 0xb9, 0x75, 0x03, 0xa6, 0xe3, 0x09, 0x4a, 0x24, 0x3e, 0x87, 0xd5, 0x26,
+	0x4f, 0x23, 0x88, 0x5a, 0x55, 0x23, 0xad, 0x43, 0x2d, 0xa9, 0xa8, 0xa4, 0x92, 0x0a, 0x03, 0x87,
+	0x55, 0x23, 0x87, 0x3b, 0xd0, 0xc8, 0xcf, 0x5c, 0xc2, 0xed, 0x6f, 0x2c, 0x58, 0x69, 0xf2, 0xb4,
+	0xe6, 0x9b, 0x73, 0x2b, 0x6c, 0x54, 0x29, 0xb4, 0x51, 0xb5, 0x7c, 0x09, 0xd6, 0x8c, 0x92, 0xdc,
+	0x81, 0xd5, 0x1c, 0x53, 0x25, 0x82, 0xfc, 0xd1, 0x82, 0xa5, 0xdd, 0x01, 0x3d, 0x09, 0x42, 0xef,
+	0x2b, 0xf2, 0x5e, 0xd0, 0x75, 0xcb, 0x36, 0xff, 0x17, 0x11, 0x20, 0x9d, 0xdf, 0xd5, 0xb2, 0xf9,
+	0xdd, 0xc8, 0x5b, 0xcc, 0x6d, 0x58, 0xce, 0xf0, 0x5a, 0x22, 0xdd, 0x1f, 0x2c, 0x16, 0x3b, 0xf6,
+	0xe9, 0x20, 0x4c, 0xc9, 0x26, 0x13, 0x4a, 0x4b, 0x4b, 0x28, 0xbf, 0xe5, 0xdd, 0xf3, 0xff, 0x60,
+	0x31, 0xc5, 0x68, 0x89, 0x60, 0x1f, 0xc1, 0xdc, 0xa7, 0x81, 0xe7, 0x96, 0x09, 0x35, 0xf2, 0x2a,
+	0x7d, 0x1d, 0xe6, 0x93, 0xf1, 0x4a, 0xe6, 0xfe, 0xda, 0x82, 0xb5, 0x03, 0x42, 0x9f, 0x9c, 0x92,
+	0xd0, 0x0d, 0x71, 0x87, 0x3e, 0x0d, 0xba, 0x5e, 0xfb, 0xa2, 0xcc, 0x6f, 0x36, 0x60, 0x72, 0xe0,
+	0x77, 0xbd, 0x9e, 0x47, 0x89, 0xcb, 0x99, 0xa8, 0x37, 0x13, 0x04, 0x8b, 0xda, 0xf9, 0xa7, 0x50,
+	0x6d, 0x0c, 0x5c, 0x8e, 0x66, 0xdf, 0x02, 0x64, 0xe2, 0xb6, 0x44, 0xc8, 0x4f, 0x60, 0xe2, 0x11,
+	0x21, 0x0f, 0xb0, 0xef, 0xda, 0x8b, 0x30, 0x36, 0xe8, 0xb7, 0x68, 0x20, 0x02, 0xe0, 0xda, 0xa0,
+	0x7f, 0x18, 0xf0, 0x18, 0xa8, 0xab, 0xc2, 0x36, 0xfe, 0x6d, 0x5f, 0x85, 0xe9, 0x23, 0x1c, 0x79,
+	0x51, 0xab, 0x1f, 0x78, 0x3e, 0x8d, 0x84, 0x2c, 0x53, 0x1c, 0xf7, 0x94, 0xa3, 0x9c, 0x7f, 0x59,
+	0x30, 0xf5, 0x88, 0x90, 0x83, 0xf6, 0x09, 0x71, 0x07, 0x5d, 0x62, 0x37, 0x60, 0xa2, 0x1d, 0xf4,
+	0x7a, 0xd8, 0x97, 0x76, 0x93, 0x20, 0x9b, 0x80, 0x7a, 0x24, 0x14, 0xf6, 0xe2, 0xdf, 0x43, 0xd7,
+	0x58, 0xa2, 0xf7, 0x5a, 0x2e, 0x58, 0x63, 0x8c, 0x8e, 0x0d, 0x61, 0x74, 0x3c, 0xc7, 0xa8, 0x7d,
+	0x1d, 0xc6, 0x8e, 0xb0, 0xef, 0x46, 0x8d, 0x89, 0x74, 0x28, 0x29, 0x94, 0xd2, 0x8c, 0x5b, 0x59,
+	0x19, 0xa6, 0xe7, 0xf9, 0xbc, 0xdc, 0x54, 0x6d, 0xb2, 0x4f, 0x8e, 0xc1, 0xe7, 0xa2, 0xc2, 0xc4,
+	0x3e, 0x9d, 0x2f, 0x61, 0xf9, 0x80, 0x50, 0x4d, 0x6a, 0xe9, 0x2a, 0xb7, 0xa1, 0x1e, 0x09, 0x94,
+	0x48, 0x9b, 0x16, 0xb5, 0x69, 0x14, 0xb5, 0x22, 0x1a, 0xdd, 0x9d, 0xdf, 0x84, 0x95, 0xec, 0x94,
+	0x25, 0xf6, 0x3e, 0x83, 0xb9, 0x8f, 0x07, 0x01, 0x25, 0x8f, 0x88, 0x62, 0xaf, 0xd8, 0x36, 0x97,
+	0x79, 0x00, 0xfb, 0x30, 0x9f, 0x4c, 0x2c, 0x98, 0x14, 0xc5, 0xad, 0xd8, 0xdf, 0xd8, 0x27, 0x5b,
+	0x1f, 0x34, 0xa0, 0xb8, 0x2b, 0xb3, 0x5a, 0x0e, 0xbc, 0x88, 0x3f, 0x38, 0x7f, 0xb2, 0x60, 0xf6,
+	0x7d, 0x51, 0x17, 0x8b, 0xd7, 0x82, 0x2a, 0x2e, 0x5a, 0x5a, 0x71, 0x71, 0x58, 0x7a, 0xb7, 0x0e,
+	0x93, 0x2e, 0xbe, 0x68, 0xb5, 0x95, 0xa4, 0x93, 0xcd, 0xba, 0x8b, 0x2f, 0xf6, 0xa4, 0xac, 0x61,
+	0x30, 0xf0, 0x5d, 0xcf, 0x3f, 0x96, 0xb2, 0x4a, 0x98, 0x69, 0xb4, 0x1f, 0xb0, 0x0d, 0xf5, 0x58,
+	0xac, 0x55, 0x09, 0x6a, 0x61, 0xde, 0xb8, 0x1e, 0xe6, 0x39, 0xbf, 0xb4, 0xa0, 0x71, 0x40, 0x68,
+	0x9a, 0xe1, 0xb2, 0xad, 0xe6, 0x16, 0x8c, 0xf7, 0x39, 0x21, 0xe7, 0x7c, 0x6a, 0x67, 0x45, 0x7a,
+	0x55, 0x66, 0x18, 0x41, 0x35, 0x7a, 0xa4, 0x70, 0x97, 0x6f, 0x7c, 0x59, 0x66, 0x4a, 0x3c, 0xeb,
+	0x73, 0x58, 0xde, 0x6d, 0xb7, 0xc3, 0x01, 0x91, 0xfd, 0x34, 0xff, 0xa2, 0x27, 0x61, 0x30, 0x38,
+	0x3e, 0x91, 0xfe, 0x25, 0xc0, 0xd1, 0xfd, 0xfc, 0x29, 0xac, 0x64, 0xc7, 0x16, 0xdc, 0x0c, 0x8f,
+	0x42, 0x1a, 0x30, 0x11, 0x3d, 0xf7, 0xfa, 0x7d, 0xbe, 0x15, 0x57, 0xd9, 0xd4, 0x02, 0x64, 0x81,
+	0xcd, 0xd2, 0x3e, 0xe9, 0x78, 0x3e, 0xd9, 0x6d, 0x73, 0x03, 0x6b, 0xc7, 0x4b, 0x3b, 0x70, 0x95,
+	0x93, 0xb0, 0x6f, 0x86, 0xf3, 0x71, 0x8f, 0xc8, 0x3d, 0x8a, 0x7d, 0xab, 0x80, 0xb5, 0x9a, 0x0e,
+	0x58, 0xfb, 0x38, 0x24, 0xbe, 0xf2, 0xc5, 0x18, 0xfa, 0x46, 0x07, 0x7f, 0x86, 0xa9, 0x12, 0xa5,
+	0xbf, 0x01, 0xcb, 0x82, 0x34, 0x53, 0xf1, 0x30, 0x88, 0xe1, 0x3c, 0x84, 0x95, 0x2c, 0xf1, 0x8b,
+	0xe4, 0xd4, 0xbf, 0xb6, 0x60, 0x6d, 0x9f, 0xf4, 0x83, 0xc8, 0xa3, 0xe6, 0x44, 0xc6, 0xe8, 0xac,
+	0x0d, 0x98, 0x70, 0xe3, 0x4e, 0x62, 0x6d, 0x4b, 0xf0, 0x72, 0x42, 0xc2, 0xb7, 0x00, 0x99, 0x78,
+	0x2a, 0x51, 0xdf, 0x6f, 0x2d, 0x40, 0xcf, 0x3c, 0x7a, 0xe2, 0x86, 0xf8, 0xec, 0x1b, 0xc8, 0x82,
+	0xa0, 0x7e, 0x26, 0x7a, 0x09, 0x61, 0x14, 0x7c, 0x39, 0xd2, 0xdc, 0x83, 0x75, 0x23, 0x5b, 0x25,
+	0xe2, 0xfc, 0xdd, 0x82, 0xf1, 0x67, 0xaa, 0xd8, 0xe2, 0xc9, 0xfd, 0x9c, 0x5d, 0x9a, 0x2c, 0xc1,
+	0x58, 0x70, 0xe6, 0xab, 0x73, 0x36, 0x06, 0xec, 0xb7, 0xa1, 0xde, 0x23, 0x14, 0xbb, 0x98, 0x62,
+	0x51, 0x89, 0xda, 0x90, 0x76, 0x8f, 0xc7, 0xb9, 0xf5, 0x7d, 0xd1, 0x1c, 0xa7, 0x45, 0x8a, 0x9a,
+	0x6f, 0x64, 0x14, 0xd3, 0x41, 0x24, 0x5d, 0x3d, 0x86, 0xd4, 0x71, 0x3e, 0x96, 0x1c, 0xe7, 0xe8,
+	0x3e, 0xcc, 0xa4, 0x86, 0x61, 0xfb, 0x3e, 0x93, 0x3d, 0xe6, 0x8e, 0x7d, 0x32, 0xf6, 0x4e, 0x71,
+	0x77, 0x20, 0x97, 0x58, 0x0c, 0xbc, 0x53, 0x79, 0xdb, 0x72, 0xfe, 0x6d, 0xc1, 0xc2, 0x93, 0x3e,
+	0xf1, 0x63, 0x7e, 0xa4, 0x65, 0x46, 0x13, 0x6f, 0x2f, 0x27, 0xde, 0xab, 0x52, 0xbc, 0xdc, 0x90,
+	0x85, 0x92, 0x8e, 0x6a, 0xb4, 0xcb, 0x17, 0xfd, 0x5d, 0xb0, 0x75, 0x36, 0x87, 0x1b, 0x5f, 0xa8,
+	0xa4, 0x22, 0x55, 0xe2, 0x7c, 0xc9, 0x63, 0x83, 0xb8, 0xf3, 0x01, 0x37, 0xce, 0x08, 0x29, 0x8f,
+	0xb0, 0x69, 0x25, 0x65, 0xd3, 0x91, 0xcf, 0x8d, 0x3b, 0xb0, 0x9a, 0x9b, 0xb2, 0xc4, 0x65, 0xff,
+	0x61, 0xc1, 0xf8, 0x63, 0x16, 0x04, 0x47, 0xf6, 0xff, 0x82, 0xdd, 0xc3, 0xe7, 0xad, 0xc8, 0xf3,
+	0x8f, 0xbb, 0xa4, 0x15, 0x0c, 0x68, 0xa7, 0x1b, 0x9c, 0x89, 0xe0, 0x60, 0xbe, 0x87, 0xcf, 0x0f,
+	0x78, 0xc3, 0x93, 0x18, 0x6f, 0xbf, 0x0e, 0x0b, 0x8c, 0xda, 0xc5, 0x5e, 0xf7, 0x42, 0x11, 0xc7,
+	0x8b, 0x71, 0xae, 0x87, 0xcf, 0xf7, 0x19, 0x5e, 0xd2, 0xde, 0x85, 0x15, 0x46, 0x4b, 0x45, 0xd9,
+	0x26, 0x6a, 0xf5, 0x49, 0xd8, 0x3a, 0x09, 0x06, 0x21, 0x97, 0x63, 0xac, 0xb9, 0xd8, 0xc3, 0xe7,
+	0xb2, 0xa6, 0x13, 0x3d, 0x25, 0xe1, 0x7b, 0xc1, 0x20, 0x64, 0x45, 0x3b, 0xd6, 0x49, 0x6c, 0x7b,
+	0x22, 0x9b, 0x83, 0x1e, 0x3e, 0x97, 0x15, 0xe3, 0x21, 0xf7, 0x98, 0xec, 0x2e, 0x27, 0xd1, 0x7e,
+	0x2c, 0x5f, 0x99, 0xf6, 0x6f, 0xc0, 0x38, 0xcf, 0x06, 0x22, 0x71, 0x9a, 0xcf, 0x4a, 0x57, 0x15,
+	0xdd, 0x45, 0xeb, 0x8b, 0x59, 0x43, 0xb2, 0x50, 0x62, 0x8d, 0x1f, 0xc3, 0xd2, 0x01, 0xa1, 0x87,
+	0x1e, 0x09, 0xd3, 0x3c, 0x4b, 0xd7, 0xb6, 0xb4, 0x20, 0xfd, 0xd2, 0xf9, 0xbd, 0x0d, 0xcb, 0x99,
+	0xc9, 0x4b, 0xb8, 0x7d, 0x1d, 0xe6, 0xbf, 0x27, 0x05, 0x2c, 0xab, 0x82, 0xdf, 0x87, 0x05, 0x8d,
+	0x56, 0x0c, 0x7c, 0x23, 0x45, 0xac, 0x89, 0x20, 0xe8, 0x64, 0xe7, 0x33, 0x98, 0xfc, 0xd4, 0x0b,
+	0xba, 0x71, 0x7a, 0xbc, 0x01, 0x93, 0x9e, 0x7f, 0x8a, 0x43, 0x0f, 0xfb, 0x72, 0x92, 0x04, 0x51,
+	0x18, 0x32, 0x0f, 0xbf, 0x98, 0x59, 0x81, 0x71, 0x97, 0x50, 0xec, 0x75, 0xe5, 0x6e, 0x1a, 0x43,
+	0x4e, 0x03, 0x56, 0xf6, 0x4e, 0x48, 0xfb, 0xf9, 0xfb, 0x72, 0x7c, 0x69, 0x11, 0xa7, 0x0f, 0xab,
+	0xb9, 0x16, 0x21, 0x95, 0x93, 0xab, 0xc7, 0x32, 0xcf, 0x4d, 0xe1, 0xec, 0x3b, 0x00, 0xa7, 0x52,
+	0xa2, 0x88, 0xc7, 0x46, 0x53, 0x3b, 0x0b, 0x52, 0x7a, 0x25, 0x6b, 0x53, 0x23, 0x72, 0x96, 0x61,
+	0xf1, 0x30, 0xf4, 0x70, 0x37, 0x1d, 0x68, 0x38, 0x14, 0xe6, 0x75, 0xf4, 0x63, 0xcf, 0x27, 0xc3,
+	0xce, 0xcd, 0xc2, 0x60, 0x5b, 0xdd, 0x67, 0x55, 0xf5, 0xfb, 0xac, 0xe4, 0x9e, 0xb0, 0xa6, 0xdf,
+	0x13, 0x3a, 0x17, 0xb0, 0xa0, 0xcf, 0x7a, 0x98, 0x4b, 0x13, 0xac, 0xa2, 0xe1, 0x2b, 0xe6, 0xe1,
+	0xab, 0xfa, 0xf0, 0x6c, 0x24, 0xb1, 0xee, 0x5d, 0x3e, 0x71, 0x5d, 0x85, 0x3f, 0x2e, 0x8b, 0x19,
+	0x96, 0xd2, 0x8a, 0x10, 0x7a, 0xbf, 0xc5, 0x72, 0x7b, 0x5f, 0x45, 0x50, 0x8d, 0xa4, 0x00, 0x9e,
+	0x56, 0x4f, 0x33, 0x26, 0xb3, 0xef, 0xc0, 0x38, 0x4f, 0x6f, 0xa4, 0xfe, 0xd7, 0x4c, 0x1d, 0xb8,
+	0x64, 0x4d, 0x41, 0x98, 0xe2, 0xab, 0x9a, 0xe1, 0xeb, 0x2b, 0x98, 0x78, 0x46, 0x8e, 0x4e, 0x82,
+	0xe0, 0x79, 0xee, 0x74, 0x9c, 0x87, 0xea, 0x20, 0xec, 0x0a, 0x95, 0xb3, 0x4f, 0x26, 0x38, 0x39,
+	0x25, 0x71, 0xf2, 0xce, 0xe2, 0x62, 0x01, 0x31, 0x7c, 0x44, 0xda, 0x61, 0x92, 0x4d, 0xc5, 0x10,
+	0xab, 0x6c, 0xb5, 0x43, 0x82, 0x69, 0xfc, 0xac, 0x22, 0xce, 0xb1, 0x27, 0x05, 0x66, 0x97, 0x3a,
+	0x9f, 0xc1, 0xd2, 0x1e, 0x07, 0x04, 0x07, 0x72, 0x35, 0x8a, 0x89, 0x2d, 0xd3, 0xc4, 0x95, 0x82,
+	0x89, 0xab, 0xfa, 0xc4, 0xce, 0x03, 0x58, 0xce, 0x8c, 0x2c, 0xb4, 0xfd, 0x1a, 0x4c, 0x9c, 0xc5,
+	0x28, 0xb1, 0x78, 0x55, 0xc4, 0x2a, 0x29, 0x65, 0x3b, 0xf3, 0xdc, 0xc7, 0x5e, 0x44, 0x05, 0x5e,
+	0x2d, 0xa1, 0x3d, 0x58, 0x4a, 0xa3, 0x93, 0x60, 0x58, 0xf4, 0xcc, 0x05, 0xc3, 0x72, 0x68, 0x45,
+	0xe0, 0xdc, 0x60, 0x69, 0x44, 0x97, 0xe4, 0x24, 0xcf, 0x98, 0xc0, 0x59, 0x85, 0xe5, 0x0c, 0x9d,
+	0xb8, 0xb2, 0xfc, 0xab, 0x05, 0x73, 0x02, 0xb7, 0x4f, 0xba, 0xde, 0x29, 0x09, 0x2f, 0x72, 0xf6,
+	0x6b, 0x24, 0xb2, 0xc6, 0x36, 0x94, 0x20, 0x73, 0x6b, 0xae, 0x40, 0xa1, 0xb5, 0x18, 0x48, 0xdd,
+	0xac, 0xd5, 0x32, 0x2f, 0x64, 0x10, 0xd4, 0x31, 0xa5, 0xa4, 0xd7, 0xa7, 0x11, 0xb7, 0xe3, 0x58,
+	0x53, 0xc1, 0xcc, 0xca, 0x5d, 0x1c, 0xd1, 0x16, 0x09, 0xc3, 0x20, 0x14, 0x19, 0xea, 0x24, 0xc3,
+	0x3c, 0x64, 0x08, 0x7b, 0x95, 0x85, 0xf0, 0x98, 0x7b, 0xc0, 0x44, 0xbc, 0x5c, 0x18, 0xb8, 0x4b,
+	0xd9, 0x36, 0xc5, 0x34, 0xb9, 0x4f, 0xb0, 0xfb, 0x98, 0x50, 0x4a, 0x42, 0xa5, 0xe3, 0x26, 0xac,
+	0xe6, 0x5a, 0xd4, 0xb5, 0x11, 0xb8, 0xb1, 0xc0, 0x49, 0x15, 0x7e, 0x35, 0xa3, 0x68, 0xa9, 0x91,
+	0xa6, 0x46, 0xea, 0xdc, 0x63, 0xd5, 0x5f, 0x01, 0x67, 0xb4, 0x8e, 0xa0, 0x2e, 0x1a, 0xd4, 0x0e,
+	0x20, 0x61, 0x07, 0xb1, 0xf2, 0x77, 0xb6, 0x9b, 0x30, 0x02, 0x4b, 0x78, 0x69, 0x48, 0x70, 0xaf,
+	0xe0, 0x62, 0xb5, 0x3d, 0x08, 0xa3, 0x20, 0x3e, 0xfe, 0x6a, 0x4d, 0x01, 0x39, 0x07, 0x80, 0x4c,
+	0x9d, 0x84, 0x78, 0xf7, 0x4c, 0xd7, 0xe5, 0xc6, 0x4b, 0x31, 0x9d, 0x6e, 0xe7, 0x57, 0x36, 0xcc,
+	0x3c, 0xe6, 0x34, 0x07, 0x24, 0x3c, 0xf5, 0xda, 0xc4, 0xfe, 0x11, 0x2c, 0xca, 0xd8, 0x44, 0xcb,
+	0x05, 0x6c, 0x27, 0x35, 0x94, 0xf1, 0x06, 0x0d, 0x5d, 0x1b, 0x4a, 0x23, 0x64, 0xff, 0x1f, 0x36,
+	0x83, 0xe1, 0xee, 0x26, 0x99, 0xa1, 0xf8, 0xda, 0x0a, 0x5d, 0x1b, 0x4a, 0xa3, 0x66, 0xb8, 0x0b,
+	0x35, 0x76, 0x39, 0x62, 0x2b, 0xf9, 0xb5, 0xbb, 0x18, 0xb4, 0x94, 0x46, 0xaa, 0x4e, 0xcf, 0x60,
+	0x3e, 0x7b, 0x5f, 0x61, 0x6f, 0x49, 0xda, 0x82, 0x3b, 0x14, 0xb4, 0x5d, 0x4c, 0xa0, 0x06, 0x3e,
+	0x84, 0xb9, 0xcc, 0xf5, 0x81, 0x7d, 0x25, 0xe9, 0x66, 0xba, 0xec, 0x40, 0x5b, 0x85, 0xed, 0x6a,
+	0xd4, 0x8f, 0x60, 0x26, 0x55, 0xb4, 0xb7, 0x55, 0x2a, 0x65, 0xba, 0x77, 0x40, 0x9b, 0x05, 0xad,
+	0x6a, 0xbc, 0xf7, 0x60, 0x4a, 0xab, 0x94, 0xdb, 0xda, 0x4b, 0x8b, 0x6c, 0x9d, 0x1f, 0xad, 0x1b,
+	0xdb, 0xd4, 0x48, 0xdf, 0x85, 0xba, 0x2c, 0x7a, 0xdb, 0x6a, 0x85, 0x65, 0xca, 0xea, 0xa8, 0x91,
+	0x6f, 0x50, 0x03, 0xfc, 0x10, 0xec, 0x7c, 0x69, 0xd9, 0xbe, 0x2a, 0x7b, 0x14, 0x16, 0xc9, 0x91,
+	0x33, 0x8c, 0x44, 0x0d, 0xff, 0x31, 0xcc, 0xa6, 0xab, 0x98, 0xf6, 0xa6, 0xd6, 0x2f, 0x5f, 0x50,
+	0x45, 0x57, 0x8a, 0x9a, 0x75, 0x91, 0x65, 0xb5, 0x31, 0x11, 0x39, 0x53, 0xf8, 0x44, 0x8d, 0x7c,
+	0x83, 0x1a, 0xe0, 0x73, 0x58, 0xc8, 0x95, 0xc0, 0xec, 0x6d, 0x6d, 0x5e, 0x63, 0xa9, 0x0e, 0x5d,
+	0x1d, 0x42, 0xa1, 0xcb, 0x9b, 0xae, 0x66, 0x25, 0xf2, 0x1a, 0x2b, 0x68, 0xe8, 0x4a, 0x51, 0xb3,
+	0xee, 0x7c, 0xa9, 0xc2, 0x51, 0xe2, 0x7c, 0xa6, 0x22, 0x17, 0xda, 0x2c, 0x68, 0xd5, 0x2d, 0x9e,
+	0x2f, 0xa7, 0x24, 0x16, 0x2f, 0x2c, 0xff, 0x20, 0x67, 0x18, 0x89, 0xbe, 0xe3, 0x18, 0xea, 0x1b,
+	0xc9, 0x8e, 0x53, 0x5c, 0x93, 0x41, 0xd7, 0x86, 0xd2, 0xa8, 0x19, 0xda, 0xb0, 0x64, 0x7a, 0x04,
+	0x68, 0xab, 0xee, 0x43, 0xde, 0x29, 0xa2, 0x57, 0x86, 0x13, 0xe9, 0x62, 0x18, 0x5e, 0xcb, 0x25,
+	0x62, 0x14, 0xbf, 0xe6, 0x43, 0xd7, 0x86, 0xd2, 0x64, 0xc5, 0xc8, 0xbd, 0xab, 0x4b, 0x89, 0x51,
+	0xf0, 0xe4, 0x0d, 0xbd, 0x32, 0x9c, 0x48, 0x4d, 0xd2, 0x81, 0x65, 0xe3, 0x93, 0x2b, 0x3b, 0x3b,
+	0x80, 0xf1, 0x61, 0x18, 0xba, 0x5e, 0x42, 0xa5, 0x3b, 0x69, 0xea, 0x51, 0x8f, 0x9d, 0x29, 0x36,
+	0xa5, 0x33, 0x0b, 0xb4, 0x59, 0xd0, 0x9a, 0x59, 0x47, 0x5a, 0x3d, 0x33, 0xb5, 0x8e, 0xf2, 0x45,
+	0x51, 0x74, 0xa5, 0xa8, 0x59, 0x3f, 0x1a, 0x32, 0xef, 0x8e, 0x92, 0xa3, 0xc1, 0xfc, 0xdc, 0x09,
+	0x6d, 0x15, 0xb6, 0xeb, 0xab, 0x29, 0xff, 0xbc, 0x27, 0x59, 0x4d, 0x85, 0x6f, 0xba, 0x90, 0x33,
+	0x8c, 0x44, 0x0d, 0x1f, 0xc2, 0x5a, 0xe1, 0xeb, 0x21, 0xfb, 0x66, 0xf1, 0x10, 0xe9, 0x67, 0x50,
+	0xe8, 0xb5, 0x11, 0x28, 0x53, 0x3e, 0x63, 0x7a, 0x15, 0xa4, 0xf9, 0xcc, 0x90, 0x27, 0x47, 0xe8,
+	0x7a, 0x09, 0x95, 0x9c, 0x67, 0xe7, 0x77, 0x55, 0xe9, 0x34, 0x32, 0x1e, 0x7a, 0x08, 0x90, 0x54,
+	0xc5, 0xec, 0xb5, 0xc2, 0x82, 0x1e, 0x42, 0xa6, 0x26, 0xdd, 0xd2, 0x99, 0x5a, 0x95, 0xad, 0x1f,
+	0x2b, 0x86, 0xba, 0x19, 0xda, 0x2a, 0x6c, 0x57, 0xa3, 0x3e, 0x80, 0x49, 0x55, 0x66, 0xb0, 0xd5,
+	0xf9, 0x92, 0xad, 0x52, 0xa0, 0x35, 0x43, 0x8b, 0x91, 0x33, 0x51, 0x1a, 0xcb, 0x73, 0x96, 0xaa,
+	0xcf, 0xa0, 0xad, 0xc2, 0x76, 0x7d, 0xf1, 0xa5, 0xaa, 0x2b, 0xc9, 0xe2, 0x33, 0x55, 0x7c, 0xd0,
+	0x66, 0x41, 0xab, 0x32, 0xcc, 0x9f, 0x2d, 0x98, 0xde, 0x75, 0x7b, 0x9e, 0x2f, 0xed, 0x72, 0x08,
+	0x73, 0x99, 0x8a, 0x44, 0xc2, 0xb6, 0xb9, 0x88, 0x81, 0xb6, 0x0a, 0xdb, 0x15, 0xdb, 0x1f, 0xc2,
+	0xb4, 0x9e, 0x0e, 0xdb, 0xeb, 0xa6, 0x24, 0x59, 0x8e, 0xb7, 0x61, 0x6e, 0x54, 0x3c, 0x7f, 0x5d,
+	0x85, 0x59, 0x11, 0xfa, 0x4b, 0xae, 0x3f, 0x82, 0x99, 0x54, 0x7e, 0x99, 0xa8, 0xc5, 0x94, 0xd0,
+	0xa2, 0xcd, 0x82, 0x56, 0x9d, 0x5f, 0x3d, 0xa9, 0x4c, 0xf8, 0x35, 0x64, 0xa0, 0x68, 0xc3, 0xdc,
+	0x98, 0x3e, 0xd5, 0xbb, 0xc4, 0xc0, 0x9c, 0x29, 0xe7, 0x44, 0x9b, 0x05, 0xad, 0xba, 0x67, 0x65,
+	0xb2, 0xb1, 0xc4, 0x44, 0xe6, 0x04, 0x0e, 0x6d, 0x15, 0xb6, 0xa7, 0xe3, 0xf4, 0x74, 0x62, 0xa5,
+	0xc7, 0xe9, 0xc6, 0x4c, 0x0d, 0x6d, 0x17, 0x13, 0x28, 0x73, 0xf9, 0xfc, 0x0d, 0x81, 0x2b, 0x4d,
+	0xd5, 0x02, 0x3b, 0x9f, 0x6f, 0x69, 0x51, 0x68, 0x51, 0x02, 0x87, 0x9c, 0x61, 0x24, 0x72, 0xb6,
+	0x37, 0xad, 0x07, 0xf0, 0x79, 0x3d, 0x26, 0xec, 0x1f, 0x1d, 0x8d, 0xf3, 0x7f, 0xc9, 0xdc, 0xfd,
+	0xcf, 0x00, 0xdc, 0x27, 0x71, 0x1f, 0x42, 0x33, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// LedgerServiceClient is the client API for LedgerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LedgerServiceClient interface {
+	TransferWalletFunds(ctx context.Context, in *TransferWalletFundsRequest, opts ...grpc.CallOption) (*TransferWalletFundsResponse, error)
+	ExchangeWalletFunds(ctx context.Context, in *ExchangeWalletFundsRequest, opts ...grpc.CallOption) (*ExchangeWalletFundsResponse, error)
+	Post(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*PostResponse, error)
+	ReverseAggregate(ctx context.Context, in *ReverseAggregateRequest, opts ...grpc.CallOption) (*ReverseAggregateResponse, error)
+	RefundAggregate(ctx context.Context, in *RefundAggregateRequest, opts ...grpc.CallOption) (*RefundAggregateResponse, error)
+	AuthorizeHold(ctx context.Context, in *AuthorizeHoldRequest, opts ...grpc.CallOption) (*AuthorizeHoldResponse, error)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error)
+	VoidHold(ctx context.Context, in *VoidHoldRequest, opts ...grpc.CallOption) (*VoidHoldResponse, error)
+	SetOverdraftPolicy(ctx context.Context, in *SetOverdraftPolicyRequest, opts ...grpc.CallOption) (*SetOverdraftPolicyResponse, error)
+	SetFeeSchedule(ctx context.Context, in *SetFeeScheduleRequest, opts ...grpc.CallOption) (*SetFeeScheduleResponse, error)
+	QuoteFee(ctx context.Context, in *QuoteFeeRequest, opts ...grpc.CallOption) (*QuoteFeeResponse, error)
+	SetInterestPolicy(ctx context.Context, in *SetInterestPolicyRequest, opts ...grpc.CallOption) (*SetInterestPolicyResponse, error)
+	AccrueInterest(ctx context.Context, in *AccrueInterestRequest, opts ...grpc.CallOption) (*AccrueInterestResponse, error)
+	DefineAccount(ctx context.Context, in *DefineAccountRequest, opts ...grpc.CallOption) (*DefineAccountResponse, error)
+	DepositWalletFunds(ctx context.Context, in *DepositWalletFundsRequest, opts ...grpc.CallOption) (*DepositWalletFundsResponse, error)
+	WithdrawWalletFunds(ctx context.Context, in *WithdrawWalletFundsRequest, opts ...grpc.CallOption) (*WithdrawWalletFundsResponse, error)
+	AddCreditTransaction(ctx context.Context, in *AddCreditTransactionRequest, opts ...grpc.CallOption) (*AddCreditTransactionResponse, error)
+	AddDebitTransaction(ctx context.Context, in *AddDebitTransactionRequest, opts ...grpc.CallOption) (*AddDebitTransactionResponse, error)
+	AddCashInTransaction(ctx context.Context, in *AddCashInTransactionRequest, opts ...grpc.CallOption) (*AddCashInTransactionResponse, error)
+	AddCashOutTransaction(ctx context.Context, in *AddCashOutTransactionRequest, opts ...grpc.CallOption) (*AddCashOutTransactionResponse, error)
+	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
+	AccountBalance(ctx context.Context, in *AccountBalanceRequest, opts ...grpc.CallOption) (*AccountBalanceResponse, error)
+	WalletBalanceAt(ctx context.Context, in *WalletBalanceAtRequest, opts ...grpc.CallOption) (*WalletBalanceAtResponse, error)
+	WalletTransactions(ctx context.Context, in *WalletTransactionsRequest, opts ...grpc.CallOption) (*WalletTransactionsResponse, error)
+	WalletTransactionsBetween(ctx context.Context, in *WalletTransactionsBetweenRequest, opts ...grpc.CallOption) (*WalletTransactionsBetweenResponse, error)
+	AggregateTransactions(ctx context.Context, in *AggregateTransactionsRequest, opts ...grpc.CallOption) (*AggregateTransactionsResponse, error)
+}
+
+type ledgerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLedgerServiceClient(cc *grpc.ClientConn) LedgerServiceClient {
+	return &ledgerServiceClient{cc}
+}
+
+func (c *ledgerServiceClient) TransferWalletFunds(ctx context.Context, in *TransferWalletFundsRequest, opts ...grpc.CallOption) (*TransferWalletFundsResponse, error) {
+	out := new(TransferWalletFundsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/TransferWalletFunds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ExchangeWalletFunds(ctx context.Context, in *ExchangeWalletFundsRequest, opts ...grpc.CallOption) (*ExchangeWalletFundsResponse, error) {
+	out := new(ExchangeWalletFundsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/ExchangeWalletFunds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) Post(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*PostResponse, error) {
+	out := new(PostResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/Post", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ReverseAggregate(ctx context.Context, in *ReverseAggregateRequest, opts ...grpc.CallOption) (*ReverseAggregateResponse, error) {
+	out := new(ReverseAggregateResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/ReverseAggregate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) RefundAggregate(ctx context.Context, in *RefundAggregateRequest, opts ...grpc.CallOption) (*RefundAggregateResponse, error) {
+	out := new(RefundAggregateResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/RefundAggregate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AuthorizeHold(ctx context.Context, in *AuthorizeHoldRequest, opts ...grpc.CallOption) (*AuthorizeHoldResponse, error) {
+	out := new(AuthorizeHoldResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AuthorizeHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*CaptureHoldResponse, error) {
+	out := new(CaptureHoldResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/CaptureHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) VoidHold(ctx context.Context, in *VoidHoldRequest, opts ...grpc.CallOption) (*VoidHoldResponse, error) {
+	out := new(VoidHoldResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/VoidHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetOverdraftPolicy(ctx context.Context, in *SetOverdraftPolicyRequest, opts ...grpc.CallOption) (*SetOverdraftPolicyResponse, error) {
+	out := new(SetOverdraftPolicyResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/SetOverdraftPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetFeeSchedule(ctx context.Context, in *SetFeeScheduleRequest, opts ...grpc.CallOption) (*SetFeeScheduleResponse, error) {
+	out := new(SetFeeScheduleResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/SetFeeSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) QuoteFee(ctx context.Context, in *QuoteFeeRequest, opts ...grpc.CallOption) (*QuoteFeeResponse, error) {
+	out := new(QuoteFeeResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/QuoteFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetInterestPolicy(ctx context.Context, in *SetInterestPolicyRequest, opts ...grpc.CallOption) (*SetInterestPolicyResponse, error) {
+	out := new(SetInterestPolicyResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/SetInterestPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AccrueInterest(ctx context.Context, in *AccrueInterestRequest, opts ...grpc.CallOption) (*AccrueInterestResponse, error) {
+	out := new(AccrueInterestResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AccrueInterest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) DefineAccount(ctx context.Context, in *DefineAccountRequest, opts ...grpc.CallOption) (*DefineAccountResponse, error) {
+	out := new(DefineAccountResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/DefineAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) DepositWalletFunds(ctx context.Context, in *DepositWalletFundsRequest, opts ...grpc.CallOption) (*DepositWalletFundsResponse, error) {
+	out := new(DepositWalletFundsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/DepositWalletFunds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WithdrawWalletFunds(ctx context.Context, in *WithdrawWalletFundsRequest, opts ...grpc.CallOption) (*WithdrawWalletFundsResponse, error) {
+	out := new(WithdrawWalletFundsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WithdrawWalletFunds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AddCreditTransaction(ctx context.Context, in *AddCreditTransactionRequest, opts ...grpc.CallOption) (*AddCreditTransactionResponse, error) {
+	out := new(AddCreditTransactionResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddCreditTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AddDebitTransaction(ctx context.Context, in *AddDebitTransactionRequest, opts ...grpc.CallOption) (*AddDebitTransactionResponse, error) {
+	out := new(AddDebitTransactionResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddDebitTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AddCashInTransaction(ctx context.Context, in *AddCashInTransactionRequest, opts ...grpc.CallOption) (*AddCashInTransactionResponse, error) {
+	out := new(AddCashInTransactionResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddCashInTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AddCashOutTransaction(ctx context.Context, in *AddCashOutTransactionRequest, opts ...grpc.CallOption) (*AddCashOutTransactionResponse, error) {
+	out := new(AddCashOutTransactionResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddCashOutTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
+	out := new(WalletBalanceResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WalletBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AccountBalance(ctx context.Context, in *AccountBalanceRequest, opts ...grpc.CallOption) (*AccountBalanceResponse, error) {
+	out := new(AccountBalanceResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AccountBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WalletBalanceAt(ctx context.Context, in *WalletBalanceAtRequest, opts ...grpc.CallOption) (*WalletBalanceAtResponse, error) {
+	out := new(WalletBalanceAtResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WalletBalanceAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WalletTransactions(ctx context.Context, in *WalletTransactionsRequest, opts ...grpc.CallOption) (*WalletTransactionsResponse, error) {
+	out := new(WalletTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WalletTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) WalletTransactionsBetween(ctx context.Context, in *WalletTransactionsBetweenRequest, opts ...grpc.CallOption) (*WalletTransactionsBetweenResponse, error) {
+	out := new(WalletTransactionsBetweenResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WalletTransactionsBetween", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) AggregateTransactions(ctx context.Context, in *AggregateTransactionsRequest, opts ...grpc.CallOption) (*AggregateTransactionsResponse, error) {
+	out := new(AggregateTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AggregateTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LedgerServiceServer is the server API for LedgerService service.
+type LedgerServiceServer interface {
+	TransferWalletFunds(context.Context, *TransferWalletFundsRequest) (*TransferWalletFundsResponse, error)
+	ExchangeWalletFunds(context.Context, *ExchangeWalletFundsRequest) (*ExchangeWalletFundsResponse, error)
+	Post(context.Context, *PostRequest) (*PostResponse, error)
+	ReverseAggregate(context.Context, *ReverseAggregateRequest) (*ReverseAggregateResponse, error)
+	RefundAggregate(context.Context, *RefundAggregateRequest) (*RefundAggregateResponse, error)
+	AuthorizeHold(context.Context, *AuthorizeHoldRequest) (*AuthorizeHoldResponse, error)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*CaptureHoldResponse, error)
+	VoidHold(context.Context, *VoidHoldRequest) (*VoidHoldResponse, error)
+	SetOverdraftPolicy(context.Context, *SetOverdraftPolicyRequest) (*SetOverdraftPolicyResponse, error)
+	SetFeeSchedule(context.Context, *SetFeeScheduleRequest) (*SetFeeScheduleResponse, error)
+	QuoteFee(context.Context, *QuoteFeeRequest) (*QuoteFeeResponse, error)
+	SetInterestPolicy(context.Context, *SetInterestPolicyRequest) (*SetInterestPolicyResponse, error)
+	AccrueInterest(context.Context, *AccrueInterestRequest) (*AccrueInterestResponse, error)
+	DefineAccount(context.Context, *DefineAccountRequest) (*DefineAccountResponse, error)
+	DepositWalletFunds(context.Context, *DepositWalletFundsRequest) (*DepositWalletFundsResponse, error)
+	WithdrawWalletFunds(context.Context, *WithdrawWalletFundsRequest) (*WithdrawWalletFundsResponse, error)
+	AddCreditTransaction(context.Context, *AddCreditTransactionRequest) (*AddCreditTransactionResponse, error)
+	AddDebitTransaction(context.Context, *AddDebitTransactionRequest) (*AddDebitTransactionResponse, error)
+	AddCashInTransaction(context.Context, *AddCashInTransactionRequest) (*AddCashInTransactionResponse, error)
+	AddCashOutTransaction(context.Context, *AddCashOutTransactionRequest) (*AddCashOutTransactionResponse, error)
+	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
+	AccountBalance(context.Context, *AccountBalanceRequest) (*AccountBalanceResponse, error)
+	WalletBalanceAt(context.Context, *WalletBalanceAtRequest) (*WalletBalanceAtResponse, error)
+	WalletTransactions(context.Context, *WalletTransactionsRequest) (*WalletTransactionsResponse, error)
+	WalletTransactionsBetween(context.Context, *WalletTransactionsBetweenRequest) (*WalletTransactionsBetweenResponse, error)
+	AggregateTransactions(context.Context, *AggregateTransactionsRequest) (*AggregateTransactionsResponse, error)
+}
+
+// UnimplementedLedgerServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedLedgerServiceServer struct {
+}
+
+func (*UnimplementedLedgerServiceServer) TransferWalletFunds(ctx context.Context, req *TransferWalletFundsRequest) (*TransferWalletFundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferWalletFunds not implemented")
+}
+func (*UnimplementedLedgerServiceServer) ExchangeWalletFunds(ctx context.Context, req *ExchangeWalletFundsRequest) (*ExchangeWalletFundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExchangeWalletFunds not implemented")
+}
+func (*UnimplementedLedgerServiceServer) Post(ctx context.Context, req *PostRequest) (*PostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Post not implemented")
+}
+func (*UnimplementedLedgerServiceServer) ReverseAggregate(ctx context.Context, req *ReverseAggregateRequest) (*ReverseAggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseAggregate not implemented")
+}
+func (*UnimplementedLedgerServiceServer) RefundAggregate(ctx context.Context, req *RefundAggregateRequest) (*RefundAggregateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundAggregate not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AuthorizeHold(ctx context.Context, req *AuthorizeHoldRequest) (*AuthorizeHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeHold not implemented")
+}
+func (*UnimplementedLedgerServiceServer) CaptureHold(ctx context.Context, req *CaptureHoldRequest) (*CaptureHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (*UnimplementedLedgerServiceServer) VoidHold(ctx context.Context, req *VoidHoldRequest) (*VoidHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidHold not implemented")
+}
+func (*UnimplementedLedgerServiceServer) SetOverdraftPolicy(ctx context.Context, req *SetOverdraftPolicyRequest) (*SetOverdraftPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOverdraftPolicy not implemented")
+}
+func (*UnimplementedLedgerServiceServer) SetFeeSchedule(ctx context.Context, req *SetFeeScheduleRequest) (*SetFeeScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeeSchedule not implemented")
+}
+func (*UnimplementedLedgerServiceServer) QuoteFee(ctx context.Context, req *QuoteFeeRequest) (*QuoteFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteFee not implemented")
+}
+func (*UnimplementedLedgerServiceServer) SetInterestPolicy(ctx context.Context, req *SetInterestPolicyRequest) (*SetInterestPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetInterestPolicy not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AccrueInterest(ctx context.Context, req *AccrueInterestRequest) (*AccrueInterestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccrueInterest not implemented")
+}
+func (*UnimplementedLedgerServiceServer) DefineAccount(ctx context.Context, req *DefineAccountRequest) (*DefineAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefineAccount not implemented")
+}
+func (*UnimplementedLedgerServiceServer) DepositWalletFunds(ctx context.Context, req *DepositWalletFundsRequest) (*DepositWalletFundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DepositWalletFunds not implemented")
+}
+func (*UnimplementedLedgerServiceServer) WithdrawWalletFunds(ctx context.Context, req *WithdrawWalletFundsRequest) (*WithdrawWalletFundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawWalletFunds not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AddCreditTransaction(ctx context.Context, req *AddCreditTransactionRequest) (*AddCreditTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCreditTransaction not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AddDebitTransaction(ctx context.Context, req *AddDebitTransactionRequest) (*AddDebitTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDebitTransaction not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AddCashInTransaction(ctx context.Context, req *AddCashInTransactionRequest) (*AddCashInTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCashInTransaction not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AddCashOutTransaction(ctx context.Context, req *AddCashOutTransactionRequest) (*AddCashOutTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCashOutTransaction not implemented")
+}
+func (*UnimplementedLedgerServiceServer) WalletBalance(ctx context.Context, req *WalletBalanceRequest) (*WalletBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletBalance not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AccountBalance(ctx context.Context, req *AccountBalanceRequest) (*AccountBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AccountBalance not implemented")
+}
+func (*UnimplementedLedgerServiceServer) WalletBalanceAt(ctx context.Context, req *WalletBalanceAtRequest) (*WalletBalanceAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletBalanceAt not implemented")
+}
+func (*UnimplementedLedgerServiceServer) WalletTransactions(ctx context.Context, req *WalletTransactionsRequest) (*WalletTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletTransactions not implemented")
+}
+func (*UnimplementedLedgerServiceServer) WalletTransactionsBetween(ctx context.Context, req *WalletTransactionsBetweenRequest) (*WalletTransactionsBetweenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalletTransactionsBetween not implemented")
+}
+func (*UnimplementedLedgerServiceServer) AggregateTransactions(ctx context.Context, req *AggregateTransactionsRequest) (*AggregateTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AggregateTransactions not implemented")
+}
+
+func RegisterLedgerServiceServer(s *grpc.Server, srv LedgerServiceServer) {
+	s.RegisterService(&_LedgerService_serviceDesc, srv)
+}
+
+func _LedgerService_TransferWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferWalletFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).TransferWalletFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/TransferWalletFunds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).TransferWalletFunds(ctx, req.(*TransferWalletFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ExchangeWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeWalletFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ExchangeWalletFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/ExchangeWalletFunds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ExchangeWalletFunds(ctx, req.(*ExchangeWalletFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_Post_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).Post(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/Post",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).Post(ctx, req.(*PostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ReverseAggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseAggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ReverseAggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/ReverseAggregate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ReverseAggregate(ctx, req.(*ReverseAggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func init() { proto.RegisterFile("api/protobuf/ledger.proto", fileDescriptor_42f128396e519916) }
+func _LedgerService_RefundAggregate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundAggregateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).RefundAggregate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/RefundAggregate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).RefundAggregate(ctx, req.(*RefundAggregateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-var fileDescriptor_42f128396e519916 = []byte{
-	// 723 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x6f, 0x6f, 0xd3, 0x3e,
-	0x10, 0x6e, 0x7e, 0x5b, 0xbb, 0xed, 0xfa, 0x9b, 0x04, 0xde, 0x1f, 0xa5, 0x5e, 0x27, 0x15, 0x0f,
-	0xa4, 0xbd, 0xda, 0xc4, 0x06, 0x42, 0x6c, 0x7b, 0xb3, 0x31, 0x21, 0x21, 0xf1, 0x4f, 0x05, 0x34,
-	0x34, 0x01, 0x9a, 0x9b, 0x78, 0x5d, 0xa4, 0x92, 0x84, 0xc4, 0x61, 0xe2, 0xc3, 0xf2, 0x5d, 0x50,
-	0x1d, 0x27, 0x71, 0x12, 0x3b, 0x2d, 0x42, 0x7b, 0xd7, 0x73, 0xef, 0xee, 0xb9, 0xe7, 0x39, 0xfb,
-	0x2e, 0xd0, 0xa3, 0xa1, 0xb7, 0x1f, 0x46, 0x01, 0x0f, 0x46, 0xc9, 0xf5, 0xfe, 0x84, 0xb9, 0x63,
-	0x16, 0xed, 0x09, 0x1b, 0x75, 0x52, 0x8b, 0x04, 0xd0, 0xfd, 0x18, 0x51, 0x3f, 0xa6, 0x0e, 0xf7,
-	0x02, 0x1f, 0x21, 0x58, 0xe4, 0xbf, 0x42, 0x66, 0x5b, 0x03, 0x6b, 0x77, 0x65, 0x28, 0x7e, 0xa3,
-	0x4d, 0xe8, 0xdc, 0xd2, 0xc9, 0x84, 0x71, 0xfb, 0x3f, 0x71, 0x2a, 0xad, 0xe9, 0x39, 0xfd, 0x1e,
-	0x24, 0x3e, 0xb7, 0x17, 0x06, 0xd6, 0x6e, 0x7b, 0x28, 0x2d, 0xd4, 0x87, 0x15, 0x3a, 0x1e, 0x47,
-	0x6c, 0x4c, 0x39, 0xb3, 0x17, 0x45, 0x48, 0x71, 0x40, 0x28, 0xdc, 0x7f, 0x11, 0x31, 0xd7, 0xe3,
-	0x2a, 0x6c, 0x01, 0x61, 0x55, 0x21, 0x1c, 0xe1, 0x2c, 0xa0, 0xdb, 0x43, 0x69, 0x95, 0x21, 0x16,
-	0xaa, 0x10, 0x97, 0xb0, 0x75, 0xea, 0xba, 0x35, 0x94, 0x21, 0xfb, 0x91, 0xb0, 0x98, 0xa3, 0x63,
-	0xe8, 0xf2, 0xe2, 0x54, 0x20, 0x76, 0x0f, 0x7a, 0x7b, 0x52, 0x9e, 0x7a, 0x98, 0xea, 0x4d, 0x8e,
-	0xa0, 0xaf, 0xcf, 0x1d, 0x87, 0x81, 0x1f, 0x33, 0x84, 0x61, 0x39, 0x92, 0xbf, 0x25, 0x97, 0xdc,
-	0x26, 0xdf, 0xe0, 0xde, 0x39, 0x1b, 0xcd, 0xc7, 0x7c, 0x1d, 0xda, 0xee, 0xd4, 0x57, 0x12, 0x4f,
-	0x8d, 0x19, 0xbc, 0x3f, 0x03, 0x3e, 0x75, 0xdd, 0x2a, 0x44, 0x46, 0xfb, 0x48, 0x47, 0xdb, 0xce,
-	0x68, 0xd7, 0xa2, 0x4a, 0xac, 0x9f, 0x0b, 0x45, 0xeb, 0x99, 0xe7, 0x20, 0x3d, 0xed, 0x37, 0x8d,
-	0x6f, 0x5e, 0xf9, 0x77, 0xde, 0xef, 0x2a, 0xca, 0x9c, 0xfd, 0xae, 0x85, 0xe9, 0xfa, 0x5d, 0xcf,
-	0x3d, 0x07, 0xf5, 0x2b, 0x40, 0xd3, 0xc0, 0x77, 0xc9, 0x9d, 0x75, 0xfc, 0x4b, 0x5e, 0x5d, 0x19,
-	0x24, 0xa3, 0x7e, 0xa2, 0xa3, 0x8e, 0x55, 0xea, 0x95, 0xb8, 0x12, 0xf7, 0x63, 0xd8, 0x36, 0x64,
-	0x9f, 0x83, 0xfc, 0x1e, 0xac, 0x5f, 0x08, 0x62, 0x67, 0x74, 0x42, 0x7d, 0x87, 0x65, 0x25, 0x19,
-	0xe8, 0x93, 0xc7, 0xb0, 0x51, 0xf1, 0x97, 0x20, 0x36, 0x2c, 0x8d, 0xd2, 0x23, 0x11, 0xd1, 0x1e,
-	0x66, 0x26, 0x39, 0x84, 0x5e, 0x1a, 0xa2, 0xd4, 0x16, 0xcf, 0xc2, 0xf9, 0x04, 0x58, 0x17, 0x24,
-	0xc1, 0x9e, 0xc1, 0xff, 0x8a, 0x02, 0xb1, 0x6d, 0x0d, 0x16, 0x76, 0xbb, 0x07, 0x6b, 0x99, 0x62,
-	0xaa, 0x08, 0x25, 0x47, 0x72, 0x02, 0xfd, 0xd3, 0xac, 0x2d, 0xba, 0x72, 0x4a, 0x7d, 0xb4, 0xea,
-	0x2f, 0x77, 0xdb, 0x10, 0xfd, 0xaf, 0x75, 0xf9, 0x80, 0xc5, 0x9f, 0xd7, 0x2c, 0x4a, 0x69, 0xbf,
-	0x4c, 0x7c, 0x57, 0x15, 0x29, 0x0e, 0x92, 0xc8, 0xc9, 0x4a, 0x92, 0x16, 0x1a, 0x40, 0xd7, 0x65,
-	0x31, 0xf7, 0x7c, 0x2a, 0xee, 0x4d, 0x3a, 0xf7, 0xd5, 0x23, 0xd3, 0xf0, 0x27, 0x4f, 0x61, 0x4b,
-	0x8b, 0x27, 0x79, 0x6c, 0x42, 0x27, 0x62, 0x71, 0x32, 0xc9, 0xbb, 0x92, 0x5a, 0xe4, 0x0d, 0xf4,
-	0xce, 0x59, 0x18, 0xc4, 0x1e, 0xd7, 0x57, 0xa9, 0x7d, 0x31, 0x36, 0x2c, 0xb9, 0x69, 0x90, 0x7c,
-	0x33, 0x99, 0x49, 0x9e, 0x00, 0xd6, 0xa5, 0x9b, 0x51, 0xc4, 0x7b, 0xc0, 0x17, 0x1e, 0xbf, 0x71,
-	0x23, 0x7a, 0xfb, 0x17, 0x55, 0x60, 0x58, 0xbe, 0x95, 0x51, 0xb2, 0x8c, 0xdc, 0x9e, 0xaa, 0xa1,
-	0xcd, 0xd8, 0x5c, 0xc8, 0xc1, 0xef, 0x25, 0x58, 0x7d, 0x2d, 0x3a, 0xfb, 0x81, 0x45, 0x3f, 0x3d,
-	0x87, 0xa1, 0x2b, 0x58, 0xd3, 0xc8, 0x8a, 0x48, 0xe9, 0x02, 0x68, 0x7b, 0x8c, 0x77, 0x1a, 0x7d,
-	0xe4, 0x6b, 0x6d, 0xa1, 0xaf, 0x80, 0xea, 0x92, 0xa1, 0x07, 0xc5, 0x7e, 0x30, 0x74, 0x07, 0x93,
-	0x26, 0x97, 0x3c, 0xfd, 0x15, 0xac, 0x69, 0x94, 0x28, 0x08, 0x98, 0x85, 0x2f, 0x08, 0x34, 0x48,
-	0x49, 0x5a, 0xc8, 0x81, 0x75, 0xdd, 0x66, 0x46, 0x79, 0x78, 0xc3, 0x37, 0x01, 0x7e, 0xd8, 0xec,
-	0xa4, 0xd2, 0xd0, 0x2c, 0xc2, 0x82, 0x86, 0x79, 0xff, 0xe2, 0x9d, 0x46, 0x9f, 0x2a, 0x8d, 0xda,
-	0xca, 0x2c, 0xd1, 0x30, 0xac, 0xba, 0x32, 0x0d, 0xd3, 0xce, 0x22, 0x2d, 0x74, 0x0d, 0x1b, 0xda,
-	0xc9, 0x8e, 0xaa, 0x09, 0xb4, 0x6b, 0x05, 0x3f, 0x9a, 0xe1, 0x95, 0xe3, 0xbc, 0x85, 0xd5, 0xd2,
-	0x50, 0x47, 0xfd, 0xbc, 0x97, 0x9a, 0xdd, 0x80, 0xb7, 0x0d, 0xff, 0xaa, 0x97, 0xb4, 0x3e, 0xbc,
-	0x8b, 0x4b, 0x6a, 0xdc, 0x06, 0xc5, 0x25, 0x35, 0xcf, 0x7e, 0x29, 0x8b, 0x6e, 0x0c, 0x2b, 0xb2,
-	0x34, 0xcc, 0x78, 0x45, 0x96, 0xa6, 0x59, 0x4e, 0x5a, 0x67, 0x70, 0xb9, 0x9c, 0x7a, 0x86, 0xa3,
-	0x51, 0x47, 0x7c, 0x8f, 0x1f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0xae, 0x4d, 0xad, 0x67, 0xac,
-	0x0b, 0x00, 0x00,
+func _LedgerService_AuthorizeHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AuthorizeHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AuthorizeHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AuthorizeHold(ctx, req.(*AuthorizeHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func _LedgerService_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/CaptureHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func _LedgerService_VoidHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).VoidHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/VoidHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).VoidHold(ctx, req.(*VoidHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// LedgerServiceClient is the client API for LedgerService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type LedgerServiceClient interface {
-	TransferWalletFunds(ctx context.Context, in *TransferWalletFundsRequest, opts ...grpc.CallOption) (*TransferWalletFundsResponse, error)
-	DepositWalletFunds(ctx context.Context, in *DepositWalletFundsRequest, opts ...grpc.CallOption) (*DepositWalletFundsResponse, error)
-	WithdrawWalletFunds(ctx context.Context, in *WithdrawWalletFundsRequest, opts ...grpc.CallOption) (*WithdrawWalletFundsResponse, error)
-	AddCreditTransaction(ctx context.Context, in *AddCreditTransactionRequest, opts ...grpc.CallOption) (*AddCreditTransactionResponse, error)
-	AddDebitTransaction(ctx context.Context, in *AddDebitTransactionRequest, opts ...grpc.CallOption) (*AddDebitTransactionResponse, error)
-	AddCashInTransaction(ctx context.Context, in *AddCashInTransactionRequest, opts ...grpc.CallOption) (*AddCashInTransactionResponse, error)
-	AddCashOutTransaction(ctx context.Context, in *AddCashOutTransactionRequest, opts ...grpc.CallOption) (*AddCashOutTransactionResponse, error)
-	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
-	WalletTransactions(ctx context.Context, in *WalletTransactionsRequest, opts ...grpc.CallOption) (*WalletTransactionsResponse, error)
-	AggregateTransactions(ctx context.Context, in *AggregateTransactionsRequest, opts ...grpc.CallOption) (*AggregateTransactionsResponse, error)
+func _LedgerService_SetOverdraftPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetOverdraftPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetOverdraftPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/SetOverdraftPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetOverdraftPolicy(ctx, req.(*SetOverdraftPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type ledgerServiceClient struct {
-	cc *grpc.ClientConn
+func _LedgerService_SetFeeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeeScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetFeeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/SetFeeSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetFeeSchedule(ctx, req.(*SetFeeScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_QuoteFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).QuoteFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/QuoteFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).QuoteFee(ctx, req.(*QuoteFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_SetInterestPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInterestPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetInterestPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/SetInterestPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetInterestPolicy(ctx, req.(*SetInterestPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AccrueInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccrueInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AccrueInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AccrueInterest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AccrueInterest(ctx, req.(*AccrueInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_DefineAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DefineAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).DefineAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/DefineAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).DefineAccount(ctx, req.(*DefineAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_DepositWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositWalletFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).DepositWalletFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/DepositWalletFunds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).DepositWalletFunds(ctx, req.(*DepositWalletFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_WithdrawWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawWalletFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WithdrawWalletFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/WithdrawWalletFunds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WithdrawWalletFunds(ctx, req.(*WithdrawWalletFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AddCreditTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCreditTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AddCreditTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AddCreditTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AddCreditTransaction(ctx, req.(*AddCreditTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AddDebitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDebitTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AddDebitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AddDebitTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AddDebitTransaction(ctx, req.(*AddDebitTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AddCashInTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCashInTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AddCashInTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AddCashInTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AddCashInTransaction(ctx, req.(*AddCashInTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_AddCashOutTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCashOutTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AddCashOutTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AddCashOutTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AddCashOutTransaction(ctx, req.(*AddCashOutTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func NewLedgerServiceClient(cc *grpc.ClientConn) LedgerServiceClient {
-	return &ledgerServiceClient{cc}
+func _LedgerService_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/WalletBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) TransferWalletFunds(ctx context.Context, in *TransferWalletFundsRequest, opts ...grpc.CallOption) (*TransferWalletFundsResponse, error) {
-	out := new(TransferWalletFundsResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/TransferWalletFunds", in, out, opts...)
-	if err != nil {
+func _LedgerService_AccountBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountBalanceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AccountBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AccountBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AccountBalance(ctx, req.(*AccountBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) DepositWalletFunds(ctx context.Context, in *DepositWalletFundsRequest, opts ...grpc.CallOption) (*DepositWalletFundsResponse, error) {
-	out := new(DepositWalletFundsResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/DepositWalletFunds", in, out, opts...)
-	if err != nil {
+func _LedgerService_WalletBalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalanceAtRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WalletBalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/WalletBalanceAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WalletBalanceAt(ctx, req.(*WalletBalanceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) WithdrawWalletFunds(ctx context.Context, in *WithdrawWalletFundsRequest, opts ...grpc.CallOption) (*WithdrawWalletFundsResponse, error) {
-	out := new(WithdrawWalletFundsResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WithdrawWalletFunds", in, out, opts...)
-	if err != nil {
+func _LedgerService_WalletTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletTransactionsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WalletTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/WalletTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WalletTransactions(ctx, req.(*WalletTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) AddCreditTransaction(ctx context.Context, in *AddCreditTransactionRequest, opts ...grpc.CallOption) (*AddCreditTransactionResponse, error) {
-	out := new(AddCreditTransactionResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddCreditTransaction", in, out, opts...)
-	if err != nil {
+func _LedgerService_WalletTransactionsBetween_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletTransactionsBetweenRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).WalletTransactionsBetween(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/WalletTransactionsBetween",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).WalletTransactionsBetween(ctx, req.(*WalletTransactionsBetweenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) AddDebitTransaction(ctx context.Context, in *AddDebitTransactionRequest, opts ...grpc.CallOption) (*AddDebitTransactionResponse, error) {
-	out := new(AddDebitTransactionResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddDebitTransaction", in, out, opts...)
-	if err != nil {
+func _LedgerService_AggregateTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AggregateTransactionsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AggregateTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.LedgerService/AggregateTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AggregateTransactions(ctx, req.(*AggregateTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) AddCashInTransaction(ctx context.Context, in *AddCashInTransactionRequest, opts ...grpc.CallOption) (*AddCashInTransactionResponse, error) {
-	out := new(AddCashInTransactionResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddCashInTransaction", in, out, opts...)
+var _LedgerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ledger.LedgerService",
+	HandlerType: (*LedgerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TransferWalletFunds",
+			Handler:    _LedgerService_TransferWalletFunds_Handler,
+		},
+		{
+			MethodName: "ExchangeWalletFunds",
+			Handler:    _LedgerService_ExchangeWalletFunds_Handler,
+		},
+		{
+			MethodName: "Post",
+			Handler:    _LedgerService_Post_Handler,
+		},
+		{
+			MethodName: "ReverseAggregate",
+			Handler:    _LedgerService_ReverseAggregate_Handler,
+		},
+		{
+			MethodName: "RefundAggregate",
+			Handler:    _LedgerService_RefundAggregate_Handler,
+		},
+		{
+			MethodName: "AuthorizeHold",
+			Handler:    _LedgerService_AuthorizeHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _LedgerService_CaptureHold_Handler,
+		},
+		{
+			MethodName: "VoidHold",
+			Handler:    _LedgerService_VoidHold_Handler,
+		},
+		{
+			MethodName: "SetOverdraftPolicy",
+			Handler:    _LedgerService_SetOverdraftPolicy_Handler,
+		},
+		{
+			MethodName: "SetFeeSchedule",
+			Handler:    _LedgerService_SetFeeSchedule_Handler,
+		},
+		{
+			MethodName: "QuoteFee",
+			Handler:    _LedgerService_QuoteFee_Handler,
+		},
+		{
+			MethodName: "SetInterestPolicy",
+			Handler:    _LedgerService_SetInterestPolicy_Handler,
+		},
+		{
+			MethodName: "AccrueInterest",
+			Handler:    _LedgerService_AccrueInterest_Handler,
+		},
+		{
+			MethodName: "DefineAccount",
+			Handler:    _LedgerService_DefineAccount_Handler,
+		},
+		{
+			MethodName: "DepositWalletFunds",
+			Handler:    _LedgerService_DepositWalletFunds_Handler,
+		},
+		{
+			MethodName: "WithdrawWalletFunds",
+			Handler:    _LedgerService_WithdrawWalletFunds_Handler,
+		},
+		{
+			MethodName: "AddCreditTransaction",
+			Handler:    _LedgerService_AddCreditTransaction_Handler,
+		},
+		{
+			MethodName: "AddDebitTransaction",
+			Handler:    _LedgerService_AddDebitTransaction_Handler,
+		},
+		{
+			MethodName: "AddCashInTransaction",
+			Handler:    _LedgerService_AddCashInTransaction_Handler,
+		},
+		{
+			MethodName: "AddCashOutTransaction",
+			Handler:    _LedgerService_AddCashOutTransaction_Handler,
+		},
+		{
+			MethodName: "WalletBalance",
+			Handler:    _LedgerService_WalletBalance_Handler,
+		},
+		{
+			MethodName: "AccountBalance",
+			Handler:    _LedgerService_AccountBalance_Handler,
+		},
+		{
+			MethodName: "WalletBalanceAt",
+			Handler:    _LedgerService_WalletBalanceAt_Handler,
+		},
+		{
+			MethodName: "WalletTransactions",
+			Handler:    _LedgerService_WalletTransactions_Handler,
+		},
+		{
+			MethodName: "WalletTransactionsBetween",
+			Handler:    _LedgerService_WalletTransactionsBetween_Handler,
+		},
+		{
+			MethodName: "AggregateTransactions",
+			Handler:    _LedgerService_AggregateTransactions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/ledger.proto",
+}
+
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WalletServiceClient interface {
+	OpenWallet(ctx context.Context, in *OpenWalletRequest, opts ...grpc.CallOption) (*OpenWalletResponse, error)
+	SetWalletStatus(ctx context.Context, in *SetWalletStatusRequest, opts ...grpc.CallOption) (*SetWalletStatusResponse, error)
+	GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*GetWalletResponse, error)
+	SetWalletLimits(ctx context.Context, in *SetWalletLimitsRequest, opts ...grpc.CallOption) (*SetWalletLimitsResponse, error)
+	SetTierLimits(ctx context.Context, in *SetTierLimitsRequest, opts ...grpc.CallOption) (*SetTierLimitsResponse, error)
+}
+
+type walletServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWalletServiceClient(cc *grpc.ClientConn) WalletServiceClient {
+	return &walletServiceClient{cc}
+}
+
+func (c *walletServiceClient) OpenWallet(ctx context.Context, in *OpenWalletRequest, opts ...grpc.CallOption) (*OpenWalletResponse, error) {
+	out := new(OpenWalletResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WalletService/OpenWallet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ledgerServiceClient) AddCashOutTransaction(ctx context.Context, in *AddCashOutTransactionRequest, opts ...grpc.CallOption) (*AddCashOutTransactionResponse, error) {
-	out := new(AddCashOutTransactionResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AddCashOutTransaction", in, out, opts...)
+func (c *walletServiceClient) SetWalletStatus(ctx context.Context, in *SetWalletStatusRequest, opts ...grpc.CallOption) (*SetWalletStatusResponse, error) {
+	out := new(SetWalletStatusResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WalletService/SetWalletStatus", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ledgerServiceClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
-	out := new(WalletBalanceResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WalletBalance", in, out, opts...)
+func (c *walletServiceClient) GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*GetWalletResponse, error) {
+	out := new(GetWalletResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WalletService/GetWallet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ledgerServiceClient) WalletTransactions(ctx context.Context, in *WalletTransactionsRequest, opts ...grpc.CallOption) (*WalletTransactionsResponse, error) {
-	out := new(WalletTransactionsResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/WalletTransactions", in, out, opts...)
+func (c *walletServiceClient) SetWalletLimits(ctx context.Context, in *SetWalletLimitsRequest, opts ...grpc.CallOption) (*SetWalletLimitsResponse, error) {
+	out := new(SetWalletLimitsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WalletService/SetWalletLimits", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *ledgerServiceClient) AggregateTransactions(ctx context.Context, in *AggregateTransactionsRequest, opts ...grpc.CallOption) (*AggregateTransactionsResponse, error) {
-	out := new(AggregateTransactionsResponse)
-	err := c.cc.Invoke(ctx, "/ledger.LedgerService/AggregateTransactions", in, out, opts...)
+func (c *walletServiceClient) SetTierLimits(ctx context.Context, in *SetTierLimitsRequest, opts ...grpc.CallOption) (*SetTierLimitsResponse, error) {
+	out := new(SetTierLimitsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WalletService/SetTierLimits", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// LedgerServiceServer is the server API for LedgerService service.
-type LedgerServiceServer interface {
-	TransferWalletFunds(context.Context, *TransferWalletFundsRequest) (*TransferWalletFundsResponse, error)
-	DepositWalletFunds(context.Context, *DepositWalletFundsRequest) (*DepositWalletFundsResponse, error)
-	WithdrawWalletFunds(context.Context, *WithdrawWalletFundsRequest) (*WithdrawWalletFundsResponse, error)
-	AddCreditTransaction(context.Context, *AddCreditTransactionRequest) (*AddCreditTransactionResponse, error)
-	AddDebitTransaction(context.Context, *AddDebitTransactionRequest) (*AddDebitTransactionResponse, error)
-	AddCashInTransaction(context.Context, *AddCashInTransactionRequest) (*AddCashInTransactionResponse, error)
-	AddCashOutTransaction(context.Context, *AddCashOutTransactionRequest) (*AddCashOutTransactionResponse, error)
-	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
-	WalletTransactions(context.Context, *WalletTransactionsRequest) (*WalletTransactionsResponse, error)
-	AggregateTransactions(context.Context, *AggregateTransactionsRequest) (*AggregateTransactionsResponse, error)
+// WalletServiceServer is the server API for WalletService service.
+type WalletServiceServer interface {
+	OpenWallet(context.Context, *OpenWalletRequest) (*OpenWalletResponse, error)
+	SetWalletStatus(context.Context, *SetWalletStatusRequest) (*SetWalletStatusResponse, error)
+	GetWallet(context.Context, *GetWalletRequest) (*GetWalletResponse, error)
+	SetWalletLimits(context.Context, *SetWalletLimitsRequest) (*SetWalletLimitsResponse, error)
+	SetTierLimits(context.Context, *SetTierLimitsRequest) (*SetTierLimitsResponse, error)
 }
 
-// UnimplementedLedgerServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedLedgerServiceServer struct {
+// UnimplementedWalletServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWalletServiceServer struct {
 }
 
-func (*UnimplementedLedgerServiceServer) TransferWalletFunds(ctx context.Context, req *TransferWalletFundsRequest) (*TransferWalletFundsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TransferWalletFunds not implemented")
-}
-func (*UnimplementedLedgerServiceServer) DepositWalletFunds(ctx context.Context, req *DepositWalletFundsRequest) (*DepositWalletFundsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DepositWalletFunds not implemented")
-}
-func (*UnimplementedLedgerServiceServer) WithdrawWalletFunds(ctx context.Context, req *WithdrawWalletFundsRequest) (*WithdrawWalletFundsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WithdrawWalletFunds not implemented")
-}
-func (*UnimplementedLedgerServiceServer) AddCreditTransaction(ctx context.Context, req *AddCreditTransactionRequest) (*AddCreditTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddCreditTransaction not implemented")
+func (*UnimplementedWalletServiceServer) OpenWallet(ctx context.Context, req *OpenWalletRequest) (*OpenWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenWallet not implemented")
 }
-func (*UnimplementedLedgerServiceServer) AddDebitTransaction(ctx context.Context, req *AddDebitTransactionRequest) (*AddDebitTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddDebitTransaction not implemented")
+func (*UnimplementedWalletServiceServer) SetWalletStatus(ctx context.Context, req *SetWalletStatusRequest) (*SetWalletStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWalletStatus not implemented")
 }
-func (*UnimplementedLedgerServiceServer) AddCashInTransaction(ctx context.Context, req *AddCashInTransactionRequest) (*AddCashInTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddCashInTransaction not implemented")
+func (*UnimplementedWalletServiceServer) GetWallet(ctx context.Context, req *GetWalletRequest) (*GetWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWallet not implemented")
 }
-func (*UnimplementedLedgerServiceServer) AddCashOutTransaction(ctx context.Context, req *AddCashOutTransactionRequest) (*AddCashOutTransactionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddCashOutTransaction not implemented")
+func (*UnimplementedWalletServiceServer) SetWalletLimits(ctx context.Context, req *SetWalletLimitsRequest) (*SetWalletLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWalletLimits not implemented")
 }
-func (*UnimplementedLedgerServiceServer) WalletBalance(ctx context.Context, req *WalletBalanceRequest) (*WalletBalanceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WalletBalance not implemented")
+func (*UnimplementedWalletServiceServer) SetTierLimits(ctx context.Context, req *SetTierLimitsRequest) (*SetTierLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTierLimits not implemented")
 }
-func (*UnimplementedLedgerServiceServer) WalletTransactions(ctx context.Context, req *WalletTransactionsRequest) (*WalletTransactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WalletTransactions not implemented")
+
+func RegisterWalletServiceServer(s *grpc.Server, srv WalletServiceServer) {
+	s.RegisterService(&_WalletService_serviceDesc, srv)
 }
-func (*UnimplementedLedgerServiceServer) AggregateTransactions(ctx context.Context, req *AggregateTransactionsRequest) (*AggregateTransactionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AggregateTransactions not implemented")
+
+func _WalletService_OpenWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).OpenWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.WalletService/OpenWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).OpenWallet(ctx, req.(*OpenWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterLedgerServiceServer(s *grpc.Server, srv LedgerServiceServer) {
-	s.RegisterService(&_LedgerService_serviceDesc, srv)
+func _WalletService_SetWalletStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetWalletStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ledger.WalletService/SetWalletStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetWalletStatus(ctx, req.(*SetWalletStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_TransferWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TransferWalletFundsRequest)
+func _WalletService_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).TransferWalletFunds(ctx, in)
+		return srv.(WalletServiceServer).GetWallet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/TransferWalletFunds",
+		FullMethod: "/ledger.WalletService/GetWallet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).TransferWalletFunds(ctx, req.(*TransferWalletFundsRequest))
+		return srv.(WalletServiceServer).GetWallet(ctx, req.(*GetWalletRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_DepositWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DepositWalletFundsRequest)
+func _WalletService_SetWalletLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWalletLimitsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).DepositWalletFunds(ctx, in)
+		return srv.(WalletServiceServer).SetWalletLimits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/DepositWalletFunds",
+		FullMethod: "/ledger.WalletService/SetWalletLimits",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).DepositWalletFunds(ctx, req.(*DepositWalletFundsRequest))
+		return srv.(WalletServiceServer).SetWalletLimits(ctx, req.(*SetWalletLimitsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_WithdrawWalletFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WithdrawWalletFundsRequest)
+func _WalletService_SetTierLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTierLimitsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).WithdrawWalletFunds(ctx, in)
+		return srv.(WalletServiceServer).SetTierLimits(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/WithdrawWalletFunds",
+		FullMethod: "/ledger.WalletService/SetTierLimits",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).WithdrawWalletFunds(ctx, req.(*WithdrawWalletFundsRequest))
+		return srv.(WalletServiceServer).SetTierLimits(ctx, req.(*SetTierLimitsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_AddCreditTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddCreditTransactionRequest)
+var _WalletService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ledger.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "OpenWallet",
+			Handler:    _WalletService_OpenWallet_Handler,
+		},
+		{
+			MethodName: "SetWalletStatus",
+			Handler:    _WalletService_SetWalletStatus_Handler,
+		},
+		{
+			MethodName: "GetWallet",
+			Handler:    _WalletService_GetWallet_Handler,
+		},
+		{
+			MethodName: "SetWalletLimits",
+			Handler:    _WalletService_SetWalletLimits_Handler,
+		},
+		{
+			MethodName: "SetTierLimits",
+			Handler:    _WalletService_SetTierLimits_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/ledger.proto",
+}
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	CheckInvariants(ctx context.Context, in *CheckInvariantsRequest, opts ...grpc.CallOption) (*CheckInvariantsResponse, error)
+	TrialBalance(ctx context.Context, in *TrialBalanceRequest, opts ...grpc.CallOption) (*TrialBalanceResponse, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) CheckInvariants(ctx context.Context, in *CheckInvariantsRequest, opts ...grpc.CallOption) (*CheckInvariantsResponse, error) {
+	out := new(CheckInvariantsResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/CheckInvariants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TrialBalance(ctx context.Context, in *TrialBalanceRequest, opts ...grpc.CallOption) (*TrialBalanceResponse, error) {
+	out := new(TrialBalanceResponse)
+	err := c.cc.Invoke(ctx, "/ledger.AdminService/TrialBalance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	CheckInvariants(context.Context, *CheckInvariantsRequest) (*CheckInvariantsResponse, error)
+	TrialBalance(context.Context, *TrialBalanceRequest) (*TrialBalanceResponse, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) CheckInvariants(ctx context.Context, req *CheckInvariantsRequest) (*CheckInvariantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInvariants not implemented")
+}
+func (*UnimplementedAdminServiceServer) TrialBalance(ctx context.Context, req *TrialBalanceRequest) (*TrialBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrialBalance not implemented")
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_CheckInvariants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInvariantsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).AddCreditTransaction(ctx, in)
+		return srv.(AdminServiceServer).CheckInvariants(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/AddCreditTransaction",
+		FullMethod: "/ledger.AdminService/CheckInvariants",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).AddCreditTransaction(ctx, req.(*AddCreditTransactionRequest))
+		return srv.(AdminServiceServer).CheckInvariants(ctx, req.(*CheckInvariantsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_AddDebitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddDebitTransactionRequest)
+func _AdminService_TrialBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrialBalanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).AddDebitTransaction(ctx, in)
+		return srv.(AdminServiceServer).TrialBalance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/AddDebitTransaction",
+		FullMethod: "/ledger.AdminService/TrialBalance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).AddDebitTransaction(ctx, req.(*AddDebitTransactionRequest))
+		return srv.(AdminServiceServer).TrialBalance(ctx, req.(*TrialBalanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_AddCashInTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddCashInTransactionRequest)
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ledger.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckInvariants",
+			Handler:    _AdminService_CheckInvariants_Handler,
+		},
+		{
+			MethodName: "TrialBalance",
+			Handler:    _AdminService_TrialBalance_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/ledger.proto",
+}
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error)
+}
+
+type webhookServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWebhookServiceClient(cc *grpc.ClientConn) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*CreateWebhookResponse, error) {
+	out := new(CreateWebhookResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WebhookService/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WebhookService/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*DeleteWebhookResponse, error) {
+	out := new(DeleteWebhookResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WebhookService/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WebhookService/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*RedeliverWebhookResponse, error) {
+	out := new(RedeliverWebhookResponse)
+	err := c.cc.Invoke(ctx, "/ledger.WebhookService/RedeliverWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+type WebhookServiceServer interface {
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*CreateWebhookResponse, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error)
+}
+
+// UnimplementedWebhookServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
+}
+
+func (*UnimplementedWebhookServiceServer) CreateWebhook(ctx context.Context, req *CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (*UnimplementedWebhookServiceServer) ListWebhooks(ctx context.Context, req *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (*UnimplementedWebhookServiceServer) DeleteWebhook(ctx context.Context, req *DeleteWebhookRequest) (*DeleteWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (*UnimplementedWebhookServiceServer) ListDeadLetters(ctx context.Context, req *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (*UnimplementedWebhookServiceServer) RedeliverWebhook(ctx context.Context, req *RedeliverWebhookRequest) (*RedeliverWebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
+
+func RegisterWebhookServiceServer(s *grpc.Server, srv WebhookServiceServer) {
+	s.RegisterService(&_WebhookService_serviceDesc, srv)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).AddCashInTransaction(ctx, in)
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/AddCashInTransaction",
+		FullMethod: "/ledger.WebhookService/CreateWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).AddCashInTransaction(ctx, req.(*AddCashInTransactionRequest))
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_AddCashOutTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddCashOutTransactionRequest)
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).AddCashOutTransaction(ctx, in)
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/AddCashOutTransaction",
+		FullMethod: "/ledger.WebhookService/ListWebhooks",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).AddCashOutTransaction(ctx, req.(*AddCashOutTransactionRequest))
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletBalanceRequest)
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).WalletBalance(ctx, in)
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/WalletBalance",
+		FullMethod: "/ledger.WebhookService/DeleteWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_WalletTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletTransactionsRequest)
+func _WebhookService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).WalletTransactions(ctx, in)
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/WalletTransactions",
+		FullMethod: "/ledger.WebhookService/ListDeadLetters",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).WalletTransactions(ctx, req.(*WalletTransactionsRequest))
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_AggregateTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AggregateTransactionsRequest)
+func _WebhookService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).AggregateTransactions(ctx, in)
+		return srv.(WebhookServiceServer).RedeliverWebhook(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ledger.LedgerService/AggregateTransactions",
+		FullMethod: "/ledger.WebhookService/RedeliverWebhook",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).AggregateTransactions(ctx, req.(*AggregateTransactionsRequest))
+		return srv.(WebhookServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _LedgerService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "ledger.LedgerService",
-	HandlerType: (*LedgerServiceServer)(nil),
+var _WebhookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ledger.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "TransferWalletFunds",
-			Handler:    _LedgerService_TransferWalletFunds_Handler,
-		},
-		{
-			MethodName: "DepositWalletFunds",
-			Handler:    _LedgerService_DepositWalletFunds_Handler,
-		},
-		{
-			MethodName: "WithdrawWalletFunds",
-			Handler:    _LedgerService_WithdrawWalletFunds_Handler,
-		},
-		{
-			MethodName: "AddCreditTransaction",
-			Handler:    _LedgerService_AddCreditTransaction_Handler,
-		},
-		{
-			MethodName: "AddDebitTransaction",
-			Handler:    _LedgerService_AddDebitTransaction_Handler,
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
 		},
 		{
-			MethodName: "AddCashInTransaction",
-			Handler:    _LedgerService_AddCashInTransaction_Handler,
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
 		},
 		{
-			MethodName: "AddCashOutTransaction",
-			Handler:    _LedgerService_AddCashOutTransaction_Handler,
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
 		},
 		{
-			MethodName: "WalletBalance",
-			Handler:    _LedgerService_WalletBalance_Handler,
+			MethodName: "ListDeadLetters",
+			Handler:    _WebhookService_ListDeadLetters_Handler,
 		},
 		{
-			MethodName: "WalletTransactions",
-			Handler:    _LedgerService_WalletTransactions_Handler,
+			MethodName: "RedeliverWebhook",
+			Handler:    _WebhookService_RedeliverWebhook_Handler,
 		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/protobuf/ledger.proto",
+}
+
+// FeedServiceClient is the client API for FeedService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FeedServiceClient interface {
+	StreamTransactions(ctx context.Context, in *StreamTransactionsRequest, opts ...grpc.CallOption) (FeedService_StreamTransactionsClient, error)
+}
+
+type feedServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFeedServiceClient(cc *grpc.ClientConn) FeedServiceClient {
+	return &feedServiceClient{cc}
+}
+
+func (c *feedServiceClient) StreamTransactions(ctx context.Context, in *StreamTransactionsRequest, opts ...grpc.CallOption) (FeedService_StreamTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FeedService_serviceDesc.Streams[0], "/ledger.FeedService/StreamTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &feedServiceStreamTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FeedService_StreamTransactionsClient interface {
+	Recv() (*StreamTransactionsResponse, error)
+	grpc.ClientStream
+}
+
+type feedServiceStreamTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *feedServiceStreamTransactionsClient) Recv() (*StreamTransactionsResponse, error) {
+	m := new(StreamTransactionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FeedServiceServer is the server API for FeedService service.
+type FeedServiceServer interface {
+	StreamTransactions(*StreamTransactionsRequest, FeedService_StreamTransactionsServer) error
+}
+
+// UnimplementedFeedServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFeedServiceServer struct {
+}
+
+func (*UnimplementedFeedServiceServer) StreamTransactions(req *StreamTransactionsRequest, srv FeedService_StreamTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTransactions not implemented")
+}
+
+func RegisterFeedServiceServer(s *grpc.Server, srv FeedServiceServer) {
+	s.RegisterService(&_FeedService_serviceDesc, srv)
+}
+
+func _FeedService_StreamTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeedServiceServer).StreamTransactions(m, &feedServiceStreamTransactionsServer{stream})
+}
+
+type FeedService_StreamTransactionsServer interface {
+	Send(*StreamTransactionsResponse) error
+	grpc.ServerStream
+}
+
+type feedServiceStreamTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *feedServiceStreamTransactionsServer) Send(m *StreamTransactionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _FeedService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ledger.FeedService",
+	HandlerType: (*FeedServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
 		{
-			MethodName: "AggregateTransactions",
-			Handler:    _LedgerService_AggregateTransactions_Handler,
+			StreamName:    "StreamTransactions",
+			Handler:       _FeedService_StreamTransactions_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/protobuf/ledger.proto",
 }
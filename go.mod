@@ -4,5 +4,6 @@ go 1.12
 
 require (
 	github.com/golang/protobuf v1.3.1
+	google.golang.org/genproto v0.0.0-20180817151627-c66870c02cf8
 	google.golang.org/grpc v1.20.1
 )
@@ -0,0 +1,12 @@
+package ledger
+
+import "fmt"
+
+// IdempotencyConflictError is returned when a command is given an idempotency key already used by a different command
+type IdempotencyConflictError struct {
+	Key string
+}
+
+func (e *IdempotencyConflictError) Error() string {
+	return fmt.Sprintf("idempotency key '%s' was already used for a different command", e.Key)
+}
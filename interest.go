@@ -0,0 +1,187 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DayCount is the convention for how many days a year has when an annual rate is turned into a daily one
+type DayCount string
+
+const (
+	DayCountActual365    DayCount = "actual/365"
+	DayCountActual360    DayCount = "actual/360"
+	DayCountActualActual DayCount = "actual/actual" // 366 days in leap years, 365 otherwise
+)
+
+// DaysInYear returns how many days the year has under the convention
+func (d DayCount) DaysInYear(year int) int64 {
+	switch d {
+	case DayCountActual360:
+		return 360
+	case DayCountActualActual:
+		if time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+			return 366
+		}
+	}
+
+	return 365
+}
+
+// Rounding is how interest accrued in fractions of a minor unit is rounded when it's posted
+type Rounding string
+
+const (
+	RoundHalfUp   Rounding = "half_up"
+	RoundHalfEven Rounding = "half_even"
+	RoundDown     Rounding = "down" // to the minor unit below
+)
+
+// Round rounds the amount to a whole minor unit
+func (r Rounding) Round(amount *big.Rat) int64 {
+	quotient, remainder := new(big.Int).DivMod(amount.Num(), amount.Denom(), new(big.Int))
+
+	if r == RoundDown || remainder.Sign() == 0 {
+		return quotient.Int64()
+	}
+
+	switch new(big.Int).Lsh(remainder, 1).Cmp(amount.Denom()) {
+	case 1:
+		quotient.Add(quotient, big.NewInt(1))
+	case 0:
+		if r == RoundHalfUp || quotient.Bit(0) == 1 {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.Int64()
+}
+
+// InterestPeriod is how often the interest accrued on a wallet is posted to it
+type InterestPeriod string
+
+const (
+	InterestDaily   InterestPeriod = "daily"
+	InterestMonthly InterestPeriod = "monthly"
+)
+
+// Ends reports whether the day is the last of a posting period
+func (p InterestPeriod) Ends(day time.Time) bool {
+	if p == InterestMonthly {
+		return day.AddDate(0, 0, 1).Day() == 1
+	}
+
+	return true
+}
+
+// InterestPolicy is how interest is accrued on a wallet's balance in one currency and posted to it
+// interest accrues daily on the balance at the end of each day in UTC, only positive balances earn interest
+type InterestPolicy struct {
+	Rate     string         `json:"rate"` // the annual rate as a decimal, "0.025" for 2.5%
+	Currency string         `json:"currency"`
+	DayCount DayCount       `json:"day_count"`
+	Rounding Rounding       `json:"rounding"`
+	Posting  InterestPeriod `json:"posting"`
+	Source   string         `json:"source"` // the wallet interest is paid out of, such as an interest expense account
+}
+
+// IsZero reports whether the policy is unset, a wallet without a policy earns no interest
+func (p InterestPolicy) IsZero() bool {
+	return p == InterestPolicy{}
+}
+
+// Validate returns an error if the policy's rate isn't a decimal of zero or more, or it's missing a currency, a known convention or a source
+func (p InterestPolicy) Validate() error {
+	rate, ok := new(big.Rat).SetString(p.Rate)
+	if !ok {
+		return fmt.Errorf("invalid interest rate '%s'", p.Rate)
+	}
+
+	if rate.Sign() < 0 {
+		return fmt.Errorf("interest rate '%s' can't be negative", p.Rate)
+	}
+
+	if p.Currency == "" {
+		return errors.New("interest policy has no currency")
+	}
+
+	switch p.DayCount {
+	case DayCountActual365, DayCountActual360, DayCountActualActual:
+	default:
+		return fmt.Errorf("unknown day count convention '%s'", p.DayCount)
+	}
+
+	switch p.Rounding {
+	case RoundHalfUp, RoundHalfEven, RoundDown:
+	default:
+		return fmt.Errorf("unknown rounding '%s'", p.Rounding)
+	}
+
+	switch p.Posting {
+	case InterestDaily, InterestMonthly:
+	default:
+		return fmt.Errorf("unknown interest posting period '%s'", p.Posting)
+	}
+
+	if p.Source == "" {
+		return errors.New("interest policy has no wallet to pay interest out of")
+	}
+
+	return nil
+}
+
+// DailyInterest returns the interest a balance earns over the day, in fractions of a minor unit
+// the policy must be valid
+func (p InterestPolicy) DailyInterest(balance int64, day time.Time) *big.Rat {
+	if balance <= 0 {
+		return new(big.Rat)
+	}
+
+	rate, _ := new(big.Rat).SetString(p.Rate)
+
+	interest := new(big.Rat).Mul(rate, new(big.Rat).SetInt64(balance))
+
+	return interest.Quo(interest, new(big.Rat).SetInt64(p.DayCount.DaysInYear(day.Year())))
+}
+
+// InterestAccrual is how far interest has been accrued on a wallet, and how much of it is still to be posted
+type InterestAccrual struct {
+	Wallet  string         `json:"wallet"`
+	Policy  InterestPolicy `json:"policy"`
+	Through string         `json:"through"` // the last day interest was accrued for, laid out like EffectiveDateLayout
+	Accrued string         `json:"accrued"` // interest accrued but not yet posted, in minor units as an exact fraction such as "1234/365"
+}
+
+// InterestAccrualError is returned by a run of interest accrual that had to skip wallets, such as frozen ones or ones whose source can't pay
+// the interest of every other wallet is still accrued and committed, the skipped ones are tried again on the next run
+type InterestAccrualError struct {
+	Through string            // the day interest was accrued up to
+	Skipped map[string]string // the wallets skipped, mapped to why
+}
+
+func (e *InterestAccrualError) Error() string {
+	wallets := e.Wallets()
+
+	reasons := make([]string, len(wallets))
+	for i, wallet := range wallets {
+		reasons[i] = fmt.Sprintf("wallet '%s', %s", wallet, e.Skipped[wallet])
+	}
+
+	return fmt.Sprintf("interest through %s couldn't be accrued on %s", e.Through, strings.Join(reasons, "; "))
+}
+
+// Wallets returns the wallets skipped, in order
+func (e *InterestAccrualError) Wallets() []string {
+	wallets := make([]string, 0, len(e.Skipped))
+	for wallet := range e.Skipped {
+		wallets = append(wallets, wallet)
+	}
+
+	sort.Strings(wallets)
+
+	return wallets
+}
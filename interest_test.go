@@ -0,0 +1,93 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestRounding_Round(t *testing.T) {
+	cases := []struct {
+		rounding Rounding
+		amount   string
+		want     int64
+	}{
+		{RoundHalfUp, "5/2", 3},
+		{RoundHalfUp, "7/3", 2},
+		{RoundHalfUp, "8/3", 3},
+		{RoundHalfEven, "5/2", 2},
+		{RoundHalfEven, "7/2", 4},
+		{RoundHalfEven, "8/3", 3},
+		{RoundDown, "8/3", 2},
+		{RoundDown, "4", 4},
+	}
+
+	for _, c := range cases {
+		t.Run("should round "+c.amount+" "+string(c.rounding), func(t *testing.T) {
+			amount, _ := new(big.Rat).SetString(c.amount)
+
+			got := c.rounding.Round(amount)
+			if got != c.want {
+				t.Errorf("got %d, wanted %d", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInterestPolicy_DailyInterest(t *testing.T) {
+	cases := []struct {
+		name     string
+		dayCount DayCount
+		balance  int64
+		day      time.Time
+		want     string
+	}{
+		{"over 365 days", DayCountActual365, 365000, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), "100"},
+		{"over 360 days", DayCountActual360, 360000, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), "100"},
+		{"over the days of a leap year", DayCountActualActual, 366000, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), "100"},
+		{"over the days of a common year", DayCountActualActual, 365000, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), "100"},
+		{"on nothing for a negative balance", DayCountActual365, -365000, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), "0"},
+	}
+
+	for _, c := range cases {
+		t.Run("should spread the annual rate "+c.name, func(t *testing.T) {
+			policy := InterestPolicy{Rate: "0.1", DayCount: c.dayCount}
+
+			got := policy.DailyInterest(c.balance, c.day).RatString()
+			if got != c.want {
+				t.Errorf("got %s, wanted %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInterestPolicy_Validate(t *testing.T) {
+	valid := InterestPolicy{Rate: "0.025", Currency: "USD", DayCount: DayCountActual365, Rounding: RoundHalfEven, Posting: InterestMonthly, Source: "interest"}
+
+	cases := []struct {
+		name   string
+		change func(p *InterestPolicy)
+		valid  bool
+	}{
+		{"a monthly policy", func(p *InterestPolicy) {}, true},
+		{"a rate that isn't a number", func(p *InterestPolicy) { p.Rate = "2.5%" }, false},
+		{"a negative rate", func(p *InterestPolicy) { p.Rate = "-0.01" }, false},
+		{"no currency", func(p *InterestPolicy) { p.Currency = "" }, false},
+		{"an unknown day count", func(p *InterestPolicy) { p.DayCount = "30/360" }, false},
+		{"an unknown rounding", func(p *InterestPolicy) { p.Rounding = "up" }, false},
+		{"an unknown posting period", func(p *InterestPolicy) { p.Posting = "yearly" }, false},
+		{"no source", func(p *InterestPolicy) { p.Source = "" }, false},
+	}
+
+	for _, c := range cases {
+		t.Run("should check "+c.name, func(t *testing.T) {
+			p := valid
+			c.change(&p)
+
+			err := p.Validate()
+			if (err == nil) != c.valid {
+				t.Errorf("got error %v validating %+v, wanted valid %t", err, p, c.valid)
+			}
+		})
+	}
+}
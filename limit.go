@@ -0,0 +1,60 @@
+package ledger
+
+import "fmt"
+
+// LimitKind names one of the limits a wallet can be given, it's how a breach is told apart by clients
+type LimitKind string
+
+const (
+	LimitSingleOutflow    LimitKind = "max_single_outflow"
+	LimitDailyOutflow     LimitKind = "max_daily_outflow"
+	LimitTransfersPerHour LimitKind = "max_transfers_per_hour"
+	LimitBalance          LimitKind = "max_balance"
+)
+
+// Limits caps how fast funds can move through a wallet, a zero field isn't a limit at all
+// amounts only apply in their own currency, funds in other currencies aren't limited by them
+// outflows are measured over the 24 hours or the hour up to each transaction, not calendar days or hours
+type Limits struct {
+	MaxSingleOutflow    Money `json:"max_single_outflow"`     // the most a single transfer, withdrawal or debit can take out of the wallet
+	MaxDailyOutflow     Money `json:"max_daily_outflow"`      // the most that can be taken out of the wallet in 24 hours
+	MaxTransfersPerHour int   `json:"max_transfers_per_hour"` // how many commands can take funds out of the wallet in an hour
+	MaxBalance          Money `json:"max_balance"`            // the most the wallet can hold, funds can't be sent to it beyond that
+}
+
+// Validate returns an error if any limit is negative
+func (l Limits) Validate() error {
+	for _, m := range []Money{l.MaxSingleOutflow, l.MaxDailyOutflow, l.MaxBalance} {
+		if m.Amount < 0 {
+			return fmt.Errorf("limit of %v can't be negative", m)
+		}
+	}
+
+	if l.MaxTransfersPerHour < 0 {
+		return fmt.Errorf("limit of %d transfers per hour can't be negative", l.MaxTransfersPerHour)
+	}
+
+	return nil
+}
+
+// IsZero reports whether none of the limits are set
+func (l Limits) IsZero() bool {
+	return l == Limits{}
+}
+
+// LimitExceededError is returned when a transaction would take a wallet past one of its limits
+type LimitExceededError struct {
+	Wallet    string
+	Limit     LimitKind
+	Max       int64  // the limit, an amount or a number of transfers
+	Attempted int64  // what the wallet would have reached
+	Currency  string // the currency of Max and Attempted, empty for a number of transfers
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.Currency == "" {
+		return fmt.Sprintf("wallet '%s' would reach %d against its %s limit of %d", e.Wallet, e.Attempted, e.Limit, e.Max)
+	}
+
+	return fmt.Sprintf("wallet '%s' would reach %v against its %s limit of %v", e.Wallet, NewMoney(e.Attempted, e.Currency), e.Limit, NewMoney(e.Max, e.Currency))
+}
@@ -0,0 +1,139 @@
+package ledger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// DefaultCurrency is the currency given to amounts stored before the ledger tracked currencies
+const DefaultCurrency = "USD"
+
+var (
+	// ErrAmountOverflow is returned when arithmetic on an amount would overflow 64 bits
+	ErrAmountOverflow = errors.New("amount overflows 64 bits")
+	// ErrCurrencyMismatch is returned when arithmetic mixes amounts in different currencies
+	ErrCurrencyMismatch = errors.New("amounts are in different currencies")
+)
+
+// Money is an amount in the minor units of a currency, e.g. cents for USD, along with its ISO-4217 currency code
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// NewMoney returns the given amount of minor units of the currency
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Add returns the sum of both amounts, which must be in the same currency
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	if (o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount) || (o.Amount < 0 && m.Amount < math.MinInt64-o.Amount) {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Sub returns the difference of both amounts, which must be in the same currency
+func (m Money) Sub(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	if (o.Amount < 0 && m.Amount > math.MaxInt64+o.Amount) || (o.Amount > 0 && m.Amount < math.MinInt64+o.Amount) {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// String formats the amount in minor units followed by its currency, e.g. "1050 USD"
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+}
+
+// UnmarshalJSON reads money either as an object or, for amounts stored before currencies were tracked, as a bare number
+// a bare number is read with no currency, leaving the caller to give it one
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount int64
+
+	if json.Unmarshal(data, &amount) == nil {
+		*m = Money{Amount: amount}
+		return nil
+	}
+
+	type money Money
+
+	var v money
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	*m = Money(v)
+
+	return nil
+}
+
+// currencyExponents holds the number of minor unit digits of every ISO-4217 currency that doesn't use two
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyExponent returns how many digits of the currency's minor units make up one major unit, e.g. 2 for USD and 0 for JPY
+func CurrencyExponent(currency string) int {
+	if e, ok := currencyExponents[currency]; ok {
+		return e
+	}
+
+	return 2
+}
+
+// Convert returns the amount in another currency, given the rate of one major unit of the amount's currency in the other
+// the result is rounded to the nearest minor unit of the other currency, with halves rounded away from zero
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	num := new(big.Int).Mul(big.NewInt(m.Amount), rate.Num())
+	den := new(big.Int).Set(rate.Denom())
+
+	shift := CurrencyExponent(currency) - CurrencyExponent(m.Currency)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil)
+
+	if shift > 0 {
+		num.Mul(num, scale)
+	} else {
+		den.Mul(den, scale)
+	}
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	// round half away from zero, the remainder takes the sign of the amount
+	if rem.Sign() != 0 && new(big.Int).Abs(new(big.Int).Lsh(rem, 1)).Cmp(den) >= 0 {
+		quo.Add(quo, big.NewInt(int64(rem.Sign())))
+	}
+
+	if !quo.IsInt64() {
+		return Money{}, ErrAmountOverflow
+	}
+
+	return Money{Amount: quo.Int64(), Currency: currency}, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
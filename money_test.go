@@ -0,0 +1,122 @@
+package ledger
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMoney_Add(t *testing.T) {
+	t.Run("should add amounts in the same currency", func(t *testing.T) {
+		got, err := NewMoney(1000, "USD").Add(NewMoney(250, "USD"))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		assertMoney(t, got, NewMoney(1250, "USD"))
+	})
+	t.Run("should return an error when adding amounts in different currencies", func(t *testing.T) {
+		_, err := NewMoney(1000, "USD").Add(NewMoney(250, "EUR"))
+		if err != ErrCurrencyMismatch {
+			t.Errorf("got error %v, wanted %v", err, ErrCurrencyMismatch)
+		}
+	})
+	t.Run("should return an error when the sum overflows", func(t *testing.T) {
+		_, err := NewMoney(math.MaxInt64, "USD").Add(NewMoney(1, "USD"))
+		if err != ErrAmountOverflow {
+			t.Errorf("got error %v, wanted %v", err, ErrAmountOverflow)
+		}
+
+		_, err = NewMoney(math.MinInt64, "USD").Add(NewMoney(-1, "USD"))
+		if err != ErrAmountOverflow {
+			t.Errorf("got error %v, wanted %v", err, ErrAmountOverflow)
+		}
+	})
+}
+
+func TestMoney_Sub(t *testing.T) {
+	t.Run("should subtract amounts in the same currency", func(t *testing.T) {
+		got, err := NewMoney(1000, "USD").Sub(NewMoney(1250, "USD"))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		assertMoney(t, got, NewMoney(-250, "USD"))
+	})
+	t.Run("should return an error when the difference overflows", func(t *testing.T) {
+		_, err := NewMoney(math.MinInt64, "USD").Sub(NewMoney(1, "USD"))
+		if err != ErrAmountOverflow {
+			t.Errorf("got error %v, wanted %v", err, ErrAmountOverflow)
+		}
+
+		_, err = NewMoney(0, "USD").Sub(NewMoney(math.MinInt64, "USD"))
+		if err != ErrAmountOverflow {
+			t.Errorf("got error %v, wanted %v", err, ErrAmountOverflow)
+		}
+	})
+}
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	t.Run("should read an amount and currency", func(t *testing.T) {
+		var got Money
+
+		err := json.Unmarshal([]byte(`{"amount":1050,"currency":"EUR"}`), &got)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		assertMoney(t, got, NewMoney(1050, "EUR"))
+	})
+	t.Run("should read a bare legacy amount without a currency", func(t *testing.T) {
+		var got Money
+
+		err := json.Unmarshal([]byte(`1050`), &got)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		assertMoney(t, got, NewMoney(1050, ""))
+	})
+}
+
+func TestMoney_Convert(t *testing.T) {
+	cases := []struct {
+		name     string
+		amount   Money
+		currency string
+		rate     *big.Rat
+		want     Money
+	}{
+		{"between currencies with the same exponent", NewMoney(10000, "USD"), "EUR", big.NewRat(92, 100), NewMoney(9200, "EUR")},
+		{"to a currency without minor units", NewMoney(1050, "USD"), "JPY", big.NewRat(1505, 10), NewMoney(1580, "JPY")},
+		{"from a currency without minor units", NewMoney(1000, "JPY"), "USD", big.NewRat(2, 300), NewMoney(667, "USD")},
+		{"rounding halves away from zero", NewMoney(-1, "USD"), "EUR", big.NewRat(1, 2), NewMoney(-1, "EUR")},
+		{"rounding down below a half", NewMoney(3, "USD"), "EUR", big.NewRat(1, 7), NewMoney(0, "EUR")},
+	}
+
+	for _, c := range cases {
+		t.Run("should convert "+c.name, func(t *testing.T) {
+			got, err := c.amount.Convert(c.currency, c.rate)
+			if err != nil {
+				t.Fatalf("returned error, %v", err)
+			}
+
+			assertMoney(t, got, c.want)
+		})
+	}
+
+	t.Run("should return an error when the converted amount overflows", func(t *testing.T) {
+		_, err := NewMoney(math.MaxInt64, "USD").Convert("EUR", big.NewRat(2, 1))
+		if err != ErrAmountOverflow {
+			t.Errorf("got error %v, wanted %v", err, ErrAmountOverflow)
+		}
+	})
+}
+
+func assertMoney(t *testing.T, got, want Money) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
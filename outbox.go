@@ -0,0 +1,23 @@
+package ledger
+
+import "encoding/json"
+
+// OutboxMessage is the event of a command kept in a book's outbox, it's written in the same commit as the command
+// so it's never lost once the command has been, and it's kept until whatever forwards it acknowledges it
+type OutboxMessage struct {
+	ID        string          `json:"id"`
+	Sequence  uint64          `json:"sequence"` // the order the message was written in, starting at 1
+	Event     string          `json:"event"`    // the name of the event
+	Wallets   []string        `json:"wallets,omitempty"`
+	Payload   json.RawMessage `json:"payload"`    // the event as JSON
+	CreatedAt int64           `json:"created_at"` // unix time in nanoseconds the command was committed
+}
+
+// Outbox is kept by books that write the events of their commands along with them, for forwarding elsewhere
+// a message is forwarded at least once, it stays in the outbox until it's acknowledged, even if the book is reopened
+type Outbox interface {
+	// PendingOutbox returns up to limit messages that haven't been acknowledged, oldest first, or all of them when limit is zero
+	PendingOutbox(limit int) []OutboxMessage
+	// AcknowledgeOutbox takes the messages out of the outbox, IDs that aren't in it are ignored
+	AcknowledgeOutbox(ids ...string) error
+}
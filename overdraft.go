@@ -0,0 +1,77 @@
+package ledger
+
+import "fmt"
+
+// OverdraftPolicy is how far below zero a wallet's available balance is allowed to go
+// the zero value is strict, nothing can be taken out of a wallet beyond what it holds
+type OverdraftPolicy struct {
+	Unlimited bool  `json:"unlimited,omitempty"` // the balance can go as far below zero as it needs to, for system and settlement wallets
+	Limit     Money `json:"limit"`               // how far below zero the balance in the limit's currency can go, other currencies stay strict
+}
+
+// StrictOverdraft doesn't let a wallet's balance go below zero
+func StrictOverdraft() OverdraftPolicy {
+	return OverdraftPolicy{}
+}
+
+// OverdraftLimit lets a wallet's balance in the limit's currency go as far below zero as the limit
+func OverdraftLimit(limit Money) OverdraftPolicy {
+	return OverdraftPolicy{Limit: limit}
+}
+
+// UnlimitedOverdraft lets a wallet's balance go as far below zero as it needs to
+func UnlimitedOverdraft() OverdraftPolicy {
+	return OverdraftPolicy{Unlimited: true}
+}
+
+// Validate returns an error if the policy's limit is negative
+func (p OverdraftPolicy) Validate() error {
+	if p.Limit.Amount < 0 {
+		return fmt.Errorf("overdraft limit of %v can't be negative", p.Limit)
+	}
+
+	return nil
+}
+
+// Covers reports whether a wallet with the available balance can pay out the amount under the policy
+func (p OverdraftPolicy) Covers(available Money, amount Money) bool {
+	if p.Unlimited {
+		return true
+	}
+
+	floor := int64(0)
+	if p.Limit.Currency == amount.Currency {
+		floor = -p.Limit.Amount
+	}
+
+	remaining, err := available.Sub(amount)
+	if err != nil {
+		return false
+	}
+
+	return remaining.Amount >= floor
+}
+
+// String describes the policy, e.g. "strict", "unlimited" or "limit of 5000 USD"
+func (p OverdraftPolicy) String() string {
+	switch {
+	case p.Unlimited:
+		return "unlimited"
+	case p.Limit.Amount == 0:
+		return "strict"
+	default:
+		return fmt.Sprintf("limit of %v", p.Limit)
+	}
+}
+
+// InsufficientFundsError is returned when paying out an amount would take a wallet below what its overdraft policy allows
+type InsufficientFundsError struct {
+	Wallet    string
+	Available Money // the wallet's available balance in the amount's currency
+	Amount    Money
+	Policy    OverdraftPolicy
+}
+
+func (e *InsufficientFundsError) Error() string {
+	return fmt.Sprintf("wallet '%s' has insufficient available balance of %v to fill transfer of %v with %s overdraft", e.Wallet, e.Available, e.Amount, e.Policy)
+}
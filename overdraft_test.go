@@ -0,0 +1,29 @@
+package ledger
+
+import "testing"
+
+func TestOverdraftPolicy_Covers(t *testing.T) {
+	cases := []struct {
+		name      string
+		policy    OverdraftPolicy
+		available Money
+		amount    Money
+		want      bool
+	}{
+		{"strict covering the amount", StrictOverdraft(), NewMoney(1000, "USD"), NewMoney(1000, "USD"), true},
+		{"strict going below zero", StrictOverdraft(), NewMoney(1000, "USD"), NewMoney(1001, "USD"), false},
+		{"a limit going down to it", OverdraftLimit(NewMoney(500, "USD")), NewMoney(1000, "USD"), NewMoney(1500, "USD"), true},
+		{"a limit going beyond it", OverdraftLimit(NewMoney(500, "USD")), NewMoney(1000, "USD"), NewMoney(1501, "USD"), false},
+		{"a limit in another currency", OverdraftLimit(NewMoney(500, "EUR")), NewMoney(1000, "USD"), NewMoney(1001, "USD"), false},
+		{"unlimited going far below zero", UnlimitedOverdraft(), NewMoney(-1000000, "USD"), NewMoney(1000000, "USD"), true},
+	}
+
+	for _, c := range cases {
+		t.Run("should work out "+c.name, func(t *testing.T) {
+			got := c.policy.Covers(c.available, c.amount)
+			if got != c.want {
+				t.Errorf("got %t covering %v out of %v with %s overdraft, wanted %t", got, c.amount, c.available, c.policy, c.want)
+			}
+		})
+	}
+}
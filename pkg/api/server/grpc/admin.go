@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/invariant"
+)
+
+// AdminServer serves checks of the book's consistency, for operators rather than the clients posting to it
+type AdminServer struct {
+	book ledger.Book
+}
+
+func NewAdminServer(book ledger.Book) *AdminServer {
+	return &AdminServer{
+		book: book,
+	}
+}
+
+// CheckInvariants checks the book is internally consistent, returning every violation found
+func (s *AdminServer) CheckInvariants(ctx context.Context, req *ledgerpb.CheckInvariantsRequest) (*ledgerpb.CheckInvariantsResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	r := invariant.Check(s.book)
+
+	violations := make([]*ledgerpb.Violation, len(r.Violations))
+	for i, v := range r.Violations {
+		violations[i] = &ledgerpb.Violation{Invariant: string(v.Invariant), Wallet: v.Wallet, Aggregate: v.Aggregate, Detail: v.Detail}
+	}
+
+	return &ledgerpb.CheckInvariantsResponse{
+		Transactions: int64(r.Transactions),
+		Violations:   violations,
+	}, nil
+}
+
+// TrialBalance returns every wallet's balance in debit and credit columns, with their totals in each currency
+func (s *AdminServer) TrialBalance(ctx context.Context, req *ledgerpb.TrialBalanceRequest) (*ledgerpb.TrialBalanceResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	tb := invariant.NewTrialBalance(s.book)
+
+	lines := make([]*ledgerpb.TrialBalanceLine, len(tb.Lines))
+	for i, l := range tb.Lines {
+		lines[i] = &ledgerpb.TrialBalanceLine{Wallet: l.Wallet, Currency: l.Currency, Debit: l.Debit, Credit: l.Credit}
+	}
+
+	totals := make([]*ledgerpb.TrialBalanceTotal, len(tb.Totals))
+	for i, t := range tb.Totals {
+		totals[i] = &ledgerpb.TrialBalanceTotal{Currency: t.Currency, Debit: t.Debit, Credit: t.Credit, Balanced: t.Balanced()}
+	}
+
+	return &ledgerpb.TrialBalanceResponse{
+		Lines:    lines,
+		Totals:   totals,
+		Balanced: tb.Balanced(),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// feedBatchSize is how many transactions are read from the book at a time while streaming them
+const feedBatchSize = 100
+
+// FeedServer streams the book's transactions in the order they were committed, for consumers tailing the ledger
+type FeedServer struct {
+	feed ledger.Feed
+}
+
+func NewFeedServer(feed ledger.Feed) *FeedServer {
+	return &FeedServer{
+		feed: feed,
+	}
+}
+
+// StreamTransactions sends every transaction after the request's cursor, then each one as it's committed, until the client goes away
+// a consumer that keeps the sequence number of the last transaction it handled resumes from it without missing or repeating any
+func (s *FeedServer) StreamTransactions(req *ledgerpb.StreamTransactionsRequest, stream ledgerpb.FeedService_StreamTransactionsServer) error {
+	ctx := stream.Context()
+	cursor := req.GetCursor()
+
+	for {
+		transactions := s.feed.TransactionsAfter(cursor, feedBatchSize)
+
+		for i := range transactions {
+			err := stream.Send(&ledgerpb.StreamTransactionsResponse{Transaction: &transactions[i]})
+			if err != nil {
+				return err
+			}
+
+			cursor = transactions[i].Sequence
+		}
+
+		if len(transactions) == feedBatchSize {
+			continue
+		}
+
+		err := s.feed.WaitForTransactions(ctx, cursor)
+		if err == context.DeadlineExceeded {
+			return status.Error(codes.DeadlineExceeded, "deadline exceeded, stopping")
+		}
+
+		if err != nil {
+			return status.Error(codes.Canceled, "client cancelled, stopping")
+		}
+	}
+}
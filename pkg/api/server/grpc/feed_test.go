@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+)
+
+func TestFeedServer_StreamTransactions(t *testing.T) {
+	t.Run("should resume from the cursor without missing or repeating a transaction across reconnects", func(t *testing.T) {
+		book := memory.NewMockInMemoryBook()
+		client, clean := newFeedClient(t, book)
+		defer clean()
+
+		got := receiveTransactions(t, client, 0, 5, nil)
+		got = append(got, receiveTransactions(t, client, got[len(got)-1], 9, func() {
+			book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		})...)
+		got = append(got, receiveTransactions(t, client, got[len(got)-1], 2, func() {
+			book.WithdrawWalletFunds("1", ledger.NewMoney(500, ledger.DefaultCurrency), "")
+		})...)
+
+		assertSequences(t, got, 1, 16)
+	})
+	t.Run("should wait at a cursor past the head until the book reaches it", func(t *testing.T) {
+		book := memory.NewMockInMemoryBook()
+		client, clean := newFeedClient(t, book)
+		defer clean()
+
+		got := receiveTransactions(t, client, 14, 2, func() {
+			book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+			book.DepositWalletFunds("1", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		})
+
+		assertSequences(t, got, 15, 16)
+	})
+	t.Run("should stop streaming once the client cancels", func(t *testing.T) {
+		server := NewFeedServer(memory.NewMockInMemoryBook())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &transactionStream{ctx: ctx}
+
+		done := make(chan error)
+		go func() {
+			done <- server.StreamTransactions(&ledgerpb.StreamTransactionsRequest{}, stream)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-done:
+			if status.Code(err) != codes.Canceled {
+				t.Errorf("got error %v, wanted %v", err, codes.Canceled)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("stream kept going after the client cancelled")
+		}
+
+		if len(stream.sent) != 12 {
+			t.Errorf("got %d transactions sent, wanted every one in the book", len(stream.sent))
+		}
+	})
+}
+
+// transactionStream is a FeedService_StreamTransactionsServer that keeps what it's sent
+type transactionStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*ledgerpb.Transaction
+}
+
+func (s *transactionStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *transactionStream) Send(res *ledgerpb.StreamTransactionsResponse) error {
+	s.sent = append(s.sent, res.GetTransaction())
+	return nil
+}
+
+// newFeedClient serves the feed over an in memory connection, returning a client connected to it
+func newFeedClient(t *testing.T, feed ledger.Feed) (ledgerpb.FeedServiceClient, func()) {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	ledgerpb.RegisterFeedServiceServer(server, NewFeedServer(feed))
+	go server.Serve(listener)
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	if err != nil {
+		t.Fatalf("unable to connect to feed server, %v", err)
+	}
+
+	return ledgerpb.NewFeedServiceClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+// receiveTransactions streams from the cursor until n transactions have been received then disconnects, returning their sequence numbers
+// commit is called once the stream is open, to commit transactions while it's streaming
+func receiveTransactions(t *testing.T, client ledgerpb.FeedServiceClient, cursor uint64, n int, commit func()) []uint64 {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.StreamTransactions(ctx, &ledgerpb.StreamTransactionsRequest{Cursor: cursor})
+	if err != nil {
+		t.Fatalf("unable to stream transactions, %v", err)
+	}
+
+	if commit != nil {
+		go commit()
+	}
+
+	sequences := []uint64{}
+	for len(sequences) < n {
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("got error after %d transactions, %v", len(sequences), err)
+		}
+
+		sequences = append(sequences, res.GetTransaction().GetSequence())
+	}
+
+	return sequences
+}
+
+func assertSequences(t *testing.T, got []uint64, first uint64, last uint64) {
+	t.Helper()
+
+	want := []uint64{}
+	for s := first; s <= last; s++ {
+		want = append(want, s)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got sequences %v, wanted %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got sequences %v, wanted %v", got, want)
+		}
+	}
+}
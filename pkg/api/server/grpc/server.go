@@ -3,6 +3,10 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -27,11 +31,11 @@ func (s *Server) TransferWalletFunds(ctx context.Context, req *ledgerpb.Transfer
 
 	source := req.GetSource()
 	destination := req.GetDestination()
-	amount := req.GetAmount()
+	amount := ledger.NewMoney(req.GetAmount(), req.GetCurrency())
 
-	aggregate, err := s.book.TransferWalletFunds(source, destination, amount)
+	aggregate, err := s.book.TransferWalletFunds(source, destination, amount, req.GetIdempotencyKey())
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when transferring wallet funds: %v", err)
+		return nil, commandError(err, "problem when transferring wallet funds")
 	}
 
 	return &ledgerpb.TransferWalletFundsResponse{
@@ -39,17 +43,269 @@ func (s *Server) TransferWalletFunds(ctx context.Context, req *ledgerpb.Transfer
 	}, nil
 }
 
+func (s *Server) ExchangeWalletFunds(ctx context.Context, req *ledgerpb.ExchangeWalletFundsRequest) (*ledgerpb.ExchangeWalletFundsResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	source := req.GetSource()
+	destination := req.GetDestination()
+	amount := ledger.NewMoney(req.GetAmount(), req.GetCurrency())
+
+	aggregate, err := s.book.ExchangeWalletFunds(source, destination, amount, req.GetTargetCurrency(), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when exchanging wallet funds")
+	}
+
+	return &ledgerpb.ExchangeWalletFundsResponse{
+		Result: fmt.Sprintf("funds exchanged successfully, exchange ID: '%s'", aggregate),
+	}, nil
+}
+
+func (s *Server) Post(ctx context.Context, req *ledgerpb.PostRequest) (*ledgerpb.PostResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	entries := make([]ledger.Entry, len(req.GetEntries()))
+	for i, e := range req.GetEntries() {
+		entries[i] = ledger.Entry{Type: e.GetType(), Wallet: e.GetWallet(), Amount: ledger.NewMoney(e.GetAmount(), e.GetCurrency())}
+	}
+
+	aggregate, err := s.book.Post(entries, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when posting entries")
+	}
+
+	return &ledgerpb.PostResponse{
+		Result: fmt.Sprintf("entries posted successfully, aggregate ID: '%s'", aggregate),
+	}, nil
+}
+
+func (s *Server) ReverseAggregate(ctx context.Context, req *ledgerpb.ReverseAggregateRequest) (*ledgerpb.ReverseAggregateResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	reversal, err := s.book.ReverseAggregate(req.GetAggregate(), req.GetReason(), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when reversing aggregate")
+	}
+
+	return &ledgerpb.ReverseAggregateResponse{
+		Result: fmt.Sprintf("aggregate reversed successfully, reversal ID: '%s'", reversal),
+	}, nil
+}
+
+func (s *Server) RefundAggregate(ctx context.Context, req *ledgerpb.RefundAggregateRequest) (*ledgerpb.RefundAggregateResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	amount := ledger.NewMoney(req.GetAmount(), req.GetCurrency())
+
+	refund, err := s.book.RefundAggregate(req.GetAggregate(), amount, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when refunding aggregate")
+	}
+
+	return &ledgerpb.RefundAggregateResponse{
+		Result: fmt.Sprintf("aggregate refunded successfully, refund ID: '%s'", refund),
+	}, nil
+}
+
+func (s *Server) AuthorizeHold(ctx context.Context, req *ledgerpb.AuthorizeHoldRequest) (*ledgerpb.AuthorizeHoldResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	amount := ledger.NewMoney(req.GetAmount(), req.GetCurrency())
+
+	// no expiry leaves it to the book's default
+	var expiresAt time.Time
+	if req.GetExpiresAt() != 0 {
+		expiresAt = time.Unix(0, req.GetExpiresAt())
+	}
+
+	hold, err := s.book.AuthorizeHold(req.GetWallet(), amount, expiresAt, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when authorizing hold")
+	}
+
+	return &ledgerpb.AuthorizeHoldResponse{
+		Result: fmt.Sprintf("hold authorized successfully, hold ID: '%s'", hold),
+	}, nil
+}
+
+func (s *Server) CaptureHold(ctx context.Context, req *ledgerpb.CaptureHoldRequest) (*ledgerpb.CaptureHoldResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	amount := ledger.NewMoney(req.GetAmount(), req.GetCurrency())
+
+	capture, err := s.book.CaptureHold(req.GetHold(), req.GetDestination(), amount, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when capturing hold")
+	}
+
+	return &ledgerpb.CaptureHoldResponse{
+		Result: fmt.Sprintf("hold captured successfully, capture ID: '%s'", capture),
+	}, nil
+}
+
+func (s *Server) VoidHold(ctx context.Context, req *ledgerpb.VoidHoldRequest) (*ledgerpb.VoidHoldResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	void, err := s.book.VoidHold(req.GetHold(), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when voiding hold")
+	}
+
+	return &ledgerpb.VoidHoldResponse{
+		Result: fmt.Sprintf("hold voided successfully, void ID: '%s'", void),
+	}, nil
+}
+
+func (s *Server) SetOverdraftPolicy(ctx context.Context, req *ledgerpb.SetOverdraftPolicyRequest) (*ledgerpb.SetOverdraftPolicyResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	policy := ledger.OverdraftPolicy{Unlimited: req.GetUnlimited(), Limit: ledger.NewMoney(req.GetLimit(), req.GetCurrency())}
+
+	err := s.book.SetOverdraftPolicy(req.GetWallet(), policy, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when setting overdraft policy")
+	}
+
+	return &ledgerpb.SetOverdraftPolicyResponse{
+		Result: fmt.Sprintf("overdraft policy of wallet '%s' set to %s", req.GetWallet(), policy),
+	}, nil
+}
+
+func (s *Server) SetFeeSchedule(ctx context.Context, req *ledgerpb.SetFeeScheduleRequest) (*ledgerpb.SetFeeScheduleResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	schedule := feeSchedule(req.GetSchedule())
+
+	err := s.book.SetFeeSchedule(schedule, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when setting fee schedule")
+	}
+
+	return &ledgerpb.SetFeeScheduleResponse{
+		Result: fmt.Sprintf("fee schedule of %s in %s set successfully", schedule.Command, schedule.Currency),
+	}, nil
+}
+
+// QuoteFee returns what a transfer or withdrawal would charge on an amount, without running it
+func (s *Server) QuoteFee(ctx context.Context, req *ledgerpb.QuoteFeeRequest) (*ledgerpb.QuoteFeeResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	quote, err := s.book.QuoteFee(ledger.FeeCommand(req.GetCommand()), req.GetWallet(), ledger.NewMoney(req.GetAmount(), req.GetCurrency()))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "problem when quoting fee: %v", err)
+	}
+
+	return &ledgerpb.QuoteFeeResponse{
+		Fee:      quote.Fee.Amount,
+		Total:    quote.Total.Amount,
+		Currency: quote.Fee.Currency,
+		Wallet:   quote.Wallet,
+	}, nil
+}
+
+// SetInterestPolicy sets how interest is accrued on a wallet and posted to it, a policy with no rate stops it earning interest
+func (s *Server) SetInterestPolicy(ctx context.Context, req *ledgerpb.SetInterestPolicyRequest) (*ledgerpb.SetInterestPolicyResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	p := req.GetPolicy()
+
+	policy := ledger.InterestPolicy{
+		Rate:     p.GetRate(),
+		Currency: p.GetCurrency(),
+		DayCount: ledger.DayCount(p.GetDayCount()),
+		Rounding: ledger.Rounding(p.GetRounding()),
+		Posting:  ledger.InterestPeriod(p.GetPosting()),
+		Source:   p.GetSource(),
+	}
+
+	err := s.book.SetInterestPolicy(req.GetWallet(), policy, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when setting interest policy")
+	}
+
+	return &ledgerpb.SetInterestPolicyResponse{
+		Result: fmt.Sprintf("interest policy of wallet '%s' set successfully", req.GetWallet()),
+	}, nil
+}
+
+// AccrueInterest accrues interest on every wallet with an interest policy up to and including a day that has ended
+func (s *Server) AccrueInterest(ctx context.Context, req *ledgerpb.AccrueInterestRequest) (*ledgerpb.AccrueInterestResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	through, err := time.Parse(ledger.EffectiveDateLayout, req.GetThrough())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid day '%s' to accrue interest through", req.GetThrough())
+	}
+
+	// wallets skipped by the run are reported alongside what it did accrue, rather than failing it
+	var skipped []string
+
+	aggregate, err := s.book.AccrueInterest(through, req.GetIdempotencyKey())
+	if e, ok := err.(*ledger.InterestAccrualError); ok {
+		skipped, err = e.Wallets(), nil
+	}
+
+	if err != nil {
+		return nil, commandError(err, "problem when accruing interest")
+	}
+
+	return &ledgerpb.AccrueInterestResponse{
+		Aggregate: aggregate,
+		Skipped:   skipped,
+	}, nil
+}
+
+func (s *Server) DefineAccount(ctx context.Context, req *ledgerpb.DefineAccountRequest) (*ledgerpb.DefineAccountResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	account := ledger.Account{Code: req.GetCode(), Name: req.GetName(), Type: ledger.AccountType(req.GetType()), Parent: req.GetParent()}
+
+	err := s.book.DefineAccount(account, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when defining account")
+	}
+
+	return &ledgerpb.DefineAccountResponse{
+		Result: fmt.Sprintf("%s account '%s' defined successfully", account.Type, account.Code),
+	}, nil
+}
+
 func (s *Server) DepositWalletFunds(ctx context.Context, req *ledgerpb.DepositWalletFundsRequest) (*ledgerpb.DepositWalletFundsResponse, error) {
 	if ctx.Err() == context.Canceled {
 		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
 	}
 
 	wallet := req.GetWallet()
-	deposit := req.GetDeposit()
+	deposit := ledger.NewMoney(req.GetDeposit(), req.GetCurrency())
 
-	aggregate, err := s.book.DepositWalletFunds(wallet, deposit)
+	aggregate, err := s.book.DepositWalletFunds(wallet, deposit, req.GetIdempotencyKey())
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when depositing wallet funds: %v", err)
+		return nil, commandError(err, "problem when depositing wallet funds")
 	}
 
 	return &ledgerpb.DepositWalletFundsResponse{
@@ -63,11 +319,11 @@ func (s *Server) WithdrawWalletFunds(ctx context.Context, req *ledgerpb.Withdraw
 	}
 
 	wallet := req.GetWallet()
-	withdraw := req.GetWithdraw()
+	withdraw := ledger.NewMoney(req.GetWithdraw(), req.GetCurrency())
 
-	aggregate, err := s.book.WithdrawWalletFunds(wallet, withdraw)
+	aggregate, err := s.book.WithdrawWalletFunds(wallet, withdraw, req.GetIdempotencyKey())
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when withdawing wallet funds: %v", err)
+		return nil, commandError(err, "problem when withdawing wallet funds")
 	}
 
 	return &ledgerpb.WithdrawWalletFundsResponse{
@@ -81,10 +337,10 @@ func (s *Server) AddCreditTransaction(ctx context.Context, req *ledgerpb.AddCred
 	}
 
 	t := req.GetTransaction()
-	err := s.book.AddTransaction(ledger.TransactionCredit, t.GetWallet(), t.GetCredit(), t.GetAggregate())
+	err := s.book.AddTransaction(ledger.TransactionCredit, t.GetWallet(), ledger.NewMoney(t.GetCredit(), t.GetCurrency()), t.GetAggregate(), req.GetIdempotencyKey())
 
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when adding credit transaction: %v", err)
+		return nil, commandError(err, "problem when adding credit transaction")
 	}
 
 	return &ledgerpb.AddCreditTransactionResponse{
@@ -98,10 +354,10 @@ func (s *Server) AddDebitTransaction(ctx context.Context, req *ledgerpb.AddDebit
 	}
 
 	t := req.GetTransaction()
-	err := s.book.AddTransaction(ledger.TransactionDebit, t.GetWallet(), t.GetDebit(), t.GetAggregate())
+	err := s.book.AddTransaction(ledger.TransactionDebit, t.GetWallet(), ledger.NewMoney(t.GetDebit(), t.GetCurrency()), t.GetAggregate(), req.GetIdempotencyKey())
 
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when adding debit transaction: %v", err)
+		return nil, commandError(err, "problem when adding debit transaction")
 	}
 
 	return &ledgerpb.AddDebitTransactionResponse{
@@ -115,10 +371,10 @@ func (s *Server) AddCashInTransaction(ctx context.Context, req *ledgerpb.AddCash
 	}
 
 	t := req.GetTransaction()
-	err := s.book.AddTransaction(ledger.TransactionCashIn, t.GetWallet(), t.GetCredit(), t.GetAggregate())
+	err := s.book.AddTransaction(ledger.TransactionCashIn, t.GetWallet(), ledger.NewMoney(t.GetCredit(), t.GetCurrency()), t.GetAggregate(), req.GetIdempotencyKey())
 
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when adding cash-in transaction: %v", err)
+		return nil, commandError(err, "problem when adding cash-in transaction")
 	}
 
 	return &ledgerpb.AddCashInTransactionResponse{
@@ -132,10 +388,10 @@ func (s *Server) AddCashOutTransaction(ctx context.Context, req *ledgerpb.AddCas
 	}
 
 	t := req.GetTransaction()
-	err := s.book.AddTransaction(ledger.TransactionCashOut, t.GetWallet(), t.GetDebit(), t.GetAggregate())
+	err := s.book.AddTransaction(ledger.TransactionCashOut, t.GetWallet(), ledger.NewMoney(t.GetDebit(), t.GetCurrency()), t.GetAggregate(), req.GetIdempotencyKey())
 
 	if err != nil {
-		return nil, status.Errorf(codes.FailedPrecondition, "problem when adding cash-out transaction: %v", err)
+		return nil, commandError(err, "problem when adding cash-out transaction")
 	}
 
 	return &ledgerpb.AddCashOutTransactionResponse{
@@ -168,14 +424,108 @@ func (s *Server) WalletBalance(ctx context.Context, req *ledgerpb.WalletBalanceR
 
 	w := req.GetWallet()
 
-	b, err := s.book.WalletBalance(w)
+	bs, err := s.book.WalletBalance(w)
 
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "wallet '%s' has no recorded transactions", w)
 	}
 
+	balances := make([]*ledgerpb.Balance, len(bs))
+	for i, b := range bs {
+		balances[i] = &ledgerpb.Balance{Amount: b.Current.Amount, Currency: b.Current.Currency, Available: b.Available.Amount}
+	}
+
 	return &ledgerpb.WalletBalanceResponse{
-		Balance: b,
+		Balances: balances,
+	}, nil
+}
+
+// WalletBalanceAt returns the balance of a wallet at a point in its history, only amount and currency are set
+func (s *Server) WalletBalanceAt(ctx context.Context, req *ledgerpb.WalletBalanceAtRequest) (*ledgerpb.WalletBalanceAtResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	w := req.GetWallet()
+
+	at := point(req.GetAt(), req.GetSequence())
+	if err := at.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bs, err := s.book.WalletBalanceAt(w, at)
+
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "problem when getting balance of wallet '%s' at %v: %v", w, at, err)
+	}
+
+	balances := make([]*ledgerpb.Balance, len(bs))
+	for i, b := range bs {
+		balances[i] = &ledgerpb.Balance{Amount: b.Amount, Currency: b.Currency}
+	}
+
+	return &ledgerpb.WalletBalanceAtResponse{
+		Balances: balances,
+	}, nil
+}
+
+// WalletTransactionsBetween returns the transactions of a wallet recorded after one point in its history, up to and including another
+func (s *Server) WalletTransactionsBetween(ctx context.Context, req *ledgerpb.WalletTransactionsBetweenRequest) (*ledgerpb.WalletTransactionsBetweenResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	w := req.GetWallet()
+
+	from := point(req.GetFrom(), req.GetFromSequence())
+	to := point(req.GetTo(), req.GetToSequence())
+
+	for _, p := range []ledger.Point{from, to} {
+		if err := p.Validate(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	ts, err := s.book.WalletTransactionsBetween(w, from, to)
+
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "problem when getting transactions of wallet '%s': %v", w, err)
+	}
+
+	return &ledgerpb.WalletTransactionsBetweenResponse{
+		Transactions: ts,
+	}, nil
+}
+
+// point returns the point in the book's history at a unix time in nanoseconds or a sequence number, neither is a zero point
+func point(at int64, sequence uint64) ledger.Point {
+	var t time.Time
+	if at != 0 {
+		t = time.Unix(0, at)
+	}
+
+	return ledger.Point{Time: t, Sequence: sequence}
+}
+
+// AccountBalance returns the current balance of an account and every account under it, only amount and currency are set
+func (s *Server) AccountBalance(ctx context.Context, req *ledgerpb.AccountBalanceRequest) (*ledgerpb.AccountBalanceResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	bs, err := s.book.AccountBalance(req.GetCode())
+
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "problem when getting balance of account '%s': %v", req.GetCode(), err)
+	}
+
+	balances := make([]*ledgerpb.Balance, len(bs))
+	for i, b := range bs {
+		balances[i] = &ledgerpb.Balance{Amount: b.Amount, Currency: b.Currency}
+	}
+
+	return &ledgerpb.AccountBalanceResponse{
+		Balances: balances,
 	}, nil
 }
 
@@ -194,3 +544,127 @@ func (s *Server) AggregateTransactions(ctx context.Context, req *ledgerpb.Aggreg
 		Transactions: ts,
 	}, nil
 }
+
+// commandError turns an error returned by a command into a status, using a code that tells clients whether to retry
+// a rejection for insufficient funds carries the wallet's available balance in its details, and invalid input is an InvalidArgument
+// a command refused by the state of the book is a FailedPrecondition and one naming something the book doesn't have is a NotFound
+// anything the book doesn't return as a typed error, such as a problem writing to its journal, is an Internal
+func commandError(err error, doing string) error {
+	switch e := err.(type) {
+	case *ledger.IdempotencyConflictError:
+		return status.Errorf(codes.AlreadyExists, "%s: %v", doing, err)
+	case *ledger.InsufficientFundsError:
+		return insufficientFunds(e, doing)
+	case *ledger.WalletStatusError:
+		return walletStatus(e, doing)
+	case *ledger.LimitExceededError:
+		return limitExceeded(e, doing)
+	case *ledger.ValidationError:
+		return invalidArgument(e, doing)
+	case *ledger.RejectedError:
+		return rejected(e, doing)
+	case *ledger.NotFoundError:
+		return notFound(e, doing)
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", doing, err)
+}
+
+// insufficientFunds returns a FailedPrecondition status for a rejected debit, with a precondition failure in its details
+// naming the wallet, so clients can tell it apart from other failed preconditions without parsing the message
+func insufficientFunds(err *ledger.InsufficientFundsError, doing string) error {
+	return failedPrecondition(err, doing, &errdetails.PreconditionFailure_Violation{
+		Type:        "INSUFFICIENT_FUNDS",
+		Subject:     err.Wallet,
+		Description: fmt.Sprintf("available balance of %v with %s overdraft can't cover %v", err.Available, err.Policy, err.Amount),
+	})
+}
+
+// walletStatus returns a FailedPrecondition status for a transaction refused by a wallet's status
+// its precondition failure is of type WALLET_FROZEN or WALLET_CLOSED
+func walletStatus(err *ledger.WalletStatusError, doing string) error {
+	return failedPrecondition(err, doing, &errdetails.PreconditionFailure_Violation{
+		Type:        "WALLET_" + strings.ToUpper(string(err.Status)),
+		Subject:     err.Wallet,
+		Description: err.Error(),
+	})
+}
+
+// limitExceeded returns a FailedPrecondition status for a transaction that would take a wallet past one of its limits
+// its precondition failure is of type LIMIT_ followed by the limit, such as LIMIT_MAX_DAILY_OUTFLOW
+func limitExceeded(err *ledger.LimitExceededError, doing string) error {
+	return failedPrecondition(err, doing, &errdetails.PreconditionFailure_Violation{
+		Type:        "LIMIT_" + strings.ToUpper(string(err.Limit)),
+		Subject:     err.Wallet,
+		Description: err.Error(),
+	})
+}
+
+// rejected returns a FailedPrecondition status for a command the state of the book doesn't allow
+// its precondition failure is of the type of rejection, such as ALREADY_REVERSED or REFUND_EXCEEDED
+func rejected(err *ledger.RejectedError, doing string) error {
+	return failedPrecondition(err, doing, &errdetails.PreconditionFailure_Violation{
+		Type:        strings.ToUpper(string(err.Rejection)),
+		Subject:     err.Subject,
+		Description: err.Error(),
+	})
+}
+
+// notFound returns a NotFound status for a command naming something the book doesn't have, with resource info in its details
+func notFound(err *ledger.NotFoundError, doing string) error {
+	s := status.Newf(codes.NotFound, "%s: %v", doing, err)
+
+	detailed, e := s.WithDetails(&errdetails.ResourceInfo{ResourceType: err.Kind, ResourceName: err.ID, Description: err.Error()})
+	if e != nil {
+		return s.Err()
+	}
+
+	return detailed.Err()
+}
+
+// invalidArgument returns an InvalidArgument status for input that could never be recorded, with a bad request in its details
+// naming the field, so clients can tell which part of the request to fix
+func invalidArgument(err *ledger.ValidationError, doing string) error {
+	s := status.Newf(codes.InvalidArgument, "%s: %v", doing, err)
+
+	detailed, e := s.WithDetails(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: err.Field, Description: err.Error()},
+	}})
+	if e != nil {
+		return s.Err()
+	}
+
+	return detailed.Err()
+}
+
+// feeSchedule reads the fee schedule of a request
+func feeSchedule(s *ledgerpb.FeeSchedule) ledger.FeeSchedule {
+	schedule := ledger.FeeSchedule{
+		Command:     ledger.FeeCommand(s.GetCommand()),
+		Tier:        s.GetTier(),
+		Currency:    s.GetCurrency(),
+		Wallet:      s.GetWallet(),
+		Flat:        s.GetFlat(),
+		BasisPoints: s.GetBasisPoints(),
+		Min:         s.GetMin(),
+		Max:         s.GetMax(),
+	}
+
+	for _, band := range s.GetBands() {
+		schedule.Bands = append(schedule.Bands, ledger.FeeBand{UpTo: band.GetUpTo(), Flat: band.GetFlat(), BasisPoints: band.GetBasisPoints()})
+	}
+
+	return schedule
+}
+
+// failedPrecondition returns a FailedPrecondition status for the error carrying the violation in its details
+func failedPrecondition(err error, doing string, violation *errdetails.PreconditionFailure_Violation) error {
+	s := status.Newf(codes.FailedPrecondition, "%s: %v", doing, err)
+
+	detailed, e := s.WithDetails(&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{violation}})
+	if e != nil {
+		return s.Err()
+	}
+
+	return detailed.Err()
+}
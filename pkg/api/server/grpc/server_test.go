@@ -1 +1,191 @@
 package grpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+)
+
+func TestCommandError(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		details []proto.Message
+	}{
+		{
+			"an idempotency key used for a different command",
+			&ledger.IdempotencyConflictError{Key: "retry-1"},
+			codes.AlreadyExists,
+			nil,
+		},
+		{
+			"insufficient funds",
+			&ledger.InsufficientFundsError{Wallet: "1", Available: ledger.NewMoney(100, "EUR"), Amount: ledger.NewMoney(500, "EUR")},
+			codes.FailedPrecondition,
+			[]proto.Message{&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: "INSUFFICIENT_FUNDS", Subject: "1", Description: "available balance of 100 EUR with strict overdraft can't cover 500 EUR"},
+			}}},
+		},
+		{
+			"a frozen wallet",
+			&ledger.WalletStatusError{Wallet: "2", Status: ledger.WalletFrozen},
+			codes.FailedPrecondition,
+			[]proto.Message{&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: "WALLET_FROZEN", Subject: "2", Description: "wallet '2' is frozen, no funds can be taken out of it"},
+			}}},
+		},
+		{
+			"an exceeded limit",
+			&ledger.LimitExceededError{Wallet: "3", Limit: ledger.LimitTransfersPerHour, Max: 5, Attempted: 6},
+			codes.FailedPrecondition,
+			[]proto.Message{&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: "LIMIT_MAX_TRANSFERS_PER_HOUR", Subject: "3", Description: "wallet '3' would reach 6 against its max_transfers_per_hour limit of 5"},
+			}}},
+		},
+		{
+			"invalid input",
+			&ledger.ValidationError{Field: "amount", Value: "-1", Reason: "must be positive"},
+			codes.InvalidArgument,
+			[]proto.Message{&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "amount", Description: "invalid amount '-1', must be positive"},
+			}}},
+		},
+		{
+			"a command the state of the book doesn't allow",
+			&ledger.RejectedError{Subject: "1111", Rejection: ledger.RejectionAlreadyReversed, Reason: "aggregate '1111' was already reversed by aggregate '1112'"},
+			codes.FailedPrecondition,
+			[]proto.Message{&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: "ALREADY_REVERSED", Subject: "1111", Description: "aggregate '1111' was already reversed by aggregate '1112'"},
+			}}},
+		},
+		{
+			"something the book doesn't have",
+			&ledger.NotFoundError{Kind: "wallet", ID: "9"},
+			codes.NotFound,
+			[]proto.Message{&errdetails.ResourceInfo{ResourceType: "wallet", ResourceName: "9", Description: "no wallet (9)"}},
+		},
+		{
+			"an unexpected error",
+			errors.New("disk full"),
+			codes.Internal,
+			nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run("should return a status for "+c.name, func(t *testing.T) {
+			s := status.Convert(commandError(c.err, "problem when testing"))
+
+			if s.Code() != c.code || s.Message() != "problem when testing: "+c.err.Error() {
+				t.Errorf("got status %v '%s', wanted %v for %v", s.Code(), s.Message(), c.code, c.err)
+			}
+
+			details := s.Details()
+			if len(details) != len(c.details) {
+				t.Fatalf("got details %v, wanted %v", details, c.details)
+			}
+
+			for i := range c.details {
+				got, ok := details[i].(proto.Message)
+				if !ok || !proto.Equal(got, c.details[i]) {
+					t.Errorf("got details %v, wanted %v", details[i], c.details[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCommandError_Book(t *testing.T) {
+	money := func(amount int64) ledger.Money {
+		return ledger.NewMoney(amount, ledger.DefaultCurrency)
+	}
+
+	book := memory.NewInMemoryBook()
+	book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+	book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+	book.DepositWalletFunds("1", money(10000), "")
+	refunded, _ := book.TransferWalletFunds("1", "2", money(1000), "")
+	reversed, _ := book.TransferWalletFunds("1", "2", money(1000), "")
+	book.RefundAggregate(refunded, money(100), "")
+	book.ReverseAggregate(reversed, "", "")
+
+	cases := []struct {
+		name   string
+		run    func() error
+		code   codes.Code
+		detail string // the type of the precondition failure, the field of the bad request or the kind of resource not found
+	}{
+		{"refunding more than was paid", func() error {
+			_, err := book.RefundAggregate(refunded, money(1000), "")
+			return err
+		}, codes.FailedPrecondition, "REFUND_EXCEEDED"},
+		{"reversing an aggregate twice", func() error {
+			_, err := book.ReverseAggregate(reversed, "", "")
+			return err
+		}, codes.FailedPrecondition, "ALREADY_REVERSED"},
+		{"reversing an aggregate that was partly refunded", func() error {
+			_, err := book.ReverseAggregate(refunded, "", "")
+			return err
+		}, codes.FailedPrecondition, "PARTLY_REFUNDED"},
+		{"reversing an aggregate it doesn't have", func() error {
+			_, err := book.ReverseAggregate("unknown", "", "")
+			return err
+		}, codes.NotFound, "aggregate"},
+		{"sending funds to an unknown wallet", func() error {
+			_, err := book.TransferWalletFunds("1", "unknown", money(100), "")
+			return err
+		}, codes.NotFound, "wallet"},
+		{"an unbalanced posting", func() error {
+			_, err := book.Post([]ledger.Entry{
+				{Type: ledger.TransactionDebit, Wallet: "1", Amount: money(100)},
+				{Type: ledger.TransactionCredit, Wallet: "2", Amount: money(50)},
+			}, "")
+			return err
+		}, codes.InvalidArgument, "entries"},
+		{"opening a wallet that's already open", func() error {
+			_, err := book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+			return err
+		}, codes.FailedPrecondition, "WALLET_OPEN"},
+		{"closing a wallet that isn't empty", func() error {
+			return book.SetWalletStatus("2", ledger.WalletClosed, "")
+		}, codes.FailedPrecondition, "WALLET_NOT_EMPTY"},
+		{"exchanging without a rate provider", func() error {
+			_, err := book.ExchangeWalletFunds("1", "2", money(100), "EUR", "")
+			return err
+		}, codes.FailedPrecondition, "NO_RATE_PROVIDER"},
+	}
+
+	for _, c := range cases {
+		t.Run("should return a status for "+c.name, func(t *testing.T) {
+			s := status.Convert(commandError(c.run(), "problem when testing"))
+
+			if s.Code() != c.code || detail(s) != c.detail {
+				t.Errorf("got status %v with details %v, wanted %v with %s", s.Code(), s.Details(), c.code, c.detail)
+			}
+		})
+	}
+}
+
+// detail returns the type of a status's precondition failure, the field of its bad request or the kind of resource it didn't find
+func detail(s *status.Status) string {
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.PreconditionFailure:
+			return d.GetViolations()[0].GetType()
+		case *errdetails.BadRequest:
+			return d.GetFieldViolations()[0].GetField()
+		case *errdetails.ResourceInfo:
+			return d.GetResourceType()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// WalletServer serves the wallet lifecycle, opening wallets and changing their status, alongside the ledger service
+type WalletServer struct {
+	book ledger.Book
+}
+
+func NewWalletServer(book ledger.Book) *WalletServer {
+	return &WalletServer{
+		book: book,
+	}
+}
+
+func (s *WalletServer) OpenWallet(ctx context.Context, req *ledgerpb.OpenWalletRequest) (*ledgerpb.OpenWalletResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	wallet := ledger.Wallet{ID: req.GetId(), Owner: req.GetOwner(), Metadata: req.GetMetadata(), Tier: req.GetTier()}
+
+	id, err := s.book.OpenWallet(wallet, req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when opening wallet")
+	}
+
+	return &ledgerpb.OpenWalletResponse{
+		Result: fmt.Sprintf("wallet opened successfully, wallet ID: '%s'", id),
+		Id:     id,
+	}, nil
+}
+
+func (s *WalletServer) SetWalletStatus(ctx context.Context, req *ledgerpb.SetWalletStatusRequest) (*ledgerpb.SetWalletStatusResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	err := s.book.SetWalletStatus(req.GetWallet(), ledger.WalletStatus(req.GetStatus()), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when setting wallet status")
+	}
+
+	return &ledgerpb.SetWalletStatusResponse{
+		Result: fmt.Sprintf("wallet '%s' is now %s", req.GetWallet(), req.GetStatus()),
+	}, nil
+}
+
+func (s *WalletServer) SetWalletLimits(ctx context.Context, req *ledgerpb.SetWalletLimitsRequest) (*ledgerpb.SetWalletLimitsResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	err := s.book.SetWalletLimits(req.GetWallet(), limits(req.GetLimits()), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when setting wallet limits")
+	}
+
+	return &ledgerpb.SetWalletLimitsResponse{
+		Result: fmt.Sprintf("limits of wallet '%s' set successfully", req.GetWallet()),
+	}, nil
+}
+
+func (s *WalletServer) SetTierLimits(ctx context.Context, req *ledgerpb.SetTierLimitsRequest) (*ledgerpb.SetTierLimitsResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	err := s.book.SetTierLimits(req.GetTier(), limits(req.GetLimits()), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, commandError(err, "problem when setting tier limits")
+	}
+
+	return &ledgerpb.SetTierLimitsResponse{
+		Result: fmt.Sprintf("limits of tier '%s' set successfully", req.GetTier()),
+	}, nil
+}
+
+func (s *WalletServer) GetWallet(ctx context.Context, req *ledgerpb.GetWalletRequest) (*ledgerpb.GetWalletResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	w, err := s.book.Wallet(req.GetWallet())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "problem when getting wallet '%s': %v", req.GetWallet(), err)
+	}
+
+	return &ledgerpb.GetWalletResponse{
+		Wallet: &ledgerpb.Wallet{Id: w.ID, Owner: w.Owner, Metadata: w.Metadata, Status: string(w.Status), Tier: w.Tier},
+	}, nil
+}
+
+// limits reads the limits of a request, amounts left at zero stay unset
+func limits(l *ledgerpb.Limits) ledger.Limits {
+	money := func(amount int64) ledger.Money {
+		if amount == 0 {
+			return ledger.Money{}
+		}
+
+		return ledger.NewMoney(amount, l.GetCurrency())
+	}
+
+	return ledger.Limits{
+		MaxSingleOutflow:    money(l.GetMaxSingleOutflow()),
+		MaxDailyOutflow:     money(l.GetMaxDailyOutflow()),
+		MaxTransfersPerHour: int(l.GetMaxTransfersPerHour()),
+		MaxBalance:          money(l.GetMaxBalance()),
+	}
+}
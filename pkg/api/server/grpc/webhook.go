@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/webhook"
+)
+
+// WebhookServer manages the webhooks the book's events are delivered to, and the deliveries that ran out of attempts
+type WebhookServer struct {
+	webhooks *webhook.Dispatcher
+}
+
+func NewWebhookServer(webhooks *webhook.Dispatcher) *WebhookServer {
+	return &WebhookServer{
+		webhooks: webhooks,
+	}
+}
+
+// CreateWebhook registers an endpoint for events, returning it along with the secret its deliveries are signed with
+func (s *WebhookServer) CreateWebhook(ctx context.Context, req *ledgerpb.CreateWebhookRequest) (*ledgerpb.CreateWebhookResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	w, err := s.webhooks.Subscribe(req.GetUrl(), req.GetEvents(), req.GetSecret())
+	if err != nil {
+		return nil, webhookError(err, "problem when creating webhook")
+	}
+
+	pb := webhookpb(w)
+	pb.Secret = w.Secret
+
+	return &ledgerpb.CreateWebhookResponse{Webhook: pb}, nil
+}
+
+// ListWebhooks returns every webhook, without their secrets
+func (s *WebhookServer) ListWebhooks(ctx context.Context, req *ledgerpb.ListWebhooksRequest) (*ledgerpb.ListWebhooksResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	webhooks, err := s.webhooks.Webhooks()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "problem when listing webhooks: %v", err)
+	}
+
+	pbs := make([]*ledgerpb.Webhook, len(webhooks))
+	for i, w := range webhooks {
+		pbs[i] = webhookpb(w)
+	}
+
+	return &ledgerpb.ListWebhooksResponse{Webhooks: pbs}, nil
+}
+
+// DeleteWebhook stops events being delivered to a webhook, dropping its deliveries
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, req *ledgerpb.DeleteWebhookRequest) (*ledgerpb.DeleteWebhookResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	err := s.webhooks.Unsubscribe(req.GetId())
+	if err != nil {
+		return nil, webhookError(err, "problem when deleting webhook")
+	}
+
+	return &ledgerpb.DeleteWebhookResponse{}, nil
+}
+
+// ListDeadLetters returns the deliveries that ran out of attempts
+func (s *WebhookServer) ListDeadLetters(ctx context.Context, req *ledgerpb.ListDeadLettersRequest) (*ledgerpb.ListDeadLettersResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	dead, err := s.webhooks.DeadLetters()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "problem when listing dead letters: %v", err)
+	}
+
+	deliveries := make([]*ledgerpb.WebhookDelivery, len(dead))
+	for i, d := range dead {
+		deliveries[i] = &ledgerpb.WebhookDelivery{
+			Id:        d.ID,
+			Webhook:   d.Webhook,
+			Event:     d.Message.Event,
+			Sequence:  d.Message.Sequence,
+			Attempts:  int32(d.Attempts),
+			LastError: d.LastError,
+			DeadAt:    d.DeadAt,
+		}
+	}
+
+	return &ledgerpb.ListDeadLettersResponse{Deliveries: deliveries}, nil
+}
+
+// RedeliverWebhook queues a delivery to be tried again with all its attempts
+func (s *WebhookServer) RedeliverWebhook(ctx context.Context, req *ledgerpb.RedeliverWebhookRequest) (*ledgerpb.RedeliverWebhookResponse, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, status.Error(codes.Canceled, "client cancelled, aborting")
+	}
+
+	err := s.webhooks.Redeliver(req.GetDelivery())
+	if err != nil {
+		return nil, webhookError(err, "problem when redelivering webhook")
+	}
+
+	return &ledgerpb.RedeliverWebhookResponse{}, nil
+}
+
+// webhookpb returns the webhook as a message, without its secret
+func webhookpb(w webhook.Webhook) *ledgerpb.Webhook {
+	return &ledgerpb.Webhook{Id: w.ID, Url: w.URL, Events: w.Events, CreatedAt: w.CreatedAt}
+}
+
+// webhookError returns the status of a failed webhook command
+func webhookError(err error, doing string) error {
+	switch e := err.(type) {
+	case *ledger.ValidationError:
+		return invalidArgument(e, doing)
+	case *webhook.NotFoundError:
+		return status.Errorf(codes.NotFound, "%s: %v", doing, err)
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", doing, err)
+}
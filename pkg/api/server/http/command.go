@@ -1,53 +1,125 @@
 package http
 
 import (
+	"time"
+
 	"gitlab.com/patchwell/ledger"
 )
 
 // AddCreditTransaction adds a new credit transaction in the given Book
-// returns a event representing the newly added transaction called CreditTransactionAdded
-func AddCreditTransaction(book ledger.Book, wallet string, credit int32, aggregate string) (*ledger.CreditTransactionAdded, error) {
-	err := book.AddTransaction(ledger.TransactionCredit, wallet, credit, aggregate)
+// the book publishes the CreditTransactionAdded event once it's committed
+func AddCreditTransaction(book ledger.Book, wallet string, credit ledger.Money, aggregate string, idempotencyKey string) error {
+	return book.AddTransaction(ledger.TransactionCredit, wallet, credit, aggregate, idempotencyKey)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// AddDebitTransaction adds a new debit type transaction in the given Book
+// the book publishes the DebitTransactionAdded event once it's committed
+func AddDebitTransaction(book ledger.Book, wallet string, debit ledger.Money, aggregate string, idempotencyKey string) error {
+	return book.AddTransaction(ledger.TransactionDebit, wallet, debit, aggregate, idempotencyKey)
+}
 
-	return &ledger.CreditTransactionAdded{Wallet: wallet, Credit: credit, Aggregate: aggregate}, nil
+// AddCashInTransaction adds a new cash in type transaction in the given Book
+// the book publishes the CashInTransactionAdded event once it's committed
+func AddCashInTransaction(book ledger.Book, wallet string, credit ledger.Money, aggregate string, idempotencyKey string) error {
+	return book.AddTransaction(ledger.TransactionCashIn, wallet, credit, aggregate, idempotencyKey)
 }
 
-// AddDebitTransaction adds a new debit type transaction in the given Book
-// returns an event representing the newly added transaction called DebitTransactionAdded
-func AddDebitTransaction(book ledger.Book, wallet string, debit int32, aggregate string) (*ledger.DebitTransactionAdded, error) {
-	err := book.AddTransaction(ledger.TransactionDebit, wallet, debit, aggregate)
+// AddCashOutTransaction adds a new cash out type transaction in the given Book
+// the book publishes the CashOutTransactionAdded event once it's committed
+func AddCashOutTransaction(book ledger.Book, wallet string, debit ledger.Money, aggregate string, idempotencyKey string) error {
+	return book.AddTransaction(ledger.TransactionCashOut, wallet, debit, aggregate, idempotencyKey)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// ExchangeWalletFunds transfers funds between wallets, converting them to another currency on the way
+// returns the aggregate of the exchange
+func ExchangeWalletFunds(book ledger.Book, source string, destination string, amount ledger.Money, currency string, idempotencyKey string) (string, error) {
+	return book.ExchangeWalletFunds(source, destination, amount, currency, idempotencyKey)
+}
 
-	return &ledger.DebitTransactionAdded{Wallet: wallet, Debit: debit, Aggregate: aggregate}, nil
+// Post adds debits and credits across any number of wallets under a single aggregate
+// returns the aggregate of the posting
+func Post(book ledger.Book, entries []ledger.Entry, idempotencyKey string) (string, error) {
+	return book.Post(entries, idempotencyKey)
 }
 
-// AddCashInTransaction adds a new cash in type transaction in the given Book
-// returns an event representing the newly added transaction called CashInTransactionAdded
-func AddCashInTransaction(book ledger.Book, wallet string, credit int32, aggregate string) (*ledger.CashInTransactionAdded, error) {
-	err := book.AddTransaction(ledger.TransactionCashIn, wallet, credit, aggregate)
+// ReverseAggregate undoes every transaction in an aggregate
+// returns the aggregate of the reversal
+func ReverseAggregate(book ledger.Book, aggregate string, reason string, idempotencyKey string) (string, error) {
+	return book.ReverseAggregate(aggregate, reason, idempotencyKey)
+}
 
-	if err != nil {
-		return nil, err
-	}
+// RefundAggregate gives back part of an aggregate
+// returns the aggregate of the refund
+func RefundAggregate(book ledger.Book, aggregate string, amount ledger.Money, idempotencyKey string) (string, error) {
+	return book.RefundAggregate(aggregate, amount, idempotencyKey)
+}
 
-	return &ledger.CashInTransactionAdded{Wallet: wallet, Credit: credit, Aggregate: aggregate}, nil
+// SetOverdraftPolicy sets how far below zero a wallet's balance may go
+func SetOverdraftPolicy(book ledger.Book, wallet string, policy ledger.OverdraftPolicy, idempotencyKey string) error {
+	return book.SetOverdraftPolicy(wallet, policy, idempotencyKey)
 }
 
-// AddCashOutTransaction adds a new cash out type transaction in the given Book
-// returns an event representing the newly added transaction called CashOutTransactionAdded
-func AddCashOutTransaction(book ledger.Book, wallet string, debit int32, aggregate string) (*ledger.CashOutTransactionAdded, error) {
-	err := book.AddTransaction(ledger.TransactionCashOut, wallet, debit, aggregate)
+// SetWalletLimits sets the limits of a wallet, in place of those of its tier
+func SetWalletLimits(book ledger.Book, wallet string, limits ledger.Limits, idempotencyKey string) error {
+	return book.SetWalletLimits(wallet, limits, idempotencyKey)
+}
 
-	if err != nil {
-		return nil, err
+// SetTierLimits sets the limits of the wallets in a tier that don't have their own
+func SetTierLimits(book ledger.Book, tier string, limits ledger.Limits, idempotencyKey string) error {
+	return book.SetTierLimits(tier, limits, idempotencyKey)
+}
+
+// SetFeeSchedule sets what's charged on a command taking amounts out of wallets in a tier
+func SetFeeSchedule(book ledger.Book, schedule ledger.FeeSchedule, idempotencyKey string) error {
+	return book.SetFeeSchedule(schedule, idempotencyKey)
+}
+
+// SetInterestPolicy sets how interest is accrued on a wallet and posted to it
+func SetInterestPolicy(book ledger.Book, wallet string, policy ledger.InterestPolicy, idempotencyKey string) error {
+	return book.SetInterestPolicy(wallet, policy, idempotencyKey)
+}
+
+// AccrueInterest accrues interest on every wallet with an interest policy up to and including a day that has ended
+// returns the aggregate interest was posted under and the wallets the run skipped, which are tried again on the next one
+func AccrueInterest(book ledger.Book, through time.Time, idempotencyKey string) (string, []string, error) {
+	aggregate, err := book.AccrueInterest(through, idempotencyKey)
+	if e, ok := err.(*ledger.InterestAccrualError); ok {
+		return aggregate, e.Wallets(), nil
 	}
 
-	return &ledger.CashOutTransactionAdded{Wallet: wallet, Debit: debit, Aggregate: aggregate}, nil
+	return aggregate, nil, err
+}
+
+// OpenWallet adds a wallet to the book, with an ID generated for it if it has none
+// returns the ID of the wallet
+func OpenWallet(book ledger.Book, wallet ledger.Wallet, idempotencyKey string) (string, error) {
+	return book.OpenWallet(wallet, idempotencyKey)
+}
+
+// SetWalletStatus freezes a wallet, makes a frozen one active again, or closes one
+func SetWalletStatus(book ledger.Book, wallet string, status ledger.WalletStatus, idempotencyKey string) error {
+	return book.SetWalletStatus(wallet, status, idempotencyKey)
+}
+
+// DefineAccount adds an account to the chart of accounts, or changes one already in it
+func DefineAccount(book ledger.Book, account ledger.Account, idempotencyKey string) error {
+	return book.DefineAccount(account, idempotencyKey)
+}
+
+// AuthorizeHold sets funds aside in a wallet until they're captured, voided or the hold expires
+// returns the hold's aggregate
+func AuthorizeHold(book ledger.Book, wallet string, amount ledger.Money, expiresAt time.Time, idempotencyKey string) (string, error) {
+	return book.AuthorizeHold(wallet, amount, expiresAt, idempotencyKey)
+}
+
+// CaptureHold transfers some or all of a hold to the destination wallet
+// returns the aggregate of the capture
+func CaptureHold(book ledger.Book, hold string, destination string, amount ledger.Money, idempotencyKey string) (string, error) {
+	return book.CaptureHold(hold, destination, amount, idempotencyKey)
+}
+
+// VoidHold releases a hold without capturing any of it
+// returns the aggregate of the release
+func VoidHold(book ledger.Book, hold string, idempotencyKey string) (string, error) {
+	return book.VoidHold(hold, idempotencyKey)
 }
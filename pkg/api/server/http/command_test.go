@@ -1,6 +1,7 @@
 package http
 
 import (
+	"gitlab.com/patchwell/ledger"
 	"gitlab.com/patchwell/ledger/pkg/book/memory"
 	"testing"
 )
@@ -9,102 +10,83 @@ func TestAddCreditTransaction(t *testing.T) {
 	book := memory.NewMockInMemoryBook()
 	walletID := "4"
 	aggID := "1114"
-	credit := int32(10000)
+	credit := ledger.NewMoney(10000, ledger.DefaultCurrency)
 
-	event, err := AddCreditTransaction(book, walletID, credit, aggID)
+	book.OpenWallet(ledger.Wallet{ID: walletID}, "")
+	err := AddCreditTransaction(book, walletID, credit, aggID, "")
 
 	if err != nil {
 		t.Errorf("error returned %v", err)
 	}
 
-	if event.Wallet != walletID {
-		t.Error("CreditTransactionAdded event has incorrect destination ID")
-	}
-
-	if event.Credit != credit {
-		t.Error("CreditTransactionAdded event has incorrect credit amount")
-	}
-
-	if event.Aggregate != aggID {
-		t.Error("CreditTransactionAdded event has incorrect aggregate ID")
-	}
+	assertAdded(t, book, aggID, ledger.TransactionCredit, walletID, credit)
 }
 
 func TestAddDebitTransaction(t *testing.T) {
 	book := memory.NewMockInMemoryBook()
 	walletID := "4"
 	aggID := "1114"
-	debit := int32(10000)
+	debit := ledger.NewMoney(10000, ledger.DefaultCurrency)
+
+	book.SetOverdraftPolicy(walletID, ledger.UnlimitedOverdraft(), "")
 
-	event, err := AddDebitTransaction(book, walletID, debit, aggID)
+	err := AddDebitTransaction(book, walletID, debit, aggID, "")
 
 	if err != nil {
 		t.Errorf("error returned %v", err)
 	}
 
-	if event.Wallet != walletID {
-		t.Error("DebitTransactionAdded event has incorrect wallet ID")
-	}
-
-	if event.Debit != debit {
-		t.Error("DebitTransactionAdded event has incorrect debit amount")
-	}
-
-	if event.Aggregate != aggID {
-		t.Error("DebitTransactionAdded event has incorrect aggregate ID")
-	}
+	assertAdded(t, book, aggID, ledger.TransactionDebit, walletID, debit)
 }
 
 func TestAddCashInTransaction(t *testing.T) {
-	t.Run("should return a valid CashInTransactionAdded event", func(t *testing.T) {
+	t.Run("should add a cash in transaction to the book", func(t *testing.T) {
 		book := memory.NewMockInMemoryBook()
 		wallet := "1"
 		aggregate := "3333"
-		credit := int32(1000)
+		credit := ledger.NewMoney(1000, ledger.DefaultCurrency)
 
-		event, err := AddCashInTransaction(book, wallet, credit, aggregate)
+		err := AddCashInTransaction(book, wallet, credit, aggregate, "")
 
 		if err != nil {
 			t.Errorf("error returned %v", err)
 		}
 
-		if event.Wallet != wallet {
-			t.Error("DebitTransactionAdded event has incorrect wallet ID")
-		}
-
-		if event.Credit != credit {
-			t.Error("DebitTransactionAdded event has incorrect debit amount")
-		}
-
-		if event.Aggregate != aggregate {
-			t.Error("DebitTransactionAdded event has incorrect aggregate ID")
-		}
+		assertAdded(t, book, aggregate, ledger.TransactionCashIn, wallet, credit)
 	})
 }
 
 func TestAddCashOutTransaction(t *testing.T) {
-	t.Run("should return a valid CashOutTransactionAdded event", func(t *testing.T) {
+	t.Run("should add a cash out transaction to the book", func(t *testing.T) {
 		book := memory.NewMockInMemoryBook()
 		wallet := "1"
 		aggregate := "3333"
-		debit := int32(1000)
+		debit := ledger.NewMoney(1000, ledger.DefaultCurrency)
 
-		event, err := AddCashOutTransaction(book, wallet, debit, aggregate)
+		err := AddCashOutTransaction(book, wallet, debit, aggregate, "")
 
 		if err != nil {
 			t.Errorf("error returned %v", err)
 		}
 
-		if event.Wallet != wallet {
-			t.Error("DebitTransactionAdded event has incorrect wallet ID")
-		}
+		assertAdded(t, book, aggregate, ledger.TransactionCashOut, wallet, debit)
+	})
+}
 
-		if event.Debit != debit {
-			t.Error("DebitTransactionAdded event has incorrect debit amount")
-		}
+// assertAdded checks the aggregate holds a transaction of the given type and amount on the wallet
+func assertAdded(t *testing.T, book ledger.Book, aggregate string, transactionType string, wallet string, amount ledger.Money) {
+	t.Helper()
 
-		if event.Aggregate != aggregate {
-			t.Error("DebitTransactionAdded event has incorrect aggregate ID")
+	transactions, err := book.AggregateTransactions(aggregate)
+	if err != nil {
+		t.Fatalf("error returned %v", err)
+	}
+
+	for _, tr := range transactions {
+		if tr.Wallet == wallet && tr.Type == transactionType && tr.Amount == amount.Amount && tr.Currency == amount.Currency {
+			return
 		}
-	})
+	}
+
+	t.Errorf("got transactions %v in aggregate %s, wanted a %s of %s on wallet %s", transactions, aggregate, transactionType, amount, wallet)
 }
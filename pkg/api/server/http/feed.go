@@ -0,0 +1,191 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	// lastEventIDHeader is the header an event stream client reconnects with, holding the id of the last event it was sent
+	lastEventIDHeader = "Last-Event-ID"
+	// defaultFeedLimit is how many transactions a page of the feed has when the request doesn't say, maxFeedLimit is the most it can have
+	defaultFeedLimit = 100
+	maxFeedLimit     = 1000
+	// maxFeedWait is the longest a long poll waits for a transaction to be committed
+	maxFeedWait = time.Minute
+	// feedHeartbeatInterval is how often an event stream with nothing to send is sent a comment, so proxies keep it open
+	feedHeartbeatInterval = 15 * time.Second
+)
+
+// feedPageDTO is a page of the feed, cursor is the sequence number of its last transaction, or the cursor asked for when it's empty
+// so it's always what to ask for the next page with
+type feedPageDTO struct {
+	Transactions []ledgerpb.Transaction `json:"transactions"`
+	Cursor       uint64                 `json:"cursor"`
+}
+
+// FeedServer serves the book's transactions in the order they were committed, for consumers tailing the ledger
+// it's served alongside a Server with its route at /feed
+type FeedServer struct {
+	feed ledger.Feed
+	http.Handler
+}
+
+func NewFeedServer(feed ledger.Feed) *FeedServer {
+	s := new(FeedServer)
+
+	s.feed = feed
+
+	router := http.NewServeMux()
+
+	router.HandleFunc("/feed", s.runFeedQuery)
+
+	s.Handler = router
+
+	return s
+}
+
+// runFeedQuery responds with the transactions after the cursor, the sequence number of the last transaction the consumer has handled
+// a request accepting text/event-stream is streamed every transaction as it's committed, resuming from Last-Event-ID when it reconnects
+// any other is responded with a page of up to limit transactions, waiting up to wait seconds for one to be committed when there are none
+func (s *FeedServer) runFeedQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	cursor, err := parseUint(query.Get("cursor"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("accept"), eventStreamContentType) {
+		if id := r.Header.Get(lastEventIDHeader); id != "" {
+			cursor, err = parseUint(id)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		s.streamFeed(w, r, cursor)
+		return
+	}
+
+	limit, err := parseUint(query.Get("limit"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if limit == 0 {
+		limit = defaultFeedLimit
+	} else if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	wait, err := parseUint(query.Get("wait"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	transactions := s.feed.TransactionsAfter(cursor, int(limit))
+
+	if len(transactions) == 0 && wait > 0 {
+		timeout := time.Duration(wait) * time.Second
+		if timeout > maxFeedWait {
+			timeout = maxFeedWait
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		if s.feed.WaitForTransactions(ctx, cursor) == nil {
+			transactions = s.feed.TransactionsAfter(cursor, int(limit))
+		}
+	}
+
+	if n := len(transactions); n > 0 {
+		cursor = transactions[n-1].Sequence
+	}
+
+	respondWithJSON(w, http.StatusOK, feedPageDTO{Transactions: transactions, Cursor: cursor})
+}
+
+// streamFeed sends every transaction after the cursor as an event, then each one as it's committed, until the client goes away
+// each event's id is the transaction's sequence number, so a client that reconnects is sent what it missed and nothing it wasn't
+func (s *FeedServer) streamFeed(w http.ResponseWriter, r *http.Request, cursor uint64) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("content-type", eventStreamContentType)
+	w.Header().Set("cache-control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		transactions := s.feed.TransactionsAfter(cursor, defaultFeedLimit)
+
+		for _, t := range transactions {
+			data, err := json.Marshal(t)
+			if err != nil {
+				return
+			}
+
+			_, err = fmt.Fprintf(w, "id: %d\nevent: transaction\ndata: %s\n\n", t.Sequence, data)
+			if err != nil {
+				return
+			}
+
+			cursor = t.Sequence
+		}
+
+		flusher.Flush()
+
+		if len(transactions) == defaultFeedLimit {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), feedHeartbeatInterval)
+		err := s.feed.WaitForTransactions(ctx, cursor)
+		cancel()
+
+		if r.Context().Err() != nil {
+			return
+		}
+
+		if err != nil {
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// parseUint reads an unsigned number from a query value or header, an empty one is zero
+func parseUint(v string) (uint64, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(v, 10, 64)
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestGETFeed(t *testing.T) {
+	t.Run("returns a page of transactions after the cursor, with the cursor of the next page", func(t *testing.T) {
+		server := NewFeedServer(memory.NewMockInMemoryBook())
+
+		request, _ := http.NewRequest(http.MethodGet, "/feed?cursor=5&limit=4", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+
+		page := getFeedPageFromResponse(t, response)
+		if len(page.Transactions) != 4 || page.Transactions[0].Sequence != 6 || page.Cursor != 9 {
+			t.Errorf("got page %+v, wanted transactions 6 to 9", page)
+		}
+	})
+	t.Run("returns an empty page with the same cursor once the consumer has caught up", func(t *testing.T) {
+		server := NewFeedServer(memory.NewMockInMemoryBook())
+
+		request, _ := http.NewRequest(http.MethodGet, "/feed?cursor=12", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+		test.AssertResponseBody(t, response, "{\"transactions\":[],\"cursor\":12}\n")
+	})
+	t.Run("it should wait for a transaction to be committed when asked to", func(t *testing.T) {
+		book := memory.NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		server := NewFeedServer(book)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		}()
+
+		request, _ := http.NewRequest(http.MethodGet, "/feed?wait=5", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+
+		page := getFeedPageFromResponse(t, response)
+		if len(page.Transactions) != 2 || page.Cursor != 2 {
+			t.Errorf("got page %+v, wanted both legs of the deposit", page)
+		}
+	})
+	t.Run("returns 400 when the cursor isn't a sequence number", func(t *testing.T) {
+		server := NewFeedServer(memory.NewMockInMemoryBook())
+
+		request, _ := http.NewRequest(http.MethodGet, "/feed?cursor=first", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+	t.Run("it should stream transactions as they're committed, resuming after the last event id", func(t *testing.T) {
+		book := memory.NewMockInMemoryBook()
+		server := httptest.NewServer(NewFeedServer(book))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		request, _ := http.NewRequest(http.MethodGet, server.URL+"/feed?cursor=1", nil)
+		request.Header.Set("accept", eventStreamContentType)
+		request.Header.Set(lastEventIDHeader, "10")
+
+		response, err := http.DefaultClient.Do(request.WithContext(ctx))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+		defer response.Body.Close()
+
+		if got := response.Header.Get("content-type"); got != eventStreamContentType {
+			t.Errorf("got content type %q, wanted %q", got, eventStreamContentType)
+		}
+
+		events := bufio.NewReader(response.Body)
+
+		for _, want := range []string{"11", "12"} {
+			if id := readFeedEventID(t, events); id != want {
+				t.Fatalf("got event %s, wanted %s", id, want)
+			}
+		}
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		if id := readFeedEventID(t, events); id != "13" {
+			t.Errorf("got event %s, wanted the deposit", id)
+		}
+	})
+}
+
+func getFeedPageFromResponse(t *testing.T, response *httptest.ResponseRecorder) (page feedPageDTO) {
+	t.Helper()
+
+	err := json.NewDecoder(response.Body).Decode(&page)
+	if err != nil {
+		t.Fatalf("unable to parse response from server %q into a feed page, %v", response.Body, err)
+	}
+
+	return
+}
+
+// readFeedEventID reads an event from the stream, returning its id
+func readFeedEventID(t *testing.T, events *bufio.Reader) (id string) {
+	t.Helper()
+
+	for {
+		line, err := events.ReadString('\n')
+		if err != nil {
+			t.Fatalf("problem reading event, %v", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && id != "":
+			return id
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		}
+	}
+}
@@ -6,12 +6,36 @@ import (
 	"gitlab.com/patchwell/ledger"
 )
 
-// WalletBalance returns the current balance of a wallet based on its transactions
+// WalletBalance returns the current and available balance of a wallet in each currency, based on its transactions
 // returns an error if wallet has no transactions
-func WalletBalance(book ledger.Book, wallet string) (int32, error) {
+func WalletBalance(book ledger.Book, wallet string) ([]ledger.Balance, error) {
 	return book.WalletBalance(wallet)
 }
 
+// AccountBalance returns the current balance of an account along with every account under it in each currency
+// returns an error if the account isn't in the chart of accounts
+func AccountBalance(book ledger.Book, code string) ([]ledger.Money, error) {
+	return book.AccountBalance(code)
+}
+
+// WalletBalanceAt returns the balance of a wallet in each currency at a point in its history
+// returns an error if wallet has no transactions
+func WalletBalanceAt(book ledger.Book, wallet string, at ledger.Point) ([]ledger.Money, error) {
+	return book.WalletBalanceAt(wallet, at)
+}
+
+// QuoteFee returns what a command would charge on taking an amount out of a wallet, without running it
+// returns an error if fees can't be charged on the command
+func QuoteFee(book ledger.Book, command ledger.FeeCommand, wallet string, amount ledger.Money) (ledger.FeeQuote, error) {
+	return book.QuoteFee(command, wallet, amount)
+}
+
+// Wallet returns a wallet's owner, metadata and status
+// returns an error if the wallet hasn't been opened and has no transactions
+func Wallet(book ledger.Book, wallet string) (ledger.Wallet, error) {
+	return book.Wallet(wallet)
+}
+
 func WalletTransactions(book ledger.Book, wallet string) ([]*ledgerpb.Transaction, error) {
 	t, err := book.WalletTransactions(wallet)
 
@@ -31,3 +55,9 @@ func AggregateTransactions(book ledger.Book, aggregate string) ([]*ledgerpb.Tran
 
 	return t, nil
 }
+
+// WalletTransactionsBetween returns the transactions of a wallet recorded after from, up to and including to
+// returns an error if wallet has no transactions
+func WalletTransactionsBetween(book ledger.Book, wallet string, from ledger.Point, to ledger.Point) ([]*ledgerpb.Transaction, error) {
+	return book.WalletTransactionsBetween(wallet, from, to)
+}
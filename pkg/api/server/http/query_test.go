@@ -1,6 +1,8 @@
 package http
 
 import (
+	"gitlab.com/patchwell/ledger"
+	"reflect"
 	"testing"
 
 	"gitlab.com/patchwell/ledger/pkg/book/memory"
@@ -16,7 +18,8 @@ func TestWalletBalance(t *testing.T) {
 	t.Run("should return the balance for a given wallet ID", func(t *testing.T) {
 		book := memory.NewMockInMemoryBook()
 		wallet := "1"
-		expected := int32(100000)
+		usd := ledger.NewMoney(100000, ledger.DefaultCurrency)
+		expected := []ledger.Balance{{Current: usd, Available: usd}}
 
 		balance, err := WalletBalance(book, wallet)
 
@@ -24,8 +27,8 @@ func TestWalletBalance(t *testing.T) {
 			t.Error("WalletBalance returned error: ", err)
 		}
 
-		if balance != expected {
-			t.Errorf("WalletBalance returned %d, should have returned %d", balance, expected)
+		if !reflect.DeepEqual(balance, expected) {
+			t.Errorf("WalletBalance returned %v, should have returned %v", balance, expected)
 		}
 	})
 	t.Run("should return an error if given wallet ID has no transactions", func(t *testing.T) {
@@ -34,8 +37,8 @@ func TestWalletBalance(t *testing.T) {
 
 		balance, err := WalletBalance(book, wallet)
 
-		if balance != 0 {
-			t.Error("balance returned was not a zero balance")
+		if balance != nil {
+			t.Error("balance returned was not nil")
 		}
 
 		if err == nil {
@@ -48,8 +51,8 @@ func TestWalletBalance(t *testing.T) {
 
 		balance, err := WalletBalance(book, wallet)
 
-		if balance != 0 {
-			t.Error("balance returned was not a zero balance")
+		if balance != nil {
+			t.Error("balance returned was not nil")
 		}
 
 		if err == nil {
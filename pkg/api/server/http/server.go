@@ -4,18 +4,157 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"gitlab.com/patchwell/ledger"
 )
 
-const jsonContentType = "application/json"
+const (
+	jsonContentType = "application/json"
+	// idempotencyKeyHeader is the request header commands take their idempotency key from
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// commandResultDTO is what a command responds with, the IDs of what the book recorded for it
+// the command's event isn't in it, it's published by the book to its subscribers
+type commandResultDTO struct {
+	Aggregate string   `json:"aggregate,omitempty"`
+	Reversal  string   `json:"reversal,omitempty"`
+	Refund    string   `json:"refund,omitempty"`
+	Hold      string   `json:"hold,omitempty"`
+	Wallet    string   `json:"wallet,omitempty"`
+	Skipped   []string `json:"skipped,omitempty"` // wallets an interest run couldn't accrue on
+}
 
 type addCreditTransactionDTO struct {
 	Wallet    string `json:"wallet"`
-	Credit    int32  `json:"credit"`
+	Credit    int64  `json:"credit"`
+	Currency  string `json:"currency"`
 	Aggregate string `json:"aggregate"`
 }
 
+type exchangeWalletFundsDTO struct {
+	Source         string `json:"source"`
+	Destination    string `json:"destination"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	TargetCurrency string `json:"target_currency"`
+}
+
+type entryDTO struct {
+	Type     string `json:"type"`
+	Wallet   string `json:"wallet"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type postDTO struct {
+	Entries []entryDTO `json:"entries"`
+}
+
+type reverseAggregateDTO struct {
+	Aggregate string `json:"aggregate"`
+	Reason    string `json:"reason"`
+}
+
+type refundAggregateDTO struct {
+	Aggregate string `json:"aggregate"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+}
+
+type authorizeHoldDTO struct {
+	Wallet    string    `json:"wallet"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	ExpiresAt time.Time `json:"expires_at"` // optional, the book's default expiry is used when it's left out
+}
+
+type captureHoldDTO struct {
+	Hold        string `json:"hold"`
+	Destination string `json:"destination"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+}
+
+type voidHoldDTO struct {
+	Hold string `json:"hold"`
+}
+
+type setOverdraftPolicyDTO struct {
+	Wallet    string `json:"wallet"`
+	Unlimited bool   `json:"unlimited"`
+	Limit     int64  `json:"limit"`
+	Currency  string `json:"currency"`
+}
+
+type openWalletDTO struct {
+	ID       string            `json:"id"` // optional, an ID is generated when it's left out
+	Owner    string            `json:"owner"`
+	Tier     string            `json:"tier"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+type setWalletStatusDTO struct {
+	Wallet string `json:"wallet"`
+	Status string `json:"status"`
+}
+
+// limitsDTO holds a wallet's or tier's limits, amounts are all in the given currency and zero leaves a limit unset
+type limitsDTO struct {
+	MaxSingleOutflow    int64  `json:"max_single_outflow"`
+	MaxDailyOutflow     int64  `json:"max_daily_outflow"`
+	MaxTransfersPerHour int    `json:"max_transfers_per_hour"`
+	MaxBalance          int64  `json:"max_balance"`
+	Currency            string `json:"currency"`
+}
+
+type setWalletLimitsDTO struct {
+	Wallet string    `json:"wallet"`
+	Limits limitsDTO `json:"limits"`
+}
+
+type setTierLimitsDTO struct {
+	Tier   string    `json:"tier"`
+	Limits limitsDTO `json:"limits"`
+}
+
+// limitExceededDTO is the body of a response to a command that would take a wallet past one of its limits
+type limitExceededDTO struct {
+	Error     string `json:"error"`
+	Wallet    string `json:"wallet"`
+	Limit     string `json:"limit"`
+	Max       int64  `json:"max"`
+	Attempted int64  `json:"attempted"`
+	Currency  string `json:"currency,omitempty"`
+}
+
+type setInterestPolicyDTO struct {
+	Wallet string                `json:"wallet"`
+	Policy ledger.InterestPolicy `json:"policy"`
+}
+
+type accrueInterestDTO struct {
+	Through string `json:"through"` // the last day to accrue interest for, laid out like ledger.EffectiveDateLayout
+}
+
+// validationErrorDTO is the body of a response to a command given input that could never be recorded
+type validationErrorDTO struct {
+	Error  string `json:"error"`
+	Field  string `json:"field"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+type defineAccountDTO struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Parent string `json:"parent"`
+}
+
 type Server struct {
 	book ledger.Book
 	http.Handler
@@ -30,9 +169,29 @@ func NewServer(book ledger.Book) *Server {
 
 	// Commands
 	router.HandleFunc("/transaction/credit", s.runAddCreditTransactionCommand)
+	router.HandleFunc("/transfer/exchange", s.runExchangeWalletFundsCommand)
+	router.HandleFunc("/posting", s.runPostCommand)
+	router.HandleFunc("/aggregate/reverse", s.runReverseAggregateCommand)
+	router.HandleFunc("/aggregate/refund", s.runRefundAggregateCommand)
+	router.HandleFunc("/hold/authorize", s.runAuthorizeHoldCommand)
+	router.HandleFunc("/hold/capture", s.runCaptureHoldCommand)
+	router.HandleFunc("/hold/void", s.runVoidHoldCommand)
+	router.HandleFunc("/wallet", s.runOpenWalletCommand)
+	router.HandleFunc("/wallet/status", s.runSetWalletStatusCommand)
+	router.HandleFunc("/wallet/overdraft", s.runSetOverdraftPolicyCommand)
+	router.HandleFunc("/wallet/limits", s.runSetWalletLimitsCommand)
+	router.HandleFunc("/tier/limits", s.runSetTierLimitsCommand)
+	router.HandleFunc("/fees/schedule", s.runSetFeeScheduleCommand)
+	router.HandleFunc("/wallet/interest", s.runSetInterestPolicyCommand)
+	router.HandleFunc("/interest/accrue", s.runAccrueInterestCommand)
+	router.HandleFunc("/account", s.runDefineAccountCommand)
 
 	// Queries
+	router.HandleFunc("/wallets/", s.runWalletQuery)
 	router.HandleFunc("/balance/wallet/", s.runWalletBalanceQuery)
+	router.HandleFunc("/balance/account/", s.runAccountBalanceQuery)
+	router.HandleFunc("/accounts", s.runAccountsQuery)
+	router.HandleFunc("/fees/quote", s.runFeeQuoteQuery)
 	router.HandleFunc("/transactions/aggregate/", s.runAggregateTransactionsQuery)
 	router.HandleFunc("/transactions/wallet/", s.runWalletTransactionsQuery)
 
@@ -51,31 +210,465 @@ func (s *Server) runAddCreditTransactionCommand(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	_, err = AddCreditTransaction(s.book, input.Wallet, input.Credit, input.Aggregate)
+	err = AddCreditTransaction(s.book, input.Wallet, ledger.NewMoney(input.Credit, input.Currency), input.Aggregate, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) runExchangeWalletFundsCommand(w http.ResponseWriter, r *http.Request) {
+	var input exchangeWalletFundsDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	aggregate, err := ExchangeWalletFunds(s.book, input.Source, input.Destination, ledger.NewMoney(input.Amount, input.Currency), input.TargetCurrency, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Aggregate: aggregate})
+}
+
+func (s *Server) runPostCommand(w http.ResponseWriter, r *http.Request) {
+	var input postDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
 
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
+	entries := make([]ledger.Entry, len(input.Entries))
+	for i, e := range input.Entries {
+		entries[i] = ledger.Entry{Type: e.Type, Wallet: e.Wallet, Amount: ledger.NewMoney(e.Amount, e.Currency)}
+	}
+
+	aggregate, err := Post(s.book, entries, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Aggregate: aggregate})
+}
+
+func (s *Server) runReverseAggregateCommand(w http.ResponseWriter, r *http.Request) {
+	var input reverseAggregateDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reversal, err := ReverseAggregate(s.book, input.Aggregate, input.Reason, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Reversal: reversal})
+}
+
+func (s *Server) runRefundAggregateCommand(w http.ResponseWriter, r *http.Request) {
+	var input refundAggregateDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	refund, err := RefundAggregate(s.book, input.Aggregate, ledger.NewMoney(input.Amount, input.Currency), r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Refund: refund})
+}
+
+func (s *Server) runAuthorizeHoldCommand(w http.ResponseWriter, r *http.Request) {
+	var input authorizeHoldDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	hold, err := AuthorizeHold(s.book, input.Wallet, ledger.NewMoney(input.Amount, input.Currency), input.ExpiresAt, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Hold: hold})
+}
+
+func (s *Server) runCaptureHoldCommand(w http.ResponseWriter, r *http.Request) {
+	var input captureHoldDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	aggregate, err := CaptureHold(s.book, input.Hold, input.Destination, ledger.NewMoney(input.Amount, input.Currency), r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Aggregate: aggregate})
+}
+
+func (s *Server) runVoidHoldCommand(w http.ResponseWriter, r *http.Request) {
+	var input voidHoldDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	aggregate, err := VoidHold(s.book, input.Hold, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Aggregate: aggregate})
+}
+
+func (s *Server) runSetOverdraftPolicyCommand(w http.ResponseWriter, r *http.Request) {
+	var input setOverdraftPolicyDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	policy := ledger.OverdraftPolicy{Unlimited: input.Unlimited, Limit: ledger.NewMoney(input.Limit, input.Currency)}
+
+	err = SetOverdraftPolicy(s.book, input.Wallet, policy, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
 	w.WriteHeader(http.StatusAccepted)
 }
 
+func (s *Server) runOpenWalletCommand(w http.ResponseWriter, r *http.Request) {
+	var input openWalletDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	wallet := ledger.Wallet{ID: input.ID, Owner: input.Owner, Tier: input.Tier, Metadata: input.Metadata}
+
+	id, err := OpenWallet(s.book, wallet, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Wallet: id})
+}
+
+func (s *Server) runSetWalletStatusCommand(w http.ResponseWriter, r *http.Request) {
+	var input setWalletStatusDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = SetWalletStatus(s.book, input.Wallet, ledger.WalletStatus(input.Status), r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) runSetWalletLimitsCommand(w http.ResponseWriter, r *http.Request) {
+	var input setWalletLimitsDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = SetWalletLimits(s.book, input.Wallet, input.Limits.limits(), r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) runSetTierLimitsCommand(w http.ResponseWriter, r *http.Request) {
+	var input setTierLimitsDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = SetTierLimits(s.book, input.Tier, input.Limits.limits(), r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) runSetFeeScheduleCommand(w http.ResponseWriter, r *http.Request) {
+	var input ledger.FeeSchedule
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = SetFeeSchedule(s.book, input, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) runSetInterestPolicyCommand(w http.ResponseWriter, r *http.Request) {
+	var input setInterestPolicyDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = SetInterestPolicy(s.book, input.Wallet, input.Policy, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) runAccrueInterestCommand(w http.ResponseWriter, r *http.Request) {
+	var input accrueInterestDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	through, err := time.Parse(ledger.EffectiveDateLayout, input.Through)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	aggregate, skipped, err := AccrueInterest(s.book, through, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	s.respondWithResult(w, commandResultDTO{Aggregate: aggregate, Skipped: skipped})
+}
+
+func (s *Server) runDefineAccountCommand(w http.ResponseWriter, r *http.Request) {
+	var input defineAccountDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	account := ledger.Account{Code: input.Code, Name: input.Name, Type: ledger.AccountType(input.Type), Parent: input.Parent}
+
+	err = DefineAccount(s.book, account, r.Header.Get(idempotencyKeyHeader))
+
+	if err != nil {
+		s.respondWithCommandError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// runWalletBalanceQuery responds with the wallet's current and available balance
+// or, given an at time (RFC 3339) or a sequence number, with its current balance at that point in its history
 func (s *Server) runWalletBalanceQuery(w http.ResponseWriter, r *http.Request) {
 	wallet := r.URL.Path[len("/balance/wallet/"):]
 
+	at, err := parsePoint(r.URL.Query(), "at", "sequence")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !at.IsZero() {
+		balance, err := WalletBalanceAt(s.book, wallet, at)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.respondWithJSON(w, balance)
+		return
+	}
+
 	balance, err := WalletBalance(s.book, wallet)
 
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
+		return
 	}
 
-	fmt.Fprint(w, balance)
+	s.respondWithJSON(w, balance)
 }
 
+func (s *Server) runWalletQuery(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Path[len("/wallets/"):]
+
+	wallet, err := Wallet(s.book, id)
+
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.respondWithJSON(w, wallet)
+}
+
+func (s *Server) runAccountBalanceQuery(w http.ResponseWriter, r *http.Request) {
+	code := r.URL.Path[len("/balance/account/"):]
+
+	balance, err := AccountBalance(s.book, code)
+
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	s.respondWithJSON(w, balance)
+}
+
+func (s *Server) runAccountsQuery(w http.ResponseWriter, r *http.Request) {
+	s.respondWithJSON(w, s.book.Accounts())
+}
+
+// runFeeQuoteQuery responds with what a command would charge on an amount before running it
+// given the command, wallet, amount and currency in the query
+func (s *Server) runFeeQuoteQuery(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	amount, err := strconv.ParseInt(query.Get("amount"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	quote, err := QuoteFee(s.book, ledger.FeeCommand(query.Get("command")), query.Get("wallet"), ledger.NewMoney(amount, query.Get("currency")))
+
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.respondWithJSON(w, quote)
+}
+
+// runWalletTransactionsQuery responds with the wallet's transactions, those recorded after from and up to and including to
+// when either is given as a time (RFC 3339) or from_sequence and to_sequence as sequence numbers
 func (s *Server) runWalletTransactionsQuery(w http.ResponseWriter, r *http.Request) {
 	wallet := r.URL.Path[len("/transactions/wallet/"):]
 
+	from, err := parsePoint(r.URL.Query(), "from", "from_sequence")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	to, err := parsePoint(r.URL.Query(), "to", "to_sequence")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !from.IsZero() || !to.IsZero() {
+		transactions, err := WalletTransactionsBetween(s.book, wallet, from, to)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.respondWithJSON(w, transactions)
+		return
+	}
+
 	transactions, err := WalletTransactions(s.book, wallet)
 
 	if err != nil {
@@ -99,6 +692,50 @@ func (s *Server) runAggregateTransactionsQuery(w http.ResponseWriter, r *http.Re
 	s.respondWithJSON(w, transactions)
 }
 
+// limits reads the limits, amounts left at zero stay unset
+func (l limitsDTO) limits() ledger.Limits {
+	money := func(amount int64) ledger.Money {
+		if amount == 0 {
+			return ledger.Money{}
+		}
+
+		return ledger.NewMoney(amount, l.Currency)
+	}
+
+	return ledger.Limits{
+		MaxSingleOutflow:    money(l.MaxSingleOutflow),
+		MaxDailyOutflow:     money(l.MaxDailyOutflow),
+		MaxTransfersPerHour: l.MaxTransfersPerHour,
+		MaxBalance:          money(l.MaxBalance),
+	}
+}
+
+// parsePoint reads a point in the book's history from the query, a time (RFC 3339) under one key or a sequence number under the other
+// a query with neither is a zero point
+func parsePoint(query url.Values, timeKey string, sequenceKey string) (ledger.Point, error) {
+	var p ledger.Point
+
+	if v := query.Get(timeKey); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return p, fmt.Errorf("problem parsing %s, %v", timeKey, err)
+		}
+
+		p.Time = t
+	}
+
+	if v := query.Get(sequenceKey); v != "" {
+		sequence, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return p, fmt.Errorf("problem parsing %s, %v", sequenceKey, err)
+		}
+
+		p.Sequence = sequence
+	}
+
+	return p, p.Validate()
+}
+
 func (s *Server) respondWithJSON(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("content-type", jsonContentType)
 
@@ -108,3 +745,49 @@ func (s *Server) respondWithJSON(w http.ResponseWriter, data interface{}) {
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+// respondWithResult accepts a command, responding with what the book recorded for it
+func (s *Server) respondWithResult(w http.ResponseWriter, result commandResultDTO) {
+	w.Header().Set("content-type", jsonContentType)
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(result)
+}
+
+// respondWithCommandError rejects a failed command, a limit breach or invalid input is described in the body
+// so clients can tell which limit or field it was
+func (s *Server) respondWithCommandError(w http.ResponseWriter, err error) {
+	var body interface{}
+
+	switch e := err.(type) {
+	case *ledger.LimitExceededError:
+		body = limitExceededDTO{
+			Error:     "limit_exceeded",
+			Wallet:    e.Wallet,
+			Limit:     string(e.Limit),
+			Max:       e.Max,
+			Attempted: e.Attempted,
+			Currency:  e.Currency,
+		}
+	case *ledger.ValidationError:
+		body = validationErrorDTO{Error: "invalid_input", Field: e.Field, Value: e.Value, Reason: e.Reason}
+	default:
+		w.WriteHeader(commandErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("content-type", jsonContentType)
+	w.WriteHeader(commandErrorStatus(err))
+	json.NewEncoder(w).Encode(body)
+}
+
+// commandErrorStatus returns the status code to respond to a failed command with
+func commandErrorStatus(err error) int {
+	switch err.(type) {
+	case *ledger.IdempotencyConflictError:
+		return http.StatusConflict
+	case *ledger.InsufficientFundsError, *ledger.WalletStatusError, *ledger.LimitExceededError:
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusBadRequest
+}
@@ -5,14 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"gitlab.com/patchwell/ledger"
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
 	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	ratememory "gitlab.com/patchwell/ledger/pkg/rate/memory"
 	"gitlab.com/patchwell/ledger/pkg/test"
 )
 
@@ -27,7 +30,7 @@ func TestGETWalletBalance(t *testing.T) {
 		server.ServeHTTP(response, request)
 
 		test.AssertResponseStatus(t, response, http.StatusOK)
-		test.AssertResponseBody(t, response, "100000")
+		test.AssertResponseBody(t, response, "[{\"current\":{\"amount\":100000,\"currency\":\"USD\"},\"available\":{\"amount\":100000,\"currency\":\"USD\"}}]\n")
 	})
 	t.Run("returns the current balance of wallet '2'", func(t *testing.T) {
 		request := newGetWalletBalanceRequest("2")
@@ -36,7 +39,24 @@ func TestGETWalletBalance(t *testing.T) {
 		server.ServeHTTP(response, request)
 
 		test.AssertResponseStatus(t, response, http.StatusOK)
-		test.AssertResponseBody(t, response, "8000")
+		test.AssertResponseBody(t, response, "[{\"current\":{\"amount\":8000,\"currency\":\"USD\"},\"available\":{\"amount\":8000,\"currency\":\"USD\"}}]\n")
+	})
+	t.Run("returns the balance of wallet '2' at a sequence number", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/balance/wallet/2?sequence=5", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+		test.AssertResponseBody(t, response, "[{\"amount\":9000,\"currency\":\"USD\"}]\n")
+	})
+	t.Run("returns 400 when given both a time and a sequence number", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/balance/wallet/2?sequence=5&at=2019-03-31T23:59:00Z", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
 	})
 	t.Run("returns 404 when wallet is not found", func(t *testing.T) {
 		request := newGetWalletBalanceRequest("-99")
@@ -52,9 +72,9 @@ func TestGETWalletTransactions(t *testing.T) {
 	book := memory.NewMockInMemoryBook()
 	server := NewServer(book)
 	wantedTransactions := []ledgerpb.Transaction{
-		{Type: ledger.TransactionCredit, Wallet: "2", Amount: 10000, Aggregate: "1112"},
-		{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Aggregate: "1113"},
-		{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Aggregate: "1114"},
+		{Type: ledger.TransactionCredit, Wallet: "2", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1112", Id: "00000000-0000-0000-0000-000000000004", Sequence: 4},
+		{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1113", Id: "00000000-0000-0000-0000-000000000005", Sequence: 5},
+		{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1114", Id: "00000000-0000-0000-0000-000000000009", Sequence: 9},
 	}
 
 	t.Run("returns all transactions for the given wallet", func(t *testing.T) {
@@ -78,6 +98,34 @@ func TestGETWalletTransactions(t *testing.T) {
 		test.AssertResponseStatus(t, response, http.StatusOK)
 		test.AssertResponseContentType(t, response, jsonContentType)
 	})
+	t.Run("returns the transactions for the given wallet between two sequence numbers", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/transactions/wallet/2?from_sequence=4&to_sequence=9", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		var transactions []ledgerpb.Transaction
+
+		err := json.NewDecoder(response.Body).Decode(&transactions)
+
+		if err != nil {
+			t.Errorf("unable to parse response from server '%s' into slice of Transaction, '%v'", response.Body, err)
+		}
+
+		if !reflect.DeepEqual(transactions, wantedTransactions[1:]) {
+			t.Error("response did not contain the expected transactions")
+		}
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+	})
+	t.Run("returns 400 if a time can't be parsed", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/transactions/wallet/2?from=yesterday", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
 	t.Run("returns 404 if wallet has no transactions", func(t *testing.T) {
 		request := newGetWalletTransactionsRequest("99")
 		response := httptest.NewRecorder()
@@ -92,7 +140,7 @@ func TestGETAggregateTransactions(t *testing.T) {
 	book := memory.NewMockInMemoryBook()
 	server := NewServer(book)
 	wantedTransactions := []ledgerpb.Transaction{
-		{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 100000, Aggregate: "1111"},
+		{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 100000, Currency: ledger.DefaultCurrency, Aggregate: "1111", Id: "00000000-0000-0000-0000-000000000001", Sequence: 1},
 	}
 
 	t.Run("returns all transactions for the given aggregate", func(t *testing.T) {
@@ -127,7 +175,7 @@ func TestPOSTCreditTransaction(t *testing.T) {
 	server := NewServer(book)
 
 	t.Run("it should add an additional transaction to the ledger book", func(t *testing.T) {
-		request := newPostCreditTransactionRequest("1", 100, "2222")
+		request := newPostCreditTransactionRequest("1", 100, ledger.DefaultCurrency, "2222")
 		response := httptest.NewRecorder()
 
 		want := len(book.Transactions()) + 1
@@ -153,6 +201,437 @@ func TestPOSTCreditTransaction(t *testing.T) {
 	})
 }
 
+func TestPOSTCreditTransactionValidation(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should return 400 naming the field of a transaction that isn't valid", func(t *testing.T) {
+		request := newPostCreditTransactionRequest("1", -100, ledger.DefaultCurrency, "2222")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+		test.AssertResponseBody(t, response, "{\"error\":\"invalid_input\",\"field\":\"amount\",\"value\":\"-100\",\"reason\":\"must be greater than zero\"}\n")
+	})
+}
+
+func TestPOSTExchangeWalletFunds(t *testing.T) {
+	rates := ratememory.NewInMemoryRates()
+	rates.SetRate(ledger.DefaultCurrency, "EUR", big.NewRat(92, 100))
+
+	book := memory.NewInMemoryBook(
+		memory.WithTransactions(memory.NewMockInMemoryBook().Transactions()),
+		memory.WithRateProvider(rates),
+	)
+	server := NewServer(book)
+
+	t.Run("it should exchange the funds at the rate and respond with the exchange's aggregate", func(t *testing.T) {
+		request := newPostExchangeWalletFundsRequest("1", "2", 10000, ledger.DefaultCurrency, "EUR")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+		test.AssertResponseContentType(t, response, jsonContentType)
+
+		var got commandResultDTO
+
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Fatalf("unable to parse response from server '%s' into commandResultDTO, '%v'", response.Body, err)
+		}
+
+		ts, err := book.AggregateTransactions(got.Aggregate)
+		if err != nil {
+			t.Fatalf("no transactions for aggregate '%s', %v", got.Aggregate, err)
+		}
+
+		test.AssertTransactionCount(t, ts, 2)
+
+		for _, tr := range ts {
+			if tr.GetType() == ledger.TransactionCredit && (tr.GetAmount() != 9200 || tr.GetCurrency() != "EUR" || tr.GetRate() != "0.92") {
+				t.Errorf("got credit of %d %s at %s, wanted 9200 EUR at 0.92", tr.GetAmount(), tr.GetCurrency(), tr.GetRate())
+			}
+		}
+	})
+	t.Run("it should return 400 when there's no rate between the currencies", func(t *testing.T) {
+		request := newPostExchangeWalletFundsRequest("1", "2", 10000, ledger.DefaultCurrency, "GBP")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestPOSTPosting(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should post every entry under one aggregate", func(t *testing.T) {
+		book.OpenWallet(ledger.Wallet{ID: "seller"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "platform"}, "")
+
+		request := newPostPostingRequest([]entryDTO{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency},
+			{Type: ledger.TransactionCredit, Wallet: "seller", Amount: 9000, Currency: ledger.DefaultCurrency},
+			{Type: ledger.TransactionCredit, Wallet: "platform", Amount: 1000, Currency: ledger.DefaultCurrency},
+		})
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		var got commandResultDTO
+
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Fatalf("unable to parse response from server '%s' into commandResultDTO, '%v'", response.Body, err)
+		}
+
+		ts, err := book.AggregateTransactions(got.Aggregate)
+		if err != nil {
+			t.Fatalf("no transactions for aggregate '%s', %v", got.Aggregate, err)
+		}
+
+		test.AssertTransactionCount(t, ts, 3)
+	})
+	t.Run("it should return 400 when the entries don't balance", func(t *testing.T) {
+		request := newPostPostingRequest([]entryDTO{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency},
+			{Type: ledger.TransactionCredit, Wallet: "seller", Amount: 9000, Currency: ledger.DefaultCurrency},
+		})
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestPOSTReverseAggregate(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should reverse the aggregate and respond with the reversal", func(t *testing.T) {
+		request := newPostReverseAggregateRequest("1113", "sent to the wrong wallet")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		var got commandResultDTO
+
+		err := json.NewDecoder(response.Body).Decode(&got)
+		if err != nil {
+			t.Fatalf("unable to parse response from server '%s' into commandResultDTO, '%v'", response.Body, err)
+		}
+
+		ts, err := book.AggregateTransactions(got.Reversal)
+		if err != nil {
+			t.Fatalf("no transactions for reversal '%s', %v", got.Reversal, err)
+		}
+
+		test.AssertTransactionCount(t, ts, 4)
+	})
+	t.Run("it should return 400 when the aggregate was already reversed", func(t *testing.T) {
+		request := newPostReverseAggregateRequest("1113", "sent to the wrong wallet")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestPOSTRefundAggregate(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should refund the aggregate up to its original amount", func(t *testing.T) {
+		request := newPostRefundAggregateRequest("1111", 60000, ledger.DefaultCurrency)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		request = newPostRefundAggregateRequest("1111", 50000, ledger.DefaultCurrency)
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestPOSTHold(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should authorize a hold then capture part of it", func(t *testing.T) {
+		body, _ := json.Marshal(authorizeHoldDTO{Wallet: "1", Amount: 40000, Currency: ledger.DefaultCurrency})
+		request, _ := http.NewRequest(http.MethodPost, "/hold/authorize", bytes.NewBuffer(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		var authorized commandResultDTO
+
+		err := json.NewDecoder(response.Body).Decode(&authorized)
+		if err != nil {
+			t.Fatalf("unable to parse response from server '%s' into commandResultDTO, '%v'", response.Body, err)
+		}
+
+		ts, err := book.AggregateTransactions(authorized.Hold)
+		if err != nil {
+			t.Fatalf("no transactions for hold '%s', %v", authorized.Hold, err)
+		}
+
+		for _, tr := range ts {
+			if expiresAt := time.Unix(0, tr.GetExpiresAt()); tr.GetType() == ledger.TransactionHold && !expiresAt.After(time.Now()) {
+				t.Errorf("got hold expiring at %v, wanted a time in the future", expiresAt)
+			}
+		}
+
+		body, _ = json.Marshal(captureHoldDTO{Hold: authorized.Hold, Destination: "3", Amount: 25000, Currency: ledger.DefaultCurrency})
+		request, _ = http.NewRequest(http.MethodPost, "/hold/capture", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		body, _ = json.Marshal(voidHoldDTO{Hold: authorized.Hold})
+		request, _ = http.NewRequest(http.MethodPost, "/hold/void", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestPOSTSetOverdraftPolicy(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should return 422 when a wallet can't cover a hold, until it's given an overdraft", func(t *testing.T) {
+		body, _ := json.Marshal(authorizeHoldDTO{Wallet: "1", Amount: 150000, Currency: ledger.DefaultCurrency})
+		request, _ := http.NewRequest(http.MethodPost, "/hold/authorize", bytes.NewBuffer(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusUnprocessableEntity)
+
+		policy, _ := json.Marshal(setOverdraftPolicyDTO{Wallet: "1", Limit: 50000, Currency: ledger.DefaultCurrency})
+		request, _ = http.NewRequest(http.MethodPost, "/wallet/overdraft", bytes.NewBuffer(policy))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		request, _ = http.NewRequest(http.MethodPost, "/hold/authorize", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+	})
+}
+
+func TestPOSTWalletLimits(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should return 422 with the limit in the body when a posting takes a wallet past it", func(t *testing.T) {
+		limits, _ := json.Marshal(setWalletLimitsDTO{Wallet: "1", Limits: limitsDTO{MaxSingleOutflow: 5000, Currency: ledger.DefaultCurrency}})
+		request, _ := http.NewRequest(http.MethodPost, "/wallet/limits", bytes.NewBuffer(limits))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		body, _ := json.Marshal(postDTO{Entries: []entryDTO{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 6000, Currency: ledger.DefaultCurrency},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: 6000, Currency: ledger.DefaultCurrency},
+		}})
+		request, _ = http.NewRequest(http.MethodPost, "/posting", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusUnprocessableEntity)
+		test.AssertResponseContentType(t, response, jsonContentType)
+		test.AssertResponseBody(t, response, "{\"error\":\"limit_exceeded\",\"wallet\":\"1\",\"limit\":\"max_single_outflow\",\"max\":5000,\"attempted\":6000,\"currency\":\"USD\"}\n")
+	})
+}
+
+func TestFees(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+
+	t.Run("it should set a fee schedule then quote its fee on a transfer", func(t *testing.T) {
+		body, _ := json.Marshal(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 30, BasisPoints: 100})
+		request, _ := http.NewRequest(http.MethodPost, "/fees/schedule", bytes.NewBuffer(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		request, _ = http.NewRequest(http.MethodGet, "/fees/quote?command=transfer&wallet=1&amount=10000&currency=USD", nil)
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+		test.AssertResponseBody(t, response, "{\"amount\":{\"amount\":10000,\"currency\":\"USD\"},\"fee\":{\"amount\":130,\"currency\":\"USD\"},\"total\":{\"amount\":10130,\"currency\":\"USD\"},\"wallet\":\"fees\"}\n")
+	})
+	t.Run("it should return 400 for a quote on a command fees aren't charged on", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/fees/quote?command=deposit&wallet=1&amount=10000&currency=USD", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestInterest(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+
+	t.Run("it should set an interest policy then accrue interest through yesterday", func(t *testing.T) {
+		policy := ledger.InterestPolicy{Rate: "0.01", Currency: ledger.DefaultCurrency, DayCount: ledger.DayCountActual365, Rounding: ledger.RoundHalfUp, Posting: ledger.InterestDaily, Source: "interest"}
+
+		body, _ := json.Marshal(setInterestPolicyDTO{Wallet: "1", Policy: policy})
+		request, _ := http.NewRequest(http.MethodPost, "/wallet/interest", bytes.NewBuffer(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+		test.AssertResponseBody(t, response, "")
+
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(ledger.EffectiveDateLayout)
+
+		body, _ = json.Marshal(accrueInterestDTO{Through: yesterday})
+		request, _ = http.NewRequest(http.MethodPost, "/interest/accrue", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+		test.AssertResponseBody(t, response, "{}\n")
+	})
+	t.Run("it should return 400 for accruing interest on a day that hasn't ended", func(t *testing.T) {
+		body, _ := json.Marshal(accrueInterestDTO{Through: time.Now().UTC().Format(ledger.EffectiveDateLayout)})
+		request, _ := http.NewRequest(http.MethodPost, "/interest/accrue", bytes.NewBuffer(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+}
+
+func TestPOSTWallet(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should open a wallet, freeze it and return 422 for funds taken out of it", func(t *testing.T) {
+		body, _ := json.Marshal(openWalletDTO{ID: "10", Owner: "alice", Metadata: map[string]string{"tier": "gold"}})
+		request, _ := http.NewRequest(http.MethodPost, "/wallet", bytes.NewBuffer(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+		test.AssertResponseBody(t, response, "{\"wallet\":\"10\"}\n")
+
+		body, _ = json.Marshal(setWalletStatusDTO{Wallet: "1", Status: string(ledger.WalletFrozen)})
+		request, _ = http.NewRequest(http.MethodPost, "/wallet/status", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusAccepted)
+
+		body, _ = json.Marshal(postDTO{Entries: []entryDTO{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency},
+			{Type: ledger.TransactionCredit, Wallet: "10", Amount: 1000, Currency: ledger.DefaultCurrency},
+		}})
+		request, _ = http.NewRequest(http.MethodPost, "/posting", bytes.NewBuffer(body))
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusUnprocessableEntity)
+	})
+	t.Run("it should return a wallet, or 404 for one it doesn't know", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/wallets/1", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+		test.AssertResponseBody(t, response, "{\"id\":\"1\",\"owner\":\"\",\"status\":\"frozen\"}\n")
+
+		request, _ = http.NewRequest(http.MethodGet, "/wallets/99", nil)
+		response = httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusNotFound)
+	})
+}
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	book := memory.NewMockInMemoryBook()
+	server := NewServer(book)
+
+	t.Run("it should only add the transaction once when a request is retried", func(t *testing.T) {
+		want := len(book.Transactions()) + 1
+
+		for i := 0; i < 2; i++ {
+			request := newPostCreditTransactionRequest("1", 100, ledger.DefaultCurrency, "2222")
+			request.Header.Set(idempotencyKeyHeader, "key-1")
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, request)
+
+			test.AssertResponseStatus(t, response, http.StatusAccepted)
+		}
+
+		got := len(book.Transactions())
+		if got != want {
+			t.Errorf("ledger book has invalid transaction count, got %d, wanted %d", got, want)
+		}
+	})
+	t.Run("it should return 409 when the key was used for a different request", func(t *testing.T) {
+		request := newPostCreditTransactionRequest("1", 200, ledger.DefaultCurrency, "2222")
+		request.Header.Set(idempotencyKeyHeader, "key-1")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusConflict)
+	})
+}
+
 func newGetWalletBalanceRequest(wallet string) *http.Request {
 	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/balance/wallet/%s", wallet), nil)
 	return req
@@ -168,10 +647,11 @@ func newGetAggregateTransactionsRequest(aggregate string) *http.Request {
 	return req
 }
 
-func newPostCreditTransactionRequest(wallet string, credit int32, aggregate string) *http.Request {
+func newPostCreditTransactionRequest(wallet string, credit int64, currency string, aggregate string) *http.Request {
 	payload := addCreditTransactionDTO{
 		Wallet:    wallet,
 		Credit:    credit,
+		Currency:  currency,
 		Aggregate: aggregate,
 	}
 	body, _ := json.Marshal(payload)
@@ -179,6 +659,37 @@ func newPostCreditTransactionRequest(wallet string, credit int32, aggregate stri
 	return req
 }
 
+func newPostExchangeWalletFundsRequest(source string, destination string, amount int64, currency string, targetCurrency string) *http.Request {
+	payload := exchangeWalletFundsDTO{
+		Source:         source,
+		Destination:    destination,
+		Amount:         amount,
+		Currency:       currency,
+		TargetCurrency: targetCurrency,
+	}
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, "/transfer/exchange", bytes.NewBuffer(body))
+	return req
+}
+
+func newPostPostingRequest(entries []entryDTO) *http.Request {
+	body, _ := json.Marshal(postDTO{Entries: entries})
+	req, _ := http.NewRequest(http.MethodPost, "/posting", bytes.NewBuffer(body))
+	return req
+}
+
+func newPostReverseAggregateRequest(aggregate string, reason string) *http.Request {
+	body, _ := json.Marshal(reverseAggregateDTO{Aggregate: aggregate, Reason: reason})
+	req, _ := http.NewRequest(http.MethodPost, "/aggregate/reverse", bytes.NewBuffer(body))
+	return req
+}
+
+func newPostRefundAggregateRequest(aggregate string, amount int64, currency string) *http.Request {
+	body, _ := json.Marshal(refundAggregateDTO{Aggregate: aggregate, Amount: amount, Currency: currency})
+	req, _ := http.NewRequest(http.MethodPost, "/aggregate/refund", bytes.NewBuffer(body))
+	return req
+}
+
 func getTransactionsFromResponse(t *testing.T, body io.Reader) (transactions []ledgerpb.Transaction) {
 	t.Helper()
 	err := json.NewDecoder(body).Decode(&transactions)
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/webhook"
+)
+
+type createWebhookDTO struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"` // optional, the webhook is sent every event when it's left out
+	Secret string   `json:"secret"` // optional, one is generated when it's left out
+}
+
+// webhookDTO is a webhook as it's responded with, its secret is only given when it's created
+type webhookDTO struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events,omitempty"`
+	Secret    string   `json:"secret,omitempty"`
+	CreatedAt int64    `json:"created_at"`
+}
+
+type redeliverWebhookDTO struct {
+	Delivery string `json:"delivery"`
+}
+
+// WebhookServer manages the webhooks the book's events are delivered to, it's served alongside a Server
+// with its routes under /webhooks
+type WebhookServer struct {
+	webhooks *webhook.Dispatcher
+	http.Handler
+}
+
+func NewWebhookServer(webhooks *webhook.Dispatcher) *WebhookServer {
+	s := new(WebhookServer)
+
+	s.webhooks = webhooks
+
+	router := http.NewServeMux()
+
+	router.HandleFunc("/webhooks", s.runWebhooks)
+	router.HandleFunc("/webhooks/dead-letters", s.runDeadLettersQuery)
+	router.HandleFunc("/webhooks/redeliver", s.runRedeliverWebhookCommand)
+	router.HandleFunc("/webhooks/", s.runDeleteWebhookCommand)
+
+	s.Handler = router
+
+	return s
+}
+
+// runWebhooks creates a webhook given a POST, responding with it and its secret, or lists every webhook given a GET
+func (s *WebhookServer) runWebhooks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		webhooks, err := s.webhooks.Webhooks()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		dtos := make([]webhookDTO, len(webhooks))
+		for i, wh := range webhooks {
+			dtos[i] = webhookDTO{ID: wh.ID, URL: wh.URL, Events: wh.Events, CreatedAt: wh.CreatedAt}
+		}
+
+		respondWithJSON(w, http.StatusOK, dtos)
+	case http.MethodPost:
+		var input createWebhookDTO
+
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		wh, err := s.webhooks.Subscribe(input.URL, input.Events, input.Secret)
+		if err != nil {
+			respondWithWebhookError(w, err)
+			return
+		}
+
+		respondWithJSON(w, http.StatusCreated, webhookDTO{ID: wh.ID, URL: wh.URL, Events: wh.Events, Secret: wh.Secret, CreatedAt: wh.CreatedAt})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// runDeleteWebhookCommand deletes the webhook given a DELETE of /webhooks/{id}, dropping its deliveries
+func (s *WebhookServer) runDeleteWebhookCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	err := s.webhooks.Unsubscribe(r.URL.Path[len("/webhooks/"):])
+	if err != nil {
+		respondWithWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// runDeadLettersQuery responds with the deliveries that ran out of attempts
+func (s *WebhookServer) runDeadLettersQuery(w http.ResponseWriter, r *http.Request) {
+	dead, err := s.webhooks.DeadLetters()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, dead)
+}
+
+// runRedeliverWebhookCommand queues a delivery to be tried again with all its attempts
+func (s *WebhookServer) runRedeliverWebhookCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var input redeliverWebhookDTO
+
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = s.webhooks.Redeliver(input.Delivery)
+	if err != nil {
+		respondWithWebhookError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func respondWithJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("content-type", jsonContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// respondWithWebhookError rejects a failed webhook command, describing invalid input in the body
+func respondWithWebhookError(w http.ResponseWriter, err error) {
+	switch e := err.(type) {
+	case *ledger.ValidationError:
+		respondWithJSON(w, http.StatusBadRequest, validationErrorDTO{Error: "invalid_input", Field: e.Field, Value: e.Value, Reason: e.Reason})
+	case *webhook.NotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	"gitlab.com/patchwell/ledger/pkg/test"
+	"gitlab.com/patchwell/ledger/pkg/webhook"
+)
+
+func TestWebhooks(t *testing.T) {
+	server := NewWebhookServer(webhook.NewDispatcher(memory.NewInMemoryBook(memory.WithOutbox()), webhook.NewMemoryStore()))
+
+	var created webhookDTO
+
+	t.Run("returns 201 with the webhook and its secret once it's created", func(t *testing.T) {
+		request := newPostWebhookRequest(createWebhookDTO{URL: "https://example.com/hook", Events: []string{"wallet_funds_deposited"}, Secret: "secret"})
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusCreated)
+
+		json.NewDecoder(response.Body).Decode(&created)
+
+		if created.ID == "" || created.Secret != "secret" || created.URL != "https://example.com/hook" {
+			t.Errorf("got webhook %+v, wanted the one created", created)
+		}
+	})
+	t.Run("returns 400 when the url isn't an http URL", func(t *testing.T) {
+		request := newPostWebhookRequest(createWebhookDTO{URL: "example.com/hook"})
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusBadRequest)
+	})
+	t.Run("returns the webhooks without their secrets", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/webhooks", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+
+		var webhooks []webhookDTO
+		json.NewDecoder(response.Body).Decode(&webhooks)
+
+		if len(webhooks) != 1 || webhooks[0].ID != created.ID || webhooks[0].Secret != "" {
+			t.Errorf("got webhooks %+v, wanted the one created without its secret", webhooks)
+		}
+	})
+	t.Run("returns no dead letters when nothing has failed", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/webhooks/dead-letters", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusOK)
+		test.AssertResponseBody(t, response, "[]\n")
+	})
+	t.Run("returns 404 when redelivering an unknown delivery", func(t *testing.T) {
+		body, _ := json.Marshal(redeliverWebhookDTO{Delivery: "unknown"})
+		request, _ := http.NewRequest(http.MethodPost, "/webhooks/redeliver", bytes.NewReader(body))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusNotFound)
+	})
+	t.Run("returns 204 once the webhook is deleted and 404 when it's deleted again", func(t *testing.T) {
+		for _, status := range []int{http.StatusNoContent, http.StatusNotFound} {
+			request, _ := http.NewRequest(http.MethodDelete, "/webhooks/"+created.ID, nil)
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, request)
+
+			test.AssertResponseStatus(t, response, status)
+		}
+	})
+	t.Run("returns 405 when given another method", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPut, "/webhooks", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+
+		test.AssertResponseStatus(t, response, http.StatusMethodNotAllowed)
+	})
+}
+
+func newPostWebhookRequest(input createWebhookDTO) *http.Request {
+	body, _ := json.Marshal(input)
+	request, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(body))
+	return request
+}
@@ -1,191 +1,485 @@
 package file
 
 import (
-	"crypto/rand"
-	"encoding/json"
-	"errors"
 	"fmt"
+	"log"
 	"os"
+	"sync"
+	"time"
 
 	"gitlab.com/patchwell/ledger"
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
 )
 
-func initializeFile(file *os.File) error {
-	file.Seek(0, 0)
+// Book is a ledger.Book persisted to an append-only journal file
+// the in memory book does the bookkeeping and locking, every batch of transactions it commits
+// is appended to the journal first, so nothing is added to the book unless it made it to the file
+// snapshots of the book are saved next to the journal, so opening it only replays records newer than the latest snapshot
+type Book struct {
+	*memory.Book
+	journal      *journal
+	snapshotting sync.Mutex // serializes saving snapshots, which is done without holding the book's lock
+	snapshotted  uint64     // sequence number of the last record in the latest snapshot, only used with snapshotting held
+	done         chan struct{}
+	closeOnce    sync.Once
+}
 
-	info, err := file.Stat()
+// Option configures a Book as it is created
+type Option func(*config)
+
+type config struct {
+	sync             SyncPolicy
+	snapshotInterval time.Duration
+	currency         string
+	rates            ledger.RateProvider
+	retention        time.Duration
+	expiryInterval   time.Duration
+	interestInterval time.Duration
+	cashAccount      string
+	publisher        ledger.Publisher
+	outbox           bool
+	readOnly         bool
+}
 
-	if err != nil {
-		return fmt.Errorf("error when info from file %s, %v", file.Name(), err)
+// WithSyncPolicy sets how often the journal is flushed to stable storage, the default is SyncAlways
+func WithSyncPolicy(policy SyncPolicy) Option {
+	return func(c *config) {
+		c.sync = policy
 	}
+}
 
-	if info.Size() == 0 {
-		file.Write([]byte("[]"))
-		file.Seek(0, 0)
+// WithSnapshotInterval has the book save a snapshot every interval, if anything was committed since the last one
+// by default snapshots are only saved when Snapshot or Compact is called
+func WithSnapshotInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.snapshotInterval = interval
 	}
+}
 
-	return nil
+// WithDefaultCurrency sets the currency given to amounts that were stored before the book tracked currencies,
+// the default is ledger.DefaultCurrency
+func WithDefaultCurrency(currency string) Option {
+	return func(c *config) {
+		c.currency = currency
+	}
 }
 
-type Book struct {
-	database     *json.Encoder
-	transactions []ledgerpb.Transaction
-	walletMap    map[string][]*ledgerpb.Transaction
-	aggregateMap map[string][]*ledgerpb.Transaction
+// WithRateProvider sets where the book looks up exchange rates, without one it can't exchange funds between currencies
+func WithRateProvider(rates ledger.RateProvider) Option {
+	return func(c *config) {
+		c.rates = rates
+	}
 }
 
-func (b *Book) TransferWalletFunds(source string, destination string, amount int32) (string, error) {
-	return "", nil
+// WithIdempotencyRetention sets how long idempotency keys are remembered for, the default is memory.DefaultIdempotencyRetention
+// keys are saved along with the transactions, so a command retried after the book is reopened still isn't run twice
+func WithIdempotencyRetention(retention time.Duration) Option {
+	return func(c *config) {
+		c.retention = retention
+	}
 }
 
-func (b *Book) DepositWalletFunds(wallet string, deposit int32) (string, error) {
-	return "", nil
+// WithHoldExpiryInterval has the book release expired holds every interval, recording when they lapsed
+// expired holds stop counting against a wallet's available balance either way, by default they're only released when ExpireHolds is called
+func WithHoldExpiryInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.expiryInterval = interval
+	}
 }
 
-func (b *Book) WithdrawWalletFunds(wallet string, withdraw int32) (string, error) {
-	return "", nil
+// WithInterestInterval has the book accrue interest every interval for the days that have ended since it last ran
+// a day is only accrued once however often it runs, by default interest is only accrued when AccrueInterest is called
+func WithInterestInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.interestInterval = interval
+	}
 }
 
-func NewFileSystemBook(file *os.File) (*Book, error) {
-	err := initializeFile(file)
+// WithCashAccount sets the account deposits and withdrawals are posted against, see memory.WithCashAccount
+func WithCashAccount(account string) Option {
+	return func(c *config) {
+		c.cashAccount = account
+	}
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("problem initializing file for book, %v", err)
+// WithPublisher has the book hand the events of every command to the publisher once it's in the journal, see memory.WithPublisher
+// commits replayed from the journal as the book is opened aren't published again
+func WithPublisher(publisher ledger.Publisher) Option {
+	return func(c *config) {
+		c.publisher = publisher
 	}
+}
 
-	b := &Book{
-		database:     json.NewEncoder(&tape{file}),
-		transactions: []ledgerpb.Transaction{},
-		walletMap:    make(map[string][]*ledgerpb.Transaction),
-		aggregateMap: make(map[string][]*ledgerpb.Transaction),
+// WithOutbox has the book write the events of every command to its outbox in the same journal record as the command
+// messages that haven't been acknowledged are kept in snapshots, so they're still in the outbox once the book is reopened
+func WithOutbox() Option {
+	return func(c *config) {
+		c.outbox = true
+	}
+}
+
+// WithReadOnly opens the book without writing to its file or the files next to it, so it can be checked while a server has it open
+// a torn record at the end of the journal is skipped rather than trimmed and a legacy file isn't migrated,
+// commands and snapshots return ErrReadOnly and nothing is done periodically, the file can be opened with os.O_RDONLY
+func WithReadOnly() Option {
+	return func(c *config) {
+		c.readOnly = true
+	}
+}
+
+// NewFileSystemBook returns a Book loaded from the given file, which is written to as new transactions are added
+// a file holding the legacy JSON array format is migrated to a journal the first time it's opened
+// the file must not be opened with os.O_APPEND, records are written at explicit offsets
+func NewFileSystemBook(file *os.File, options ...Option) (*Book, error) {
+	c := &config{sync: SyncAlways, currency: ledger.DefaultCurrency, retention: memory.DefaultIdempotencyRetention}
+
+	for _, option := range options {
+		option(c)
 	}
 
-	err = b.loadTransactions(file)
+	s, _, err := readSnapshot(file.Name() + snapshotSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("problem loading snapshot, %v", err)
+	}
 
+	segments, err := archiveSegments(file.Name())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("problem loading transactions, %v", err)
 	}
 
-	return b, nil
-}
+	// snapshots saved before transactions were left out of them already hold every transaction they cover
+	legacy := len(s.State.Transactions) > 0
+
+	// segments the snapshot covers are only read once one of their transactions is needed,
+	// snapshots saved before the time of the last transaction was kept with them can't tell the book which transactions are too old to count against its limits
+	covered := 0
+	if !legacy && s.State.RecordedAt != 0 {
+		for covered < len(segments) && segments[covered].last <= s.Sequence {
+			covered++
+		}
+	}
 
-func (b *Book) AddTransaction(transactionType string, wallet string, amount int32, aggregate string) error {
-	t := ledgerpb.Transaction{
-		Type:      transactionType,
-		Wallet:    wallet,
-		Amount:    amount,
-		Aggregate: aggregate,
+	archived, err := readSegments(segments[covered:])
+	if err != nil {
+		return nil, fmt.Errorf("problem loading transactions, %v", err)
 	}
 
-	b.transactions = append(b.transactions, t)
-	b.addMapEntries(t)
+	var j *journal
+	var records []record
 
-	b.database.Encode(b.transactions)
+	if c.readOnly {
+		j, records, err = readJournal(file)
+	} else {
+		j, records, err = openJournal(file, c.sync)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("problem loading transactions, %v", err)
+	}
 
-	return nil
-}
+	// a crash while compacting can leave records in the journal that were already written to the last segment
+	var lastArchived uint64
+	if len(segments) > 0 {
+		lastArchived = segments[len(segments)-1].last
+		records, err = skipArchived(j, records, lastArchived)
+		if err != nil {
+			return nil, fmt.Errorf("problem loading transactions, %v", err)
+		}
+	}
 
-func (b *Book) WalletBalance(wallet string) (int32, error) {
-	var balance int32
+	read := append(archived, records...)
+
+	archivedCount := 0
+	if covered > 0 {
+		last := segments[covered-1].last
+
+		switch {
+		case len(read) > 0 && read[0].Sequence != last+1:
+			return nil, fmt.Errorf("problem loading transactions, archive segment %s ends on record %d but the next record is %d", segments[covered-1].path, last, read[0].Sequence)
+		case len(read) == 0 && last != s.Sequence:
+			return nil, fmt.Errorf("problem loading transactions, the snapshot covers %d records but the journal and its archive hold %d", s.Sequence, last)
+		case len(read) > 0 && read[0].Sequence <= s.Sequence:
+			archivedCount = read[0].Position
+		default:
+			archivedCount = s.Position
+		}
+	}
 
-	ts, err := b.WalletTransactions(wallet)
+	position := archivedCount + len(s.State.Transactions)
+	commits := []memory.Commit{}
 
-	if err != nil {
-		return balance, err
-	}
-
-	for _, v := range ts {
-		if v.GetWallet() == wallet {
-			switch v.GetType() {
-			case ledger.TransactionCredit:
-				balance += v.GetAmount()
-			case ledger.TransactionDebit:
-				balance -= v.GetAmount()
-			case ledger.TransactionCashIn:
-				balance += v.GetAmount()
-			case ledger.TransactionCashOut:
-				balance -= v.GetAmount()
+	for _, r := range read {
+		// records already included in the snapshot only add their transactions, some hold none so it's told by their sequence number
+		if r.Sequence <= s.Sequence {
+			if legacy {
+				continue
 			}
+
+			if r.Position != position {
+				return nil, fmt.Errorf("problem loading transactions, record %d starts at transaction %d but the book holds %d", r.Sequence, r.Position, position)
+			}
+
+			s.State.Transactions = append(s.State.Transactions, r.Transactions...)
+			position += len(r.Transactions)
+			continue
+		}
+
+		if r.Position != position {
+			return nil, fmt.Errorf("problem loading transactions, record %d starts at transaction %d but the book holds %d", r.Sequence, r.Position, position)
 		}
+
+		commits = append(commits, r.Commit)
+		position += len(r.Transactions)
 	}
 
-	return balance, nil
-}
+	if !legacy && archivedCount+len(s.State.Transactions) != s.Position {
+		return nil, fmt.Errorf("problem loading transactions, the snapshot covers %d transactions but the journal and its archive hold %d", s.Position, archivedCount+len(s.State.Transactions))
+	}
 
-func (b *Book) WalletTransactions(wallet string) ([]*ledgerpb.Transaction, error) {
-	if t, ok := b.walletMap[wallet]; ok {
-		return t, nil
-	} else {
-		return nil, errors.New("no transactions for wallet (" + wallet + ")")
+	s.State.Archived = archivedCount
+	migrateCurrency(s.State, commits, c.currency)
+
+	j.resume(s.Sequence, position)
+	j.resume(lastArchived, position)
+
+	b := &Book{
+		journal:     j,
+		snapshotted: s.Sequence,
+		done:        make(chan struct{}),
+	}
+	bookOptions := []memory.Option{
+		// the retention window has to be set before replaying commits, which forget expired keys as they go
+		memory.WithIdempotencyRetention(c.retention),
+		// and so does the cash account, its balance is worked out as an asset's
+		memory.WithCashAccount(c.cashAccount),
+		memory.WithState(s.State),
+		memory.WithArchive(archiveLoader(segments[:covered], c.currency)),
+		memory.WithCommits(commits),
+		memory.WithCommitHook(j.append),
+		memory.WithRateProvider(c.rates),
+		memory.WithPublisher(c.publisher),
 	}
-}
 
-func (b *Book) AggregateTransactions(aggregate string) ([]*ledgerpb.Transaction, error) {
-	if t, ok := b.aggregateMap[aggregate]; ok {
-		return t, nil
-	} else {
-		return nil, errors.New("no transactions for aggregate (" + aggregate + ")")
+	if c.outbox {
+		bookOptions = append(bookOptions, memory.WithOutbox())
+	}
+
+	b.Book = memory.NewInMemoryBook(bookOptions...)
+
+	if c.readOnly {
+		return b, nil
+	}
+
+	if c.snapshotInterval > 0 {
+		go b.snapshotEvery(c.snapshotInterval)
 	}
+
+	if c.expiryInterval > 0 {
+		go b.expireHoldsEvery(c.expiryInterval)
+	}
+
+	if c.interestInterval > 0 {
+		go b.accrueInterestEvery(c.interestInterval)
+	}
+
+	return b, nil
+}
+
+// Sync flushes every committed transaction to stable storage, only needed when the sync policy isn't SyncAlways
+func (b *Book) Sync() error {
+	return b.journal.flush()
 }
 
-func (b *Book) Transactions() []ledgerpb.Transaction {
-	return b.transactions
+// Snapshot saves the book's current state next to its journal, commits only wait while the state is copied, not while it's written
+func (b *Book) Snapshot() error {
+	b.snapshotting.Lock()
+	defer b.snapshotting.Unlock()
+
+	if b.journal.readOnly {
+		return ErrReadOnly
+	}
+
+	var sequence uint64
+
+	state, err := b.Book.Checkpoint(func() error {
+		sequence = b.journal.last()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("problem saving snapshot, %v", err)
+	}
+
+	if sequence == b.snapshotted {
+		return nil
+	}
+
+	// every record the snapshot covers has to be on stable storage before it is, they hold its transactions
+	err = b.journal.flush()
+	if err != nil {
+		return fmt.Errorf("problem saving snapshot, %v", err)
+	}
+
+	position := state.Archived + len(state.Transactions)
+	state.Transactions = nil
+	state.Archived = 0
+
+	err = writeSnapshot(b.journal.file.Name()+snapshotSuffix, snapshot{Sequence: sequence, Position: position, State: state})
+	if err != nil {
+		return fmt.Errorf("problem saving snapshot, %v", err)
+	}
+
+	b.snapshotted = sequence
+
+	return nil
 }
 
-func (b *Book) loadTransactions(file *os.File) error {
-	err := json.NewDecoder(file).Decode(&b.transactions)
+// Compact saves a snapshot then moves every record in the journal to an archive segment next to it
+// the journal is left empty, opening the book reads the transactions in the segments back along with it
+func (b *Book) Compact() error {
+	err := b.Snapshot()
 	if err != nil {
-		err = fmt.Errorf("problem parsing transactions, %v", err)
 		return err
 	}
 
-	for _, t := range b.transactions {
-		b.addMapEntries(t)
+	err = b.journal.archive()
+	if err != nil {
+		return fmt.Errorf("problem compacting journal, %v", err)
 	}
 
 	return nil
 }
 
-func (b *Book) addWalletMapEntry(transaction ledgerpb.Transaction) {
-	// create wallet map entry if necessary
-	if _, ok := b.walletMap[transaction.GetWallet()]; !ok {
-		b.walletMap[transaction.GetWallet()] = []*ledgerpb.Transaction{}
+// skipArchived leaves out the records at the start of the journal that are already in the archive, emptying the journal if it holds nothing else
+// they're only left there by a crash between writing a segment and emptying the journal, so the records after them start where the segment ends
+func skipArchived(j *journal, records []record, lastArchived uint64) ([]record, error) {
+	skipped := 0
+	for skipped < len(records) && records[skipped].Sequence <= lastArchived {
+		skipped++
+	}
+
+	if skipped == 0 {
+		return records, nil
+	}
+
+	if skipped < len(records) {
+		return nil, fmt.Errorf("journal holds records %d to %d after archiving up to record %d", records[0].Sequence, records[len(records)-1].Sequence, lastArchived)
+	}
+
+	if !j.readOnly {
+		err := j.clear()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// append transaction pointer to wallet's slice of transactions
-	b.walletMap[transaction.GetWallet()] = append(b.walletMap[transaction.GetWallet()], &transaction)
+	return nil, nil
 }
 
-func (b *Book) addAggregateMapEntry(transaction ledgerpb.Transaction) {
-	// create aggregate map entry if necessary
-	if _, ok := b.aggregateMap[transaction.GetAggregate()]; !ok {
-		b.aggregateMap[transaction.GetAggregate()] = []*ledgerpb.Transaction{}
+// archiveLoader returns a function reading the transactions in the segments, for the book to call once it needs them
+func archiveLoader(segments []segment, currency string) func() ([]ledgerpb.Transaction, error) {
+	return func() ([]ledgerpb.Transaction, error) {
+		records, err := readSegments(segments)
+		if err != nil {
+			return nil, err
+		}
+
+		transactions := []ledgerpb.Transaction{}
+
+		for _, r := range records {
+			if r.Position != len(transactions) {
+				return nil, fmt.Errorf("record %d starts at transaction %d but the archive holds %d", r.Sequence, r.Position, len(transactions))
+			}
+
+			transactions = append(transactions, r.Transactions...)
+		}
+
+		defaultCurrency(transactions, currency)
+
+		return transactions, nil
 	}
+}
+
+// Close stops saving periodic snapshots, releasing expired holds and accruing interest, and flushes the journal, the file itself is left open for the caller to close
+func (b *Book) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.done)
+	})
 
-	// append transaction pointer to aggregate's slice of transactions
-	b.aggregateMap[transaction.GetAggregate()] = append(b.aggregateMap[transaction.GetAggregate()], &transaction)
+	return b.Sync()
+}
+
+func (b *Book) snapshotEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := b.Snapshot()
+			if err != nil {
+				log.Printf("problem taking periodic snapshot of %s, %v", b.journal.file.Name(), err)
+			}
+		case <-b.done:
+			return
+		}
+	}
 }
 
-func (b *Book) addMapEntries(transaction ledgerpb.Transaction) {
-	b.addWalletMapEntry(transaction)
-	b.addAggregateMapEntry(transaction)
+func (b *Book) expireHoldsEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := b.ExpireHolds()
+			if err != nil {
+				log.Printf("problem releasing expired holds in %s, %v", b.journal.file.Name(), err)
+			}
+		case <-b.done:
+			return
+		}
+	}
 }
 
-func (b *Book) addTransactions(transactions []ledgerpb.Transaction) {
-	b.transactions = append(b.transactions, transactions...)
+func (b *Book) accrueInterestEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	for _, t := range transactions {
-		b.addMapEntries(t)
+	for {
+		select {
+		case <-ticker.C:
+			_, err := b.AccrueInterest(time.Now().AddDate(0, 0, -1), "")
+			if err != nil {
+				log.Printf("problem accruing interest in %s, %v", b.journal.file.Name(), err)
+			}
+		case <-b.done:
+			return
+		}
 	}
 }
 
-func genUUID() (string, error) {
-	b := make([]byte, 16)
-	_, err := rand.Read(b)
-	if err != nil {
-		return "", fmt.Errorf("error when generating uuid: %v", err)
+// migrateCurrency gives every transaction and balance stored without a currency the default currency
+func migrateCurrency(state memory.State, commits []memory.Commit, currency string) {
+	defaultCurrency(state.Transactions, currency)
+
+	for _, c := range commits {
+		defaultCurrency(c.Transactions, currency)
+	}
+
+	for _, balances := range state.Balances {
+		if balance, ok := balances[""]; ok {
+			delete(balances, "")
+			balances[currency] = ledger.NewMoney(balance.Amount, currency)
+		}
 	}
+}
 
-	return fmt.Sprintf("%X-%X-%X-%X-%X", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+// defaultCurrency sets the currency of transactions recorded before the book held more than one
+func defaultCurrency(transactions []ledgerpb.Transaction, currency string) {
+	for i := range transactions {
+		if transactions[i].Currency == "" {
+			transactions[i].Currency = currency
+		}
+	}
 }
@@ -1,10 +1,22 @@
 package file
 
 import (
-	"gitlab.com/patchwell/ledger"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
+	"gitlab.com/patchwell/ledger"
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	"gitlab.com/patchwell/ledger/pkg/event"
+	ratememory "gitlab.com/patchwell/ledger/pkg/rate/memory"
 	"gitlab.com/patchwell/ledger/pkg/test"
 )
 
@@ -20,7 +32,8 @@ func TestBook_AddTransaction(t *testing.T) {
 			t.Errorf("error returned when creating file system book, %v", err)
 		}
 
-		err = book.AddTransaction(ledger.TransactionCredit, "2", 50000, "1112")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		err = book.AddTransaction(ledger.TransactionCredit, "2", ledger.NewMoney(50000, ledger.DefaultCurrency), "1112", "")
 		if err != nil {
 			t.Errorf("error returned from adding transaction, %v", err)
 		}
@@ -30,13 +43,263 @@ func TestBook_AddTransaction(t *testing.T) {
 			t.Errorf("error when reloading file, %v", err)
 		}
 
-		transactions := newBook.transactions
+		transactions := newBook.Transactions()
+
+		want := []ledgerpb.Transaction{
+			{Type: "credit", Wallet: "2", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: "1112"},
+		}
+
+		test.AssertRecordedTransactions(t, transactions, want)
+	})
+	t.Run("should refuse a transaction of an unknown type without writing it to file", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, data, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error returned when creating file system book, %v", err)
+		}
+
+		err = book.AddTransaction("invalid", "2", ledger.NewMoney(50000, ledger.DefaultCurrency), "1112", "")
+		if _, ok := err.(*ledger.ValidationError); !ok {
+			t.Errorf("got error %v, wanted a ValidationError", err)
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error when reloading file, %v", err)
+		}
+
+		test.AssertRecordedTransactions(t, newBook.Transactions(), []ledgerpb.Transaction{})
+	})
+}
+
+func TestBook_TransferWalletFunds(t *testing.T) {
+	data := `[
+		{"type": "cash in", "wallet": "1", "amount": 100000, "aggregate": "1111"}]`
+
+	t.Run("should write a debit and a credit transaction to file", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, data, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+		aggregate, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(40000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned from transferring wallet funds, %v", err)
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error when reloading file, %v", err)
+		}
+
+		want := []ledgerpb.Transaction{
+			{Type: "cash in", Wallet: "1", Amount: 100000, Currency: ledger.DefaultCurrency, Aggregate: "1111"},
+			{Type: "debit", Wallet: "1", Amount: 40000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: "credit", Wallet: "2", Amount: 40000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+		}
+
+		test.AssertRecordedTransactions(t, newBook.Transactions(), want)
+
+		balance, err := newBook.WalletBalance("2")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(40000, ledger.DefaultCurrency)})
+	})
+	t.Run("should return an error and write nothing when the source has insufficient balance", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, data, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+		_, err = book.TransferWalletFunds("1", "2", ledger.NewMoney(200000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error when reloading file, %v", err)
+		}
+
+		want := []ledgerpb.Transaction{
+			{Type: "cash in", Wallet: "1", Amount: 100000, Currency: ledger.DefaultCurrency, Aggregate: "1111"},
+		}
+
+		test.AssertRecordedTransactions(t, newBook.Transactions(), want)
+	})
+}
+
+func TestBook_TransferWalletFundsConcurrently(t *testing.T) {
+	data := `[
+		{"type": "cash in", "wallet": "1", "amount": 10000, "aggregate": "1111"}]`
+
+	t.Run("should never let parallel transfers overdraw the source wallet", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, data, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+			}()
+			go func() {
+				defer wg.Done()
+				book.WalletBalance("1")
+				book.WalletTransactions("2")
+				book.Transactions()
+			}()
+		}
+
+		wg.Wait()
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error when reloading file, %v", err)
+		}
+
+		balance, err := newBook.WalletBalance("1")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(0, ledger.DefaultCurrency)})
+
+		balance, err = newBook.WalletBalance("2")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(10000, ledger.DefaultCurrency)})
+	})
+}
+
+func TestBook_ExchangeWalletFunds(t *testing.T) {
+	t.Run("should reload balances held in several currencies, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		rates := ratememory.NewInMemoryRates()
+		rates.SetRate(ledger.DefaultCurrency, "EUR", big.NewRat(92, 100))
+
+		book, err := NewFileSystemBook(database, WithRateProvider(rates))
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		_, err = book.ExchangeWalletFunds("1", "1", ledger.NewMoney(20000, ledger.DefaultCurrency), "EUR", "")
+		if err != nil {
+			t.Fatalf("error returned when exchanging wallet funds, %v", err)
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newBook, book, "1")
+
+		err = newBook.Snapshot()
+		if err != nil {
+			t.Fatalf("error returned when saving snapshot, %v", err)
+		}
+
+		newestBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		balance, _ := newestBook.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(18400, "EUR"), ledger.NewMoney(30000, ledger.DefaultCurrency)})
+	})
+}
+
+func TestBook_DepositWalletFunds(t *testing.T) {
+	t.Run("should write a cash in transaction to file", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, `[]`, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		aggregate, err := book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned from depositing wallet funds, %v", err)
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error when reloading file, %v", err)
+		}
+
+		want := []ledgerpb.Transaction{
+			{Type: "cash in", Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: "debit", Wallet: memory.DefaultCashAccount, Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+		}
+
+		test.AssertRecordedTransactions(t, newBook.Transactions(), want)
+	})
+}
+
+func TestBook_WithdrawWalletFunds(t *testing.T) {
+	data := `[
+		{"type": "cash in", "wallet": "1", "amount": 100000, "aggregate": "1111"}]`
+
+	t.Run("should write a cash out transaction to file", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, data, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error returned when creating file system book, %v", err)
+		}
+
+		aggregate, err := book.WithdrawWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned from withdrawing wallet funds, %v", err)
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Errorf("error when reloading file, %v", err)
+		}
 
 		want := []ledgerpb.Transaction{
-			{Type: "credit", Wallet: "2", Amount: 50000, Aggregate: "1112"},
+			{Type: "cash in", Wallet: "1", Amount: 100000, Currency: ledger.DefaultCurrency, Aggregate: "1111"},
+			{Type: "cash out", Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: "credit", Wallet: memory.DefaultCashAccount, Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
 		}
 
-		test.AssertTransactions(t, transactions, want)
+		test.AssertRecordedTransactions(t, newBook.Transactions(), want)
 	})
 }
 
@@ -60,8 +323,8 @@ func TestBook_WalletTransactions(t *testing.T) {
 		}
 
 		want := []ledgerpb.Transaction{
-			{Type: "credit", Wallet: "1", Amount: 100000, Aggregate: "1111"},
-			{Type: "debit", Wallet: "1", Amount: 50000, Aggregate: "1112"},
+			{Type: "credit", Wallet: "1", Amount: 100000, Currency: ledger.DefaultCurrency, Aggregate: "1111"},
+			{Type: "debit", Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: "1112"},
 		}
 
 		test.AssertTransactionCount(t, transactions, len(want))
@@ -87,7 +350,7 @@ func TestBook_WalletBalance(t *testing.T) {
 			t.Errorf("returned error, %v", err)
 		}
 
-		want := int32(50000)
+		want := []ledger.Money{ledger.NewMoney(50000, ledger.DefaultCurrency)}
 
 		test.AssertWalletBalance(t, balance, want)
 	})
@@ -114,10 +377,986 @@ func TestBook_AggregateTransactions(t *testing.T) {
 		}
 
 		want := []*ledgerpb.Transaction{
-			{Type: "debit", Wallet: "1", Amount: 50000, Aggregate: "1112"},
-			{Type: "credit", Wallet: "2", Amount: 50000, Aggregate: "1112"},
+			{Type: "debit", Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: "1112", Id: "00000000-0000-0000-0000-000000000002", Sequence: 2},
+			{Type: "credit", Wallet: "2", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: "1112", Id: "00000000-0000-0000-0000-000000000003", Sequence: 3},
 		}
 
 		test.AssertTransactionPointers(t, ts, want)
 	})
 }
+
+func TestBook_Snapshot(t *testing.T) {
+	t.Run("should reload the snapshot along with records committed after it", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "3"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+
+		err = book.Snapshot()
+		if err != nil {
+			t.Fatalf("error returned when saving snapshot, %v", err)
+		}
+
+		book.TransferWalletFunds("2", "3", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newBook, book, "1", "2", "3")
+	})
+	t.Run("should work out balances again for a snapshot saved without them", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		state := memory.State{
+			Transactions: []ledgerpb.Transaction{
+				{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: "1111"},
+				{Type: ledger.TransactionDebit, Wallet: "1", Amount: 20000, Currency: ledger.DefaultCurrency, Aggregate: "1112"},
+				{Type: ledger.TransactionCredit, Wallet: "2", Amount: 20000, Currency: ledger.DefaultCurrency, Aggregate: "1112"},
+			},
+			Wallets:    map[string][]int{"1": {0, 1}, "2": {2}},
+			Aggregates: map[string][]int{"1111": {0}, "1112": {1, 2}},
+		}
+
+		err := writeSnapshot(database.Name()+snapshotSuffix, snapshot{Sequence: 2, State: state})
+		if err != nil {
+			t.Fatalf("unable to write snapshot, %v", err)
+		}
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(30000, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("2")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(20000, ledger.DefaultCurrency)})
+	})
+	t.Run("should save snapshots periodically when given an interval", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database, WithSnapshotInterval(time.Millisecond))
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+		defer book.Close()
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			s, ok, err := readSnapshot(database.Name() + snapshotSuffix)
+			if err != nil {
+				t.Fatalf("error returned when reading snapshot, %v", err)
+			}
+			if ok && s.Sequence == 2 {
+				if len(s.State.Transactions) != 0 {
+					t.Errorf("got %d transactions in the snapshot, wanted them left in the journal", len(s.State.Transactions))
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("no snapshot was saved")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+func TestBook_Compact(t *testing.T) {
+	t.Run("should archive the journal without changing any balance or query result", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "3"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+
+		err = book.Compact()
+		if err != nil {
+			t.Fatalf("error returned when compacting, %v", err)
+		}
+
+		info, _ := database.Stat()
+		if info.Size() != 0 {
+			t.Errorf("journal was not emptied by compaction, it still holds %d bytes", info.Size())
+		}
+
+		segments, _ := filepath.Glob(database.Name() + ".*" + archiveSuffix)
+		if len(segments) != 1 {
+			t.Errorf("got incorrect number of archive segments, got %d, wanted %d", len(segments), 1)
+		}
+
+		book.TransferWalletFunds("2", "3", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newBook, book, "1", "2", "3")
+
+		_, err = newBook.TransferWalletFunds("3", "1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned from transferring wallet funds after compaction, %v", err)
+		}
+
+		newestBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newestBook, newBook, "1", "2", "3")
+	})
+	t.Run("should refuse to open a book whose snapshot covers transactions missing from the journal and its archive", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		err = book.Compact()
+		if err != nil {
+			t.Fatalf("error returned when compacting, %v", err)
+		}
+
+		segments, _ := filepath.Glob(database.Name() + ".*" + archiveSuffix)
+		for _, segment := range segments {
+			os.Remove(segment)
+		}
+
+		_, err = NewFileSystemBook(database)
+		if err == nil {
+			t.Error("no error returned for a snapshot whose transactions are missing")
+		}
+	})
+	t.Run("should open a book whose compaction crashed after writing the segment, skipping the records left in the journal", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+
+		journal, _ := ioutil.ReadFile(database.Name())
+
+		err = book.Compact()
+		if err != nil {
+			t.Fatalf("error returned when compacting, %v", err)
+		}
+
+		// the journal as it was before it was emptied
+		database.WriteAt(journal, 0)
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newBook, book, "1", "2")
+
+		info, _ := database.Stat()
+		if info.Size() != 0 {
+			t.Errorf("journal was not emptied as the book was opened, it still holds %d bytes", info.Size())
+		}
+
+		_, err = newBook.TransferWalletFunds("2", "1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned from transferring wallet funds after reopening, %v", err)
+		}
+
+		newestBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newestBook, newBook, "1", "2")
+	})
+	t.Run("should open a compacted book without reading its archive until one of its transactions is needed", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+
+		err = book.Compact()
+		if err != nil {
+			t.Fatalf("error returned when compacting, %v", err)
+		}
+
+		book.TransferWalletFunds("2", "1", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+
+		// a segment that can't be read is only noticed once the book reads it
+		segments, _ := filepath.Glob(database.Name() + ".*" + archiveSuffix)
+		contents, _ := ioutil.ReadFile(segments[0])
+		ioutil.WriteFile(segments[0], []byte("not a record\n"), 0644)
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		balance, _ := newBook.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(35000, ledger.DefaultCurrency)})
+
+		_, err = newBook.WalletTransactions("1")
+		if err == nil {
+			t.Error("no error returned for transactions in an archive that can't be read")
+		}
+
+		ioutil.WriteFile(segments[0], contents, 0644)
+
+		assertBooksMatch(t, newBook, book, "1", "2")
+	})
+}
+
+func assertRetried(t *testing.T, book ledger.Book, aggregate string, deposit ledger.Money) {
+	t.Helper()
+
+	count := len(book.Transactions())
+
+	got, err := book.DepositWalletFunds("1", deposit, "key-1")
+	if err != nil {
+		t.Fatalf("returned error on retry, %v", err)
+	}
+
+	if got != aggregate {
+		t.Errorf("retry returned a different aggregate, got '%s', wanted '%s'", got, aggregate)
+	}
+
+	if len(book.Transactions()) != count {
+		t.Errorf("retry added transactions, book holds %d, wanted %d", len(book.Transactions()), count)
+	}
+}
+
+func assertBooksMatch(t *testing.T, got, want ledger.Book, wallets ...string) {
+	t.Helper()
+
+	test.AssertTransactions(t, got.Transactions(), want.Transactions())
+
+	for _, w := range wallets {
+		gotBalance, _ := got.WalletBalance(w)
+		wantBalance, _ := want.WalletBalance(w)
+		if !reflect.DeepEqual(gotBalance, wantBalance) {
+			t.Errorf("got balance of wallet '%s' %v, wanted %v", w, gotBalance, wantBalance)
+		}
+
+		gotTransactions, _ := got.WalletTransactions(w)
+		wantTransactions, _ := want.WalletTransactions(w)
+		test.AssertTransactionPointers(t, gotTransactions, wantTransactions)
+	}
+}
+
+func TestBook_ReadOnly(t *testing.T) {
+	t.Run("should read a journal opened read only without trimming its torn record or taking commands", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		// a server part way through appending a record
+		database.WriteAt([]byte(`{"crc":12,"record":{"seq":2,"transa`), book.journal.offset)
+
+		contents, _ := ioutil.ReadFile(database.Name())
+
+		f, err := os.Open(database.Name())
+		if err != nil {
+			t.Fatalf("unable to open file read only, %v", err)
+		}
+		defer f.Close()
+
+		readOnly, err := NewFileSystemBook(f, WithReadOnly())
+		if err != nil {
+			t.Fatalf("error returned when opening book read only, %v", err)
+		}
+
+		balance, _ := readOnly.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(50000, ledger.DefaultCurrency)})
+
+		_, err = readOnly.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned when depositing to a read only book")
+		}
+
+		err = readOnly.Snapshot()
+		if err != ErrReadOnly {
+			t.Errorf("got error %v when saving a snapshot, wanted %v", err, ErrReadOnly)
+		}
+
+		assertFileContents(t, database.Name(), string(contents))
+	})
+	t.Run("should read a legacy file opened read only without migrating it", func(t *testing.T) {
+		legacy := `[{"type": "credit", "wallet": "1", "amount": 100000, "aggregate": "1111"}]`
+		database, clean := test.CreateTempFile(t, legacy, "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database, WithReadOnly())
+		if err != nil {
+			t.Fatalf("error returned when opening book read only, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)})
+
+		assertFileContents(t, database.Name(), legacy)
+
+		if _, err := os.Stat(database.Name() + legacyBackupSuffix); !os.IsNotExist(err) {
+			t.Errorf("got a legacy backup, wanted the file left alone")
+		}
+	})
+}
+
+func TestBook_ReverseAggregate(t *testing.T) {
+	t.Run("should remember reversed and refunded aggregates once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		reversed, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+		refunded, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+
+		book.ReverseAggregate(reversed, "sent twice", "")
+		book.RefundAggregate(refunded, ledger.NewMoney(6000, ledger.DefaultCurrency), "")
+
+		for i := 0; i < 2; i++ {
+			newBook, err := NewFileSystemBook(database)
+			if err != nil {
+				t.Fatalf("error when reloading file, %v", err)
+			}
+
+			assertBooksMatch(t, newBook, book, "1", "2")
+
+			_, err = newBook.ReverseAggregate(reversed, "sent twice", "")
+			if err == nil {
+				t.Error("no error returned reversing an aggregate twice")
+			}
+
+			_, err = newBook.RefundAggregate(refunded, ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+			if err == nil {
+				t.Error("no error returned refunding more than the original amount")
+			}
+
+			err = newBook.Snapshot()
+			if err != nil {
+				t.Fatalf("error returned when saving snapshot, %v", err)
+			}
+		}
+	})
+}
+
+func TestBook_AuthorizeHold(t *testing.T) {
+	t.Run("should keep open holds once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		open, _ := book.AuthorizeHold("1", ledger.NewMoney(20000, ledger.DefaultCurrency), time.Time{}, "")
+		voided, _ := book.AuthorizeHold("1", ledger.NewMoney(10000, ledger.DefaultCurrency), time.Time{}, "")
+		book.VoidHold(voided, "")
+
+		for i := 0; i < 2; i++ {
+			newBook, err := NewFileSystemBook(database)
+			if err != nil {
+				t.Fatalf("error when reloading file, %v", err)
+			}
+
+			balance, _ := newBook.WalletBalance("1")
+			test.AssertAvailableBalance(t, balance, []ledger.Money{ledger.NewMoney(30000, ledger.DefaultCurrency)})
+
+			_, err = newBook.VoidHold(voided, "")
+			if err == nil {
+				t.Error("no error returned voiding a hold twice")
+			}
+
+			err = newBook.Snapshot()
+			if err != nil {
+				t.Fatalf("error returned when saving snapshot, %v", err)
+			}
+		}
+
+		newBook, _ := NewFileSystemBook(database)
+
+		_, err = newBook.CaptureHold(open, "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned capturing a hold after reopening, %v", err)
+		}
+	})
+}
+
+func TestBook_SetOverdraftPolicy(t *testing.T) {
+	t.Run("should keep overdraft policies once reopened, including ones set after the latest snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		err = book.Snapshot()
+		if err != nil {
+			t.Fatalf("error returned when saving snapshot, %v", err)
+		}
+
+		book.SetOverdraftPolicy("1", ledger.OverdraftLimit(ledger.NewMoney(500, ledger.DefaultCurrency)), "")
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		_, err = newBook.WithdrawWalletFunds("1", ledger.NewMoney(1500, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("error returned withdrawing within the overdraft limit, %v", err)
+		}
+
+		_, err = newBook.WithdrawWalletFunds("1", ledger.NewMoney(1, ledger.DefaultCurrency), "")
+		if _, ok := err.(*ledger.InsufficientFundsError); !ok {
+			t.Errorf("got error %v, wanted an InsufficientFundsError", err)
+		}
+	})
+}
+
+func TestBook_OpenWallet(t *testing.T) {
+	t.Run("should keep opened wallets and their status once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1", Owner: "alice", Metadata: map[string]string{"tier": "gold"}}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2", Owner: "bob"}, "")
+		book.SetWalletStatus("2", ledger.WalletFrozen, "")
+
+		for i := 0; i < 2; i++ {
+			newBook, err := NewFileSystemBook(database)
+			if err != nil {
+				t.Fatalf("error when reloading file, %v", err)
+			}
+
+			for _, id := range []string{"1", "2"} {
+				got, _ := newBook.Wallet(id)
+				want, _ := book.Wallet(id)
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("got wallet %v, wanted %v", got, want)
+				}
+			}
+
+			err = newBook.Snapshot()
+			if err != nil {
+				t.Fatalf("error returned when saving snapshot, %v", err)
+			}
+		}
+	})
+}
+
+func TestBook_SetWalletLimits(t *testing.T) {
+	t.Run("should keep wallet and tier limits once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "2", Tier: "basic"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.SetWalletLimits("1", ledger.Limits{MaxSingleOutflow: ledger.NewMoney(5000, ledger.DefaultCurrency)}, "")
+		book.SetTierLimits("basic", ledger.Limits{MaxTransfersPerHour: 3}, "")
+
+		for i := 0; i < 2; i++ {
+			newBook, err := NewFileSystemBook(database)
+			if err != nil {
+				t.Fatalf("error when reloading file, %v", err)
+			}
+
+			for _, id := range []string{"1", "2"} {
+				got := newBook.Limits(id)
+				want := book.Limits(id)
+
+				if got != want {
+					t.Errorf("got limits %v, wanted %v", got, want)
+				}
+			}
+
+			_, err = newBook.WithdrawWalletFunds("1", ledger.NewMoney(5001, ledger.DefaultCurrency), "")
+			if _, ok := err.(*ledger.LimitExceededError); !ok {
+				t.Errorf("got error %v, wanted a LimitExceededError", err)
+			}
+
+			err = newBook.Snapshot()
+			if err != nil {
+				t.Fatalf("error returned when saving snapshot, %v", err)
+			}
+		}
+	})
+}
+
+func TestBook_SetFeeSchedule(t *testing.T) {
+	t.Run("should keep fee schedules and charged fees once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		schedule := ledger.FeeSchedule{Command: ledger.FeeWithdrawal, Currency: ledger.DefaultCurrency, Wallet: "fees", Bands: []ledger.FeeBand{{UpTo: 1000, Flat: 10}, {BasisPoints: 100}}}
+
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.SetFeeSchedule(schedule, "")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+
+		for i := 0; i < 2; i++ {
+			newBook, err := NewFileSystemBook(database)
+			if err != nil {
+				t.Fatalf("error when reloading file, %v", err)
+			}
+
+			got := newBook.FeeSchedules()
+			if !reflect.DeepEqual(got, []ledger.FeeSchedule{schedule}) {
+				t.Errorf("got fee schedules %v, wanted %v", got, schedule)
+			}
+
+			balance, _ := newBook.WalletBalance("fees")
+			test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(200, ledger.DefaultCurrency)})
+
+			err = newBook.Snapshot()
+			if err != nil {
+				t.Fatalf("error returned when saving snapshot, %v", err)
+			}
+		}
+	})
+}
+
+func TestBook_SetInterestPolicy(t *testing.T) {
+	t.Run("should keep interest accruals once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		policy := ledger.InterestPolicy{Rate: "0.02", Currency: ledger.DefaultCurrency, DayCount: ledger.DayCountActual360, Rounding: ledger.RoundDown, Posting: ledger.InterestMonthly, Source: "interest"}
+
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		err = book.SetInterestPolicy("1", policy, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		want, _ := book.InterestAccrual("1")
+
+		for i := 0; i < 2; i++ {
+			newBook, err := NewFileSystemBook(database)
+			if err != nil {
+				t.Fatalf("error when reloading file, %v", err)
+			}
+
+			got, err := newBook.InterestAccrual("1")
+			if err != nil || got != want {
+				t.Errorf("got accrual %+v, wanted %+v", got, want)
+			}
+
+			err = newBook.Snapshot()
+			if err != nil {
+				t.Fatalf("error returned when saving snapshot, %v", err)
+			}
+		}
+	})
+}
+
+func TestBook_Post(t *testing.T) {
+	t.Run("should write every leg of a posting to the journal as one record", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "3"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+
+		_, err = book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: ledger.NewMoney(10000, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: ledger.NewMoney(7000, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "3", Amount: ledger.NewMoney(3000, ledger.DefaultCurrency)},
+		}, "")
+		if err != nil {
+			t.Fatalf("error returned posting entries, %v", err)
+		}
+
+		_, records, err := openJournal(database, SyncNever)
+		if err != nil {
+			t.Fatalf("error reading journal, %v", err)
+		}
+
+		if len(records) != 5 || len(records[4].Transactions) != 3 {
+			t.Errorf("got %d records, wanted the posting in a record of its own after the wallets it credits and the deposit", len(records))
+		}
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertBooksMatch(t, newBook, book, "1", "2", "3")
+	})
+}
+
+func TestBook_Publish(t *testing.T) {
+	t.Run("should publish commands once they're in the journal, but not the ones replayed when it's reopened", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		bus := event.NewBus()
+		defer bus.Close()
+
+		handled := make(chan event.Envelope, 10)
+		bus.Subscribe(func(e event.Envelope) error {
+			handled <- e
+			return nil
+		})
+
+		book, err := NewFileSystemBook(database, WithPublisher(bus))
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		deposit, _ := book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		reopened, err := NewFileSystemBook(database, WithPublisher(bus))
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		withdrawal, _ := reopened.WithdrawWalletFunds("1", ledger.NewMoney(400, ledger.DefaultCurrency), "")
+
+		want := []ledger.Event{
+			ledger.WalletOpened{Wallet: ledger.Wallet{ID: "1", Status: ledger.WalletActive}},
+			ledger.WalletFundsDeposited{Wallet: "1", Deposit: ledger.NewMoney(1000, ledger.DefaultCurrency), Aggregate: deposit},
+			ledger.WalletFundsWithdrawn{Wallet: "1", Withdraw: ledger.NewMoney(400, ledger.DefaultCurrency), Aggregate: withdrawal},
+		}
+
+		for i, w := range want {
+			select {
+			case e := <-handled:
+				if !reflect.DeepEqual(e.Event, w) {
+					t.Errorf("got event %+v, wanted %+v", e.Event, w)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("only %d of %d events were published", i, len(want))
+			}
+		}
+
+		select {
+		case e := <-handled:
+			t.Errorf("got event %+v, wanted no more", e.Event)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+}
+
+func TestBook_AcknowledgeOutbox(t *testing.T) {
+	t.Run("should keep messages that haven't been acknowledged once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database, WithOutbox())
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("1", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		book.AcknowledgeOutbox(book.PendingOutbox(2)[0].ID, book.PendingOutbox(2)[1].ID)
+
+		err = book.Snapshot()
+		if err != nil {
+			t.Fatalf("error returned when saving snapshot, %v", err)
+		}
+
+		book.WithdrawWalletFunds("1", ledger.NewMoney(500, ledger.DefaultCurrency), "")
+		book.AcknowledgeOutbox(book.PendingOutbox(1)[0].ID)
+
+		want := book.PendingOutbox(0)
+
+		newBook, err := NewFileSystemBook(database, WithOutbox())
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		got := newBook.PendingOutbox(0)
+		if !reflect.DeepEqual(got, want) || len(got) != 1 || got[0].Event != ledger.EventWalletFundsWithdrawn || got[0].Sequence != 4 {
+			t.Errorf("got messages %+v, wanted only the withdrawal", got)
+		}
+	})
+}
+
+func TestBook_TransactionsAfter(t *testing.T) {
+	t.Run("should resume the feed from a cursor once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		err = book.Snapshot()
+		if err != nil {
+			t.Fatalf("error returned when saving snapshot, %v", err)
+		}
+
+		book.WithdrawWalletFunds("1", ledger.NewMoney(400, ledger.DefaultCurrency), "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.DepositWalletFunds("2", ledger.NewMoney(300, ledger.DefaultCurrency), "")
+
+		cursor := book.TransactionsAfter(0, 1)[0].Sequence
+		want := book.TransactionsAfter(cursor, 0)
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		got := newBook.TransactionsAfter(cursor, 0)
+		if !reflect.DeepEqual(got, want) || len(got) != 5 {
+			t.Errorf("got transactions %+v, wanted %+v", got, want)
+		}
+	})
+}
+
+func TestBook_Idempotency(t *testing.T) {
+	t.Run("should remember idempotency keys once reopened, from the journal and from a snapshot", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		book, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		deposit := ledger.NewMoney(50000, ledger.DefaultCurrency)
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		first, _ := book.DepositWalletFunds("1", deposit, "key-1")
+
+		newBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertRetried(t, newBook, first, deposit)
+
+		err = newBook.Compact()
+		if err != nil {
+			t.Fatalf("error returned when compacting, %v", err)
+		}
+
+		newestBook, err := NewFileSystemBook(database)
+		if err != nil {
+			t.Fatalf("error when reloading file, %v", err)
+		}
+
+		assertRetried(t, newestBook, first, deposit)
+	})
+}
+
+func TestBook_DefaultCurrency(t *testing.T) {
+	t.Run("should give amounts stored without a currency the book's default currency", func(t *testing.T) {
+		database, clean := test.CreateTempFile(t, "", "db.json")
+		defer clean()
+
+		// a snapshot saved before balances tracked a currency, with a journal record written after it
+		raw := `{"seq":1,"state":{"transactions":[{"type":"cash in","wallet":"1","amount":1000,"aggregate":"1111"}],` +
+			`"wallets":{"1":[0]},"aggregates":{"1111":[0]},"balances":{"1":1000}}}`
+		envelope := fmt.Sprintf(`{"crc":%d,"snapshot":%s}`, crc32.ChecksumIEEE([]byte(raw)), raw)
+		record := `{"seq":2,"pos":1,"transactions":[{"type":"cash in","wallet":"1","amount":500,"aggregate":"1112"}]}`
+		line := fmt.Sprintf(`{"crc":%d,"record":%s}`+"\n", crc32.ChecksumIEEE([]byte(record)), record)
+
+		err := ioutil.WriteFile(database.Name()+snapshotSuffix, []byte(envelope), 0644)
+		if err != nil {
+			t.Fatalf("unable to write snapshot, %v", err)
+		}
+
+		_, err = database.WriteString(line)
+		if err != nil {
+			t.Fatalf("unable to write journal, %v", err)
+		}
+
+		book, err := NewFileSystemBook(database, WithDefaultCurrency("EUR"))
+		if err != nil {
+			t.Fatalf("error returned when creating file system book, %v", err)
+		}
+
+		balance, err := book.WalletBalance("1")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(1500, "EUR")})
+
+		for _, transaction := range book.Transactions() {
+			if transaction.Currency != "EUR" {
+				t.Errorf("transaction %v wasn't given the default currency", transaction)
+			}
+		}
+	})
+}
+
+// historySizes are the numbers of transactions a busy wallet has behind it in the benchmarks
+// balances are saved in the snapshot the book is loaded from, so the time taken shouldn't grow with them
+var historySizes = []int{1000, 10000, 100000, 1000000}
+
+// openWithHistory opens a book from a snapshot holding the wallet's history, the book is closed and its files removed by clean
+func openWithHistory(b *testing.B, wallet string, size int) (book *Book, clean func()) {
+	b.Helper()
+
+	database, removeFile := test.CreateTempFile(b, "", "db.json")
+
+	state, _ := memory.NewInMemoryBook(memory.WithTransactions(test.WalletHistory(wallet, size))).Checkpoint(nil)
+
+	err := writeSnapshot(database.Name()+snapshotSuffix, snapshot{Sequence: 0, State: state})
+	if err != nil {
+		b.Fatalf("unable to write snapshot, %v", err)
+	}
+
+	book, err = NewFileSystemBook(database, WithSyncPolicy(SyncNever))
+	if err != nil {
+		b.Fatalf("error returned when creating file system book, %v", err)
+	}
+
+	return book, func() {
+		book.Close()
+		removeFile()
+	}
+}
+
+func BenchmarkBook_WalletBalance(b *testing.B) {
+	for _, size := range historySizes {
+		b.Run(fmt.Sprintf("history of %d", size), func(b *testing.B) {
+			book, clean := openWithHistory(b, "1", size)
+			defer clean()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, err := book.WalletBalance("1")
+				if err != nil {
+					b.Fatalf("returned error, %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBook_TransferWalletFunds(b *testing.B) {
+	for _, size := range historySizes {
+		b.Run(fmt.Sprintf("history of %d", size), func(b *testing.B) {
+			book, clean := openWithHistory(b, "1", size)
+			defer clean()
+
+			// every transfer moves out the smallest amount, wallet 1 is given an overdraft so it can't run dry however long the run
+			book.SetOverdraftPolicy("1", ledger.UnlimitedOverdraft(), "")
+			book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(1, ledger.DefaultCurrency), "")
+				if err != nil {
+					b.Fatalf("returned error, %v", err)
+				}
+			}
+		})
+	}
+}
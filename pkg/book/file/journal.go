@@ -0,0 +1,587 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+)
+
+const (
+	// legacyBackupSuffix is appended to the database file name when a legacy JSON array file is migrated to a journal,
+	// the backup holds the original contents until the migration is known to be good
+	legacyBackupSuffix = ".legacy"
+	// archiveSuffix is appended to the database file name, along with the sequence number of the last record in the segment,
+	// for segments of records moved out of the journal when it's compacted
+	archiveSuffix = ".archive"
+)
+
+// ErrReadOnly is returned for anything that would write to a journal opened read only
+var ErrReadOnly = errors.New("journal was opened read only")
+
+// SyncPolicy decides how often the journal is flushed to stable storage with fsync
+type SyncPolicy struct {
+	every int // number of records between each fsync, zero leaves flushing to the operating system
+}
+
+var (
+	// SyncAlways flushes the journal after every record, a command that returned successfully survives a crash
+	SyncAlways = SyncPolicy{every: 1}
+	// SyncNever leaves flushing the journal to the operating system, a crash may lose recently committed records
+	SyncNever = SyncPolicy{}
+)
+
+// SyncEvery flushes the journal after every n records, a crash may lose up to n-1 committed records
+func SyncEvery(n int) SyncPolicy {
+	if n < 1 {
+		n = 1
+	}
+
+	return SyncPolicy{every: n}
+}
+
+// record is a commit to the book, a batch of transactions added together
+type record struct {
+	Sequence uint64 `json:"seq"`
+	Position int    `json:"pos"` // position in the book of the record's first transaction
+	memory.Commit
+}
+
+// envelope is a single line of the journal, holding a record along with a checksum of its encoded bytes
+type envelope struct {
+	Checksum uint32          `json:"crc"`
+	Record   json.RawMessage `json:"record"`
+}
+
+// journal is an append-only log of records, stored one JSON envelope per line
+// a record is only ever appended in a single write at the end of the file, so a crash can at worst leave a torn last line,
+// which is detected by its missing newline or bad checksum and trimmed the next time the journal is opened
+type journal struct {
+	mu       sync.Mutex // serializes appends with flushes requested outside of a commit
+	file     *os.File
+	offset   int64  // end of the last complete record in the file
+	sequence uint64 // sequence number of the last record written
+	position int    // number of transactions in the book once every record written is replayed
+	policy   SyncPolicy
+	unsynced int                  // number of records written since the last fsync
+	readOnly bool                 // nothing is written to the file, or next to it
+	broken   error                // set once the file may no longer match the records written, nothing more is written to it
+	fsync    func(*os.File) error // flushes the file to stable storage, replaced in tests to make it fail
+}
+
+// openJournal reads every record from the file, recovering from a torn trailing record and
+// migrating a legacy JSON array file to the journal format if needed
+func openJournal(file *os.File, policy SyncPolicy) (*journal, []record, error) {
+	j := &journal{file: file, policy: policy, fsync: (*os.File).Sync}
+
+	legacy, err := isLegacyFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if legacy {
+		records, err := j.migrate()
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem migrating legacy file %s, %v", file.Name(), err)
+		}
+
+		return j, records, nil
+	}
+
+	records, err := j.recover()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return j, records, nil
+}
+
+// readJournal reads every record from the file without writing to it or next to it, so it's safe on a journal in use
+// a torn trailing record is skipped rather than trimmed and a legacy JSON array file is read as it is, without being migrated
+func readJournal(file *os.File) (*journal, []record, error) {
+	j := &journal{file: file, readOnly: true, fsync: (*os.File).Sync}
+
+	legacy, err := isLegacyFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if legacy {
+		transactions, _, err := j.readLegacy()
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem reading legacy file %s, %v", file.Name(), err)
+		}
+
+		records := []record{}
+
+		if len(transactions) > 0 {
+			j.sequence = 1
+			j.position = len(transactions)
+			records = append(records, record{Sequence: 1, Position: 0, Commit: memory.Commit{Transactions: transactions}})
+		}
+
+		return j, records, nil
+	}
+
+	records, err := j.recover()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return j, records, nil
+}
+
+// append writes the commit to the end of the journal as a single record
+func (j *journal) append(c memory.Commit) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.write(c)
+}
+
+// flush syncs every record written so far to stable storage
+func (j *journal) flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.sync()
+}
+
+// last returns the sequence number of the last record written
+func (j *journal) last() uint64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.sequence
+}
+
+// archive moves every record in the journal to a segment file next to it, leaving the journal empty
+// segments hold the transactions snapshots leave out, the book reads them once it needs them, or as it's opened if a snapshot doesn't cover them
+// a crash after the segment is written but before the journal is emptied leaves its records in both, they're skipped in the journal as it's opened
+func (j *journal) archive() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.readOnly {
+		return ErrReadOnly
+	}
+
+	if j.broken != nil {
+		return j.broken
+	}
+
+	if j.offset == 0 {
+		return nil
+	}
+
+	segment := fmt.Sprintf("%s.%020d%s", j.file.Name(), j.sequence, archiveSuffix)
+
+	contents := make([]byte, j.offset)
+
+	_, err := j.file.ReadAt(contents, 0)
+	if err != nil {
+		return fmt.Errorf("problem reading records from %s, %v", j.file.Name(), err)
+	}
+
+	err = writeFileAtomically(segment, contents)
+	if err != nil {
+		return fmt.Errorf("problem writing archive segment %s, %v", segment, err)
+	}
+
+	return j.empty()
+}
+
+// clear empties a journal whose records have all been archived already, finishing an archive a crash interrupted
+func (j *journal) clear() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.readOnly {
+		return ErrReadOnly
+	}
+
+	return j.empty()
+}
+
+// empty truncates the journal, numbering of records and transactions carries on from where it was, callers must hold mu
+func (j *journal) empty() error {
+	err := j.file.Truncate(0)
+	if err != nil {
+		return fmt.Errorf("problem truncating %s, %v", j.file.Name(), err)
+	}
+
+	j.offset = 0
+	j.unsynced = 1
+
+	return j.sync()
+}
+
+// resume continues numbering records and transactions after a snapshot, when the journal holds nothing newer
+func (j *journal) resume(sequence uint64, position int) {
+	if sequence > j.sequence {
+		j.sequence = sequence
+	}
+	if position > j.position {
+		j.position = position
+	}
+}
+
+func (j *journal) write(c memory.Commit) error {
+	if j.readOnly {
+		return ErrReadOnly
+	}
+
+	if j.broken != nil {
+		return j.broken
+	}
+
+	r := record{Sequence: j.sequence + 1, Position: j.position, Commit: c}
+
+	line, err := encodeRecord(r)
+	if err != nil {
+		return err
+	}
+
+	offset, sequence, position, unsynced := j.offset, j.sequence, j.position, j.unsynced
+
+	n, err := j.file.WriteAt(line, j.offset)
+	if err != nil {
+		j.rollback(offset)
+		return fmt.Errorf("problem appending record %d to %s, %v", r.Sequence, j.file.Name(), err)
+	}
+
+	j.offset += int64(n)
+	j.sequence = r.Sequence
+	j.position += len(c.Transactions)
+	j.unsynced++
+
+	if j.policy.every > 0 && j.unsynced >= j.policy.every {
+		err = j.sync()
+		if err != nil {
+			// the book doesn't take a commit the journal failed to sync, so the record is dropped and numbering picks up where it was
+			j.offset, j.sequence, j.position, j.unsynced = offset, sequence, position, unsynced
+			j.rollback(offset)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollback drops whatever part of a record that failed made it to the file, so the journal still ends on the last record the book holds
+// if the file can't be cut back and synced it may hold a record the book doesn't, and the journal is broken so nothing more is written to it
+func (j *journal) rollback(offset int64) {
+	err := j.file.Truncate(offset)
+	if err == nil {
+		err = j.fsync(j.file)
+	}
+	if err != nil {
+		j.broken = fmt.Errorf("journal %s can't be written to after failing to drop a record that wasn't committed, %v", j.file.Name(), err)
+	}
+}
+
+func (j *journal) sync() error {
+	if j.broken != nil {
+		return j.broken
+	}
+
+	if j.unsynced == 0 {
+		return nil
+	}
+
+	err := j.fsync(j.file)
+	if err != nil {
+		return fmt.Errorf("problem syncing %s, %v", j.file.Name(), err)
+	}
+
+	j.unsynced = 0
+
+	return nil
+}
+
+// recover reads every record in the journal, trimming a torn record from the end of the file
+// a bad record anywhere but the end means the file is corrupt and is returned as an error
+func (j *journal) recover() ([]record, error) {
+	info, err := j.file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("problem reading info from file %s, %v", j.file.Name(), err)
+	}
+
+	size := info.Size()
+	reader := bufio.NewReader(io.NewSectionReader(j.file, 0, size))
+	records := []record{}
+
+	for j.offset < size {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// the last record was never finished
+			return records, j.trim(size)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("problem reading %s at offset %d, %v", j.file.Name(), j.offset, err)
+		}
+
+		r, err := decodeRecord(line)
+		// the first record is only numbered 1 if the journal was never compacted
+		if err == nil && len(records) > 0 && r.Sequence != j.sequence+1 {
+			err = fmt.Errorf("expected record %d, found record %d", j.sequence+1, r.Sequence)
+		}
+		if err != nil {
+			if j.offset+int64(len(line)) == size {
+				// only the last record is bad, which is what a crash part way through a write looks like
+				return records, j.trim(size)
+			}
+
+			return nil, fmt.Errorf("corrupt record in %s at offset %d, %v", j.file.Name(), j.offset, err)
+		}
+
+		records = append(records, r)
+		j.offset += int64(len(line))
+		j.sequence = r.Sequence
+		j.position = r.Position + len(r.Transactions)
+	}
+
+	return records, nil
+}
+
+// segment is an archive segment of a journal
+type segment struct {
+	path string
+	last uint64 // sequence number of the last record in the segment
+}
+
+// archiveSegments lists the archive segments of the named journal, oldest first, without reading them
+func archiveSegments(name string) ([]segment, error) {
+	paths, err := filepath.Glob(name + ".*" + archiveSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("problem listing archive segments of %s, %v", name, err)
+	}
+
+	// segments are named after the zero padded sequence number of their last record, so they sort oldest first
+	sort.Strings(paths)
+
+	segments := make([]segment, len(paths))
+
+	for i, path := range paths {
+		last, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(path, name+"."), archiveSuffix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("archive segment %s isn't named after the sequence number of its last record", path)
+		}
+
+		segments[i] = segment{path: path, last: last}
+	}
+
+	return segments, nil
+}
+
+// readSegments reads every record in the archive segments, in order
+// segments are only written whole and synced, so unlike the journal a bad record in one is never a torn write
+func readSegments(segments []segment) ([]record, error) {
+	records := []record{}
+
+	for _, s := range segments {
+		contents, err := ioutil.ReadFile(s.path)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading archive segment %s, %v", s.path, err)
+		}
+
+		for _, line := range bytes.SplitAfter(contents, []byte{'\n'}) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			r, err := decodeRecord(line)
+			if err != nil {
+				return nil, fmt.Errorf("corrupt record in archive segment %s, %v", s.path, err)
+			}
+
+			records = append(records, r)
+		}
+
+		if n := len(records); n == 0 || records[n-1].Sequence != s.last {
+			return nil, fmt.Errorf("archive segment %s doesn't end on record %d", s.path, s.last)
+		}
+	}
+
+	return records, nil
+}
+
+// trim cuts a torn record off the end of the journal, a read only journal leaves it where it is and reads up to it
+func (j *journal) trim(size int64) error {
+	if j.readOnly {
+		return nil
+	}
+
+	err := j.file.Truncate(j.offset)
+	if err != nil {
+		return fmt.Errorf("problem trimming torn record of %d bytes from %s, %v", size-j.offset, j.file.Name(), err)
+	}
+
+	return j.file.Sync()
+}
+
+// migrate rewrites a legacy JSON array file as a journal holding all of its transactions in a single record
+// the original contents are backed up next to the file first, so a crash part way through loses nothing
+func (j *journal) migrate() ([]record, error) {
+	transactions, contents, err := j.readLegacy()
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFileSynced(j.file.Name()+legacyBackupSuffix, contents)
+	if err != nil {
+		return nil, fmt.Errorf("problem backing up legacy file, %v", err)
+	}
+
+	err = j.file.Truncate(0)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []record{}
+
+	if len(transactions) > 0 {
+		c := memory.Commit{Transactions: transactions}
+
+		err = j.write(c)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record{Sequence: j.sequence, Position: 0, Commit: c})
+	}
+
+	err = j.file.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	j.unsynced = 0
+
+	return records, nil
+}
+
+// readLegacy returns the transactions in a legacy JSON array file, along with the file's contents
+func (j *journal) readLegacy() ([]ledgerpb.Transaction, []byte, error) {
+	contents, err := ioutil.ReadAll(io.NewSectionReader(j.file, 0, 1<<62))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var transactions []ledgerpb.Transaction
+
+	err = json.Unmarshal(contents, &transactions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem parsing transactions, %v", err)
+	}
+
+	return transactions, contents, nil
+}
+
+// isLegacyFile reports whether the file holds the legacy format, a single JSON array of transactions
+func isLegacyFile(file *os.File) (bool, error) {
+	reader := bufio.NewReader(io.NewSectionReader(file, 0, 1<<62))
+
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("problem reading from file %s, %v", file.Name(), err)
+		}
+
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		default:
+			return c == '[', nil
+		}
+	}
+}
+
+func encodeRecord(r record) ([]byte, error) {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("problem encoding record %d, %v", r.Sequence, err)
+	}
+
+	line, err := json.Marshal(envelope{Checksum: crc32.ChecksumIEEE(raw), Record: raw})
+	if err != nil {
+		return nil, fmt.Errorf("problem encoding record %d, %v", r.Sequence, err)
+	}
+
+	return append(line, '\n'), nil
+}
+
+func decodeRecord(line []byte) (record, error) {
+	var e envelope
+	var r record
+
+	err := json.Unmarshal(bytes.TrimSpace(line), &e)
+	if err != nil {
+		return r, fmt.Errorf("problem parsing record, %v", err)
+	}
+
+	if crc32.ChecksumIEEE(e.Record) != e.Checksum {
+		return r, fmt.Errorf("record checksum mismatch")
+	}
+
+	err = json.Unmarshal(e.Record, &r)
+	if err != nil {
+		return r, fmt.Errorf("problem parsing record, %v", err)
+	}
+
+	return r, nil
+}
+
+// writeFileAtomically replaces the named file in a single rename, so a crash leaves either the old file or the new one, never a partly written one
+func writeFileAtomically(name string, contents []byte) error {
+	temp := name + ".tmp"
+
+	err := writeFileSynced(temp, contents)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(temp, name)
+	if err != nil {
+		return err
+	}
+
+	// make the rename itself durable, where the platform allows syncing a directory
+	if dir, err := os.Open(filepath.Dir(name)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	return nil
+}
+
+func writeFileSynced(name string, contents []byte) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(contents)
+	if err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
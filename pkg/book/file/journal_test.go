@@ -0,0 +1,224 @@
+package file
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestJournal_Append(t *testing.T) {
+	t.Run("should append records that are read back in order when reopened", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, "", "journal")
+		defer clean()
+
+		j, _, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when opening journal, %v", err)
+		}
+
+		first := []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 1000, Aggregate: "1111"}}
+		second := []ledgerpb.Transaction{
+			{Type: "debit", Wallet: "1", Amount: 500, Aggregate: "1112"},
+			{Type: "credit", Wallet: "2", Amount: 500, Aggregate: "1112"},
+		}
+
+		j.append(memory.Commit{Transactions: first})
+		j.append(memory.Commit{Transactions: second})
+
+		_, records, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when reopening journal, %v", err)
+		}
+
+		assertRecordCount(t, records, 2)
+		test.AssertTransactions(t, records[0].Transactions, first)
+		test.AssertTransactions(t, records[1].Transactions, second)
+	})
+	t.Run("should drop a record it failed to sync and number the next one in its place", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, "", "journal")
+		defer clean()
+
+		j, _, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when opening journal, %v", err)
+		}
+
+		j.append(memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 1000, Aggregate: "1111"}}})
+
+		contents, _ := ioutil.ReadFile(file.Name())
+
+		failures := 1
+		j.fsync = func(f *os.File) error {
+			if failures > 0 {
+				failures--
+				return errors.New("disk went away")
+			}
+			return f.Sync()
+		}
+
+		err = j.append(memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 2000, Aggregate: "1112"}}})
+		if err == nil {
+			t.Fatal("no error returned when the record couldn't be synced")
+		}
+
+		assertFileContents(t, file.Name(), string(contents))
+
+		if j.last() != 1 || j.position != 1 {
+			t.Errorf("got record %d at position %d, wanted the journal back at record 1", j.last(), j.position)
+		}
+
+		err = j.append(memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 3000, Aggregate: "1113"}}})
+		if err != nil {
+			t.Fatalf("error returned appending once syncing works again, %v", err)
+		}
+
+		_, records, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when reopening journal, %v", err)
+		}
+
+		assertRecordCount(t, records, 2)
+		if records[1].Sequence != 2 || records[1].Position != 1 || records[1].Transactions[0].Aggregate != "1113" {
+			t.Errorf("got record %+v, wanted the one appended after the failure numbered 2", records[1])
+		}
+	})
+	t.Run("should refuse to append once it couldn't drop a record it failed to sync", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, "", "journal")
+		defer clean()
+
+		j, _, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when opening journal, %v", err)
+		}
+
+		j.fsync = func(*os.File) error {
+			return errors.New("disk went away")
+		}
+
+		err = j.append(memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 1000, Aggregate: "1111"}}})
+		if err == nil {
+			t.Fatal("no error returned when the record couldn't be synced")
+		}
+
+		j.fsync = (*os.File).Sync
+
+		for _, err := range []error{
+			j.append(memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 2000, Aggregate: "1112"}}}),
+			j.flush(),
+			j.archive(),
+		} {
+			if err == nil || err != j.broken {
+				t.Errorf("got error %v, wanted the journal to stay broken", err)
+			}
+		}
+	})
+}
+
+func TestJournal_Recover(t *testing.T) {
+	good := func() string {
+		line, _ := encodeRecord(record{Sequence: 1, Commit: memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "1", Amount: 1000, Aggregate: "1111"}}}})
+		return string(line)
+	}()
+
+	t.Run("should trim a record torn part way through writing and keep appending after it", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, good+`{"crc":12,"record":{"seq":2,"transa`, "journal")
+		defer clean()
+
+		j, records, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when opening journal, %v", err)
+		}
+
+		assertRecordCount(t, records, 1)
+		assertFileContents(t, file.Name(), good)
+
+		j.append(memory.Commit{Transactions: []ledgerpb.Transaction{{Type: "cash in", Wallet: "2", Amount: 1000, Aggregate: "1112"}}})
+
+		_, records, err = openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when reopening journal, %v", err)
+		}
+
+		assertRecordCount(t, records, 2)
+	})
+	t.Run("should trim a complete last record with a bad checksum", func(t *testing.T) {
+		torn := strings.Replace(good, `"seq":1`, `"seq":2`, 1)
+		file, clean := test.CreateTempFile(t, good+torn, "journal")
+		defer clean()
+
+		_, records, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when opening journal, %v", err)
+		}
+
+		assertRecordCount(t, records, 1)
+		assertFileContents(t, file.Name(), good)
+	})
+	t.Run("should return an error for a bad record that isn't the last one", func(t *testing.T) {
+		corrupt := strings.Replace(good, `"amount":1000`, `"amount":9000`, 1)
+		file, clean := test.CreateTempFile(t, corrupt+good, "journal")
+		defer clean()
+
+		_, _, err := openJournal(file, SyncAlways)
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func TestJournal_Migrate(t *testing.T) {
+	legacy := `[
+		{"type": "credit", "wallet": "1", "amount": 100000, "aggregate": "1111"},
+		{"type": "debit", "wallet": "1", "amount": 50000, "aggregate": "1112"}]`
+
+	t.Run("should rewrite a legacy JSON array file as a journal and keep a backup", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, legacy, "journal")
+		defer clean()
+
+		_, records, err := openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when opening journal, %v", err)
+		}
+
+		assertRecordCount(t, records, 1)
+		assertFileContents(t, file.Name()+legacyBackupSuffix, legacy)
+
+		_, records, err = openJournal(file, SyncAlways)
+		if err != nil {
+			t.Fatalf("error returned when reopening journal, %v", err)
+		}
+
+		want := []ledgerpb.Transaction{
+			{Type: "credit", Wallet: "1", Amount: 100000, Aggregate: "1111"},
+			{Type: "debit", Wallet: "1", Amount: 50000, Aggregate: "1112"},
+		}
+
+		assertRecordCount(t, records, 1)
+		test.AssertTransactions(t, records[0].Transactions, want)
+	})
+}
+
+func assertRecordCount(t *testing.T, records []record, want int) {
+	t.Helper()
+	if len(records) != want {
+		t.Fatalf("got incorrect number of records, got %d, wanted %d", len(records), want)
+	}
+}
+
+func assertFileContents(t *testing.T, name string, want string) {
+	t.Helper()
+	contents, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unable to read file %s, %v", name, err)
+	}
+
+	if string(contents) != want {
+		t.Errorf("got incorrect file contents, got '%s', wanted '%s'", contents, want)
+	}
+}
@@ -0,0 +1,80 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+)
+
+// snapshotSuffix is appended to the database file name for the file holding the book's latest snapshot
+const snapshotSuffix = ".snapshot"
+
+// snapshot is the state of the book once every journal record up to and including Sequence was replayed
+// the transactions those records hold are left out of it, they're read back from the journal and its archive segments
+// snapshots saved before that hold them all in State and have no Position
+type snapshot struct {
+	Sequence uint64       `json:"seq"`
+	Position int          `json:"pos,omitempty"` // number of transactions in the book once every record it covers is replayed
+	State    memory.State `json:"state"`
+}
+
+// snapshotEnvelope is the contents of a snapshot file, the snapshot along with a checksum of its encoded bytes
+type snapshotEnvelope struct {
+	Checksum uint32          `json:"crc"`
+	Snapshot json.RawMessage `json:"snapshot"`
+}
+
+// readSnapshot loads the snapshot saved at the given path, returning false if there isn't one
+func readSnapshot(path string) (snapshot, bool, error) {
+	var s snapshot
+	var e snapshotEnvelope
+
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, false, nil
+	}
+	if err != nil {
+		return s, false, fmt.Errorf("problem reading snapshot %s, %v", path, err)
+	}
+
+	err = json.Unmarshal(contents, &e)
+	if err != nil {
+		return s, false, fmt.Errorf("problem parsing snapshot %s, %v", path, err)
+	}
+
+	if crc32.ChecksumIEEE(e.Snapshot) != e.Checksum {
+		return s, false, fmt.Errorf("snapshot %s checksum mismatch", path)
+	}
+
+	err = json.Unmarshal(e.Snapshot, &s)
+	if err != nil {
+		return s, false, fmt.Errorf("problem parsing snapshot %s, %v", path, err)
+	}
+
+	return s, true, nil
+}
+
+// writeSnapshot saves the snapshot to the given path, replacing the previous one in a single rename
+// so a crash leaves either the old or the new snapshot in place, never a partly written one
+func writeSnapshot(path string, s snapshot) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("problem encoding snapshot, %v", err)
+	}
+
+	contents, err := json.Marshal(snapshotEnvelope{Checksum: crc32.ChecksumIEEE(raw), Snapshot: raw})
+	if err != nil {
+		return fmt.Errorf("problem encoding snapshot, %v", err)
+	}
+
+	err = writeFileAtomically(path, contents)
+	if err != nil {
+		return fmt.Errorf("problem replacing snapshot %s, %v", path, err)
+	}
+
+	return nil
+}
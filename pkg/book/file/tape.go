@@ -1,13 +0,0 @@
-package file
-
-import "os"
-
-type tape struct {
-	file *os.File
-}
-
-func (t *tape) Write(p []byte) (n int, err error) {
-	t.file.Truncate(0)
-	t.file.Seek(0, 0)
-	return t.file.Write(p)
-}
\ No newline at end of file
@@ -0,0 +1,194 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// DefaultCashAccount is the account deposits and withdrawals are posted against when the book isn't given one
+const DefaultCashAccount = "cash"
+
+// WithCashAccount sets the account deposits and withdrawals are posted against, so every deposit and withdrawal balances
+// the account is an asset unless the chart of accounts says otherwise, an empty account leaves DefaultCashAccount in place
+// it must come before WithTransactions and WithCommits, the cash account's balance is worked out as they're added
+func WithCashAccount(account string) Option {
+	return func(b *Book) {
+		if account != "" {
+			b.cashAccount = account
+		}
+	}
+}
+
+// DefineAccount adds an account to the chart of accounts, or changes one already in it
+// an account that already has transactions can't be given a type with a different normal side, its balance was worked out by the old one
+func (b *Book) DefineAccount(account ledger.Account, idempotencyKey string) error {
+	fingerprint := fingerprint("account", account)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		err := account.Validate()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		if _, ok := b.walletMap[account.Code]; ok && account.Type.NormalSide() != b.normalSide(account.Code) {
+			return "", Commit{}, &ledger.RejectedError{Subject: account.Code, Rejection: ledger.RejectionAccountInUse, Reason: fmt.Sprintf("account '%s' already has transactions, it can't be made a %s account", account.Code, account.Type)}
+		}
+
+		if account.Parent != "" {
+			err := b.checkParent(account)
+			if err != nil {
+				return "", Commit{}, err
+			}
+		}
+
+		for _, child := range b.accounts {
+			if child.Parent == account.Code && child.Type != account.Type {
+				return "", Commit{}, &ledger.RejectedError{Subject: account.Code, Rejection: ledger.RejectionChartConflict, Reason: fmt.Sprintf("account '%s' has %s account '%s' under it, it can't be made a %s account", account.Code, child.Type, child.Code, account.Type)}
+			}
+		}
+
+		return "", Commit{Account: &account}, nil
+	}, func(string) ledger.Event {
+		return ledger.AccountDefined{Account: account}
+	})
+	if err != nil {
+		return commandError("problem when defining account", err)
+	}
+
+	return nil
+}
+
+// Accounts returns the chart of accounts, ordered by code
+func (b *Book) Accounts() []ledger.Account {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	accounts := make([]ledger.Account, 0, len(b.accounts))
+
+	for _, account := range b.accounts {
+		accounts = append(accounts, account)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+
+	return accounts
+}
+
+// AccountBalance returns the current balance of the account along with every account under it in each currency, ordered by currency code
+func (b *Book) AccountBalance(code string) ([]ledger.Money, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if _, ok := b.accounts[code]; !ok {
+		return nil, errors.New("no account (" + code + ")")
+	}
+
+	totals := make(map[string]ledger.Money)
+
+	for _, account := range b.accounts {
+		if !b.rollsUpInto(account.Code, code) {
+			continue
+		}
+
+		if reason, ok := b.unbalanced[account.Code]; ok {
+			return nil, fmt.Errorf("problem working out balance of account '%s': %s", account.Code, reason)
+		}
+
+		for currency, balance := range b.balances[account.Code] {
+			total, ok := totals[currency]
+			if !ok {
+				total = ledger.NewMoney(0, currency)
+			}
+
+			total, err := total.Add(balance)
+			if err != nil {
+				return nil, fmt.Errorf("problem working out balance of account '%s': %v", code, err)
+			}
+
+			totals[currency] = total
+		}
+	}
+
+	balances := make([]ledger.Money, 0, len(totals))
+
+	for _, total := range totals {
+		balances = append(balances, total)
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+// checkParent returns an error unless the account's parent is in the chart, of the same type, and doesn't already roll up into the account
+// callers must hold the book's lock
+func (b *Book) checkParent(account ledger.Account) error {
+	parent, ok := b.accounts[account.Parent]
+	if !ok {
+		return &ledger.NotFoundError{Kind: "account", ID: account.Parent}
+	}
+
+	if parent.Type != account.Type {
+		return &ledger.RejectedError{Subject: account.Code, Rejection: ledger.RejectionChartConflict, Reason: fmt.Sprintf("account '%s' is a %s account, it can't roll up into %s account '%s'", account.Code, account.Type, parent.Type, parent.Code)}
+	}
+
+	if b.rollsUpInto(parent.Code, account.Code) {
+		return &ledger.RejectedError{Subject: account.Code, Rejection: ledger.RejectionChartConflict, Reason: fmt.Sprintf("account '%s' can't roll up into '%s', which already rolls up into it", account.Code, parent.Code)}
+	}
+
+	return nil
+}
+
+// rollsUpInto reports whether the account is the ancestor or one of its descendants, callers must hold the book's lock
+func (b *Book) rollsUpInto(code string, ancestor string) bool {
+	for seen := 0; code != "" && seen <= len(b.accounts); seen++ {
+		if code == ancestor {
+			return true
+		}
+
+		code = b.accounts[code].Parent
+	}
+
+	return false
+}
+
+// WalletAccountType returns the type of account the wallet is posted as, wallets missing from the chart of accounts
+// are liabilities, apart from the cash account which is an asset
+func (b *Book) WalletAccountType(wallet string) ledger.AccountType {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.accountType(wallet)
+}
+
+// accountType returns the type of account the wallet is posted as, callers must hold the book's lock
+func (b *Book) accountType(wallet string) ledger.AccountType {
+	if account, ok := b.accounts[wallet]; ok {
+		return account.Type
+	}
+
+	if wallet == b.cashAccount {
+		return ledger.AccountAsset
+	}
+
+	return ledger.DefaultAccountType
+}
+
+// normalSide returns the side postings that increase the wallet's balance go on, callers must hold the book's lock
+func (b *Book) normalSide(wallet string) string {
+	return b.accountType(wallet).NormalSide()
+}
+
+// decreases reports whether the transaction takes funds out of its wallet, callers must hold the book's lock
+func (b *Book) decreases(transactionType string, wallet string) bool {
+	side := ledger.Side(transactionType)
+
+	return side != "" && side != b.normalSide(wallet)
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"testing"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_CashAccount(t *testing.T) {
+	t.Run("should post deposits and withdrawals against the cash account so the book sums to zero", func(t *testing.T) {
+		book := NewInMemoryBook(WithCashAccount("cash"))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		deposit, err := book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		withdrawal, err := book.WithdrawWalletFunds("1", ledger.NewMoney(4000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: deposit},
+			{Type: ledger.TransactionDebit, Wallet: "cash", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: deposit},
+			{Type: ledger.TransactionCashOut, Wallet: "1", Amount: 4000, Currency: ledger.DefaultCurrency, Aggregate: withdrawal},
+			{Type: ledger.TransactionCredit, Wallet: "cash", Amount: 4000, Currency: ledger.DefaultCurrency, Aggregate: withdrawal},
+		}
+
+		test.AssertRecordedTransactions(t, book.Transactions(), want)
+
+		// the cash account is an asset, the cash it holds is owed to the wallet, a liability
+		balance, _ := book.WalletBalance("cash")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(6000, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(6000, ledger.DefaultCurrency)})
+
+		assertSidesBalance(t, book.Transactions())
+	})
+}
+
+func TestBook_DefineAccount(t *testing.T) {
+	t.Run("should work out balances on the account type's normal side", func(t *testing.T) {
+		book := NewInMemoryBook(WithCashAccount("cash"))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DefineAccount(ledger.Account{Code: "fees", Name: "Fee income", Type: ledger.AccountIncome}, "")
+		book.DefineAccount(ledger.Account{Code: "hosting", Name: "Hosting", Type: ledger.AccountExpense}, "")
+		book.SetOverdraftPolicy("cash", ledger.UnlimitedOverdraft(), "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+
+		_, err := book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: ledger.NewMoney(500, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "fees", Amount: ledger.NewMoney(500, ledger.DefaultCurrency)},
+		}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "hosting", Amount: ledger.NewMoney(200, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "cash", Amount: ledger.NewMoney(200, ledger.DefaultCurrency)},
+		}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("fees")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(500, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("hosting")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(200, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("cash")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(9800, ledger.DefaultCurrency)})
+
+		assertSidesBalance(t, book.Transactions())
+	})
+	t.Run("should check funds on the side that takes them out of the account", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		book.DefineAccount(ledger.Account{Code: "bank", Name: "Bank", Type: ledger.AccountAsset}, "")
+		book.SetOverdraftPolicy("equity", ledger.UnlimitedOverdraft(), "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		// debiting an asset adds funds to it, debiting equity takes them out
+		_, err := book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "bank", Amount: ledger.NewMoney(100, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "1", Amount: ledger.NewMoney(100, ledger.DefaultCurrency)},
+		}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		// crediting an asset takes funds out of it
+		_, err = book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "equity", Amount: ledger.NewMoney(150, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "bank", Amount: ledger.NewMoney(150, ledger.DefaultCurrency)},
+		}, "")
+		assertInsufficientFunds(t, err, "bank")
+	})
+	t.Run("should roll balances up into parent accounts", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		book.DefineAccount(ledger.Account{Code: "income", Name: "Income", Type: ledger.AccountIncome}, "")
+		book.DefineAccount(ledger.Account{Code: "fees", Name: "Fees", Type: ledger.AccountIncome, Parent: "income"}, "")
+		book.DefineAccount(ledger.Account{Code: "interest", Name: "Interest", Type: ledger.AccountIncome, Parent: "income"}, "")
+		book.SetOverdraftPolicy("settlement", ledger.UnlimitedOverdraft(), "")
+
+		book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "settlement", Amount: ledger.NewMoney(700, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "fees", Amount: ledger.NewMoney(500, ledger.DefaultCurrency)},
+			{Type: ledger.TransactionCredit, Wallet: "interest", Amount: ledger.NewMoney(200, ledger.DefaultCurrency)},
+		}, "")
+
+		balance, err := book.AccountBalance("income")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(700, ledger.DefaultCurrency)})
+
+		_, err = book.AccountBalance("settlement")
+		if err == nil {
+			t.Error("no error returned for a wallet missing from the chart of accounts")
+		}
+	})
+
+	invalid := []struct {
+		name    string
+		account ledger.Account
+	}{
+		{"an unknown type", ledger.Account{Code: "x", Type: "revenue"}},
+		{"no code", ledger.Account{Type: ledger.AccountAsset}},
+		{"a parent missing from the chart", ledger.Account{Code: "x", Type: ledger.AccountIncome, Parent: "missing"}},
+		{"a parent of another type", ledger.Account{Code: "x", Type: ledger.AccountExpense, Parent: "income"}},
+		{"a parent that rolls up into it", ledger.Account{Code: "income", Type: ledger.AccountIncome, Parent: "fees"}},
+		{"a new normal side for an account with transactions", ledger.Account{Code: "1", Type: ledger.AccountAsset}},
+	}
+
+	for _, c := range invalid {
+		t.Run("should refuse an account with "+c.name, func(t *testing.T) {
+			book := NewInMemoryBook()
+			book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+			book.DefineAccount(ledger.Account{Code: "income", Name: "Income", Type: ledger.AccountIncome}, "")
+			book.DefineAccount(ledger.Account{Code: "fees", Name: "Fees", Type: ledger.AccountIncome, Parent: "income"}, "")
+			book.DepositWalletFunds("1", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+
+			err := book.DefineAccount(c.account, "")
+			if err == nil {
+				t.Error("no error returned")
+			}
+		})
+	}
+}
+
+// assertSidesBalance checks the transactions' debits add up to their credits in each currency
+func assertSidesBalance(t *testing.T, transactions []ledgerpb.Transaction) {
+	t.Helper()
+
+	totals := make(map[string]int64)
+
+	for _, tr := range transactions {
+		switch ledger.Side(tr.Type) {
+		case ledger.TransactionDebit:
+			totals[tr.Currency] -= tr.Amount
+		case ledger.TransactionCredit:
+			totals[tr.Currency] += tr.Amount
+		}
+	}
+
+	for currency, total := range totals {
+		if total != 0 {
+			t.Errorf("credits and debits in %s differ by %d", currency, total)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"fmt"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// WithArchive sets how the book reads the transactions a state left out of it, see State.Archived
+// they're only read the first time one of them is needed, such as to list a wallet's transactions or reverse an aggregate they're in,
+// if reading them fails whatever needed them returns the error and they're read again the next time
+func WithArchive(load func() ([]ledgerpb.Transaction, error)) Option {
+	return func(b *Book) {
+		b.archiveLoader = load
+	}
+}
+
+// size returns the number of transactions in the book, archived ones included, callers must hold the book's lock
+func (b *Book) size() int {
+	return b.archived + len(b.transactions)
+}
+
+// loadArchive reads the book's archived transactions unless they've been read already, callers must hold the book's lock
+// queries sharing the read lock are kept apart while they're read by archiveMu
+func (b *Book) loadArchive() error {
+	if b.archived == 0 {
+		return nil
+	}
+
+	b.archiveMu.Lock()
+	defer b.archiveMu.Unlock()
+
+	if b.archive != nil {
+		return nil
+	}
+
+	if b.archiveLoader == nil {
+		return fmt.Errorf("the first %d transactions of the book are archived and it has no way to read them", b.archived)
+	}
+
+	transactions, err := b.archiveLoader()
+	if err != nil {
+		return fmt.Errorf("problem reading archived transactions: %v", err)
+	}
+
+	if len(transactions) != b.archived {
+		return fmt.Errorf("problem reading archived transactions: got %d, wanted %d", len(transactions), b.archived)
+	}
+
+	b.archive = transactions
+
+	return nil
+}
+
+// transaction returns the transaction at the position in the book, reading the archive first if it's held there
+// callers must hold the book's lock
+func (b *Book) transaction(position int) (*ledgerpb.Transaction, error) {
+	if position < b.archived {
+		err := b.loadArchive()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.at(position), nil
+}
+
+// at returns the transaction at the position in the book, callers must hold the book's lock
+// and have read the archive if the position is in it
+func (b *Book) at(position int) *ledgerpb.Transaction {
+	if position < b.archived {
+		return &b.archive[position]
+	}
+
+	return &b.transactions[position-b.archived]
+}
@@ -4,198 +4,767 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"gitlab.com/patchwell/ledger"
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
 )
 
 // Book represents a collection of transactions showing credit/debit actions against a given wallet
+// it is safe for concurrent use, commands take the write lock for their whole check-then-write cycle
+// while queries share the read lock and only ever hand out copies of the book's transactions
 type Book struct {
-	transactions []ledgerpb.Transaction             // the collection of transactions in the book
-	walletMap    map[string][]*ledgerpb.Transaction // bookmarks for each wallet pointing to all transactions for that wallet
-	aggregateMap map[string][]*ledgerpb.Transaction // bookmarks for each aggregate pointing to all transactions for that aggregate
+	mu             sync.RWMutex
+	transactions   []ledgerpb.Transaction                 // the collection of transactions in the book, after those in its archive
+	archived       int                                    // number of transactions at the start of the book kept in an archive rather than in transactions, positions and sequence numbers count them
+	archive        []ledgerpb.Transaction                 // the archived transactions, nil until one of them is needed
+	archiveLoader  func() ([]ledgerpb.Transaction, error) // reads the archived transactions
+	archiveMu      sync.Mutex                             // serializes reading the archive between queries sharing the read lock
+	archivedAt     int64                                  // when the last transaction of the state the book was restored from was recorded, no archived one was recorded after it
+	recordedAt     int64                                  // when the last transaction was recorded, new ones are never recorded before it
+	walletMap      map[string][]int                       // bookmarks for each wallet pointing to the positions of all transactions for that wallet
+	aggregateMap   map[string][]int                       // bookmarks for each aggregate pointing to the positions of all transactions for that aggregate
+	balances       map[string]Balances                    // running balances of each wallet, kept up to date as transactions are added
+	unbalanced     map[string]string                      // wallets whose balance can't be worked out, mapped to the reason why
+	history        map[string]map[string]*balanceHistory  // balance of each wallet in each currency after every transaction, nil until the first point in time query
+	historyOnce    sync.Once                              // builds the history for the first point in time query
+	reversals      map[string]string                      // aggregates that have been reversed, mapped to the aggregate that reversed them
+	refunds        map[string]ledger.Money                // aggregates that have been refunded, mapped to the total amount refunded
+	holds          map[string]map[string]Hold             // holds on each wallet that haven't been captured or voided, keyed by the hold's aggregate
+	overdrafts     map[string]ledger.OverdraftPolicy      // wallets that may go below zero, mapped to how far
+	accounts       map[string]ledger.Account              // the chart of accounts, keyed by code, wallets missing from it are liabilities
+	registry       map[string]ledger.Wallet               // wallets that have been opened, keyed by ID
+	walletLimits   map[string]ledger.Limits               // limits of wallets that have their own
+	tierLimits     map[string]ledger.Limits               // limits of the wallets in each tier that don't have their own
+	fees           map[feeKey]ledger.FeeSchedule          // what's charged on each command, keyed by command, tier and currency
+	accruals       map[string]ledger.InterestAccrual      // interest accrued on each wallet with an interest policy
+	cashAccount    string                                 // the account deposits and withdrawals are posted against
+	idempotency    map[string]IdempotencyRecord           // idempotency keys used within the retention window, mapped to what they were used for
+	keys           []string                               // idempotency keys in the order they were used, so expired ones can be dropped oldest first
+	retention      time.Duration                          // how long an idempotency key is remembered for
+	commitHook     func(Commit) error                     // called with every commit before it is added to the book
+	publisher      ledger.Publisher                       // handed the events of every commit once it's added to the book
+	outbox         []ledger.OutboxMessage                 // events written with their commits that haven't been acknowledged, oldest first
+	outboxSequence uint64                                 // sequence number of the last message written to the outbox
+	outboxEnabled  bool                                   // whether the events of new commits are written to the outbox
+	appended       chan struct{}                          // closed and replaced whenever transactions are added, to wake those waiting for them
+	rates          ledger.RateProvider                    // looks up the rates used to exchange funds between currencies
+	clock          func() time.Time                       // the time transactions are recorded at
+}
+
+// Commit is a batch of transactions added to the book together, along with the idempotency key of the command that added them
+// and any change it made to the wallets
+type Commit struct {
+	Transactions []ledgerpb.Transaction   `json:"transactions"`
+	Idempotency  *IdempotencyRecord       `json:"idempotency,omitempty"`
+	Overdraft    *OverdraftChange         `json:"overdraft,omitempty"`
+	Account      *ledger.Account          `json:"account,omitempty"`
+	Wallet       *ledger.Wallet           `json:"wallet,omitempty"` // a wallet opened or given a new status
+	Limits       *LimitsChange            `json:"limits,omitempty"`
+	FeeSchedule  *ledger.FeeSchedule      `json:"fee_schedule,omitempty"`
+	Interest     []ledger.InterestAccrual `json:"interest,omitempty"`     // accruals of wallets given an interest policy or accrued interest
+	Events       []ledger.Event           `json:"-"`                      // what the command did, published once the commit is added, they aren't kept with it
+	Outbox       []ledger.OutboxMessage   `json:"outbox,omitempty"`       // the events written to the outbox along with the commit
+	Acknowledged []string                 `json:"acknowledged,omitempty"` // IDs of outbox messages taken out of it
+}
+
+// balanceKey identifies a wallet's balance in one currency
+type balanceKey struct {
+	wallet   string
+	currency string
+}
+
+// Option configures a Book as it is created
+type Option func(*Book)
+
+// WithCommitHook registers a function that is handed every commit before it's added to the book
+// the hook runs while the write lock is held, so commits arrive in order, and if it returns an error the commit is dropped
+// this is how a persistent book writes ahead of the in memory state
+func WithCommitHook(hook func(commit Commit) error) Option {
+	return func(b *Book) {
+		b.commitHook = hook
+	}
+}
+
+// WithTransactions pre-populates the book with existing transactions, such as ones loaded from disk
+// the transactions are not passed to the commit hook
+func WithTransactions(transactions []ledgerpb.Transaction) Option {
+	return func(b *Book) {
+		b.appendTransactions(transactions)
+	}
+}
+
+// WithCommits pre-populates the book with earlier commits, such as ones loaded from disk, restoring their idempotency keys
+// the commits are not passed to the commit hook
+func WithCommits(commits []Commit) Option {
+	return func(b *Book) {
+		for _, c := range commits {
+			b.appendCommit(c)
+		}
+	}
+}
+
+// WithRateProvider sets where the book looks up exchange rates, without one it can't exchange funds between currencies
+func WithRateProvider(rates ledger.RateProvider) Option {
+	return func(b *Book) {
+		b.rates = rates
+	}
+}
+
+// WithClock sets where the book gets the time new transactions are recorded at, the default is time.Now
+func WithClock(clock func() time.Time) Option {
+	return func(b *Book) {
+		b.clock = clock
+	}
 }
 
 // NewInMemoryBook returns a new Book with no existing transactions
-func NewInMemoryBook() *Book {
-	return &Book{
+func NewInMemoryBook(options ...Option) *Book {
+	b := &Book{
 		transactions: []ledgerpb.Transaction{},
-		walletMap:    make(map[string][]*ledgerpb.Transaction),
-		aggregateMap: make(map[string][]*ledgerpb.Transaction),
+		walletMap:    make(map[string][]int),
+		aggregateMap: make(map[string][]int),
+		balances:     make(map[string]Balances),
+		unbalanced:   make(map[string]string),
+		cashAccount:  DefaultCashAccount,
+		reversals:    make(map[string]string),
+		refunds:      make(map[string]ledger.Money),
+		holds:        make(map[string]map[string]Hold),
+		overdrafts:   make(map[string]ledger.OverdraftPolicy),
+		accounts:     make(map[string]ledger.Account),
+		registry:     make(map[string]ledger.Wallet),
+		walletLimits: make(map[string]ledger.Limits),
+		tierLimits:   make(map[string]ledger.Limits),
+		fees:         make(map[feeKey]ledger.FeeSchedule),
+		accruals:     make(map[string]ledger.InterestAccrual),
+		outbox:       []ledger.OutboxMessage{},
+		appended:     make(chan struct{}),
+		idempotency:  make(map[string]IdempotencyRecord),
+		retention:    DefaultIdempotencyRetention,
+		clock:        time.Now,
+	}
+
+	for _, option := range options {
+		option(b)
 	}
+
+	return b
 }
 
 // NewMockInMemoryBook returns a new Book pre-populated with transactions
 // used for testing
 func NewMockInMemoryBook() *Book {
-	t1 := ledgerpb.Transaction{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 100000, Aggregate: "1111"}
-	t2 := ledgerpb.Transaction{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 10000, Aggregate: "1112"}
-	t3 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "1", Amount: 10000, Aggregate: "1112"}
-	t4 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "2", Amount: 10000, Aggregate: "1112"}
-	t5 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Aggregate: "1113"}
-	t6 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "1", Amount: 1000, Aggregate: "1113"}
-	t7 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "1", Amount: 1000, Aggregate: "1113"}
-	t8 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "3", Amount: 1000, Aggregate: "1113"}
-	t9 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Aggregate: "1114"}
-	t10 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "1", Amount: 1000, Aggregate: "1114"}
-	t11 := ledgerpb.Transaction{Type: ledger.TransactionCashOut, Wallet: "1", Amount: 1000, Aggregate: "1114"}
-	t12 := ledgerpb.Transaction{Type: "invalid", Wallet: "-999", Amount: 1000, Aggregate: "2222"}
+	t1 := ledgerpb.Transaction{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 100000, Currency: ledger.DefaultCurrency, Aggregate: "1111"}
+	t2 := ledgerpb.Transaction{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1112"}
+	t3 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1112"}
+	t4 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "2", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1112"}
+	t5 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1113"}
+	t6 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1113"}
+	t7 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1113"}
+	t8 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "3", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1113"}
+	t9 := ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: "2", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1114"}
+	t10 := ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1114"}
+	t11 := ledgerpb.Transaction{Type: ledger.TransactionCashOut, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "1114"}
+	t12 := ledgerpb.Transaction{Type: "invalid", Wallet: "-999", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: "2222"}
 
 	transactions := []ledgerpb.Transaction{t1, t2, t3, t4, t5, t6, t7, t8, t9, t10, t11, t12}
 
-	l := &Book{transactions, make(map[string][]*ledgerpb.Transaction), make(map[string][]*ledgerpb.Transaction)}
+	return NewInMemoryBook(WithTransactions(transactions))
+}
 
-	for _, t := range l.transactions {
-		l.addWalletMapEntry(t)
-		l.addAggregateMapEntry(t)
+func (b *Book) TransferWalletFunds(source string, destination string, amount ledger.Money, idempotencyKey string) (string, error) {
+	err := validateMovement(amount, source, destination)
+	if err != nil {
+		return "", err
 	}
 
-	return l
+	fingerprint := fingerprint("transfer", source, destination, amount)
+
+	aggregate, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		err := b.checkDestination(destination)
+		if err != nil {
+			return "", nil, err
+		}
+
+		aggregate, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		fee, fees, err := b.chargeFee(ledger.FeeTransfer, source, amount, aggregate)
+		if err != nil {
+			return "", nil, err
+		}
+
+		total, err := amount.Add(fee)
+		if err != nil {
+			return "", nil, err
+		}
+
+		// the balance check and the debit happen under the same lock so parallel transfers can't overdraw the source
+		err = b.checkFunds(source, total)
+		if err != nil {
+			return "", nil, err
+		}
+
+		ts := []ledgerpb.Transaction{
+			{Type: ledger.TransactionDebit, Wallet: source, Amount: amount.Amount, Currency: amount.Currency, Aggregate: aggregate},
+			{Type: ledger.TransactionCredit, Wallet: destination, Amount: amount.Amount, Currency: amount.Currency, Aggregate: aggregate},
+		}
+
+		return aggregate, append(ts, fees...), nil
+	}, func(aggregate string) ledger.Event {
+		return ledger.WalletFundsTransferred{Source: source, Destination: destination, Amount: amount, Aggregate: aggregate}
+	})
+	if err != nil {
+		return "", commandError("problem when transferring wallet funds", err)
+	}
+
+	return aggregate, nil
 }
 
-func (b *Book) TransferWalletFunds(source string, destination string, amount int32) (string, error) {
-	balance, err := b.WalletBalance(source)
+// ExchangeWalletFunds transfers an amount out of the source wallet and credits the destination wallet with it in another currency
+// both transactions share an aggregate and record the rate the amount was exchanged at
+func (b *Book) ExchangeWalletFunds(source string, destination string, amount ledger.Money, currency string, idempotencyKey string) (string, error) {
+	err := validateMovement(amount, source, destination)
+	if err != nil {
+		return "", err
+	}
+
+	err = ledger.ValidateID("target_currency", currency)
 	if err != nil {
-		return "", fmt.Errorf("problem when transferring wallet funds: %v", err)
+		return "", err
+	}
+
+	if amount.Currency == currency {
+		return b.TransferWalletFunds(source, destination, amount, idempotencyKey)
 	}
 
-	if balance < amount {
-		return "", fmt.Errorf("wallet '%s' has insufficient balance of %d to fill transfer of %d", source, balance, amount)
+	fingerprint := fingerprint("exchange", source, destination, amount, currency)
+
+	// a retried exchange is answered without looking up a rate, the provider may no longer have one
+	if aggregate, ok, err := b.replay(idempotencyKey, fingerprint); ok {
+		if err != nil {
+			return "", commandError("problem when exchanging wallet funds", err)
+		}
+
+		return aggregate, nil
 	}
 
-	aggregate, err := genUUID()
+	if b.rates == nil {
+		return "", &ledger.RejectedError{Rejection: ledger.RejectionNoRateProvider, Reason: "book has no rate provider"}
+	}
+
+	// the rate is looked up before taking the lock, a provider may have to go to a file or the network
+	rate, err := b.rates.Rate(amount.Currency, currency)
 	if err != nil {
-		return "", fmt.Errorf("problem when transferring wallet funds: %v", err)
+		return "", commandError("problem when exchanging wallet funds", err)
 	}
 
-	ts := []ledgerpb.Transaction{
-		{Type: ledger.TransactionDebit, Wallet: source, Amount: amount, Aggregate: aggregate},
-		{Type: ledger.TransactionCredit, Wallet: destination, Amount: amount, Aggregate: aggregate},
+	credit, err := amount.Convert(currency, rate)
+	if err != nil {
+		return "", fmt.Errorf("problem when exchanging wallet funds: %v", err)
 	}
 
-	b.addTransactions(ts)
+	aggregate, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		err := b.checkDestination(destination)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = b.checkFunds(source, amount)
+		if err != nil {
+			return "", nil, err
+		}
+
+		aggregate, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		r := ledger.FormatRate(rate)
+
+		return aggregate, []ledgerpb.Transaction{
+			{Type: ledger.TransactionDebit, Wallet: source, Amount: amount.Amount, Currency: amount.Currency, Aggregate: aggregate, Rate: r},
+			{Type: ledger.TransactionCredit, Wallet: destination, Amount: credit.Amount, Currency: credit.Currency, Aggregate: aggregate, Rate: r},
+		}, nil
+	}, func(aggregate string) ledger.Event {
+		return ledger.WalletFundsExchanged{Source: source, Destination: destination, Debit: amount, Credit: credit, Rate: ledger.FormatRate(rate), Aggregate: aggregate}
+	})
+	if err != nil {
+		return "", commandError("problem when exchanging wallet funds", err)
+	}
 
 	return aggregate, nil
 }
 
-func (b *Book) DepositWalletFunds(wallet string, deposit int32) (string, error) {
-	aggregate, err := genUUID()
+func (b *Book) DepositWalletFunds(wallet string, deposit ledger.Money, idempotencyKey string) (string, error) {
+	err := validateMovement(deposit, wallet)
 	if err != nil {
-		return "", fmt.Errorf("problem while depositing funds to wallet: %v", err)
+		return "", err
 	}
 
-	err = b.AddTransaction(ledger.TransactionCashIn, wallet, deposit, aggregate)
+	fingerprint := fingerprint("deposit", wallet, deposit)
+
+	aggregate, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		err := b.checkDestination(wallet)
+		if err != nil {
+			return "", nil, err
+		}
+
+		aggregate, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		return aggregate, b.cashLegs(ledger.TransactionCashIn, wallet, deposit, aggregate), nil
+	}, func(aggregate string) ledger.Event {
+		return ledger.WalletFundsDeposited{Wallet: wallet, Deposit: deposit, Aggregate: aggregate}
+	})
 	if err != nil {
-		return "", fmt.Errorf("problem while depositing funds to wallet: %v", err)
+		return "", commandError("problem while depositing funds to wallet", err)
 	}
 
 	return aggregate, nil
 }
 
-func (b *Book) WithdrawWalletFunds(wallet string, withdraw int32) (string, error) {
-	aggregate, err := genUUID()
+func (b *Book) WithdrawWalletFunds(wallet string, withdraw ledger.Money, idempotencyKey string) (string, error) {
+	err := validateMovement(withdraw, wallet)
 	if err != nil {
-		return "", fmt.Errorf("problem while withdrawing funds from wallet: %v", err)
+		return "", err
 	}
 
-	err = b.AddTransaction(ledger.TransactionCashOut, wallet, withdraw, aggregate)
+	fingerprint := fingerprint("withdraw", wallet, withdraw)
+
+	aggregate, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		aggregate, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		fee, fees, err := b.chargeFee(ledger.FeeWithdrawal, wallet, withdraw, aggregate)
+		if err != nil {
+			return "", nil, err
+		}
+
+		total, err := withdraw.Add(fee)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = b.checkFunds(wallet, total)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return aggregate, append(b.cashLegs(ledger.TransactionCashOut, wallet, withdraw, aggregate), fees...), nil
+	}, func(aggregate string) ledger.Event {
+		return ledger.WalletFundsWithdrawn{Wallet: wallet, Withdraw: withdraw, Aggregate: aggregate}
+	})
 	if err != nil {
-		return "", fmt.Errorf("problem while withdrawing funds from wallet: %v", err)
+		return "", commandError("problem while withdrawing funds from wallet", err)
 	}
 
 	return aggregate, nil
 }
 
-func (b *Book) AddTransaction(transactionType string, wallet string, amount int32, aggregate string) error {
-	// Create transaction
-	t := ledgerpb.Transaction{Type: transactionType, Wallet: wallet, Amount: amount, Aggregate: aggregate}
+// AddTransaction adds a single transaction to the book, its type, wallet, amount and aggregate are checked first
+// so anything the book couldn't balance is refused with a ValidationError, cash in and cash out are balanced by a leg on the cash account
+func (b *Book) AddTransaction(transactionType string, wallet string, amount ledger.Money, aggregate string, idempotencyKey string) error {
+	err := ledger.ValidateTransaction(transactionType, wallet, amount, aggregate)
+	if err != nil {
+		return err
+	}
 
-	// Append to master slice of transactions
-	b.transactions = append(b.transactions, t)
+	fingerprint := fingerprint("add", transactionType, wallet, amount, aggregate)
+
+	_, err = b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		if b.decreases(transactionType, wallet) {
+			err := b.checkFunds(wallet, amount)
+			if err != nil {
+				return "", nil, err
+			}
+		} else {
+			err := b.checkDestination(wallet)
+			if err != nil {
+				return "", nil, err
+			}
+		}
 
-	b.addMapEntries(t)
+		if transactionType == ledger.TransactionCashIn || transactionType == ledger.TransactionCashOut {
+			return "", b.cashLegs(transactionType, wallet, amount, aggregate), nil
+		}
 
-	return nil
+		// Create transaction
+		t := ledgerpb.Transaction{Type: transactionType, Wallet: wallet, Amount: amount.Amount, Currency: amount.Currency, Aggregate: aggregate}
+
+		return "", []ledgerpb.Transaction{t}, nil
+	}, func(string) ledger.Event {
+		return transactionAdded(transactionType, wallet, amount, aggregate)
+	})
+
+	return err
+}
+
+// cashLegs returns the legs of cash coming into or going out of the wallet, balanced by a leg on the cash account
+// the cash the wallet is credited with is then held in the cash account, and cash paid out to the wallet's owner leaves it
+// callers must hold the book's lock
+func (b *Book) cashLegs(transactionType string, wallet string, amount ledger.Money, aggregate string) []ledgerpb.Transaction {
+	side := ledger.TransactionDebit
+	if transactionType == ledger.TransactionCashOut {
+		side = ledger.TransactionCredit
+	}
+
+	return []ledgerpb.Transaction{
+		{Type: transactionType, Wallet: wallet, Amount: amount.Amount, Currency: amount.Currency, Aggregate: aggregate},
+		{Type: side, Wallet: b.cashAccount, Amount: amount.Amount, Currency: amount.Currency, Aggregate: aggregate},
+	}
 }
 
 func (b *Book) WalletTransactions(wallet string) ([]*ledgerpb.Transaction, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	if t, ok := b.walletMap[wallet]; ok {
-		return t, nil
+		return b.copyTransactions(t)
+	} else if _, ok := b.registry[wallet]; ok {
+		return []*ledgerpb.Transaction{}, nil
 	} else {
 		return nil, errors.New("no transactions for wallet (" + wallet + ")")
 	}
 }
 
-func (b *Book) WalletBalance(wallet string) (int32, error) {
-	ts, err := b.WalletTransactions(wallet)
+// WalletBalance returns the wallet's current and available balance in each currency it has transactions in, ordered by currency code
+func (b *Book) WalletBalance(wallet string) ([]ledger.Balance, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 
+	err := b.checkBalanced(wallet)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	balance := int32(0)
+	now := b.clock()
+	balances := make([]ledger.Balance, 0, len(b.balances[wallet]))
 
-	for _, t := range ts {
-		switch t.Type {
-		case ledger.TransactionCredit:
-			balance += t.Amount
-		case ledger.TransactionDebit:
-			balance -= t.Amount
-		case ledger.TransactionCashIn:
-			balance += t.Amount
-		case ledger.TransactionCashOut:
-			balance -= t.Amount
-		default:
-			return 0, errors.New("invalid transaction type: " + t.Type)
+	for currency, balance := range b.balances[wallet] {
+		available, err := b.availableBalance(wallet, currency, now)
+		if err != nil {
+			return nil, err
 		}
+
+		balances = append(balances, ledger.Balance{Current: balance, Available: available})
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Current.Currency < balances[j].Current.Currency
+	})
+
+	return balances, nil
+}
+
+// checkBalanced returns an error if the wallet hasn't been opened and has no transactions, or its balance can't be worked out
+// callers must hold the book's lock
+func (b *Book) checkBalanced(wallet string) error {
+	_, opened := b.registry[wallet]
+	if _, ok := b.walletMap[wallet]; !ok && !opened {
+		return errors.New("no transactions for wallet (" + wallet + ")")
+	}
+
+	if reason, ok := b.unbalanced[wallet]; ok {
+		return errors.New(reason)
 	}
 
-	return balance, nil
+	return nil
+}
+
+// checkFunds returns an InsufficientFundsError unless the wallet's overdraft policy lets the amount be paid out of its available balance
+// a wallet without transactions has nothing available, callers must hold the book's lock
+func (b *Book) checkFunds(wallet string, amount ledger.Money) error {
+	if reason, ok := b.unbalanced[wallet]; ok {
+		return errors.New(reason)
+	}
+
+	available, err := b.availableBalance(wallet, amount.Currency, b.clock())
+	if err != nil {
+		return err
+	}
+
+	return b.checkOverdraft(wallet, available, amount)
 }
 
 func (b *Book) AggregateTransactions(aggregate string) ([]*ledgerpb.Transaction, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	if t, ok := b.aggregateMap[aggregate]; ok {
-		return t, nil
+		return b.copyTransactions(t)
 	} else {
 		return nil, errors.New("no transactions for aggregate (" + aggregate + ")")
 	}
 }
 
+// Transactions returns copies of every transaction in the book, or none if its archive can't be read
 func (b *Book) Transactions() []ledgerpb.Transaction {
-	return b.transactions
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	err := b.loadArchive()
+	if err != nil {
+		return []ledgerpb.Transaction{}
+	}
+
+	ts := make([]ledgerpb.Transaction, 0, b.size())
+	ts = append(ts, b.archive...)
+	ts = append(ts, b.transactions...)
+
+	return ts
+}
+
+// commit adds a batch of transactions to the book, callers must hold the book's write lock
+func (b *Book) commit(c Commit, now time.Time) error {
+	transactions := c.Transactions
+
+	// make sure every wallet's balance can take the new transactions before anything is written
+	err := b.checkCommit(transactions, now)
+	if err != nil {
+		return err
+	}
+
+	err = b.stampTransactions(transactions, now)
+	if err != nil {
+		return fmt.Errorf("problem recording transactions: %v", err)
+	}
+
+	// the outbox is filled before the hook, so its messages are written ahead along with the transactions
+	err = b.fillOutbox(&c, now)
+	if err != nil {
+		return fmt.Errorf("problem writing outbox: %v", err)
+	}
+
+	if b.commitHook != nil {
+		err := b.commitHook(c)
+		if err != nil {
+			return fmt.Errorf("problem committing transactions: %v", err)
+		}
+	}
+
+	b.appendCommit(c)
+	b.publish(c)
+
+	return nil
 }
 
-func (b *Book) addWalletMapEntry(transaction ledgerpb.Transaction) {
-	// create wallet map entry if necessary
-	if _, ok := b.walletMap[transaction.Wallet]; !ok {
-		b.walletMap[transaction.Wallet] = []*ledgerpb.Transaction{}
+// checkCommit returns an error unless every wallet's status, balance and limits can take the transactions
+// it's how commit makes sure of them before anything is written, callers must hold the book's lock
+func (b *Book) checkCommit(transactions []ledgerpb.Transaction, now time.Time) error {
+	balances := make(map[balanceKey]ledger.Money)
+
+	for _, t := range transactions {
+		err := b.checkStatus(t.Type, t.Wallet)
+		if err != nil {
+			return err
+		}
+
+		if reason, unbalanced := b.unbalanced[t.Wallet]; unbalanced {
+			return fmt.Errorf("problem updating balance of wallet '%s': %s", t.Wallet, reason)
+		}
+
+		key := balanceKey{wallet: t.Wallet, currency: t.Currency}
+
+		balance, ok := balances[key]
+		if !ok {
+			balance, ok = b.balances[t.Wallet][t.Currency]
+		}
+
+		balance, err = nextBalance(balance, ok, t, b.normalSide(t.Wallet))
+		if err != nil {
+			return fmt.Errorf("problem updating balance of wallet '%s': %v", t.Wallet, err)
+		}
+
+		balances[key] = balance
 	}
 
-	// append transaction pointer to wallet's slice of transactions
-	b.walletMap[transaction.Wallet] = append(b.walletMap[transaction.Wallet], &transaction)
+	return b.checkLimits(transactions, balances, now)
 }
 
-func (b *Book) addAggregateMapEntry(transaction ledgerpb.Transaction) {
-	// create aggregate map entry if necessary
-	if _, ok := b.aggregateMap[transaction.Aggregate]; !ok {
-		b.aggregateMap[transaction.Aggregate] = []*ledgerpb.Transaction{}
+// appendCommit adds a commit's transactions to the book and remembers its idempotency key
+func (b *Book) appendCommit(c Commit) {
+	b.appendTransactions(c.Transactions)
+
+	if c.Overdraft != nil {
+		b.applyOverdraft(*c.Overdraft)
+	}
+
+	if c.Account != nil {
+		b.accounts[c.Account.Code] = *c.Account
+	}
+
+	if c.Wallet != nil {
+		b.registry[c.Wallet.ID] = *c.Wallet
 	}
 
-	// append transaction pointer to aggregate's slice of transactions
-	b.aggregateMap[transaction.Aggregate] = append(b.aggregateMap[transaction.Aggregate], &transaction)
+	if c.Limits != nil {
+		b.applyLimits(*c.Limits)
+	}
+
+	if c.FeeSchedule != nil {
+		b.applyFeeSchedule(*c.FeeSchedule)
+	}
+
+	b.applyInterest(c.Interest)
+	b.applyOutbox(c.Outbox, c.Acknowledged)
+
+	if c.Idempotency != nil {
+		b.remember(*c.Idempotency)
+	}
 }
 
-func (b *Book) addMapEntries(transaction ledgerpb.Transaction) {
-	b.addWalletMapEntry(transaction)
-	b.addAggregateMapEntry(transaction)
+// stampTransactions gives each new transaction its ID, sequence number, the time it was recorded and its effective date
+// callers must hold the book's write lock, sequence numbers follow on from the last transaction in the book
+func (b *Book) stampTransactions(transactions []ledgerpb.Transaction, now time.Time) error {
+	// the time index needs transactions recorded in order, so a clock that steps back doesn't take them back with it
+	if b.recordedAt > now.UnixNano() {
+		now = time.Unix(0, b.recordedAt)
+	}
+
+	for i := range transactions {
+		id, err := genUUID()
+		if err != nil {
+			return err
+		}
+
+		transactions[i].Id = id
+		transactions[i].Sequence = uint64(b.size() + i + 1)
+		transactions[i].RecordedAt = now.UnixNano()
+
+		// transactions posted for an earlier day, such as interest, already take effect on it
+		if transactions[i].EffectiveDate == "" {
+			transactions[i].EffectiveDate = now.UTC().Format(ledger.EffectiveDateLayout)
+		}
+	}
+
+	return nil
 }
 
-func (b *Book) addTransactions(transactions []ledgerpb.Transaction) {
-	b.transactions = append(b.transactions, transactions...)
+// stampLegacyTransaction gives a transaction recorded before the book assigned IDs and sequence numbers
+// ones worked out from its position, so they're the same every time it's loaded
+// the time it was recorded isn't known, so it is left as zero
+func stampLegacyTransaction(transaction *ledgerpb.Transaction, position int) {
+	if transaction.Sequence == 0 {
+		transaction.Sequence = uint64(position + 1)
+	}
+
+	if transaction.Id == "" {
+		transaction.Id = fmt.Sprintf("00000000-0000-0000-0000-%012X", transaction.Sequence)
+	}
+}
 
+// appendTransactions adds transactions to the master slice and updates the bookmarks and balances
+func (b *Book) appendTransactions(transactions []ledgerpb.Transaction) {
 	for _, t := range transactions {
-		b.addMapEntries(t)
+		position := b.size()
+
+		stampLegacyTransaction(&t, position)
+
+		// Append to master slice of transactions
+		b.transactions = append(b.transactions, t)
+
+		if t.RecordedAt > b.recordedAt {
+			b.recordedAt = t.RecordedAt
+		}
+
+		b.walletMap[t.Wallet] = append(b.walletMap[t.Wallet], position)
+		b.aggregateMap[t.Aggregate] = append(b.aggregateMap[t.Aggregate], position)
+		b.addBalanceEntry(t)
+		b.addHistory(position, t)
+		b.addCompensation(t)
+		b.addHold(t)
+	}
+
+	if len(transactions) > 0 {
+		b.notifyAppended()
+	}
+}
+
+func (b *Book) addBalanceEntry(transaction ledgerpb.Transaction) {
+	if _, ok := b.unbalanced[transaction.Wallet]; ok {
+		return
+	}
+
+	balances, ok := b.balances[transaction.Wallet]
+	if !ok {
+		balances = make(Balances)
+		b.balances[transaction.Wallet] = balances
 	}
+
+	balance, ok := balances[transaction.Currency]
+
+	balance, err := nextBalance(balance, ok, transaction, b.normalSide(transaction.Wallet))
+	if err != nil {
+		b.unbalanced[transaction.Wallet] = err.Error()
+		delete(b.balances, transaction.Wallet)
+		return
+	}
+
+	balances[transaction.Currency] = balance
+}
+
+// nextBalance returns a wallet's current balance in the transaction's currency after the transaction
+// a transaction on the wallet's normal side adds to it and one on the other side takes away from it,
+// holds and their releases leave it as it was, they only change the available balance
+func nextBalance(balance ledger.Money, ok bool, transaction ledgerpb.Transaction, normalSide string) (ledger.Money, error) {
+	amount := ledger.NewMoney(transaction.Amount, transaction.Currency)
+
+	if !ok {
+		balance = ledger.NewMoney(0, transaction.Currency)
+	}
+
+	switch transaction.Type {
+	case ledger.TransactionHold, ledger.TransactionRelease:
+		return balance, nil
+	}
+
+	switch ledger.Side(transaction.Type) {
+	case normalSide:
+		return balance.Add(amount)
+	case "":
+		return balance, errors.New("invalid transaction type: " + transaction.Type)
+	default:
+		return balance.Sub(amount)
+	}
+}
+
+// copyTransactions returns pointers to copies of the transactions at the given positions, so callers can't race with the book
+// (or with each other, the protobuf marshaller writes to a message's size cache)
+func (b *Book) copyTransactions(positions []int) ([]*ledgerpb.Transaction, error) {
+	ts := make([]*ledgerpb.Transaction, len(positions))
+
+	for i, p := range positions {
+		t, err := b.transaction(p)
+		if err != nil {
+			return nil, err
+		}
+
+		c := *t
+		ts[i] = &c
+	}
+
+	return ts, nil
+}
+
+// validateMovement returns a ValidationError if the amount moved isn't greater than zero or one of the wallets has no usable ID
+func validateMovement(amount ledger.Money, wallets ...string) error {
+	for _, wallet := range wallets {
+		err := ledger.ValidateID("wallet", wallet)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ledger.ValidateAmount(amount)
 }
 
 func genUUID() (string, error) {
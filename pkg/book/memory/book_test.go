@@ -1,10 +1,15 @@
 package memory
 
 import (
+	"fmt"
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"math/big"
+	"sync"
 	"testing"
+	"time"
 
 	"gitlab.com/patchwell/ledger"
+	ratememory "gitlab.com/patchwell/ledger/pkg/rate/memory"
 	"gitlab.com/patchwell/ledger/pkg/test"
 )
 
@@ -31,10 +36,10 @@ func TestBook_TransferWalletFunds(t *testing.T) {
 		book := NewMockInMemoryBook()
 		source := "1"
 		destination := "2"
-		amount := int32(50000)
+		amount := ledger.NewMoney(50000, ledger.DefaultCurrency)
 		transactionCount := len(book.transactions)
 
-		aggregate, err := book.TransferWalletFunds(source, destination, amount)
+		aggregate, err := book.TransferWalletFunds(source, destination, amount, "")
 		if err != nil {
 			t.Errorf("returned error when it shouldn't have: %v", err)
 		}
@@ -47,77 +52,304 @@ func TestBook_TransferWalletFunds(t *testing.T) {
 		newTransactions := book.transactions[len(book.transactions)-2 : len(book.transactions)]
 
 		want := []ledgerpb.Transaction{
-			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 50000, Aggregate: aggregate},
-			{Type: ledger.TransactionCredit, Wallet: "2", Amount: 50000, Aggregate: aggregate},
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
 		}
 
-		test.AssertTransactions(t, newTransactions, want)
+		test.AssertRecordedTransactions(t, newTransactions, want)
+	})
+}
+
+func TestBook_TransferWalletFundsConcurrently(t *testing.T) {
+	t.Run("should never let parallel transfers overdraw the source wallet", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		succeeded := 0
+
+		for i := 0; i < 20; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if _, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), ""); err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				book.WalletBalance("1")
+				book.WalletTransactions("2")
+				book.Transactions()
+			}()
+		}
+
+		wg.Wait()
+
+		if succeeded != 10 {
+			t.Errorf("got incorrect number of successful transfers, got %d, wanted %d", succeeded, 10)
+		}
+
+		balance, err := book.WalletBalance("1")
+		if err != nil {
+			t.Errorf("error returned, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(0, ledger.DefaultCurrency)})
+	})
+}
+
+func TestBook_ExchangeWalletFunds(t *testing.T) {
+	newBook := func() *Book {
+		rates := ratememory.NewInMemoryRates()
+		rates.SetRate(ledger.DefaultCurrency, "EUR", big.NewRat(92, 100))
+
+		return NewInMemoryBook(
+			WithTransactions(NewMockInMemoryBook().Transactions()),
+			WithRateProvider(rates),
+		)
+	}
+
+	t.Run("should debit the source in one currency and credit the destination in another at the rate used", func(t *testing.T) {
+		book := newBook()
+
+		aggregate, err := book.ExchangeWalletFunds("1", "2", ledger.NewMoney(50000, ledger.DefaultCurrency), "EUR", "")
+		if err != nil {
+			t.Fatalf("returned error when it shouldn't have: %v", err)
+		}
+
+		newTransactions := book.transactions[len(book.transactions)-2:]
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate, Rate: "0.92"},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: 46000, Currency: "EUR", Aggregate: aggregate, Rate: "0.92"},
+		}
+
+		test.AssertRecordedTransactions(t, newTransactions, want)
+
+		balance, err := book.WalletBalance("2")
+		if err != nil {
+			t.Fatalf("error returned, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(46000, "EUR"), ledger.NewMoney(8000, ledger.DefaultCurrency)})
+	})
+	t.Run("should return an error when the source can't cover the amount in its currency", func(t *testing.T) {
+		book := newBook()
+
+		_, err := book.ExchangeWalletFunds("1", "2", ledger.NewMoney(1000, "EUR"), ledger.DefaultCurrency, "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should return an error when the book has no rate provider", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.ExchangeWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "EUR", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
 	})
 }
 
 func TestBook_DepositWalletFunds(t *testing.T) {
-	t.Run("should create one new transaction, of type 'cash in'", func(t *testing.T) {
+	t.Run("should create a 'cash in' transaction balanced by a debit on the cash account", func(t *testing.T) {
 		book := NewMockInMemoryBook()
 		wallet := "1"
-		amount := int32(50000)
+		amount := ledger.NewMoney(50000, ledger.DefaultCurrency)
 		transactionCount := len(book.transactions)
 
-		aggregate, err := book.DepositWalletFunds(wallet, amount)
+		aggregate, err := book.DepositWalletFunds(wallet, amount, "")
 		if err != nil {
 			t.Errorf("returned error when it shouldn't have: %v", err)
 		}
 
 		newCount := len(book.transactions)
-		if newCount != transactionCount+1 {
+		if newCount != transactionCount+2 {
 			t.Errorf("book has incorrect transaction count after deposit, got %d, wanted %d", newCount, transactionCount+2)
 		}
 
-		newTransactions := book.transactions[len(book.transactions)-1:]
+		newTransactions := book.transactions[len(book.transactions)-2:]
 
 		want := []ledgerpb.Transaction{
-			{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 50000, Aggregate: aggregate},
+			{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: ledger.TransactionDebit, Wallet: DefaultCashAccount, Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
 		}
 
-		test.AssertTransactions(t, newTransactions, want)
+		test.AssertRecordedTransactions(t, newTransactions, want)
 	})
 }
 
 func TestBook_WithdrawWalletFunds(t *testing.T) {
-	t.Run("should create one new transaction, of type 'cash-out'", func(t *testing.T) {
+	t.Run("should create a 'cash-out' transaction balanced by a credit on the cash account", func(t *testing.T) {
 		book := NewMockInMemoryBook()
 		wallet := "1"
-		amount := int32(50000)
+		amount := ledger.NewMoney(50000, ledger.DefaultCurrency)
 		transactionCount := len(book.transactions)
 
-		aggregate, err := book.WithdrawWalletFunds(wallet, amount)
+		aggregate, err := book.WithdrawWalletFunds(wallet, amount, "")
 		if err != nil {
 			t.Errorf("returned error when it shouldn't have: %v", err)
 		}
 
 		newCount := len(book.transactions)
-		if newCount != transactionCount+1 {
+		if newCount != transactionCount+2 {
 			t.Errorf("book has incorrect transaction count after withdrawel, got %d, wanted %d", newCount, transactionCount+2)
 		}
 
-		newTransactions := book.transactions[len(book.transactions)-1:]
+		newTransactions := book.transactions[len(book.transactions)-2:]
 
 		want := []ledgerpb.Transaction{
-			{Type: ledger.TransactionCashOut, Wallet: "1", Amount: 50000, Aggregate: aggregate},
+			{Type: ledger.TransactionCashOut, Wallet: "1", Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: ledger.TransactionCredit, Wallet: DefaultCashAccount, Amount: 50000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
 		}
 
-		test.AssertTransactions(t, newTransactions, want)
+		test.AssertRecordedTransactions(t, newTransactions, want)
 	})
 }
 
+func TestBook_AddTransactionValidation(t *testing.T) {
+	t.Run("should refuse a transaction the book couldn't balance before recording anything", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		count := len(book.Transactions())
+
+		err := book.AddTransaction("invalid", "1", ledger.NewMoney(100, ledger.DefaultCurrency), "3333", "")
+		assertValidationError(t, err, "type")
+
+		err = book.AddTransaction(ledger.TransactionCredit, "1", ledger.NewMoney(0, ledger.DefaultCurrency), "3333", "")
+		assertValidationError(t, err, "amount")
+
+		err = book.AddTransaction(ledger.TransactionCredit, "", ledger.NewMoney(100, ledger.DefaultCurrency), "3333", "")
+		assertValidationError(t, err, "wallet")
+
+		err = book.AddTransaction(ledger.TransactionCredit, "1", ledger.NewMoney(100, ledger.DefaultCurrency), "", "")
+		assertValidationError(t, err, "aggregate")
+
+		if got := len(book.Transactions()); got != count {
+			t.Errorf("got %d transactions, wanted %d", got, count)
+		}
+
+		balance, err := book.WalletBalance("1")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)})
+	})
+	t.Run("should refuse deposits, withdrawals and transfers of amounts that aren't positive", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		negative := ledger.NewMoney(-100, ledger.DefaultCurrency)
+
+		_, err := book.DepositWalletFunds("1", negative, "")
+		assertValidationError(t, err, "amount")
+
+		_, err = book.WithdrawWalletFunds("1", negative, "")
+		assertValidationError(t, err, "amount")
+
+		_, err = book.TransferWalletFunds("1", "2", negative, "")
+		assertValidationError(t, err, "amount")
+
+		_, err = book.TransferWalletFunds("1", "", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		assertValidationError(t, err, "wallet")
+	})
+	t.Run("should refuse deposits and credits to a wallet the book doesn't know without recording anything", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		count := len(book.Transactions())
+
+		_, err := book.DepositWalletFunds("4", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		if e, ok := err.(*ledger.NotFoundError); !ok || e.Kind != "wallet" || e.ID != "4" {
+			t.Errorf("got error %v, wanted a NotFoundError of wallet '4'", err)
+		}
+
+		err = book.AddTransaction(ledger.TransactionCredit, "4", ledger.NewMoney(100, ledger.DefaultCurrency), "3333", "")
+		if e, ok := err.(*ledger.NotFoundError); !ok || e.Kind != "wallet" || e.ID != "4" {
+			t.Errorf("got error %v, wanted a NotFoundError of wallet '4'", err)
+		}
+
+		if got := len(book.Transactions()); got != count {
+			t.Errorf("got %d transactions, wanted %d", got, count)
+		}
+
+		if _, err := book.OpenWallet(ledger.Wallet{ID: "4"}, ""); err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.DepositWalletFunds("4", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error once the wallet was opened, %v", err)
+		}
+	})
+	t.Run("should refuse exchanges, postings, holds and refunds with a missing wallet, currency or positive amount", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		count := len(book.Transactions())
+		amount := ledger.NewMoney(100, ledger.DefaultCurrency)
+
+		_, err := book.ExchangeWalletFunds("1", "2", ledger.NewMoney(-100, ledger.DefaultCurrency), "EUR", "")
+		assertValidationError(t, err, "amount")
+
+		_, err = book.ExchangeWalletFunds("1", "", amount, "EUR", "")
+		assertValidationError(t, err, "wallet")
+
+		_, err = book.ExchangeWalletFunds("1", "2", amount, "", "")
+		assertValidationError(t, err, "target_currency")
+
+		_, err = book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: amount},
+			{Type: ledger.TransactionCredit, Wallet: "", Amount: amount},
+		}, "")
+		assertValidationError(t, err, "wallet")
+
+		_, err = book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: ledger.NewMoney(100, "")},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: ledger.NewMoney(100, "")},
+		}, "")
+		assertValidationError(t, err, "currency")
+
+		_, err = book.AuthorizeHold("1", ledger.NewMoney(100, ""), time.Time{}, "")
+		assertValidationError(t, err, "currency")
+
+		_, err = book.CaptureHold("", "2", amount, "")
+		assertValidationError(t, err, "hold")
+
+		_, err = book.RefundAggregate("1112", ledger.NewMoney(0, ledger.DefaultCurrency), "")
+		assertValidationError(t, err, "amount")
+
+		if got := len(book.Transactions()); got != count {
+			t.Errorf("got %d transactions, wanted %d", got, count)
+		}
+	})
+}
+
+func assertValidationError(t *testing.T, err error, field string) {
+	t.Helper()
+
+	e, ok := err.(*ledger.ValidationError)
+	if !ok {
+		t.Fatalf("got error %v, wanted a ValidationError", err)
+	}
+
+	if e.Field != field {
+		t.Errorf("got invalid %s, wanted invalid %s", e.Field, field)
+	}
+}
+
 func TestBook_AddTransaction(t *testing.T) {
 	book := NewMockInMemoryBook()
 	transactionType := ledger.TransactionDebit
 	walletID := "4"
 	aggID := "1115"
-	debit := int32(10000)
+	debit := ledger.NewMoney(10000, ledger.DefaultCurrency)
 	count := len(book.transactions)
 
-	book.AddTransaction(transactionType, walletID, debit, aggID)
+	// wallet 4 has no funds of its own, it has to be allowed to go below zero
+	book.SetOverdraftPolicy(walletID, ledger.UnlimitedOverdraft(), "")
+
+	book.AddTransaction(transactionType, walletID, debit, aggID, "")
 
 	newCount := len(book.transactions)
 
@@ -127,8 +359,8 @@ func TestBook_AddTransaction(t *testing.T) {
 
 	transaction := book.transactions[len(book.transactions)-1]
 
-	test.AssertTransaction(t, *book.walletMap[walletID][0], transaction)
-	test.AssertTransaction(t, *book.aggregateMap[aggID][0], transaction)
+	test.AssertTransaction(t, book.transactions[book.walletMap[walletID][0]], transaction)
+	test.AssertTransaction(t, book.transactions[book.aggregateMap[aggID][0]], transaction)
 
 	if transaction.Type != transactionType {
 		t.Errorf("new transaction has type of %s, should be %s", transaction.Type, transactionType)
@@ -138,8 +370,8 @@ func TestBook_AddTransaction(t *testing.T) {
 		t.Errorf("new transaction has wallet reference of %s, should be %s", transaction.Wallet, walletID)
 	}
 
-	if transaction.Amount != debit {
-		t.Errorf("new transaction has amount of %d, should be %d", transaction.Amount, debit)
+	if transaction.Amount != debit.Amount || transaction.Currency != debit.Currency {
+		t.Errorf("new transaction has amount of %d %s, should be %s", transaction.Amount, transaction.Currency, debit)
 	}
 
 	if transaction.Aggregate != aggID {
@@ -147,6 +379,78 @@ func TestBook_AddTransaction(t *testing.T) {
 	}
 }
 
+func TestBook_AddCashTransaction(t *testing.T) {
+	t.Run("should balance cash in and cash out added on their own with a leg on the cash account", func(t *testing.T) {
+		book := NewInMemoryBook(WithCashAccount("till"))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		amount := ledger.NewMoney(10000, ledger.DefaultCurrency)
+
+		book.AddTransaction(ledger.TransactionCashIn, "1", amount, "1115", "")
+		book.AddTransaction(ledger.TransactionCashOut, "1", amount, "1116", "")
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1115"},
+			{Type: ledger.TransactionDebit, Wallet: "till", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1115"},
+			{Type: ledger.TransactionCashOut, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1116"},
+			{Type: ledger.TransactionCredit, Wallet: "till", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: "1116"},
+		}
+
+		test.AssertRecordedTransactions(t, book.transactions, want)
+	})
+	t.Run("should post cash against the default cash account when the book isn't given one", func(t *testing.T) {
+		book := NewInMemoryBook(WithCashAccount(""))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+
+		balance, _ := book.WalletBalance(DefaultCashAccount)
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(10000, ledger.DefaultCurrency)})
+	})
+}
+
+func TestBook_RecordsTransactions(t *testing.T) {
+	recordedAt := time.Date(2019, time.March, 31, 23, 59, 0, 0, time.UTC)
+
+	t.Run("should give every new transaction an ID, the next sequence number, the time it was recorded and its effective date", func(t *testing.T) {
+		book := NewInMemoryBook(
+			WithTransactions(NewMockInMemoryBook().Transactions()),
+			WithClock(func() time.Time { return recordedAt }),
+		)
+
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		transactions := book.Transactions()
+		ids := make(map[string]bool)
+
+		for i, transaction := range transactions {
+			if transaction.Id == "" || ids[transaction.Id] {
+				t.Errorf("transaction %d has a missing or duplicate ID '%s'", i, transaction.Id)
+			}
+			ids[transaction.Id] = true
+
+			if transaction.Sequence != uint64(i+1) {
+				t.Errorf("transaction %d has incorrect sequence number, got %d, wanted %d", i, transaction.Sequence, i+1)
+			}
+		}
+
+		for _, transaction := range transactions[len(transactions)-2:] {
+			if transaction.RecordedAt != recordedAt.UnixNano() {
+				t.Errorf("transaction recorded at incorrect time, got %d, wanted %d", transaction.RecordedAt, recordedAt.UnixNano())
+			}
+
+			if transaction.EffectiveDate != "2019-03-31" {
+				t.Errorf("transaction has incorrect effective date, got '%s', wanted '%s'", transaction.EffectiveDate, "2019-03-31")
+			}
+		}
+	})
+	t.Run("should give transactions recorded without an ID the same one every time they're loaded", func(t *testing.T) {
+		first := NewMockInMemoryBook().Transactions()
+		second := NewMockInMemoryBook().Transactions()
+
+		test.AssertTransactions(t, first, second)
+	})
+}
+
 func TestBook_WalletTransactions(t *testing.T) {
 	t.Run("should return all transactions for a given wallet ID", func(t *testing.T) {
 		book := NewMockInMemoryBook()
@@ -187,7 +491,7 @@ func TestBook_WalletBalance(t *testing.T) {
 		book := NewMockInMemoryBook()
 
 		wallet := "1"
-		expected := int32(100000)
+		expected := []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)}
 
 		balance, err := book.WalletBalance(wallet)
 
@@ -245,3 +549,92 @@ func TestBook_Transactions(t *testing.T) {
 		}
 	})
 }
+
+// historySizes are the numbers of transactions a busy wallet has behind it in the benchmarks
+// balances are kept as transactions are added, so the time taken shouldn't grow with them
+var historySizes = []int{1000, 10000, 100000, 1000000}
+
+func BenchmarkBook_WalletBalance(b *testing.B) {
+	for _, size := range historySizes {
+		book := NewInMemoryBook(WithTransactions(test.WalletHistory("1", size)))
+
+		b.Run(fmt.Sprintf("history of %d", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, err := book.WalletBalance("1")
+				if err != nil {
+					b.Fatalf("returned error, %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBook_TransferWalletFunds(b *testing.B) {
+	for _, size := range historySizes {
+		book := NewInMemoryBook(WithTransactions(test.WalletHistory("1", size)))
+
+		b.Run(fmt.Sprintf("history of %d", size), func(b *testing.B) {
+			b.ReportAllocs()
+
+			// every transfer moves out the smallest amount, wallet 1 is given an overdraft so it can't run dry however long the run
+			book.SetOverdraftPolicy("1", ledger.UnlimitedOverdraft(), "")
+			book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+			for i := 0; i < b.N; i++ {
+				_, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(1, ledger.DefaultCurrency), "")
+				if err != nil {
+					b.Fatalf("returned error, %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestBook_Checkpoint(t *testing.T) {
+	t.Run("should return a copy of the state that neither the book nor a book restored from it changes", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		state, err := book.Checkpoint(nil)
+		if err != nil {
+			t.Fatalf("error returned from checkpoint, %v", err)
+		}
+
+		book.DepositWalletFunds("1", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+
+		if len(state.Transactions) != 2 || len(state.Wallets["1"]) != 1 {
+			t.Errorf("got %d transactions and %d positions for wallet 1, wanted 2 and 1", len(state.Transactions), len(state.Wallets["1"]))
+		}
+
+		if got := state.Balances["1"][ledger.DefaultCurrency]; got != ledger.NewMoney(50000, ledger.DefaultCurrency) {
+			t.Errorf("got balance %v in the state, wanted %v", got, ledger.NewMoney(50000, ledger.DefaultCurrency))
+		}
+
+		restored := NewInMemoryBook(WithState(state))
+
+		restored.DepositWalletFunds("1", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(70000, ledger.DefaultCurrency)})
+
+		balance, _ = restored.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(55000, ledger.DefaultCurrency)})
+
+		ts, _ := book.WalletTransactions("1")
+		test.AssertTransactionCount(t, ts, 2)
+	})
+	t.Run("should return the error fn returns", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		_, err := book.Checkpoint(func() error {
+			return fmt.Errorf("journal unavailable")
+		})
+		if err == nil {
+			t.Error("no error returned for a failed checkpoint")
+		}
+	})
+}
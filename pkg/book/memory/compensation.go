@@ -0,0 +1,285 @@
+package memory
+
+import (
+	"fmt"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// opposites maps each transaction type to the type that undoes it
+var opposites = map[string]string{
+	ledger.TransactionCredit:  ledger.TransactionDebit,
+	ledger.TransactionDebit:   ledger.TransactionCredit,
+	ledger.TransactionCashIn:  ledger.TransactionCashOut,
+	ledger.TransactionCashOut: ledger.TransactionCashIn,
+}
+
+// ReverseAggregate undoes every transaction in the aggregate by posting its opposite under a new aggregate, which is returned
+// each reversing transaction points back to the original aggregate and records the reason it was reversed, fees charged in it are given back too
+// an aggregate can only be reversed once, and not at all once part of it has been refunded
+func (b *Book) ReverseAggregate(aggregate string, reason string, idempotencyKey string) (string, error) {
+	err := ledger.ValidateID("aggregate", aggregate)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := fingerprint("reverse", aggregate, reason)
+
+	reversal, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		originals, err := b.compensable(aggregate)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if _, ok := b.refunds[aggregate]; ok {
+			return "", nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionPartlyRefunded, Reason: fmt.Sprintf("aggregate '%s' has been partly refunded, refund the rest of it instead", aggregate)}
+		}
+
+		reversal, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		ts := make([]ledgerpb.Transaction, len(originals))
+
+		for i, o := range originals {
+			ts[i] = compensate(o, ledger.NewMoney(o.Amount, o.Currency), reversal)
+			ts[i].ReversalOf = aggregate
+			ts[i].Reason = reason
+		}
+
+		err = b.checkDebits(ts)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return reversal, ts, nil
+	}, func(reversal string) ledger.Event {
+		return ledger.AggregateReversed{Aggregate: aggregate, Reversal: reversal, Reason: reason}
+	})
+	if err != nil {
+		return "", commandError("problem when reversing aggregate", err)
+	}
+
+	return reversal, nil
+}
+
+// RefundAggregate gives back part of an aggregate by posting the opposite of its transactions for the amount under a new aggregate, which is returned
+// the amount is in the currency of the aggregate's first transaction, for an exchange the other leg is refunded at the rate it was exchanged at
+// only aggregates of one or two transactions besides their fees can be refunded, and the refunds of an aggregate can't add up to more than it was for
+// fees charged in the aggregate aren't refunded
+func (b *Book) RefundAggregate(aggregate string, amount ledger.Money, idempotencyKey string) (string, error) {
+	err := ledger.ValidateID("aggregate", aggregate)
+	if err != nil {
+		return "", err
+	}
+
+	err = ledger.ValidateAmount(amount)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := fingerprint("refund", aggregate, amount)
+
+	refund, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		originals, err := b.compensable(aggregate)
+		if err != nil {
+			return "", nil, err
+		}
+
+		originals = withoutFees(originals)
+
+		if len(originals) > 2 {
+			return "", nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionNotCompensable, Reason: fmt.Sprintf("aggregate '%s' has %d transactions, only transfers and single transactions can be refunded", aggregate, len(originals))}
+		}
+
+		principal := ledger.NewMoney(originals[0].Amount, originals[0].Currency)
+
+		if amount.Currency != principal.Currency {
+			return "", nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionCurrencyMismatch, Reason: fmt.Sprintf("aggregate '%s' was for %v, it can't be refunded in %s", aggregate, principal, amount.Currency)}
+		}
+
+		refunded, ok := b.refunds[aggregate]
+		if !ok {
+			refunded = ledger.NewMoney(0, principal.Currency)
+		}
+
+		total, err := refunded.Add(amount)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if total.Amount > principal.Amount {
+			return "", nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionRefundExceeded, Reason: fmt.Sprintf("aggregate '%s' was for %v and %v has been refunded already, it can't be refunded %v more", aggregate, principal, refunded, amount)}
+		}
+
+		refund, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		ts := make([]ledgerpb.Transaction, len(originals))
+
+		for i, o := range originals {
+			leg := amount
+
+			// the other leg of an exchange is refunded in its own currency, at the rate of the exchange
+			if o.Currency != amount.Currency {
+				rate, err := ledger.ParseRate(o.Rate)
+				if err != nil {
+					return "", nil, fmt.Errorf("problem reading the rate of aggregate '%s', %v", aggregate, err)
+				}
+
+				leg, err = amount.Convert(o.Currency, rate)
+				if err != nil {
+					return "", nil, err
+				}
+			}
+
+			ts[i] = compensate(o, leg, refund)
+			ts[i].RefundOf = aggregate
+		}
+
+		err = b.checkDebits(ts)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return refund, ts, nil
+	}, func(refund string) ledger.Event {
+		return ledger.AggregateRefunded{Aggregate: aggregate, Refund: refund, Amount: amount}
+	})
+	if err != nil {
+		return "", commandError("problem when refunding aggregate", err)
+	}
+
+	return refund, nil
+}
+
+// compensable returns the transactions of an aggregate that can still be reversed or refunded, callers must hold the book's lock
+func (b *Book) compensable(aggregate string) ([]ledgerpb.Transaction, error) {
+	positions, ok := b.aggregateMap[aggregate]
+	if !ok {
+		return nil, &ledger.NotFoundError{Kind: "aggregate", ID: aggregate}
+	}
+
+	if reversal, ok := b.reversals[aggregate]; ok {
+		return nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionAlreadyReversed, Reason: fmt.Sprintf("aggregate '%s' was already reversed by aggregate '%s'", aggregate, reversal)}
+	}
+
+	originals := make([]ledgerpb.Transaction, 0, len(positions))
+
+	for _, p := range positions {
+		t, err := b.transaction(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.ReversalOf != "" || t.RefundOf != "" {
+			return nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionNotCompensable, Reason: fmt.Sprintf("aggregate '%s' compensates another aggregate, it can't be reversed or refunded itself", aggregate)}
+		}
+
+		// a hold moves no funds, it's voided rather than reversed, and the release of a captured hold isn't undone along with the capture
+		if t.Type == ledger.TransactionHold {
+			return nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionNotCompensable, Reason: fmt.Sprintf("aggregate '%s' is a hold, void it instead", aggregate)}
+		}
+
+		if t.Type == ledger.TransactionRelease {
+			continue
+		}
+
+		if _, ok := opposites[t.Type]; !ok {
+			return nil, fmt.Errorf("aggregate '%s' holds a transaction of invalid type '%s'", aggregate, t.Type)
+		}
+
+		originals = append(originals, *t)
+	}
+
+	if len(originals) == 0 {
+		return nil, &ledger.RejectedError{Subject: aggregate, Rejection: ledger.RejectionNotCompensable, Reason: fmt.Sprintf("aggregate '%s' only releases a hold, there's nothing to reverse or refund", aggregate)}
+	}
+
+	return originals, nil
+}
+
+// checkDebits makes sure every wallet that the transactions take funds out of can cover all of them together
+// callers must hold the book's lock
+func (b *Book) checkDebits(transactions []ledgerpb.Transaction) error {
+	out := make(map[balanceKey]ledger.Money)
+
+	for _, t := range transactions {
+		if !b.decreases(t.Type, t.Wallet) {
+			continue
+		}
+
+		key := balanceKey{wallet: t.Wallet, currency: t.Currency}
+
+		total, ok := out[key]
+		if !ok {
+			total = ledger.NewMoney(0, t.Currency)
+		}
+
+		total, err := total.Add(ledger.NewMoney(t.Amount, t.Currency))
+		if err != nil {
+			return err
+		}
+
+		out[key] = total
+	}
+
+	for key, total := range out {
+		err := b.checkFunds(key.wallet, total)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compensate returns the transaction that undoes the amount of the original, under the given aggregate
+func compensate(original ledgerpb.Transaction, amount ledger.Money, aggregate string) ledgerpb.Transaction {
+	return ledgerpb.Transaction{
+		Type:      opposites[original.Type],
+		Wallet:    original.Wallet,
+		Amount:    amount.Amount,
+		Currency:  amount.Currency,
+		Aggregate: aggregate,
+		Rate:      original.Rate,
+		Fee:       original.Fee,
+	}
+}
+
+// addCompensation keeps track of which aggregates have been reversed or refunded, as the transactions compensating them are added
+// only the first transaction of a refund counts towards the amount refunded, it mirrors the first transaction of the original
+func (b *Book) addCompensation(transaction ledgerpb.Transaction) {
+	if transaction.ReversalOf != "" {
+		b.reversals[transaction.ReversalOf] = transaction.Aggregate
+	}
+
+	if transaction.RefundOf != "" && len(b.aggregateMap[transaction.Aggregate]) == 1 {
+		refunded, ok := b.refunds[transaction.RefundOf]
+		if !ok {
+			refunded = ledger.NewMoney(0, transaction.Currency)
+		}
+
+		refunded, err := refunded.Add(ledger.NewMoney(transaction.Amount, transaction.Currency))
+		if err == nil {
+			b.refunds[transaction.RefundOf] = refunded
+		}
+	}
+}
+
+// withoutFees returns the transactions that aren't fees
+func withoutFees(transactions []ledgerpb.Transaction) []ledgerpb.Transaction {
+	kept := transactions[:0]
+
+	for _, t := range transactions {
+		if !t.Fee {
+			kept = append(kept, t)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,190 @@
+package memory
+
+import (
+	"math/big"
+	"testing"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+
+	"gitlab.com/patchwell/ledger"
+	ratememory "gitlab.com/patchwell/ledger/pkg/rate/memory"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_ReverseAggregate(t *testing.T) {
+	t.Run("should post the opposite of every transaction under a new aggregate pointing back to the original", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		reversal, err := book.ReverseAggregate("1113", "sent to the wrong wallet", "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(reversal)
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: reversal, ReversalOf: "1113", Reason: "sent to the wrong wallet"},
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: reversal, ReversalOf: "1113", Reason: "sent to the wrong wallet"},
+			{Type: ledger.TransactionCredit, Wallet: "1", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: reversal, ReversalOf: "1113", Reason: "sent to the wrong wallet"},
+			{Type: ledger.TransactionDebit, Wallet: "3", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: reversal, ReversalOf: "1113", Reason: "sent to the wrong wallet"},
+		}
+
+		test.AssertRecordedTransactions(t, dereference(ts), want)
+
+		balance, _ := book.WalletBalance("3")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(0, ledger.DefaultCurrency)})
+	})
+	t.Run("should refuse to reverse an aggregate twice", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		book.ReverseAggregate("1112", "duplicate", "")
+
+		_, err := book.ReverseAggregate("1112", "duplicate", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse to reverse a reversal", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		reversal, _ := book.ReverseAggregate("1112", "duplicate", "")
+
+		_, err := book.ReverseAggregate(reversal, "undo", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse to reverse an aggregate that has been refunded", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		book.RefundAggregate("1111", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+
+		_, err := book.ReverseAggregate("1111", "duplicate", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse to take more out of a wallet than it holds", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		book.TransferWalletFunds("3", "1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		_, err := book.ReverseAggregate("1113", "sent to the wrong wallet", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should return an error for an aggregate with no transactions", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.ReverseAggregate("9999", "missing", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func TestBook_RefundAggregate(t *testing.T) {
+	newBook := func() (*Book, string) {
+		rates := ratememory.NewInMemoryRates()
+		rates.SetRate(ledger.DefaultCurrency, "EUR", big.NewRat(92, 100))
+
+		book := NewInMemoryBook(WithRateProvider(rates))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "3"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+		transfer, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+
+		return book, transfer
+	}
+
+	t.Run("should post the opposite of each transaction for the amount under a new aggregate pointing back to the original", func(t *testing.T) {
+		book, transfer := newBook()
+
+		refund, err := book.RefundAggregate(transfer, ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(refund)
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionCredit, Wallet: "1", Amount: 2000, Currency: ledger.DefaultCurrency, Aggregate: refund, RefundOf: transfer},
+			{Type: ledger.TransactionDebit, Wallet: "2", Amount: 2000, Currency: ledger.DefaultCurrency, Aggregate: refund, RefundOf: transfer},
+		}
+
+		test.AssertRecordedTransactions(t, dereference(ts), want)
+	})
+	t.Run("should refund the other leg of an exchange at the rate it was exchanged at", func(t *testing.T) {
+		book, _ := newBook()
+
+		exchange, _ := book.ExchangeWalletFunds("1", "3", ledger.NewMoney(5000, ledger.DefaultCurrency), "EUR", "")
+
+		refund, err := book.RefundAggregate(exchange, ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("3")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(3680, "EUR")})
+
+		ts, _ := book.AggregateTransactions(refund)
+		if ts[1].GetRate() != "0.92" {
+			t.Errorf("refund has incorrect rate, got '%s', wanted '%s'", ts[1].GetRate(), "0.92")
+		}
+	})
+	t.Run("should refuse refunds adding up to more than the original amount", func(t *testing.T) {
+		book, transfer := newBook()
+
+		_, err := book.RefundAggregate(transfer, ledger.NewMoney(3000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.RefundAggregate(transfer, ledger.NewMoney(2001, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+
+		_, err = book.RefundAggregate(transfer, ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error for the rest of the amount, %v", err)
+		}
+	})
+	t.Run("should refuse to refund an aggregate that has been reversed", func(t *testing.T) {
+		book, transfer := newBook()
+
+		book.ReverseAggregate(transfer, "duplicate", "")
+
+		_, err := book.RefundAggregate(transfer, ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse a refund in another currency", func(t *testing.T) {
+		book, transfer := newBook()
+
+		_, err := book.RefundAggregate(transfer, ledger.NewMoney(1000, "EUR"), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse to refund an aggregate of more than two transactions", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.RefundAggregate("1112", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func dereference(transactions []*ledgerpb.Transaction) []ledgerpb.Transaction {
+	ts := make([]ledgerpb.Transaction, len(transactions))
+	for i, t := range transactions {
+		ts[i] = *t
+	}
+
+	return ts
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"sort"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// WithPublisher has the book hand the events of every command to the publisher once it has been committed
+// commits restored from disk and retried commands answered from their idempotency key aren't published again
+func WithPublisher(publisher ledger.Publisher) Option {
+	return func(b *Book) {
+		b.publisher = publisher
+	}
+}
+
+// publish hands the commit's events to the publisher along with the wallets it touched
+// callers must hold the book's write lock, so events are published in the order they were committed
+func (b *Book) publish(c Commit) {
+	if b.publisher == nil {
+		return
+	}
+
+	wallets := commitWallets(c)
+
+	for _, e := range c.Events {
+		b.publisher.Publish(e, wallets...)
+	}
+}
+
+// commitWallets returns the wallets a commit posted to or changed, in order
+func commitWallets(c Commit) []string {
+	seen := make(map[string]bool)
+
+	for _, t := range c.Transactions {
+		seen[t.Wallet] = true
+	}
+
+	if c.Wallet != nil {
+		seen[c.Wallet.ID] = true
+	}
+
+	if c.Overdraft != nil {
+		seen[c.Overdraft.Wallet] = true
+	}
+
+	if c.Limits != nil && c.Limits.Wallet != "" {
+		seen[c.Limits.Wallet] = true
+	}
+
+	for _, a := range c.Interest {
+		seen[a.Wallet] = true
+	}
+
+	wallets := make([]string, 0, len(seen))
+	for wallet := range seen {
+		wallets = append(wallets, wallet)
+	}
+
+	sort.Strings(wallets)
+
+	return wallets
+}
+
+// transactionAdded returns the event of a single transaction added to the book
+func transactionAdded(transactionType string, wallet string, amount ledger.Money, aggregate string) ledger.Event {
+	switch transactionType {
+	case ledger.TransactionCredit:
+		return ledger.CreditTransactionAdded{Wallet: wallet, Credit: amount, Aggregate: aggregate}
+	case ledger.TransactionDebit:
+		return ledger.DebitTransactionAdded{Wallet: wallet, Debit: amount, Aggregate: aggregate}
+	case ledger.TransactionCashIn:
+		return ledger.CashInTransactionAdded{Wallet: wallet, Credit: amount, Aggregate: aggregate}
+	default:
+		return ledger.CashOutTransactionAdded{Wallet: wallet, Debit: amount, Aggregate: aggregate}
+	}
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+type publishedEvent struct {
+	event   ledger.Event
+	wallets []string
+}
+
+type spyPublisher struct {
+	mu     sync.Mutex
+	events []publishedEvent
+}
+
+func (p *spyPublisher) Publish(event ledger.Event, wallets ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, publishedEvent{event: event, wallets: wallets})
+}
+
+func (p *spyPublisher) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := []string{}
+	for _, e := range p.events {
+		names = append(names, e.event.EventName())
+	}
+
+	return names
+}
+
+func TestBook_Publish(t *testing.T) {
+	t.Run("should publish the event of every command once it's committed, with the wallets it touched", func(t *testing.T) {
+		publisher := &spyPublisher{}
+		book := NewInMemoryBook(WithPublisher(publisher), WithCashAccount("cash"))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		deposit, _ := book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		transfer, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(400, ledger.DefaultCurrency), "")
+		book.AddTransaction(ledger.TransactionCredit, "2", ledger.NewMoney(5, ledger.DefaultCurrency), "A1", "")
+
+		want := []string{ledger.EventWalletOpened, ledger.EventWalletOpened, ledger.EventWalletFundsDeposited, ledger.EventWalletFundsTransferred, ledger.EventCreditTransactionAdded}
+		if got := publisher.names(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got events %v, wanted %v", got, want)
+		}
+
+		got := publisher.events[2]
+		if !reflect.DeepEqual(got.event, ledger.WalletFundsDeposited{Wallet: "1", Deposit: ledger.NewMoney(1000, ledger.DefaultCurrency), Aggregate: deposit}) ||
+			!reflect.DeepEqual(got.wallets, []string{"1", "cash"}) {
+			t.Errorf("got deposit event %+v for wallets %v, wanted the deposit for wallets 1 and cash", got.event, got.wallets)
+		}
+
+		got = publisher.events[3]
+		if !reflect.DeepEqual(got.event, ledger.WalletFundsTransferred{Source: "1", Destination: "2", Amount: ledger.NewMoney(400, ledger.DefaultCurrency), Aggregate: transfer}) ||
+			!reflect.DeepEqual(got.wallets, []string{"1", "2"}) {
+			t.Errorf("got transfer event %+v for wallets %v, wanted the transfer for wallets 1 and 2", got.event, got.wallets)
+		}
+	})
+	t.Run("should not publish commands that fail or are retried with their idempotency key", func(t *testing.T) {
+		publisher := &spyPublisher{}
+		book := NewInMemoryBook(WithPublisher(publisher))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "key")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "key")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "unknown", ledger.NewMoney(10, ledger.DefaultCurrency), "")
+
+		want := []string{ledger.EventWalletOpened, ledger.EventWalletFundsDeposited}
+		if got := publisher.names(); !reflect.DeepEqual(got, want) {
+			t.Errorf("got events %v, wanted %v", got, want)
+		}
+	})
+	t.Run("should publish the release of every hold that expires", func(t *testing.T) {
+		now := time.Now()
+		publisher := &spyPublisher{}
+		book := NewInMemoryBook(WithPublisher(publisher), WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(300, ledger.DefaultCurrency), now.Add(time.Minute), "")
+
+		now = now.Add(time.Hour)
+
+		err := book.ExpireHolds()
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		want := []string{ledger.EventWalletOpened, ledger.EventWalletFundsDeposited, ledger.EventHoldAuthorized, ledger.EventHoldExpired}
+		if got := publisher.names(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got events %v, wanted %v", got, want)
+		}
+
+		expired := publisher.events[3].event.(ledger.HoldExpired)
+		if expired.Hold != hold || expired.Wallet != "1" || expired.Amount != ledger.NewMoney(300, ledger.DefaultCurrency) {
+			t.Errorf("got %+v, wanted hold %s on wallet 1 for 300 to have expired", expired, hold)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// feeKey identifies the fee schedule of a command on amounts in one currency taken out of wallets in one tier
+type feeKey struct {
+	command  ledger.FeeCommand
+	tier     string
+	currency string
+}
+
+// SetFeeSchedule sets what's charged on a command taking amounts in the schedule's currency out of wallets in its tier
+// replacing any schedule already set for them, a schedule that charges nothing removes it
+// fees are posted in the same aggregate as the command, debited from the wallet funds are taken out of and credited to the schedule's wallet
+func (b *Book) SetFeeSchedule(schedule ledger.FeeSchedule, idempotencyKey string) error {
+	fingerprint := fingerprint("fee schedule", schedule)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		err := schedule.Validate()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		if !schedule.IsZero() {
+			err := b.checkDestination(schedule.Wallet)
+			if err != nil {
+				return "", Commit{}, err
+			}
+		}
+
+		return "", Commit{FeeSchedule: copyFeeSchedule(schedule)}, nil
+	}, func(string) ledger.Event {
+		return ledger.FeeScheduleSet{Schedule: *copyFeeSchedule(schedule)}
+	})
+	if err != nil {
+		return commandError("problem when setting fee schedule", err)
+	}
+
+	return nil
+}
+
+// FeeSchedules returns every fee schedule, ordered by command, tier and currency
+func (b *Book) FeeSchedules() []ledger.FeeSchedule {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.feeSchedules()
+}
+
+// feeSchedules returns copies of every fee schedule ordered by command, tier and currency, callers must hold the book's lock
+func (b *Book) feeSchedules() []ledger.FeeSchedule {
+	schedules := make([]ledger.FeeSchedule, 0, len(b.fees))
+	for _, s := range b.fees {
+		schedules = append(schedules, *copyFeeSchedule(s))
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		a, b := schedules[i], schedules[j]
+
+		if a.Command != b.Command {
+			return a.Command < b.Command
+		}
+
+		if a.Tier != b.Tier {
+			return a.Tier < b.Tier
+		}
+
+		return a.Currency < b.Currency
+	})
+
+	return schedules
+}
+
+// QuoteFee returns what the command would charge on taking the amount out of the wallet, without running it
+func (b *Book) QuoteFee(command ledger.FeeCommand, wallet string, amount ledger.Money) (ledger.FeeQuote, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if command != ledger.FeeTransfer && command != ledger.FeeWithdrawal {
+		return ledger.FeeQuote{}, fmt.Errorf("fees can't be charged on command '%s'", command)
+	}
+
+	if amount.Amount <= 0 {
+		return ledger.FeeQuote{}, fmt.Errorf("amount of %v must be greater than zero", amount)
+	}
+
+	fee, income, err := b.fee(command, wallet, amount)
+	if err != nil {
+		return ledger.FeeQuote{}, err
+	}
+
+	total, err := amount.Add(fee)
+	if err != nil {
+		return ledger.FeeQuote{}, err
+	}
+
+	return ledger.FeeQuote{Amount: amount, Fee: fee, Total: total, Wallet: income}, nil
+}
+
+// fee returns what the command charges on taking the amount out of the wallet and the fee income wallet it's credited to
+// a wallet is charged by the schedule of its tier, or the schedule for every tier when its own has none
+// fee income wallets aren't charged for moving their own funds, callers must hold the book's lock
+func (b *Book) fee(command ledger.FeeCommand, wallet string, amount ledger.Money) (ledger.Money, string, error) {
+	none := ledger.NewMoney(0, amount.Currency)
+
+	tier := b.registry[wallet].Tier
+
+	schedule, ok := b.fees[feeKey{command: command, tier: tier, currency: amount.Currency}]
+	if !ok && tier != "" {
+		schedule, ok = b.fees[feeKey{command: command, currency: amount.Currency}]
+	}
+
+	if !ok || schedule.Wallet == wallet {
+		return none, "", nil
+	}
+
+	fee, err := schedule.Fee(amount.Amount)
+	if err != nil {
+		return none, "", fmt.Errorf("problem working out fee on %v, %v", amount, err)
+	}
+
+	if fee == 0 {
+		return none, "", nil
+	}
+
+	return ledger.NewMoney(fee, amount.Currency), schedule.Wallet, nil
+}
+
+// chargeFee returns the fee the command charges on taking the amount out of the wallet, along with the transactions posting it
+// under the aggregate, the fee income wallet has to be known to the book, callers must hold the book's lock
+func (b *Book) chargeFee(command ledger.FeeCommand, wallet string, amount ledger.Money, aggregate string) (ledger.Money, []ledgerpb.Transaction, error) {
+	fee, income, err := b.fee(command, wallet, amount)
+	if err != nil {
+		return fee, nil, err
+	}
+
+	if income == "" {
+		return fee, nil, nil
+	}
+
+	err = b.checkDestination(income)
+	if err != nil {
+		return fee, nil, &ledger.RejectedError{Subject: income, Rejection: ledger.RejectionNoFeeWallet, Reason: fmt.Sprintf("problem charging fee, %v", err)}
+	}
+
+	return fee, []ledgerpb.Transaction{
+		{Type: ledger.TransactionDebit, Wallet: wallet, Amount: fee.Amount, Currency: fee.Currency, Aggregate: aggregate, Fee: true},
+		{Type: ledger.TransactionCredit, Wallet: income, Amount: fee.Amount, Currency: fee.Currency, Aggregate: aggregate, Fee: true},
+	}, nil
+}
+
+// applyFeeSchedule records a new fee schedule, schedules that charge nothing aren't kept as they're the default
+func (b *Book) applyFeeSchedule(schedule ledger.FeeSchedule) {
+	key := feeKey{command: schedule.Command, tier: schedule.Tier, currency: schedule.Currency}
+
+	if schedule.IsZero() {
+		delete(b.fees, key)
+		return
+	}
+
+	b.fees[key] = schedule
+}
+
+// copyFeeSchedule returns a copy of the schedule that doesn't share its bands
+func copyFeeSchedule(schedule ledger.FeeSchedule) *ledger.FeeSchedule {
+	if schedule.Bands != nil {
+		schedule.Bands = append([]ledger.FeeBand(nil), schedule.Bands...)
+	}
+
+	return &schedule
+}
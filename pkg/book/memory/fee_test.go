@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"testing"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_SetFeeSchedule(t *testing.T) {
+	t.Run("should charge a transfer its fee in the same aggregate, crediting the fee income wallet", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+
+		err := book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 30, BasisPoints: 100}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		aggregate, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(aggregate)
+		if len(ts) != 4 {
+			t.Fatalf("got %d transactions in the aggregate, wanted the transfer and its fee", len(ts))
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(89870, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("2")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(18000, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("fees")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(130, ledger.DefaultCurrency)})
+	})
+	t.Run("should charge a withdrawal by its own schedule, and refuse one that can't cover its fee", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 1}, "")
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeWithdrawal, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 200}, "")
+
+		_, err := book.WithdrawWalletFunds("1", ledger.NewMoney(99900, ledger.DefaultCurrency), "")
+		assertInsufficientFunds(t, err, "1")
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(99800, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(0, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("fees")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(200, ledger.DefaultCurrency)})
+	})
+	t.Run("should charge a wallet by its tier's schedule, or the one for every tier when its tier has none", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1", Tier: "gold"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2", Tier: "basic"}, "")
+
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeWithdrawal, Currency: "EUR", Wallet: "fees", Flat: 100}, "")
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeWithdrawal, Tier: "gold", Currency: "EUR", Wallet: "fees", Flat: 10}, "")
+
+		for wallet, want := range map[string]int64{"1": 10, "2": 100, "3": 100} {
+			quote, err := book.QuoteFee(ledger.FeeWithdrawal, wallet, ledger.NewMoney(5000, "EUR"))
+			if err != nil {
+				t.Fatalf("returned error, %v", err)
+			}
+
+			if quote.Fee != ledger.NewMoney(want, "EUR") || quote.Total != ledger.NewMoney(5000+want, "EUR") || quote.Wallet != "fees" {
+				t.Errorf("got quote %+v for wallet '%s', wanted a fee of %d EUR", quote, wallet, want)
+			}
+		}
+
+		quote, _ := book.QuoteFee(ledger.FeeWithdrawal, "1", ledger.NewMoney(5000, ledger.DefaultCurrency))
+		if quote.Fee.Amount != 0 || quote.Wallet != "" {
+			t.Errorf("got quote %+v in a currency with no schedule, wanted no fee", quote)
+		}
+	})
+	t.Run("should give the fee back when the transfer is reversed, but not when it's refunded", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 100}, "")
+
+		reversed, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		refunded, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		_, err := book.ReverseAggregate(reversed, "sent twice", "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.RefundAggregate(refunded, ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(99900, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalance("fees")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(100, ledger.DefaultCurrency)})
+	})
+	t.Run("should stop charging once a schedule charging nothing replaces it", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 100}, "")
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency}, "")
+
+		if s := book.FeeSchedules(); len(s) != 0 {
+			t.Errorf("got schedules %v, wanted none", s)
+		}
+
+		aggregate, _ := book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		ts, _ := book.AggregateTransactions(aggregate)
+		if len(ts) != 2 {
+			t.Errorf("got %d transactions in the aggregate, wanted just the transfer", len(ts))
+		}
+	})
+	t.Run("should refuse a schedule crediting a wallet the book doesn't know", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		err := book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 100}, "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// TransactionsAfter returns copies of up to limit transactions with a sequence number after the cursor, in commit order
+// or all of them when limit is zero, none are returned if the cursor is in the book's archive and it can't be read
+func (b *Book) TransactionsAfter(cursor uint64, limit int) []ledgerpb.Transaction {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	position := b.positionAfter(cursor)
+
+	if position < b.archived && b.loadArchive() != nil {
+		return []ledgerpb.Transaction{}
+	}
+
+	n := b.size() - position
+	if limit > 0 && n > limit {
+		n = limit
+	}
+
+	ts := make([]ledgerpb.Transaction, n)
+	for i := range ts {
+		ts[i] = *b.at(position + i)
+	}
+
+	return ts
+}
+
+// WaitForTransactions blocks until a transaction with a sequence number after the cursor has been committed
+// returning straight away if there already is one, or with the context's error once it's done
+// if the cursor is in the book's archive it's read first, returning the error if it can't be
+func (b *Book) WaitForTransactions(ctx context.Context, cursor uint64) error {
+	for {
+		b.mu.RLock()
+		position := b.positionAfter(cursor)
+		found := position < b.size()
+		appended := b.appended
+
+		var err error
+		if position < b.archived {
+			err = b.loadArchive()
+		}
+		b.mu.RUnlock()
+
+		if err != nil {
+			return err
+		}
+
+		if found {
+			return nil
+		}
+
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// positionAfter returns the position of the first transaction with a sequence number after the cursor
+// sequence numbers only ever go up, callers must hold the book's read lock
+func (b *Book) positionAfter(cursor uint64) int {
+	// transactions are numbered from 1 in the order they're added, so a cursor in the archive is the position after it without reading it
+	if cursor < uint64(b.archived) {
+		return int(cursor)
+	}
+
+	return b.archived + sort.Search(len(b.transactions), func(i int) bool {
+		return b.transactions[i].Sequence > cursor
+	})
+}
+
+// notifyAppended wakes everything waiting for transactions, callers must hold the book's write lock
+func (b *Book) notifyAppended() {
+	close(b.appended)
+	b.appended = make(chan struct{})
+}
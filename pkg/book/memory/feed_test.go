@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+func TestBook_TransactionsAfter(t *testing.T) {
+	t.Run("should return the transactions after the cursor in commit order, a page at a time", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		var sequences []uint64
+		for cursor := uint64(0); ; {
+			page := book.TransactionsAfter(cursor, 5)
+			if len(page) == 0 {
+				break
+			}
+
+			for _, transaction := range page {
+				sequences = append(sequences, transaction.Sequence)
+			}
+
+			cursor = page[len(page)-1].Sequence
+		}
+
+		if len(sequences) != 12 {
+			t.Fatalf("got sequences %v, wanted all 12 transactions", sequences)
+		}
+
+		for i, sequence := range sequences {
+			if sequence != uint64(i+1) {
+				t.Fatalf("got sequences %v, wanted each transaction once in commit order", sequences)
+			}
+		}
+
+		if got := book.TransactionsAfter(10, 0); len(got) != 2 || got[0].Sequence != 11 {
+			t.Errorf("got %+v, wanted every transaction after the 10th", got)
+		}
+	})
+	t.Run("should hand out copies of the transactions", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		book.TransactionsAfter(0, 1)[0].Amount = 1
+
+		if got := book.TransactionsAfter(0, 1)[0].Amount; got != 100000 {
+			t.Errorf("got amount %d, wanted the book left as it was", got)
+		}
+	})
+}
+
+func TestBook_WaitForTransactions(t *testing.T) {
+	t.Run("should return once a transaction after the cursor is committed", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		ts := book.Transactions()
+		cursor := ts[len(ts)-1].Sequence
+
+		waited := make(chan error)
+		go func() {
+			waited <- book.WaitForTransactions(context.Background(), cursor)
+		}()
+
+		select {
+		case err := <-waited:
+			t.Fatalf("returned %v before anything was committed", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		book.DepositWalletFunds("1", ledger.NewMoney(500, ledger.DefaultCurrency), "")
+
+		select {
+		case err := <-waited:
+			if err != nil {
+				t.Errorf("returned error, %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("still waiting once a transaction was committed")
+		}
+	})
+	t.Run("should return straight away when there are transactions after the cursor", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		err := book.WaitForTransactions(context.Background(), 11)
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+	})
+	t.Run("should return the context's error once it's done", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := book.WaitForTransactions(ctx, 12)
+		if err != context.DeadlineExceeded {
+			t.Errorf("got error %v, wanted %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// balanceHistory is a wallet's balance in one currency after each transaction that changed it, in the order they were added
+type balanceHistory struct {
+	positions []int   // positions of the transactions in the book
+	amounts   []int64 // the wallet's balance after each of them
+}
+
+// WalletBalanceAt returns the wallet's current balance in each currency it had transactions in at the point, ordered by currency code
+// a zero point is now, holds aren't taken into account, only transactions posted to the wallet
+func (b *Book) WalletBalanceAt(wallet string, at ledger.Point) ([]ledger.Money, error) {
+	err := at.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	err = b.checkBalanced(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	// the history is built from every transaction in the book
+	err = b.loadArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := []ledger.Money{}
+
+	for currency := range b.walletHistory(wallet) {
+		balance, ok := b.balanceAt(wallet, currency, at)
+		if !ok {
+			continue
+		}
+
+		balances = append(balances, ledger.NewMoney(balance, currency))
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+// balanceAt returns the wallet's balance in the currency at the point, or false if it had no transactions in the currency by then
+// callers must hold the book's lock and have read its archive
+func (b *Book) balanceAt(wallet string, currency string, at ledger.Point) (int64, bool) {
+	h, ok := b.walletHistory(wallet)[currency]
+	if !ok {
+		return 0, false
+	}
+
+	// the first transaction after the point, the balance at the point is the one after the transaction before it
+	i := sort.Search(len(h.positions), func(i int) bool {
+		return !b.reached(h.positions[i], at)
+	})
+
+	if i == 0 {
+		return 0, false
+	}
+
+	return h.amounts[i-1], true
+}
+
+// WalletTransactionsBetween returns the wallet's transactions recorded after from, up to and including to
+// so the balance at from plus the transactions adds up to the balance at to, a zero from is the start of the history and a zero to is now
+func (b *Book) WalletTransactionsBetween(wallet string, from ledger.Point, to ledger.Point) ([]*ledgerpb.Transaction, error) {
+	for _, p := range []ledger.Point{from, to} {
+		err := p.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	err := b.checkBalanced(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := b.walletMap[wallet]
+
+	if len(positions) > 0 && positions[0] < b.archived {
+		err = b.loadArchive()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(positions), func(i int) bool {
+			return !b.reached(positions[i], from)
+		})
+	}
+
+	end := sort.Search(len(positions), func(i int) bool {
+		return !b.reached(positions[i], to)
+	})
+
+	if start > end {
+		return nil, fmt.Errorf("from %v comes after to %v", from, to)
+	}
+
+	return b.copyTransactions(positions[start:end])
+}
+
+// reached reports whether the transaction at the position was recorded by the point, every transaction has been by a zero point
+// callers must hold the book's lock and have read its archive if the position is in it
+func (b *Book) reached(position int, p ledger.Point) bool {
+	t := b.at(position)
+
+	switch {
+	case p.Sequence != 0:
+		return t.Sequence <= p.Sequence
+	case !p.Time.IsZero():
+		return t.RecordedAt <= p.Time.UnixNano()
+	default:
+		return true
+	}
+}
+
+// walletHistory returns the wallet's balance history in each currency, building the book's history on the first call
+// callers must hold the book's lock and have read its archive, readers sharing it are kept apart while it's built by historyOnce
+func (b *Book) walletHistory(wallet string) map[string]*balanceHistory {
+	b.historyOnce.Do(b.rebuildHistory)
+
+	return b.history[wallet]
+}
+
+// addHistory records the wallet's balance after the transaction at the position, if the transaction changed it
+// nothing is recorded before the history is built, building it takes in every transaction added until then
+// a wallet whose balance can't be worked out stops being recorded, queries about it return why instead
+func (b *Book) addHistory(position int, transaction ledgerpb.Transaction) {
+	if b.history == nil || ledger.Side(transaction.Type) == "" {
+		return
+	}
+
+	histories, ok := b.history[transaction.Wallet]
+	if !ok {
+		histories = make(map[string]*balanceHistory)
+		b.history[transaction.Wallet] = histories
+	}
+
+	h, ok := histories[transaction.Currency]
+	if !ok {
+		h = &balanceHistory{}
+		histories[transaction.Currency] = h
+	}
+
+	var balance ledger.Money
+	if n := len(h.amounts); n > 0 {
+		balance = ledger.NewMoney(h.amounts[n-1], transaction.Currency)
+	}
+
+	balance, err := nextBalance(balance, len(h.amounts) > 0, transaction, b.normalSide(transaction.Wallet))
+	if err != nil {
+		return
+	}
+
+	h.positions = append(h.positions, position)
+	h.amounts = append(h.amounts, balance.Amount)
+}
+
+// rebuildHistory works out every wallet's balance history from the book's transactions, it isn't saved along with them
+// so it's only done once something asks for a balance at a point in time, not every time the book is loaded
+func (b *Book) rebuildHistory() {
+	b.history = make(map[string]map[string]*balanceHistory)
+
+	for i := 0; i < b.size(); i++ {
+		b.addHistory(i, *b.at(i))
+	}
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_WalletBalanceAt(t *testing.T) {
+	march := time.Date(2019, time.March, 31, 23, 59, 0, 0, time.UTC)
+
+	// a deposit on the 31st, a transfer and a deposit in euros on the 1st, one day apart
+	newBook := func() *Book {
+		now := march.Add(-time.Hour)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		now = march.Add(time.Hour)
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("1", ledger.NewMoney(300, "EUR"), "")
+
+		return book
+	}
+
+	t.Run("should return the balance at a time", func(t *testing.T) {
+		book := newBook()
+
+		balance, err := book.WalletBalanceAt("1", ledger.AtTime(march))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(50000, ledger.DefaultCurrency)})
+
+		balance, _ = book.WalletBalanceAt("1", ledger.AtTime(march.Add(time.Hour)))
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(300, "EUR"), ledger.NewMoney(30000, ledger.DefaultCurrency)})
+	})
+	t.Run("should return the balance just after a sequence number", func(t *testing.T) {
+		book := newBook()
+
+		balance, err := book.WalletBalanceAt("1", ledger.AtSequence(4))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(30000, ledger.DefaultCurrency)})
+	})
+	t.Run("should return the current balance at a zero point", func(t *testing.T) {
+		book := newBook()
+
+		balance, _ := book.WalletBalanceAt("1", ledger.Point{})
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(300, "EUR"), ledger.NewMoney(30000, ledger.DefaultCurrency)})
+	})
+	t.Run("should return no balances before the wallet's first transaction", func(t *testing.T) {
+		book := newBook()
+
+		balance, err := book.WalletBalanceAt("2", ledger.AtTime(march))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertBalance(t, balance, []ledger.Money{})
+	})
+	t.Run("should return an error for a wallet without transactions or a point that's both a time and a sequence number", func(t *testing.T) {
+		book := newBook()
+
+		_, err := book.WalletBalanceAt("3", ledger.AtTime(march))
+		if err == nil {
+			t.Error("no error returned for a wallet without transactions")
+		}
+
+		_, err = book.WalletBalanceAt("1", ledger.Point{Time: march, Sequence: 1})
+		if err == nil {
+			t.Error("no error returned for a point that's both a time and a sequence number")
+		}
+	})
+	t.Run("should keep transactions in time order when the clock steps back", func(t *testing.T) {
+		now := march
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		now = march.Add(-time.Minute)
+		book.DepositWalletFunds("1", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+
+		balance, _ := book.WalletBalanceAt("1", ledger.AtTime(march.Add(-time.Second)))
+		test.AssertBalance(t, balance, []ledger.Money{})
+
+		balance, _ = book.WalletBalanceAt("1", ledger.AtTime(march))
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(200, ledger.DefaultCurrency)})
+	})
+	t.Run("should work out the history again when restored from a state", func(t *testing.T) {
+		book := newBook()
+
+		state, _ := book.Checkpoint(nil)
+		restored := NewInMemoryBook(WithState(state))
+
+		for _, at := range []ledger.Point{ledger.AtTime(march), ledger.AtSequence(2), {}} {
+			got, _ := restored.WalletBalanceAt("1", at)
+			want, _ := book.WalletBalanceAt("1", at)
+
+			test.AssertBalance(t, got, want)
+		}
+	})
+	t.Run("should only work out the history once a balance at a point in time is asked for", func(t *testing.T) {
+		book := newBook()
+
+		state, _ := book.Checkpoint(nil)
+		restored := NewInMemoryBook(WithState(state))
+
+		restored.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		if restored.history != nil {
+			t.Fatal("history was worked out before any balance at a point in time was asked for")
+		}
+
+		balance, _ := restored.WalletBalanceAt("1", ledger.AtSequence(7))
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(300, "EUR"), ledger.NewMoney(31000, ledger.DefaultCurrency)})
+
+		restored.DepositWalletFunds("1", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+
+		balance, _ = restored.WalletBalanceAt("1", ledger.Point{})
+		test.AssertBalance(t, balance, []ledger.Money{ledger.NewMoney(300, "EUR"), ledger.NewMoney(33000, ledger.DefaultCurrency)})
+	})
+}
+
+func TestBook_WalletTransactionsBetween(t *testing.T) {
+	t.Run("should return the transactions after from up to and including to", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		ts, err := book.WalletTransactionsBetween("1", ledger.AtSequence(2), ledger.AtSequence(6))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if len(ts) != 2 || ts[0].Sequence != 3 || ts[1].Sequence != 5 {
+			t.Fatalf("got transactions %v, wanted the wallet's ones with sequence numbers 3 and 5", ts)
+		}
+
+		// the opening balance plus the transactions adds up to the closing balance
+		opening, _ := book.WalletBalanceAt("1", ledger.AtSequence(2))
+		closing, _ := book.WalletBalanceAt("1", ledger.AtSequence(6))
+
+		if opening[0].Amount-ts[0].Amount-ts[1].Amount != closing[0].Amount {
+			t.Errorf("opening balance %v and transactions %v don't add up to closing balance %v", opening, ts, closing)
+		}
+
+		ts, _ = book.WalletTransactionsBetween("1", ledger.Point{}, ledger.Point{})
+		if len(ts) != 4 {
+			t.Errorf("got %d transactions between zero points, wanted all 4", len(ts))
+		}
+	})
+	t.Run("should return an error when from comes after to", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("1", ledger.NewMoney(50000, ledger.DefaultCurrency), "")
+
+		_, err := book.WalletTransactionsBetween("1", ledger.AtSequence(3), ledger.AtSequence(1))
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// DefaultHoldExpiry is how long a hold lasts when it's authorized without an expiry
+const DefaultHoldExpiry = 7 * 24 * time.Hour
+
+// Hold is funds set aside in a wallet that haven't been captured or voided since
+type Hold struct {
+	Amount    ledger.Money `json:"amount"`
+	ExpiresAt int64        `json:"expires_at"` // unix time in nanoseconds the hold lapses at, it no longer counts against the wallet after
+}
+
+// AuthorizeHold sets an amount aside in the wallet until it's captured, voided or expires, returning the hold's aggregate
+// the hold takes the amount off the wallet's available balance but not its current balance, a zero expiresAt lasts DefaultHoldExpiry
+func (b *Book) AuthorizeHold(wallet string, amount ledger.Money, expiresAt time.Time, idempotencyKey string) (string, error) {
+	err := validateMovement(amount, wallet)
+	if err != nil {
+		return "", err
+	}
+
+	var expires int64
+	if !expiresAt.IsZero() {
+		expires = expiresAt.UnixNano()
+	}
+
+	fingerprint := fingerprint("authorize", wallet, amount, expires)
+
+	hold, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		now := b.clock()
+
+		if expires == 0 {
+			expires = now.Add(DefaultHoldExpiry).UnixNano()
+		} else if expires <= now.UnixNano() {
+			return "", nil, &ledger.ValidationError{Field: "expires_at", Value: expiresAt.String(), Reason: "has already passed"}
+		}
+
+		err := b.checkFunds(wallet, amount)
+		if err != nil {
+			return "", nil, err
+		}
+
+		hold, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		return hold, []ledgerpb.Transaction{
+			{Type: ledger.TransactionHold, Wallet: wallet, Amount: amount.Amount, Currency: amount.Currency, Aggregate: hold, ExpiresAt: expires},
+		}, nil
+	}, func(hold string) ledger.Event {
+		return ledger.HoldAuthorized{Wallet: wallet, Amount: amount, ExpiresAt: time.Unix(0, expires).UTC(), Hold: hold}
+	})
+	if err != nil {
+		return "", commandError("problem when authorizing hold", err)
+	}
+
+	return hold, nil
+}
+
+// CaptureHold transfers up to the amount held to the destination wallet and releases the whole hold, returning the capture's aggregate
+// a hold can only be captured once, whatever isn't captured goes back to the wallet's available balance
+func (b *Book) CaptureHold(hold string, destination string, amount ledger.Money, idempotencyKey string) (string, error) {
+	err := ledger.ValidateID("hold", hold)
+	if err != nil {
+		return "", err
+	}
+
+	err = validateMovement(amount, destination)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := fingerprint("capture", hold, destination, amount)
+
+	capture, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		now := b.clock()
+
+		wallet, h, err := b.openHold(hold)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = b.checkDestination(destination)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if h.ExpiresAt <= now.UnixNano() {
+			return "", nil, &ledger.RejectedError{Subject: hold, Rejection: ledger.RejectionHoldExpired, Reason: fmt.Sprintf("hold '%s' expired at %v", hold, time.Unix(0, h.ExpiresAt).UTC())}
+		}
+
+		if amount.Currency != h.Amount.Currency {
+			return "", nil, &ledger.RejectedError{Subject: hold, Rejection: ledger.RejectionCurrencyMismatch, Reason: fmt.Sprintf("hold '%s' is for %v, it can't be captured in %s", hold, h.Amount, amount.Currency)}
+		}
+
+		if amount.Amount > h.Amount.Amount {
+			return "", nil, &ledger.RejectedError{Subject: hold, Rejection: ledger.RejectionCaptureExceeded, Reason: fmt.Sprintf("capture of %v must be no more than the %v held", amount, h.Amount)}
+		}
+
+		// the hold is released in the same commit, so what it set aside counts towards the funds available to capture
+		available, err := b.availableBalance(wallet, amount.Currency, now)
+		if err != nil {
+			return "", nil, err
+		}
+
+		available, err = available.Add(h.Amount)
+		if err != nil {
+			return "", nil, err
+		}
+
+		err = b.checkOverdraft(wallet, available, amount)
+		if err != nil {
+			return "", nil, err
+		}
+
+		capture, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		return capture, []ledgerpb.Transaction{
+			{Type: ledger.TransactionRelease, Wallet: wallet, Amount: h.Amount.Amount, Currency: h.Amount.Currency, Aggregate: capture, Hold: hold},
+			{Type: ledger.TransactionDebit, Wallet: wallet, Amount: amount.Amount, Currency: amount.Currency, Aggregate: capture, Hold: hold},
+			{Type: ledger.TransactionCredit, Wallet: destination, Amount: amount.Amount, Currency: amount.Currency, Aggregate: capture, Hold: hold},
+		}, nil
+	}, func(capture string) ledger.Event {
+		return ledger.HoldCaptured{Hold: hold, Destination: destination, Amount: amount, Aggregate: capture}
+	})
+	if err != nil {
+		return "", commandError("problem when capturing hold", err)
+	}
+
+	return capture, nil
+}
+
+// VoidHold releases a hold without capturing any of it, returning the aggregate of the release
+func (b *Book) VoidHold(hold string, idempotencyKey string) (string, error) {
+	err := ledger.ValidateID("hold", hold)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := fingerprint("void", hold)
+
+	void, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		wallet, h, err := b.openHold(hold)
+		if err != nil {
+			return "", nil, err
+		}
+
+		void, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		return void, []ledgerpb.Transaction{
+			{Type: ledger.TransactionRelease, Wallet: wallet, Amount: h.Amount.Amount, Currency: h.Amount.Currency, Aggregate: void, Hold: hold},
+		}, nil
+	}, func(void string) ledger.Event {
+		return ledger.HoldVoided{Hold: hold, Aggregate: void}
+	})
+	if err != nil {
+		return "", commandError("problem when voiding hold", err)
+	}
+
+	return void, nil
+}
+
+// ExpireHolds releases every hold that has expired, so the book records when each one lapsed
+// expired holds already stop counting against a wallet's available balance, this only writes that down
+func (b *Book) ExpireHolds() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock()
+
+	wallets := make([]string, 0, len(b.holds))
+	for wallet := range b.holds {
+		wallets = append(wallets, wallet)
+	}
+	sort.Strings(wallets)
+
+	var c Commit
+
+	for _, wallet := range wallets {
+		holds := make([]string, 0, len(b.holds[wallet]))
+		for hold, h := range b.holds[wallet] {
+			if h.ExpiresAt <= now.UnixNano() {
+				holds = append(holds, hold)
+			}
+		}
+		sort.Strings(holds)
+
+		for _, hold := range holds {
+			h := b.holds[wallet][hold]
+
+			aggregate, err := genUUID()
+			if err != nil {
+				return fmt.Errorf("problem when expiring holds: %v", err)
+			}
+
+			c.Transactions = append(c.Transactions, ledgerpb.Transaction{
+				Type: ledger.TransactionRelease, Wallet: wallet, Amount: h.Amount.Amount, Currency: h.Amount.Currency, Aggregate: aggregate, Hold: hold, Reason: "expired",
+			})
+			c.Events = append(c.Events, ledger.HoldExpired{Hold: hold, Wallet: wallet, Amount: h.Amount, Aggregate: aggregate})
+		}
+	}
+
+	if len(c.Transactions) == 0 {
+		return nil
+	}
+
+	err := b.commit(c, now)
+	if err != nil {
+		return fmt.Errorf("problem when expiring holds: %v", err)
+	}
+
+	return nil
+}
+
+// openHold returns the wallet and details of a hold that hasn't been captured or voided, callers must hold the book's lock
+func (b *Book) openHold(hold string) (string, Hold, error) {
+	positions, ok := b.aggregateMap[hold]
+	if !ok {
+		return "", Hold{}, &ledger.NotFoundError{Kind: "hold", ID: hold}
+	}
+
+	first, err := b.transaction(positions[0])
+	if err != nil {
+		return "", Hold{}, err
+	}
+
+	if first.Type != ledger.TransactionHold {
+		return "", Hold{}, &ledger.NotFoundError{Kind: "hold", ID: hold}
+	}
+
+	wallet := first.Wallet
+
+	h, ok := b.holds[wallet][hold]
+	if !ok {
+		return "", Hold{}, &ledger.RejectedError{Subject: hold, Rejection: ledger.RejectionHoldSettled, Reason: fmt.Sprintf("hold '%s' has already been captured, voided or expired", hold)}
+	}
+
+	return wallet, h, nil
+}
+
+// availableBalance returns the wallet's current balance in the currency less the holds on it that haven't expired by now
+// callers must hold the book's lock
+func (b *Book) availableBalance(wallet string, currency string, now time.Time) (ledger.Money, error) {
+	available, ok := b.balances[wallet][currency]
+	if !ok {
+		available = ledger.NewMoney(0, currency)
+	}
+
+	for _, h := range b.holds[wallet] {
+		if h.Amount.Currency != currency || h.ExpiresAt <= now.UnixNano() {
+			continue
+		}
+
+		var err error
+
+		available, err = available.Sub(h.Amount)
+		if err != nil {
+			return ledger.Money{}, fmt.Errorf("problem working out available balance of wallet '%s': %v", wallet, err)
+		}
+	}
+
+	return available, nil
+}
+
+// addHold keeps track of the holds on each wallet as the transactions placing and releasing them are added
+func (b *Book) addHold(transaction ledgerpb.Transaction) {
+	switch transaction.Type {
+	case ledger.TransactionHold:
+		holds, ok := b.holds[transaction.Wallet]
+		if !ok {
+			holds = make(map[string]Hold)
+			b.holds[transaction.Wallet] = holds
+		}
+
+		holds[transaction.Aggregate] = Hold{Amount: ledger.NewMoney(transaction.Amount, transaction.Currency), ExpiresAt: transaction.ExpiresAt}
+	case ledger.TransactionRelease:
+		delete(b.holds[transaction.Wallet], transaction.Hold)
+
+		if len(b.holds[transaction.Wallet]) == 0 {
+			delete(b.holds, transaction.Wallet)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_AuthorizeHold(t *testing.T) {
+	t.Run("should take the hold off the available balance but not the current balance", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.AuthorizeHold("1", ledger.NewMoney(40000, ledger.DefaultCurrency), time.Time{}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)})
+		test.AssertAvailableBalance(t, balance, []ledger.Money{ledger.NewMoney(60000, ledger.DefaultCurrency)})
+	})
+	t.Run("should refuse transfers, withdrawals and holds the available balance can't cover", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		book.AuthorizeHold("1", ledger.NewMoney(90000, ledger.DefaultCurrency), time.Time{}, "")
+
+		_, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned transferring held funds")
+		}
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(20000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned withdrawing held funds")
+		}
+
+		_, err = book.AuthorizeHold("1", ledger.NewMoney(20000, ledger.DefaultCurrency), time.Time{}, "")
+		if err == nil {
+			t.Error("no error returned holding held funds")
+		}
+	})
+	t.Run("should refuse a hold that has already expired", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.AuthorizeHold("1", ledger.NewMoney(100, ledger.DefaultCurrency), time.Now().Add(-time.Minute), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func TestBook_CaptureHold(t *testing.T) {
+	t.Run("should transfer the captured amount and release the rest of the hold", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(40000, ledger.DefaultCurrency), time.Time{}, "")
+
+		capture, err := book.CaptureHold(hold, "3", ledger.NewMoney(25000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(capture)
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionRelease, Wallet: "1", Amount: 40000, Currency: ledger.DefaultCurrency, Aggregate: capture, Hold: hold},
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 25000, Currency: ledger.DefaultCurrency, Aggregate: capture, Hold: hold},
+			{Type: ledger.TransactionCredit, Wallet: "3", Amount: 25000, Currency: ledger.DefaultCurrency, Aggregate: capture, Hold: hold},
+		}
+
+		test.AssertRecordedTransactions(t, dereference(ts), want)
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(75000, ledger.DefaultCurrency)})
+		test.AssertAvailableBalance(t, balance, []ledger.Money{ledger.NewMoney(75000, ledger.DefaultCurrency)})
+
+		_, err = book.CaptureHold(hold, "3", ledger.NewMoney(15000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned capturing a hold twice")
+		}
+	})
+	t.Run("should refuse to capture more than the hold, or in another currency", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(40000, ledger.DefaultCurrency), time.Time{}, "")
+
+		_, err := book.CaptureHold(hold, "3", ledger.NewMoney(40001, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned capturing more than the hold")
+		}
+
+		_, err = book.CaptureHold(hold, "3", ledger.NewMoney(100, "EUR"), "")
+		if err == nil {
+			t.Error("no error returned capturing in another currency")
+		}
+	})
+	t.Run("should refuse to capture a hold once it has expired", func(t *testing.T) {
+		now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(1000, ledger.DefaultCurrency), now.Add(time.Hour), "")
+
+		now = now.Add(time.Hour)
+
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+		_, err := book.CaptureHold(hold, "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refund a capture without touching the released hold", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(40000, ledger.DefaultCurrency), time.Time{}, "")
+		capture, _ := book.CaptureHold(hold, "3", ledger.NewMoney(25000, ledger.DefaultCurrency), "")
+
+		_, err := book.RefundAggregate(capture, ledger.NewMoney(25000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertAvailableBalance(t, balance, []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)})
+	})
+}
+
+func TestBook_VoidHold(t *testing.T) {
+	t.Run("should give the held funds back to the available balance", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(40000, ledger.DefaultCurrency), time.Time{}, "")
+
+		_, err := book.VoidHold(hold, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertAvailableBalance(t, balance, []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)})
+
+		_, err = book.VoidHold(hold, "")
+		if err == nil {
+			t.Error("no error returned voiding a hold twice")
+		}
+
+		_, err = book.ReverseAggregate(hold, "undo", "")
+		if err == nil {
+			t.Error("no error returned reversing a hold")
+		}
+	})
+	t.Run("should return an error for an aggregate that isn't a hold", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.VoidHold("1112", "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func TestBook_ExpireHolds(t *testing.T) {
+	t.Run("should stop counting expired holds and release them when asked to", func(t *testing.T) {
+		now := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		expiring, _ := book.AuthorizeHold("1", ledger.NewMoney(300, ledger.DefaultCurrency), now.Add(time.Hour), "")
+		book.AuthorizeHold("1", ledger.NewMoney(200, ledger.DefaultCurrency), now.Add(2*time.Hour), "")
+
+		now = now.Add(time.Hour)
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertAvailableBalance(t, balance, []ledger.Money{ledger.NewMoney(800, ledger.DefaultCurrency)})
+
+		err := book.ExpireHolds()
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.WalletTransactions("1")
+		released := ts[len(ts)-1]
+
+		if released.Type != ledger.TransactionRelease || released.Hold != expiring || released.Reason != "expired" {
+			t.Errorf("got last transaction %v, wanted the release of hold '%s'", released, expiring)
+		}
+
+		count := len(book.Transactions())
+
+		book.ExpireHolds()
+
+		if len(book.Transactions()) != count {
+			t.Error("expired holds released more than once")
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// DefaultIdempotencyRetention is how long an idempotency key is remembered for unless the book is given another window
+const DefaultIdempotencyRetention = 24 * time.Hour
+
+// IdempotencyRecord is what an idempotency key was used for, so a retried command can be answered without running it again
+type IdempotencyRecord struct {
+	Key         string `json:"key"`
+	Fingerprint string `json:"fingerprint"` // hash of the command and its arguments
+	Result      string `json:"result"`      // what the command returned, the aggregate of the transactions it added
+	RecordedAt  int64  `json:"recorded_at"` // unix time in nanoseconds the command was committed
+}
+
+// WithIdempotencyRetention sets how long idempotency keys are remembered for, a command retried after that runs again
+func WithIdempotencyRetention(retention time.Duration) Option {
+	return func(b *Book) {
+		b.retention = retention
+	}
+}
+
+// execute runs a command under the write lock, build checks the command can go ahead and returns its result along with the transactions to commit
+// and announce returns the event published once they're committed, given the result
+// a command given an idempotency key that's still remembered isn't run again, the result of the first attempt is returned instead
+// and nothing is published
+func (b *Book) execute(key string, fingerprint string, build func() (string, []ledgerpb.Transaction, error), announce func(result string) ledger.Event) (string, error) {
+	return b.executeCommit(key, fingerprint, func() (string, Commit, error) {
+		result, transactions, err := build()
+
+		return result, Commit{Transactions: transactions}, err
+	}, announce)
+}
+
+// executeCommit is execute for commands that commit more than transactions
+func (b *Book) executeCommit(key string, fingerprint string, build func() (string, Commit, error), announce func(result string) ledger.Event) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock()
+
+	if result, ok, err := b.recall(key, fingerprint, now); ok {
+		return result, err
+	}
+
+	result, c, err := build()
+	if err != nil {
+		return "", err
+	}
+
+	c.Events = append(c.Events, announce(result))
+
+	if key != "" {
+		c.Idempotency = &IdempotencyRecord{Key: key, Fingerprint: fingerprint, Result: result, RecordedAt: now.UnixNano()}
+	}
+
+	err = b.commit(c, now)
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+// replay returns the result of a command already run with the idempotency key, for commands with work to do before taking the write lock
+// it returns false if the key isn't remembered, execute must still be used to run the command
+func (b *Book) replay(key string, fingerprint string) (string, bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.recall(key, fingerprint, b.clock())
+}
+
+// recall looks up a remembered idempotency key, returning the result of the command it was used for
+// or an IdempotencyConflictError if that wasn't the command with the given fingerprint, callers must hold the book's lock
+func (b *Book) recall(key string, fingerprint string, now time.Time) (string, bool, error) {
+	if key == "" {
+		return "", false, nil
+	}
+
+	r, ok := b.idempotency[key]
+	if !ok || b.expired(r, now.UnixNano()) {
+		return "", false, nil
+	}
+
+	if r.Fingerprint != fingerprint {
+		return "", true, &ledger.IdempotencyConflictError{Key: key}
+	}
+
+	return r.Result, true, nil
+}
+
+// remember stores an idempotency record and forgets every key that has expired since, callers must hold the book's write lock
+func (b *Book) remember(r IdempotencyRecord) {
+	b.idempotency[r.Key] = r
+	b.keys = append(b.keys, r.Key)
+
+	// keys are used in time order, so the expired ones are always at the front
+	for len(b.keys) > 0 {
+		oldest, ok := b.idempotency[b.keys[0]]
+
+		// a key reused once it expired is queued again, only the newest use of it counts
+		if ok && !b.expired(oldest, r.RecordedAt) {
+			break
+		}
+
+		if ok {
+			delete(b.idempotency, oldest.Key)
+		}
+
+		b.keys = b.keys[1:]
+	}
+}
+
+func (b *Book) expired(r IdempotencyRecord, now int64) bool {
+	return now-r.RecordedAt > int64(b.retention)
+}
+
+// restoreKeys rebuilds the order idempotency keys were used in, from records restored without it
+func (b *Book) restoreKeys() {
+	b.keys = make([]string, 0, len(b.idempotency))
+
+	for key := range b.idempotency {
+		b.keys = append(b.keys, key)
+	}
+
+	sort.Slice(b.keys, func(i, j int) bool {
+		return b.idempotency[b.keys[i]].RecordedAt < b.idempotency[b.keys[j]].RecordedAt
+	})
+}
+
+// fingerprint hashes a command and its arguments, so a reused idempotency key can be told apart from a retry
+func fingerprint(command string, arguments ...interface{}) string {
+	encoded, _ := json.Marshal(append([]interface{}{command}, arguments...))
+	sum := sha256.Sum256(encoded)
+
+	return fmt.Sprintf("%x", sum)
+}
+
+// commandError describes what the book was doing when a command failed
+// errors callers are expected to check the type of are returned untouched
+func commandError(doing string, err error) error {
+	switch err.(type) {
+	case *ledger.IdempotencyConflictError, *ledger.InsufficientFundsError, *ledger.WalletStatusError, *ledger.LimitExceededError, *ledger.ValidationError, *ledger.InterestAccrualError,
+		*ledger.RejectedError, *ledger.NotFoundError:
+		return err
+	}
+
+	return fmt.Errorf("%s: %v", doing, err)
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+func TestBook_Idempotency(t *testing.T) {
+	deposit := ledger.NewMoney(5000, ledger.DefaultCurrency)
+
+	t.Run("should return the original result for a retried command without running it again", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		count := len(book.transactions)
+
+		first, err := book.DepositWalletFunds("1", deposit, "key-1")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		second, err := book.DepositWalletFunds("1", deposit, "key-1")
+		if err != nil {
+			t.Fatalf("returned error on retry, %v", err)
+		}
+
+		if first != second {
+			t.Errorf("retry returned a different aggregate, got '%s', wanted '%s'", second, first)
+		}
+
+		if len(book.transactions) != count+2 {
+			t.Errorf("book has incorrect transaction count after retry, got %d, wanted %d", len(book.transactions), count+2)
+		}
+	})
+	t.Run("should reject a key reused for a different command", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		book.DepositWalletFunds("1", deposit, "key-1")
+
+		_, err := book.TransferWalletFunds("1", "2", deposit, "key-1")
+		assertIdempotencyConflict(t, err, "key-1")
+
+		_, err = book.DepositWalletFunds("2", deposit, "key-1")
+		assertIdempotencyConflict(t, err, "key-1")
+	})
+	t.Run("should run a command again once its key has expired", func(t *testing.T) {
+		now := time.Date(2019, time.March, 31, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(
+			WithIdempotencyRetention(time.Hour),
+			WithClock(func() time.Time { return now }),
+		)
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		first, _ := book.DepositWalletFunds("1", deposit, "key-1")
+
+		now = now.Add(2 * time.Hour)
+
+		second, err := book.DepositWalletFunds("1", deposit, "key-1")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if first == second {
+			t.Error("command wasn't run again after its key expired")
+		}
+
+		if len(book.idempotency) != 1 {
+			t.Errorf("expired keys weren't forgotten, book remembers %d keys, wanted %d", len(book.idempotency), 1)
+		}
+	})
+	t.Run("should not remember the key of a command that failed", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.TransferWalletFunds("3", "1", deposit, "key-1")
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		book.DepositWalletFunds("3", deposit, "key-2")
+
+		_, err = book.TransferWalletFunds("3", "1", deposit, "key-1")
+		if err != nil {
+			t.Errorf("returned error once the command could succeed, %v", err)
+		}
+	})
+}
+
+func assertIdempotencyConflict(t *testing.T, err error, key string) {
+	t.Helper()
+	conflict, ok := err.(*ledger.IdempotencyConflictError)
+	if !ok {
+		t.Fatalf("got error %v, wanted an IdempotencyConflictError", err)
+	}
+
+	if conflict.Key != key {
+		t.Errorf("got conflict on key '%s', wanted '%s'", conflict.Key, key)
+	}
+}
@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// SetInterestPolicy sets how interest is accrued on the wallet and posted to it, interest starts accruing with the balance at the end of the day
+// it's set on, a new policy for a wallet that already earns interest applies from the first day not yet accrued
+// a zero policy stops the wallet earning interest, dropping any accrued but not yet posted
+func (b *Book) SetInterestPolicy(wallet string, policy ledger.InterestPolicy, idempotencyKey string) error {
+	fingerprint := fingerprint("interest policy", wallet, policy)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		accrual, ok := b.accruals[wallet]
+		if !ok {
+			yesterday := day(b.clock()).AddDate(0, 0, -1)
+			accrual = ledger.InterestAccrual{Wallet: wallet, Through: yesterday.Format(ledger.EffectiveDateLayout), Accrued: "0"}
+		}
+
+		if !policy.IsZero() {
+			err := policy.Validate()
+			if err != nil {
+				return "", Commit{}, err
+			}
+
+			_, err = b.wallet(wallet)
+			if err != nil {
+				return "", Commit{}, err
+			}
+
+			err = b.checkDestination(policy.Source)
+			if err != nil {
+				return "", Commit{}, err
+			}
+		}
+
+		accrual.Policy = policy
+
+		return "", Commit{Interest: []ledger.InterestAccrual{accrual}}, nil
+	}, func(string) ledger.Event {
+		return ledger.InterestPolicySet{Wallet: wallet, Policy: policy}
+	})
+	if err != nil {
+		return commandError("problem when setting interest policy", err)
+	}
+
+	return nil
+}
+
+// AccrueInterest accrues interest on every wallet with an interest policy for each day up to and including the given one
+// that hasn't been accrued yet, posting what's accrued whenever a wallet's posting period ends, and returns the aggregate
+// every posting is made under, empty when nothing was posted
+// a day is only ever accrued once, so running it again for the same day, or after a crash, never pays interest twice
+// interest is paid out of each policy's source like any other debit, a wallet whose interest can't be posted, such as one
+// whose source its overdraft policy doesn't cover, is skipped without holding up the others and returned in an InterestAccrualError
+// along with the aggregate, it's tried again on the next run, a run that can't accrue on any wallet commits nothing
+// interest is posted on the day its period ends, a late run posting it only has it earn interest from the day it ran on
+func (b *Book) AccrueInterest(through time.Time, idempotencyKey string) (string, error) {
+	last := day(through)
+
+	if !last.Before(day(b.clock())) {
+		return "", &ledger.ValidationError{Field: "through", Value: last.Format(ledger.EffectiveDateLayout), Reason: "interest can only be accrued for days that have ended"}
+	}
+
+	// runs without a key that have nothing to accrue, such as the periodic ones, aren't committed at all
+	if idempotencyKey == "" && b.accruedThrough(last) {
+		return "", nil
+	}
+
+	date := last.Format(ledger.EffectiveDateLayout)
+	fingerprint := fingerprint("interest", date)
+
+	failures := &ledger.InterestAccrualError{Through: date, Skipped: make(map[string]string)}
+
+	aggregate, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		now := b.clock()
+
+		aggregate, err := genUUID()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		wallets := make([]string, 0, len(b.accruals))
+		for wallet := range b.accruals {
+			wallets = append(wallets, wallet)
+		}
+
+		sort.Strings(wallets)
+
+		var c Commit
+
+		for _, wallet := range wallets {
+			accrual, ts, err := b.accrue(b.accruals[wallet], last, aggregate)
+			if err == nil {
+				// a source paying several wallets has to cover all of them together
+				err = b.checkInterest(append(append([]ledgerpb.Transaction{}, c.Transactions...), ts...), now)
+			}
+
+			if err != nil {
+				failures.Skipped[wallet] = err.Error()
+				continue
+			}
+
+			if accrual == b.accruals[wallet] {
+				continue
+			}
+
+			c.Interest = append(c.Interest, accrual)
+			c.Transactions = append(c.Transactions, ts...)
+		}
+
+		if len(c.Interest) == 0 && len(failures.Skipped) > 0 {
+			return "", Commit{}, failures
+		}
+
+		if len(c.Transactions) == 0 {
+			aggregate = ""
+		}
+
+		return aggregate, c, nil
+	}, func(aggregate string) ledger.Event {
+		return ledger.InterestAccrued{Through: date, Aggregate: aggregate, Skipped: failures.Wallets()}
+	})
+	if err != nil {
+		return "", commandError("problem when accruing interest", err)
+	}
+
+	if len(failures.Skipped) > 0 {
+		return aggregate, failures
+	}
+
+	return aggregate, nil
+}
+
+// checkInterest returns an error unless the interest postings of a run can be committed, callers must hold the book's lock
+func (b *Book) checkInterest(transactions []ledgerpb.Transaction, now time.Time) error {
+	err := b.checkDebits(transactions)
+	if err != nil {
+		return err
+	}
+
+	return b.checkCommit(transactions, now)
+}
+
+// InterestAccrual returns how far interest has been accrued on the wallet, and how much of it is still to be posted
+func (b *Book) InterestAccrual(wallet string) (ledger.InterestAccrual, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	accrual, ok := b.accruals[wallet]
+	if !ok {
+		return ledger.InterestAccrual{}, fmt.Errorf("wallet '%s' has no interest policy", wallet)
+	}
+
+	return accrual, nil
+}
+
+// accruedThrough reports whether interest on every wallet has already been accrued up to and including the day
+func (b *Book) accruedThrough(last time.Time) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	through := last.Format(ledger.EffectiveDateLayout)
+
+	for _, accrual := range b.accruals {
+		if accrual.Through < through {
+			return false
+		}
+	}
+
+	return true
+}
+
+// accrue returns the accrual brought up to the end of the given day, along with the transactions posting the interest
+// whose periods ended by then under the aggregate, a closed wallet earns nothing, callers must hold the book's lock
+func (b *Book) accrue(accrual ledger.InterestAccrual, last time.Time, aggregate string) (ledger.InterestAccrual, []ledgerpb.Transaction, error) {
+	policy := accrual.Policy
+
+	from, err := time.Parse(ledger.EffectiveDateLayout, accrual.Through)
+	if err != nil {
+		return accrual, nil, err
+	}
+
+	accrued, ok := new(big.Rat).SetString(accrual.Accrued)
+	if !ok {
+		return accrual, nil, fmt.Errorf("invalid accrued interest '%s'", accrual.Accrued)
+	}
+
+	if !from.Before(last) {
+		return accrual, nil, nil
+	}
+
+	accrual.Through = last.Format(ledger.EffectiveDateLayout)
+
+	if b.registry[accrual.Wallet].Status == ledger.WalletClosed {
+		return accrual, nil, nil
+	}
+
+	err = b.checkBalanced(accrual.Wallet)
+	if err != nil {
+		return accrual, nil, err
+	}
+
+	// interest is worked out from the wallet's balance history, which is built from every transaction in the book
+	err = b.loadArchive()
+	if err != nil {
+		return accrual, nil, err
+	}
+
+	var ts []ledgerpb.Transaction
+
+	// interest posted earlier in the run isn't in the wallet's history yet, but it earns interest from the day after it's posted
+	var posted int64
+
+	for d := from.AddDate(0, 0, 1); !d.After(last); d = d.AddDate(0, 0, 1) {
+		end := ledger.AtTime(d.AddDate(0, 0, 1).Add(-time.Nanosecond))
+
+		balance, _ := b.balanceAt(accrual.Wallet, policy.Currency, end)
+
+		accrued.Add(accrued, policy.DailyInterest(balance+posted, d))
+
+		if !policy.Posting.Ends(d) {
+			continue
+		}
+
+		amount := policy.Rounding.Round(accrued)
+		if amount <= 0 {
+			continue
+		}
+
+		date := d.Format(ledger.EffectiveDateLayout)
+
+		ts = append(ts,
+			ledgerpb.Transaction{Type: ledger.TransactionCredit, Wallet: accrual.Wallet, Amount: amount, Currency: policy.Currency, Aggregate: aggregate, EffectiveDate: date, Interest: true},
+			ledgerpb.Transaction{Type: ledger.TransactionDebit, Wallet: policy.Source, Amount: amount, Currency: policy.Currency, Aggregate: aggregate, EffectiveDate: date, Interest: true},
+		)
+
+		accrued.Sub(accrued, new(big.Rat).SetInt64(amount))
+		posted += amount
+	}
+
+	accrual.Accrued = accrued.RatString()
+
+	return accrual, ts, nil
+}
+
+// applyInterest records the wallets' new interest accruals, those with a zero policy no longer earn interest
+func (b *Book) applyInterest(accruals []ledger.InterestAccrual) {
+	for _, a := range accruals {
+		if a.Policy.IsZero() {
+			delete(b.accruals, a.Wallet)
+			continue
+		}
+
+		b.accruals[a.Wallet] = a
+	}
+}
+
+// day returns the start of the day in UTC the time falls on
+func day(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_AccrueInterest(t *testing.T) {
+	policy := ledger.InterestPolicy{
+		Rate:     "0.1",
+		Currency: ledger.DefaultCurrency,
+		DayCount: ledger.DayCountActual365,
+		Rounding: ledger.RoundHalfUp,
+		Posting:  ledger.InterestDaily,
+		Source:   "interest",
+	}
+
+	t.Run("should post each day's interest on the balance at its end, carrying fractions of a minor unit over", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.SetOverdraftPolicy("interest", ledger.UnlimitedOverdraft(), "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(365000, ledger.DefaultCurrency), "")
+
+		err := book.SetInterestPolicy("1", policy, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		now = time.Date(2019, 6, 4, 9, 0, 0, 0, time.UTC)
+
+		aggregate, err := book.AccrueInterest(time.Date(2019, 6, 3, 0, 0, 0, 0, time.UTC), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(aggregate)
+		if len(ts) != 6 {
+			t.Fatalf("got %d transactions in the aggregate, wanted interest posted for each of 3 days", len(ts))
+		}
+
+		for i, date := range []string{"2019-06-01", "2019-06-02", "2019-06-03"} {
+			if got := ts[i*2].GetEffectiveDate(); got != date {
+				t.Errorf("got interest effective on %s, wanted %s", got, date)
+			}
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(365300, ledger.DefaultCurrency)})
+
+		accrual, _ := book.InterestAccrual("1")
+		if accrual.Through != "2019-06-03" || accrual.Accrued != "6/73" {
+			t.Errorf("got accrual %+v, wanted it through 2019-06-03 with 30/365 of a minor unit left", accrual)
+		}
+	})
+	t.Run("should post monthly interest on the last day of the month, accruing the rest", func(t *testing.T) {
+		now := time.Date(2019, 1, 15, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.SetOverdraftPolicy("interest", ledger.UnlimitedOverdraft(), "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(100000, ledger.DefaultCurrency), "")
+
+		monthly := policy
+		monthly.Rate, monthly.Posting = "0.0365", ledger.InterestMonthly
+
+		book.SetInterestPolicy("1", monthly, "")
+
+		now = time.Date(2019, 2, 2, 0, 0, 0, 0, time.UTC)
+
+		aggregate, err := book.AccrueInterest(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(aggregate)
+		if len(ts) != 2 || ts[0].GetAmount() != 170 || ts[0].GetEffectiveDate() != "2019-01-31" {
+			t.Fatalf("got transactions %v, wanted 17 days of interest posted on 2019-01-31", ts)
+		}
+
+		accrual, _ := book.InterestAccrual("1")
+		if accrual.Accrued != "10017/1000" {
+			t.Errorf("got %s accrued, wanted a day's interest on the balance with January's interest", accrual.Accrued)
+		}
+
+		balance, _ := book.WalletBalance("interest")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(-170, ledger.DefaultCurrency)})
+	})
+	t.Run("should never pay a day's interest twice however often it runs", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.SetOverdraftPolicy("interest", ledger.UnlimitedOverdraft(), "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(365000, ledger.DefaultCurrency), "")
+		book.SetInterestPolicy("1", policy, "")
+
+		now = now.AddDate(0, 0, 2)
+
+		book.AccrueInterest(now.AddDate(0, 0, -1), "")
+
+		aggregate, err := book.AccrueInterest(now.AddDate(0, 0, -1), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if aggregate != "" {
+			t.Errorf("got aggregate '%s', wanted nothing posted", aggregate)
+		}
+
+		_, err = book.AccrueInterest(now.AddDate(0, 0, -2), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(365200, ledger.DefaultCurrency)})
+	})
+	t.Run("should refuse to pay interest out of a source its overdraft policy doesn't cover", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(365000, ledger.DefaultCurrency), "")
+		book.SetInterestPolicy("1", policy, "")
+
+		count := len(book.Transactions())
+		now = time.Date(2019, 6, 4, 9, 0, 0, 0, time.UTC)
+
+		_, err := book.AccrueInterest(time.Date(2019, 6, 3, 0, 0, 0, 0, time.UTC), "")
+		if e, ok := err.(*ledger.InterestAccrualError); !ok || !reflect.DeepEqual(e.Wallets(), []string{"1"}) {
+			t.Errorf("got error %v, wanted wallet '1' skipped", err)
+		}
+
+		if got := len(book.Transactions()); got != count {
+			t.Errorf("got %d transactions, wanted %d", got, count)
+		}
+	})
+	t.Run("should skip a wallet whose interest can't be paid, accruing on the others and on it once it can be", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.SetOverdraftPolicy("interest", ledger.UnlimitedOverdraft(), "")
+		book.OpenWallet(ledger.Wallet{ID: "promotions"}, "")
+
+		promotional := policy
+		promotional.Source = "promotions"
+
+		for _, wallet := range []string{"1", "2", "3"} {
+			book.OpenWallet(ledger.Wallet{ID: wallet}, "")
+			book.DepositWalletFunds(wallet, ledger.NewMoney(365000, ledger.DefaultCurrency), "")
+		}
+
+		book.SetInterestPolicy("1", policy, "")
+		book.SetInterestPolicy("2", promotional, "")
+		book.SetInterestPolicy("3", policy, "")
+
+		now = time.Date(2019, 6, 4, 9, 0, 0, 0, time.UTC)
+		through := time.Date(2019, 6, 3, 0, 0, 0, 0, time.UTC)
+
+		aggregate, err := book.AccrueInterest(through, "")
+		if e, ok := err.(*ledger.InterestAccrualError); !ok || !reflect.DeepEqual(e.Wallets(), []string{"2"}) {
+			t.Fatalf("got error %v, wanted wallet '2' skipped", err)
+		}
+
+		ts, _ := book.AggregateTransactions(aggregate)
+		if len(ts) != 12 {
+			t.Errorf("got %d transactions, wanted 3 days of interest on wallets '1' and '3'", len(ts))
+		}
+
+		if accrual, _ := book.InterestAccrual("2"); accrual.Through != "2019-05-31" {
+			t.Errorf("got wallet '2' accrued through %s, wanted it left where it was", accrual.Through)
+		}
+
+		book.SetOverdraftPolicy("promotions", ledger.UnlimitedOverdraft(), "")
+
+		aggregate, err = book.AccrueInterest(through, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ = book.AggregateTransactions(aggregate)
+		if len(ts) != 6 || ts[0].Wallet != "2" {
+			t.Errorf("got transactions %v, wanted 3 days of interest on wallet '2' alone", ts)
+		}
+	})
+	t.Run("should refuse to accrue interest for a day that hasn't ended", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		_, err := book.AccrueInterest(time.Now(), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should stop accruing interest once the policy is removed", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "interest"}, "")
+		book.SetOverdraftPolicy("interest", ledger.UnlimitedOverdraft(), "")
+		book.SetInterestPolicy("1", policy, "")
+
+		err := book.SetInterestPolicy("1", ledger.InterestPolicy{}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.InterestAccrual("1")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse a policy paying interest out of a wallet the book doesn't know", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		err := book.SetInterestPolicy("1", policy, "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
@@ -0,0 +1,275 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// LimitsChange sets the limits of a wallet, or of every wallet in a tier
+type LimitsChange struct {
+	Wallet string        `json:"wallet,omitempty"`
+	Tier   string        `json:"tier,omitempty"`
+	Limits ledger.Limits `json:"limits"`
+}
+
+// SetWalletLimits sets the limits of the wallet, they replace those of its tier, zero limits leave the wallet with its tier's limits again
+// they only apply to transactions from then on, a wallet already past its new limits isn't touched
+func (b *Book) SetWalletLimits(wallet string, limits ledger.Limits, idempotencyKey string) error {
+	fingerprint := fingerprint("wallet limits", wallet, limits)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		err := limits.Validate()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		return "", Commit{Limits: &LimitsChange{Wallet: wallet, Limits: limits}}, nil
+	}, func(string) ledger.Event {
+		return ledger.WalletLimitsSet{Wallet: wallet, Limits: limits}
+	})
+	if err != nil {
+		return commandError("problem when setting wallet limits", err)
+	}
+
+	return nil
+}
+
+// SetTierLimits sets the limits of every wallet opened in the tier that doesn't have limits of its own
+func (b *Book) SetTierLimits(tier string, limits ledger.Limits, idempotencyKey string) error {
+	fingerprint := fingerprint("tier limits", tier, limits)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		if tier == "" {
+			return "", Commit{}, &ledger.ValidationError{Field: "tier", Value: tier, Reason: "can't be empty"}
+		}
+
+		err := limits.Validate()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		return "", Commit{Limits: &LimitsChange{Tier: tier, Limits: limits}}, nil
+	}, func(string) ledger.Event {
+		return ledger.TierLimitsSet{Tier: tier, Limits: limits}
+	})
+	if err != nil {
+		return commandError("problem when setting tier limits", err)
+	}
+
+	return nil
+}
+
+// Limits returns the limits that apply to the wallet, its own if it has any, otherwise those of its tier
+func (b *Book) Limits(wallet string) ledger.Limits {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.limits(wallet)
+}
+
+// limits returns the limits that apply to the wallet, callers must hold the book's lock
+func (b *Book) limits(wallet string) ledger.Limits {
+	if l, ok := b.walletLimits[wallet]; ok {
+		return l
+	}
+
+	return b.tierLimits[b.registry[wallet].Tier]
+}
+
+// applyLimits records a wallet's or tier's new limits, zero limits aren't kept as they're the default
+func (b *Book) applyLimits(change LimitsChange) {
+	limits := b.walletLimits
+	key := change.Wallet
+
+	if change.Tier != "" {
+		limits = b.tierLimits
+		key = change.Tier
+	}
+
+	if change.Limits.IsZero() {
+		delete(limits, key)
+		return
+	}
+
+	limits[key] = change.Limits
+}
+
+// outflow is what a commit takes out of a wallet in one currency
+type outflow struct {
+	amount     ledger.Money
+	aggregates map[string]bool
+}
+
+// checkLimits returns a LimitExceededError if the transactions would take any wallet past its limits
+// balances holds each wallet's balance once the transactions are added, reversals, refunds, holds and interest aren't limited
+// so funds owed can always be paid, callers must hold the book's lock
+func (b *Book) checkLimits(transactions []ledgerpb.Transaction, balances map[balanceKey]ledger.Money, now time.Time) error {
+	if len(b.walletLimits) == 0 && len(b.tierLimits) == 0 {
+		return nil
+	}
+
+	outflows := make(map[balanceKey]*outflow)
+	increased := make(map[balanceKey]bool)
+
+	for _, t := range transactions {
+		if t.ReversalOf != "" || t.RefundOf != "" || t.Interest || ledger.Side(t.Type) == "" {
+			continue
+		}
+
+		key := balanceKey{wallet: t.Wallet, currency: t.Currency}
+
+		if !b.decreases(t.Type, t.Wallet) {
+			increased[key] = true
+			continue
+		}
+
+		o, ok := outflows[key]
+		if !ok {
+			o = &outflow{amount: ledger.NewMoney(0, t.Currency), aggregates: make(map[string]bool)}
+			outflows[key] = o
+		}
+
+		amount, err := o.amount.Add(ledger.NewMoney(t.Amount, t.Currency))
+		if err != nil {
+			return fmt.Errorf("problem adding up what's taken out of wallet '%s': %v", t.Wallet, err)
+		}
+
+		o.amount = amount
+		o.aggregates[t.Aggregate] = true
+	}
+
+	for key, o := range outflows {
+		err := b.checkOutflow(key, o, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key := range increased {
+		limit := b.limits(key.wallet).MaxBalance
+		if limit.Amount == 0 || limit.Currency != key.currency {
+			continue
+		}
+
+		balance := balances[key]
+		if balance.Amount > limit.Amount && balance.Amount > b.balances[key.wallet][key.currency].Amount {
+			return &ledger.LimitExceededError{Wallet: key.wallet, Limit: ledger.LimitBalance, Max: limit.Amount, Attempted: balance.Amount, Currency: key.currency}
+		}
+	}
+
+	return nil
+}
+
+// checkOutflow returns a LimitExceededError if what the commit takes out of the wallet would take it past its outflow limits
+// callers must hold the book's lock
+func (b *Book) checkOutflow(key balanceKey, o *outflow, now time.Time) error {
+	limits := b.limits(key.wallet)
+	if limits.IsZero() {
+		return nil
+	}
+
+	single := limits.MaxSingleOutflow
+	if single.Amount > 0 && single.Currency == key.currency && o.amount.Amount > single.Amount {
+		return &ledger.LimitExceededError{Wallet: key.wallet, Limit: ledger.LimitSingleOutflow, Max: single.Amount, Attempted: o.amount.Amount, Currency: key.currency}
+	}
+
+	daily := limits.MaxDailyOutflow
+	if daily.Amount > 0 && daily.Currency == key.currency {
+		recent, err := b.recentOutflow(key, now.Add(-24*time.Hour))
+		if err != nil {
+			return err
+		}
+
+		total, err := o.amount.Add(recent)
+		if err != nil {
+			return fmt.Errorf("problem adding up what's taken out of wallet '%s' in a day: %v", key.wallet, err)
+		}
+
+		if total.Amount > daily.Amount {
+			return &ledger.LimitExceededError{Wallet: key.wallet, Limit: ledger.LimitDailyOutflow, Max: daily.Amount, Attempted: total.Amount, Currency: key.currency}
+		}
+	}
+
+	if limits.MaxTransfersPerHour > 0 {
+		recent, err := b.recentTransfers(key.wallet, o.aggregates, now.Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+
+		count := int64(len(o.aggregates) + recent)
+		if count > int64(limits.MaxTransfersPerHour) {
+			return &ledger.LimitExceededError{Wallet: key.wallet, Limit: ledger.LimitTransfersPerHour, Max: int64(limits.MaxTransfersPerHour), Attempted: count}
+		}
+	}
+
+	return nil
+}
+
+// recentOutflow returns how much was taken out of the wallet in the currency since the given time, callers must hold the book's lock
+// or an error if it doesn't fit in 64 bits
+func (b *Book) recentOutflow(key balanceKey, since time.Time) (ledger.Money, error) {
+	total := ledger.NewMoney(0, key.currency)
+	var err error
+
+	outflow := b.eachRecentOutflow(key.wallet, since, func(t *ledgerpb.Transaction) {
+		if t.Currency != key.currency || err != nil {
+			return
+		}
+
+		total, err = total.Add(ledger.NewMoney(t.Amount, t.Currency))
+	})
+	if outflow != nil {
+		return total, outflow
+	}
+	if err != nil {
+		return total, fmt.Errorf("problem adding up what was taken out of wallet '%s' since %v: %v", key.wallet, since, err)
+	}
+
+	return total, nil
+}
+
+// recentTransfers returns how many commands took funds out of the wallet since the given time, in any currency
+// leaving out those with the given aggregates, callers must hold the book's lock
+func (b *Book) recentTransfers(wallet string, exclude map[string]bool, since time.Time) (int, error) {
+	seen := make(map[string]bool)
+
+	err := b.eachRecentOutflow(wallet, since, func(t *ledgerpb.Transaction) {
+		if !exclude[t.Aggregate] {
+			seen[t.Aggregate] = true
+		}
+	})
+
+	return len(seen), err
+}
+
+// eachRecentOutflow calls fn with every transaction that took funds out of the wallet after the given time, newest first
+// transactions are recorded in order, so it stops at the first one recorded before then, callers must hold the book's lock
+// the archive is only read if it may hold some of them
+func (b *Book) eachRecentOutflow(wallet string, since time.Time, fn func(t *ledgerpb.Transaction)) error {
+	positions := b.walletMap[wallet]
+	after := since.UnixNano()
+
+	for i := len(positions) - 1; i >= 0; i-- {
+		if positions[i] < b.archived && b.archivedAt <= after {
+			return nil
+		}
+
+		t, err := b.transaction(positions[i])
+		if err != nil {
+			return err
+		}
+
+		if t.RecordedAt <= after {
+			return nil
+		}
+
+		if t.ReversalOf == "" && t.RefundOf == "" && b.decreases(t.Type, wallet) {
+			fn(t)
+		}
+	}
+
+	return nil
+}
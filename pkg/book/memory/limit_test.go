@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_SetWalletLimits(t *testing.T) {
+	t.Run("should reject a single outflow over the limit from every command that takes funds out", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "10"}, "")
+
+		err := book.SetWalletLimits("1", ledger.Limits{MaxSingleOutflow: ledger.NewMoney(5000, ledger.DefaultCurrency)}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		amount := ledger.NewMoney(5001, ledger.DefaultCurrency)
+
+		_, err = book.WithdrawWalletFunds("1", amount, "")
+		assertLimitExceeded(t, err, "1", ledger.LimitSingleOutflow)
+
+		_, err = book.TransferWalletFunds("1", "10", amount, "")
+		assertLimitExceeded(t, err, "1", ledger.LimitSingleOutflow)
+
+		_, err = book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: amount},
+			{Type: ledger.TransactionCredit, Wallet: "10", Amount: amount},
+		}, "")
+		assertLimitExceeded(t, err, "1", ledger.LimitSingleOutflow)
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+	})
+	t.Run("should reject outflows over the daily limit until a day has passed", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(100000, ledger.DefaultCurrency), "")
+		book.SetWalletLimits("1", ledger.Limits{MaxDailyOutflow: ledger.NewMoney(10000, ledger.DefaultCurrency)}, "")
+
+		_, err := book.WithdrawWalletFunds("1", ledger.NewMoney(6000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		now = now.Add(12 * time.Hour)
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+		assertLimitExceeded(t, err, "1", ledger.LimitDailyOutflow)
+
+		e := err.(*ledger.LimitExceededError)
+		if e.Attempted != 11000 || e.Max != 10000 || e.Currency != ledger.DefaultCurrency {
+			t.Errorf("got attempted %d against max %d in '%s', wanted 11000 against 10000 in '%s'", e.Attempted, e.Max, e.Currency, ledger.DefaultCurrency)
+		}
+
+		now = now.Add(12 * time.Hour)
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(5000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+	})
+	t.Run("should reject an outflow whose daily total doesn't fit in 64 bits rather than let it wrap under the limit", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+
+		max := ledger.NewMoney(math.MaxInt64, ledger.DefaultCurrency)
+
+		book.DepositWalletFunds("1", max, "")
+		book.TransferWalletFunds("1", "2", max, "")
+		book.TransferWalletFunds("2", "1", max, "")
+		book.SetWalletLimits("1", ledger.Limits{MaxDailyOutflow: ledger.NewMoney(10000, ledger.DefaultCurrency)}, "")
+
+		transactions := len(book.Transactions())
+
+		_, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(2, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+
+		if len(book.Transactions()) != transactions {
+			t.Errorf("got %d transactions, wanted the transfer not to be recorded", len(book.Transactions()))
+		}
+	})
+	t.Run("should reject transfers over the hourly count in any currency", func(t *testing.T) {
+		now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+		book := NewInMemoryBook(WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(100000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("1", ledger.NewMoney(100000, "EUR"), "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.SetWalletLimits("1", ledger.Limits{MaxTransfersPerHour: 2}, "")
+
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(100, "EUR"), "")
+
+		_, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		assertLimitExceeded(t, err, "1", ledger.LimitTransfersPerHour)
+
+		now = now.Add(time.Hour)
+
+		_, err = book.TransferWalletFunds("1", "2", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+	})
+	t.Run("should reject funds sent to a wallet beyond its max balance, but still let it be reversed into", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "10"}, "")
+
+		sent, err := book.TransferWalletFunds("1", "10", ledger.NewMoney(3000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		book.SetWalletLimits("1", ledger.Limits{MaxBalance: ledger.NewMoney(97000, ledger.DefaultCurrency)}, "")
+
+		_, err = book.DepositWalletFunds("1", ledger.NewMoney(1, ledger.DefaultCurrency), "")
+		assertLimitExceeded(t, err, "1", ledger.LimitBalance)
+
+		_, err = book.ReverseAggregate(sent, "sent by mistake", "")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(100000, ledger.DefaultCurrency)})
+	})
+	t.Run("should leave funds in other currencies unlimited", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(100000, "EUR"), "")
+		book.SetWalletLimits("1", ledger.Limits{MaxSingleOutflow: ledger.NewMoney(5000, ledger.DefaultCurrency)}, "")
+
+		_, err := book.WithdrawWalletFunds("1", ledger.NewMoney(50000, "EUR"), "")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+	})
+	t.Run("should refuse negative limits", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		err := book.SetWalletLimits("1", ledger.Limits{MaxTransfersPerHour: -1}, "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func TestBook_SetTierLimits(t *testing.T) {
+	t.Run("should apply a tier's limits to its wallets, unless they have their own", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		book.OpenWallet(ledger.Wallet{ID: "1", Tier: "basic"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2", Tier: "basic"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(100000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("2", ledger.NewMoney(100000, ledger.DefaultCurrency), "")
+
+		err := book.SetTierLimits("basic", ledger.Limits{MaxSingleOutflow: ledger.NewMoney(1000, ledger.DefaultCurrency)}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		book.SetWalletLimits("2", ledger.Limits{MaxSingleOutflow: ledger.NewMoney(50000, ledger.DefaultCurrency)}, "")
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		assertLimitExceeded(t, err, "1", ledger.LimitSingleOutflow)
+
+		_, err = book.WithdrawWalletFunds("2", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+
+		book.SetWalletLimits("2", ledger.Limits{}, "")
+
+		got := book.Limits("2")
+		if got.MaxSingleOutflow != ledger.NewMoney(1000, ledger.DefaultCurrency) {
+			t.Errorf("got limits %v, wanted those of the tier", got)
+		}
+	})
+	t.Run("should refuse limits for no tier", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		err := book.SetTierLimits("", ledger.Limits{MaxTransfersPerHour: 1}, "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func assertLimitExceeded(t *testing.T, err error, wallet string, limit ledger.LimitKind) {
+	t.Helper()
+
+	e, ok := err.(*ledger.LimitExceededError)
+	if !ok {
+		t.Fatalf("got error %v, wanted a LimitExceededError", err)
+	}
+
+	if e.Wallet != wallet || e.Limit != limit {
+		t.Errorf("got %s limit exceeded in wallet '%s', wanted %s in '%s'", e.Limit, e.Wallet, limit, wallet)
+	}
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// WithOutbox has the book write the events of every command to its outbox in the same commit as the command
+// they're kept until they're acknowledged, so a persistent book never loses one it has committed
+func WithOutbox() Option {
+	return func(b *Book) {
+		b.outboxEnabled = true
+	}
+}
+
+// PendingOutbox returns up to limit messages in the outbox that haven't been acknowledged, oldest first, or all of them when limit is zero
+func (b *Book) PendingOutbox(limit int) []ledger.OutboxMessage {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := len(b.outbox)
+	if limit > 0 && limit < n {
+		n = limit
+	}
+
+	messages := make([]ledger.OutboxMessage, n)
+	copy(messages, b.outbox)
+
+	return messages
+}
+
+// AcknowledgeOutbox takes the messages out of the outbox in a commit of their own, IDs that aren't in it are ignored
+func (b *Book) AcknowledgeOutbox(ids ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pending := make(map[string]bool, len(b.outbox))
+	for _, m := range b.outbox {
+		pending[m.ID] = true
+	}
+
+	acknowledged := []string{}
+	for _, id := range ids {
+		if pending[id] {
+			acknowledged = append(acknowledged, id)
+			pending[id] = false
+		}
+	}
+
+	if len(acknowledged) == 0 {
+		return nil
+	}
+
+	err := b.commit(Commit{Acknowledged: acknowledged}, b.clock())
+	if err != nil {
+		return fmt.Errorf("problem when acknowledging outbox messages: %v", err)
+	}
+
+	return nil
+}
+
+// fillOutbox writes the commit's events to its outbox messages, if the book keeps an outbox, callers must hold the book's write lock
+func (b *Book) fillOutbox(c *Commit, now time.Time) error {
+	if !b.outboxEnabled || len(c.Events) == 0 {
+		return nil
+	}
+
+	wallets := commitWallets(*c)
+
+	for i, e := range c.Events {
+		id, err := genUUID()
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("problem encoding event %s, %v", e.EventName(), err)
+		}
+
+		c.Outbox = append(c.Outbox, ledger.OutboxMessage{
+			ID:        id,
+			Sequence:  b.outboxSequence + uint64(i) + 1,
+			Event:     e.EventName(),
+			Wallets:   wallets,
+			Payload:   payload,
+			CreatedAt: now.UnixNano(),
+		})
+	}
+
+	return nil
+}
+
+// applyOutbox adds a commit's messages to the outbox and takes out the ones it acknowledged
+func (b *Book) applyOutbox(messages []ledger.OutboxMessage, acknowledged []string) {
+	for _, m := range messages {
+		b.outbox = append(b.outbox, m)
+
+		if m.Sequence > b.outboxSequence {
+			b.outboxSequence = m.Sequence
+		}
+	}
+
+	if len(acknowledged) == 0 {
+		return
+	}
+
+	done := make(map[string]bool, len(acknowledged))
+	for _, id := range acknowledged {
+		done[id] = true
+	}
+
+	pending := b.outbox[:0]
+	for _, m := range b.outbox {
+		if !done[m.ID] {
+			pending = append(pending, m)
+		}
+	}
+
+	b.outbox = pending
+}
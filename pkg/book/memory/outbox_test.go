@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+func TestBook_PendingOutbox(t *testing.T) {
+	t.Run("should write the event of every command to the outbox in the commit that made it", func(t *testing.T) {
+		var commits []Commit
+		book := NewInMemoryBook(WithOutbox(), WithCommitHook(func(c Commit) error {
+			commits = append(commits, c)
+			return nil
+		}))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		deposit, _ := book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(400, ledger.DefaultCurrency), "")
+
+		messages := book.PendingOutbox(0)
+		if len(messages) != 3 || messages[1].Sequence != 2 || messages[2].Sequence != 3 || messages[0].Event != ledger.EventWalletOpened ||
+			messages[1].Event != ledger.EventWalletFundsDeposited || messages[2].Event != ledger.EventWalletFundsWithdrawn {
+			t.Fatalf("got messages %+v, wanted the wallet opened, the deposit then the withdrawal", messages)
+		}
+
+		var event ledger.WalletFundsDeposited
+		json.Unmarshal(messages[1].Payload, &event)
+
+		if event != (ledger.WalletFundsDeposited{Wallet: "1", Deposit: ledger.NewMoney(1000, ledger.DefaultCurrency), Aggregate: deposit}) {
+			t.Errorf("got payload %s, wanted the deposit", messages[1].Payload)
+		}
+
+		if !reflect.DeepEqual(commits[1].Outbox, messages[1:2]) || !reflect.DeepEqual(commits[2].Outbox, messages[2:]) {
+			t.Errorf("got commits %+v, wanted each to carry its own message", commits)
+		}
+
+		if got := book.PendingOutbox(1); len(got) != 1 || got[0].ID != messages[0].ID {
+			t.Errorf("got %+v, wanted only the oldest message", got)
+		}
+	})
+	t.Run("should keep no outbox unless asked to", func(t *testing.T) {
+		book := NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		if got := book.PendingOutbox(0); len(got) != 0 {
+			t.Errorf("got messages %+v, wanted none", got)
+		}
+	})
+}
+
+func TestBook_AcknowledgeOutbox(t *testing.T) {
+	t.Run("should take acknowledged messages out of the outbox in a commit of their own", func(t *testing.T) {
+		var commits []Commit
+		book := NewInMemoryBook(WithOutbox(), WithCommitHook(func(c Commit) error {
+			commits = append(commits, c)
+			return nil
+		}))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("1", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+
+		messages := book.PendingOutbox(0)
+
+		err := book.AcknowledgeOutbox(messages[0].ID, messages[1].ID, "unknown")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if got := book.PendingOutbox(0); !reflect.DeepEqual(got, messages[2:]) {
+			t.Errorf("got messages %+v, wanted only the second deposit", got)
+		}
+
+		last := commits[len(commits)-1]
+		if !reflect.DeepEqual(last.Acknowledged, []string{messages[0].ID, messages[1].ID}) || len(last.Transactions) != 0 || len(last.Outbox) != 0 {
+			t.Errorf("got commit %+v, wanted one acknowledging the wallet opened and the first deposit", last)
+		}
+
+		book.AcknowledgeOutbox("unknown")
+
+		if len(commits) != 4 {
+			t.Errorf("got %d commits, wanted nothing committed for messages that aren't in the outbox", len(commits))
+		}
+	})
+	t.Run("should restore the outbox from commits and saved state", func(t *testing.T) {
+		var commits []Commit
+		book := NewInMemoryBook(WithOutbox(), WithCommitHook(func(c Commit) error {
+			commits = append(commits, c)
+			return nil
+		}))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		book.DepositWalletFunds("1", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		book.AcknowledgeOutbox(book.PendingOutbox(0)[2].ID)
+
+		want := book.PendingOutbox(0)
+
+		replayed := NewInMemoryBook(WithOutbox(), WithCommits(commits))
+		if got := replayed.PendingOutbox(0); !reflect.DeepEqual(got, want) {
+			t.Errorf("got messages %+v from commits, wanted %+v", got, want)
+		}
+
+		var state State
+		s, _ := book.Checkpoint(nil)
+		raw, _ := json.Marshal(s)
+		json.Unmarshal(raw, &state)
+
+		restored := NewInMemoryBook(WithOutbox(), WithState(state))
+		if got := restored.PendingOutbox(0); !reflect.DeepEqual(got, want) {
+			t.Errorf("got messages %+v from state, wanted %+v", got, want)
+		}
+
+		restored.DepositWalletFunds("1", ledger.NewMoney(3000, ledger.DefaultCurrency), "")
+
+		if got := restored.PendingOutbox(0); got[len(got)-1].Sequence != 4 {
+			t.Errorf("got messages %+v, wanted the next to follow on from the last written", got)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"gitlab.com/patchwell/ledger"
+)
+
+// OverdraftChange sets the overdraft policy of a wallet
+type OverdraftChange struct {
+	Wallet string                 `json:"wallet"`
+	Policy ledger.OverdraftPolicy `json:"policy"`
+}
+
+// SetOverdraftPolicy sets how far below zero the wallet's available balance may go, wallets are strict until given a policy
+// it only applies to funds taken out of the wallet from then on, a wallet already below its new limit isn't touched
+func (b *Book) SetOverdraftPolicy(wallet string, policy ledger.OverdraftPolicy, idempotencyKey string) error {
+	fingerprint := fingerprint("overdraft", wallet, policy)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		err := policy.Validate()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		return "", Commit{Overdraft: &OverdraftChange{Wallet: wallet, Policy: policy}}, nil
+	}, func(string) ledger.Event {
+		return ledger.OverdraftPolicySet{Wallet: wallet, Policy: policy}
+	})
+	if err != nil {
+		return commandError("problem when setting overdraft policy", err)
+	}
+
+	return nil
+}
+
+// OverdraftPolicy returns the wallet's overdraft policy
+func (b *Book) OverdraftPolicy(wallet string) ledger.OverdraftPolicy {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.overdrafts[wallet]
+}
+
+// checkOverdraft returns an InsufficientFundsError unless the wallet's overdraft policy lets it pay the amount out of its available balance
+// callers must hold the book's lock
+func (b *Book) checkOverdraft(wallet string, available ledger.Money, amount ledger.Money) error {
+	policy := b.overdrafts[wallet]
+
+	if !policy.Covers(available, amount) {
+		return &ledger.InsufficientFundsError{Wallet: wallet, Available: available, Amount: amount, Policy: policy}
+	}
+
+	return nil
+}
+
+// applyOverdraft records a wallet's new overdraft policy, strict policies aren't kept as they're the default
+func (b *Book) applyOverdraft(change OverdraftChange) {
+	if change.Policy == ledger.StrictOverdraft() {
+		delete(b.overdrafts, change.Wallet)
+		return
+	}
+
+	b.overdrafts[change.Wallet] = change.Policy
+}
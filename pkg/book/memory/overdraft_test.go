@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_SetOverdraftPolicy(t *testing.T) {
+	t.Run("should reject every debit a strict wallet can't cover with an InsufficientFundsError", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		amount := ledger.NewMoney(100001, ledger.DefaultCurrency)
+
+		_, err := book.WithdrawWalletFunds("1", amount, "")
+		assertInsufficientFunds(t, err, "1")
+
+		_, err = book.TransferWalletFunds("1", "2", amount, "")
+		assertInsufficientFunds(t, err, "1")
+
+		_, err = book.AuthorizeHold("1", amount, time.Time{}, "")
+		assertInsufficientFunds(t, err, "1")
+
+		err = book.AddTransaction(ledger.TransactionDebit, "1", amount, "3333", "")
+		assertInsufficientFunds(t, err, "1")
+
+		err = book.AddTransaction(ledger.TransactionCashOut, "1", amount, "3333", "")
+		assertInsufficientFunds(t, err, "1")
+	})
+	t.Run("should let a wallet with a limit go that far below zero in the limit's currency", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		err := book.SetOverdraftPolicy("1", ledger.OverdraftLimit(ledger.NewMoney(5000, ledger.DefaultCurrency)), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(105000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{ledger.NewMoney(-5000, ledger.DefaultCurrency)})
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(1, ledger.DefaultCurrency), "")
+		assertInsufficientFunds(t, err, "1")
+	})
+	t.Run("should let an unlimited wallet go as far below zero as it needs to, until it's made strict again", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		book.SetOverdraftPolicy("settlement", ledger.UnlimitedOverdraft(), "")
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		_, err := book.TransferWalletFunds("settlement", "1", ledger.NewMoney(1000000, "EUR"), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		book.SetOverdraftPolicy("settlement", ledger.StrictOverdraft(), "")
+
+		_, err = book.TransferWalletFunds("settlement", "1", ledger.NewMoney(1, "EUR"), "")
+		assertInsufficientFunds(t, err, "settlement")
+	})
+	t.Run("should refuse a negative limit", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		err := book.SetOverdraftPolicy("1", ledger.OverdraftLimit(ledger.NewMoney(-1, ledger.DefaultCurrency)), "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should restore overdraft policies from earlier commits", func(t *testing.T) {
+		var commits []Commit
+
+		book := NewInMemoryBook(WithCommitHook(func(c Commit) error {
+			commits = append(commits, c)
+			return nil
+		}))
+
+		policy := ledger.OverdraftLimit(ledger.NewMoney(5000, ledger.DefaultCurrency))
+		book.SetOverdraftPolicy("1", policy, "")
+
+		restored := NewInMemoryBook(WithCommits(commits))
+
+		if got := restored.OverdraftPolicy("1"); got != policy {
+			t.Errorf("got overdraft policy %s, wanted %s", got, policy)
+		}
+	})
+}
+
+func assertInsufficientFunds(t *testing.T, err error, wallet string) {
+	t.Helper()
+
+	e, ok := err.(*ledger.InsufficientFundsError)
+	if !ok {
+		t.Fatalf("got error %v, wanted an InsufficientFundsError", err)
+	}
+
+	if e.Wallet != wallet {
+		t.Errorf("got insufficient funds in wallet '%s', wanted '%s'", e.Wallet, wallet)
+	}
+}
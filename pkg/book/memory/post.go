@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// Post commits every entry as a transaction under a single new aggregate, which is returned, or commits none of them
+// the debits have to add up to the credits in each currency, and every wallet debited has to be able to cover its debits
+func (b *Book) Post(entries []ledger.Entry, idempotencyKey string) (string, error) {
+	err := checkEntries(entries)
+	if err != nil {
+		return "", err
+	}
+
+	fingerprint := fingerprint("post", entries)
+
+	aggregate, err := b.execute(idempotencyKey, fingerprint, func() (string, []ledgerpb.Transaction, error) {
+		// every wallet credited has to be known, as for a transfer, so a posting can't send funds to a mistyped one
+		for _, e := range entries {
+			if e.Type != ledger.TransactionCredit {
+				continue
+			}
+
+			err := b.checkDestination(e.Wallet)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+		aggregate, err := genUUID()
+		if err != nil {
+			return "", nil, err
+		}
+
+		ts := make([]ledgerpb.Transaction, len(entries))
+
+		for i, e := range entries {
+			ts[i] = ledgerpb.Transaction{Type: e.Type, Wallet: e.Wallet, Amount: e.Amount.Amount, Currency: e.Amount.Currency, Aggregate: aggregate}
+		}
+
+		err = b.checkDebits(ts)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return aggregate, ts, nil
+	}, func(aggregate string) ledger.Event {
+		return ledger.EntriesPosted{Entries: append([]ledger.Entry{}, entries...), Aggregate: aggregate}
+	})
+	if err != nil {
+		return "", commandError("problem when posting entries", err)
+	}
+
+	return aggregate, nil
+}
+
+// checkEntries returns an error unless the entries are debits and credits of positive amounts that balance in every currency
+// an entry of another type, a missing wallet or an amount that isn't positive is a ValidationError
+func checkEntries(entries []ledger.Entry) error {
+	if len(entries) < 2 {
+		return &ledger.ValidationError{Field: "entries", Value: strconv.Itoa(len(entries)), Reason: "a posting needs at least one debit and one credit"}
+	}
+
+	// debits count against a currency and credits towards it, so a balanced posting leaves every currency at zero
+	totals := make(map[string]ledger.Money)
+
+	for _, e := range entries {
+		if e.Type != ledger.TransactionDebit && e.Type != ledger.TransactionCredit {
+			return &ledger.ValidationError{Field: "type", Value: e.Type, Reason: "postings can only hold debits and credits"}
+		}
+
+		err := validateMovement(e.Amount, e.Wallet)
+		if err != nil {
+			return err
+		}
+
+		total, ok := totals[e.Amount.Currency]
+		if !ok {
+			total = ledger.NewMoney(0, e.Amount.Currency)
+		}
+
+		if e.Type == ledger.TransactionDebit {
+			total, err = total.Sub(e.Amount)
+		} else {
+			total, err = total.Add(e.Amount)
+		}
+
+		if err != nil {
+			return fmt.Errorf("problem adding up entries: %v", err)
+		}
+
+		totals[e.Amount.Currency] = total
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for currency := range totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		if total := totals[currency]; total.Amount != 0 {
+			return &ledger.ValidationError{Field: "entries", Value: currency, Reason: fmt.Sprintf("debits and credits differ by %d, they have to add up to the same amount", total.Amount)}
+		}
+	}
+
+	return nil
+}
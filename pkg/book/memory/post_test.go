@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"testing"
+
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_Post(t *testing.T) {
+	usd := func(amount int64) ledger.Money {
+		return ledger.NewMoney(amount, ledger.DefaultCurrency)
+	}
+
+	t.Run("should split a payment between several wallets under one aggregate", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		for _, wallet := range []string{"seller", "platform", "tax"} {
+			book.OpenWallet(ledger.Wallet{ID: wallet}, "")
+		}
+
+		entries := []ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(10000)},
+			{Type: ledger.TransactionCredit, Wallet: "seller", Amount: usd(8500)},
+			{Type: ledger.TransactionCredit, Wallet: "platform", Amount: usd(1000)},
+			{Type: ledger.TransactionCredit, Wallet: "tax", Amount: usd(500)},
+		}
+
+		aggregate, err := book.Post(entries, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		ts, _ := book.AggregateTransactions(aggregate)
+
+		want := []ledgerpb.Transaction{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 10000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: ledger.TransactionCredit, Wallet: "seller", Amount: 8500, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: ledger.TransactionCredit, Wallet: "platform", Amount: 1000, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+			{Type: ledger.TransactionCredit, Wallet: "tax", Amount: 500, Currency: ledger.DefaultCurrency, Aggregate: aggregate},
+		}
+
+		test.AssertRecordedTransactions(t, dereference(ts), want)
+
+		balance, _ := book.WalletBalance("1")
+		test.AssertWalletBalance(t, balance, []ledger.Money{usd(90000)})
+	})
+
+	invalid := []struct {
+		name    string
+		entries []ledger.Entry
+	}{
+		{"a single entry", []ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(100)},
+		}},
+		{"debits and credits that don't add up", []ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(100)},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: usd(99)},
+		}},
+		{"amounts that add up in different currencies", []ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(100)},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: ledger.NewMoney(100, "EUR")},
+		}},
+		{"an entry that isn't a debit or credit", []ledger.Entry{
+			{Type: ledger.TransactionCashOut, Wallet: "1", Amount: usd(100)},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: usd(100)},
+		}},
+		{"an amount that isn't positive", []ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(-100)},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: usd(-100)},
+		}},
+		{"a credit to a wallet the book doesn't know", []ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(100)},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: usd(50)},
+			{Type: ledger.TransactionCredit, Wallet: "selller", Amount: usd(50)},
+		}},
+	}
+
+	for _, c := range invalid {
+		t.Run("should refuse "+c.name, func(t *testing.T) {
+			book := NewMockInMemoryBook()
+			count := len(book.Transactions())
+
+			_, err := book.Post(c.entries, "")
+			if err == nil {
+				t.Error("no error returned")
+			}
+
+			if len(book.Transactions()) != count {
+				t.Error("transactions added by a posting that was refused")
+			}
+		})
+	}
+
+	t.Run("should commit nothing when any source can't cover its debits together", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+		count := len(book.Transactions())
+
+		// wallet 2 holds 8000, which covers each of its debits but not both
+		_, err := book.Post([]ledger.Entry{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: usd(5000)},
+			{Type: ledger.TransactionDebit, Wallet: "2", Amount: usd(5000)},
+			{Type: ledger.TransactionDebit, Wallet: "2", Amount: usd(5000)},
+			{Type: ledger.TransactionCredit, Wallet: "3", Amount: usd(15000)},
+		}, "")
+		assertInsufficientFunds(t, err, "2")
+
+		if len(book.Transactions()) != count {
+			t.Error("transactions added by a posting that was refused")
+		}
+	})
+}
@@ -0,0 +1,287 @@
+package memory
+
+import (
+	"encoding/json"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// State is everything held in a Book, so it can be saved and restored without replaying or re-indexing its transactions
+type State struct {
+	Transactions   []ledgerpb.Transaction            `json:"transactions"`
+	Archived       int                               `json:"archived,omitempty"`        // number of transactions at the start of the book left out of Transactions, read with WithArchive once one is needed
+	RecordedAt     int64                             `json:"recorded_at,omitempty"`     // when the last transaction in the book was recorded
+	Wallets        map[string][]int                  `json:"wallets"`                   // positions in Transactions of each wallet's transactions
+	Aggregates     map[string][]int                  `json:"aggregates"`                // positions in Transactions of each aggregate's transactions
+	Balances       map[string]Balances               `json:"balances"`                  // running balances of each wallet
+	Unbalanced     map[string]string                 `json:"unbalanced,omitempty"`      // wallets whose balance can't be worked out
+	Reversals      map[string]string                 `json:"reversals,omitempty"`       // aggregates that have been reversed, mapped to the aggregate that reversed them
+	Refunds        map[string]ledger.Money           `json:"refunds,omitempty"`         // aggregates that have been refunded, mapped to the total amount refunded
+	Holds          map[string]map[string]Hold        `json:"holds,omitempty"`           // holds on each wallet that haven't been captured or voided
+	Overdrafts     map[string]ledger.OverdraftPolicy `json:"overdrafts,omitempty"`      // wallets that may go below zero, mapped to how far
+	Accounts       map[string]ledger.Account         `json:"accounts,omitempty"`        // the chart of accounts
+	Registry       map[string]ledger.Wallet          `json:"registry,omitempty"`        // wallets that have been opened
+	WalletLimits   map[string]ledger.Limits          `json:"wallet_limits,omitempty"`   // limits of wallets that have their own
+	TierLimits     map[string]ledger.Limits          `json:"tier_limits,omitempty"`     // limits of each tier of wallets
+	Fees           []ledger.FeeSchedule              `json:"fees,omitempty"`            // fee schedules of each command
+	Accruals       map[string]ledger.InterestAccrual `json:"accruals,omitempty"`        // interest accrued on each wallet with an interest policy
+	Idempotency    map[string]IdempotencyRecord      `json:"idempotency,omitempty"`     // idempotency keys still remembered
+	Outbox         []ledger.OutboxMessage            `json:"outbox,omitempty"`          // outbox messages that haven't been acknowledged
+	OutboxSequence uint64                            `json:"outbox_sequence,omitempty"` // sequence number of the last message written to the outbox
+}
+
+// Balances is a wallet's running balance in each currency it holds, keyed by currency code
+type Balances map[string]ledger.Money
+
+// UnmarshalJSON reads balances either keyed by currency or, for states saved when a wallet held a single currency,
+// as a lone balance, which is keyed by its currency (empty if it was saved before currencies were tracked)
+func (bs *Balances) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+
+	// a lone balance is a bare number or an object with an amount, currency codes are never "amount"
+	if json.Unmarshal(data, &fields) != nil || fields["amount"] != nil {
+		var balance ledger.Money
+
+		err := json.Unmarshal(data, &balance)
+		if err != nil {
+			return err
+		}
+
+		*bs = Balances{balance.Currency: balance}
+		return nil
+	}
+
+	balances := make(map[string]ledger.Money)
+
+	err := json.Unmarshal(data, &balances)
+	if err != nil {
+		return err
+	}
+
+	*bs = balances
+
+	return nil
+}
+
+// WithState restores a book from a previously saved State, the book takes ownership of the state's slices and maps
+// it can be combined with WithCommits to add transactions committed after the state was saved
+// running balances missing from the state are worked out again from its transactions, so it must come after WithCashAccount
+func WithState(state State) Option {
+	return func(b *Book) {
+		b.transactions = state.Transactions
+		b.archived = state.Archived
+		b.archivedAt = state.RecordedAt
+		b.recordedAt = state.RecordedAt
+		b.walletMap = state.Wallets
+		b.aggregateMap = state.Aggregates
+		b.balances = state.Balances
+		b.unbalanced = state.Unbalanced
+		b.reversals = state.Reversals
+		b.refunds = state.Refunds
+		b.holds = state.Holds
+		b.overdrafts = state.Overdrafts
+		b.accounts = state.Accounts
+		b.registry = state.Registry
+		b.walletLimits = state.WalletLimits
+		b.tierLimits = state.TierLimits
+		b.accruals = state.Accruals
+		b.idempotency = state.Idempotency
+		b.outbox = state.Outbox
+		b.outboxSequence = state.OutboxSequence
+
+		if b.transactions == nil {
+			b.transactions = []ledgerpb.Transaction{}
+		}
+		// only transactions from before the book stamped them need it, they all come ahead of the first one it did stamp
+		for i := range b.transactions {
+			if b.transactions[i].Sequence != 0 {
+				break
+			}
+
+			stampLegacyTransaction(&b.transactions[i], b.archived+i)
+		}
+		// states saved before the time the last transaction was recorded was kept with them only have it on the transaction
+		if n := len(b.transactions); n > 0 && b.transactions[n-1].RecordedAt > b.recordedAt {
+			b.recordedAt = b.transactions[n-1].RecordedAt
+		}
+		if b.walletMap == nil {
+			b.walletMap = make(map[string][]int)
+		}
+		if b.aggregateMap == nil {
+			b.aggregateMap = make(map[string][]int)
+		}
+		if b.unbalanced == nil {
+			b.unbalanced = make(map[string]string)
+		}
+		if b.balances == nil {
+			b.rebuildBalances()
+		}
+		if b.reversals == nil {
+			b.reversals = make(map[string]string)
+		}
+		if b.refunds == nil {
+			b.refunds = make(map[string]ledger.Money)
+		}
+		if b.holds == nil {
+			b.holds = make(map[string]map[string]Hold)
+		}
+		if b.overdrafts == nil {
+			b.overdrafts = make(map[string]ledger.OverdraftPolicy)
+		}
+		if b.accounts == nil {
+			b.accounts = make(map[string]ledger.Account)
+		}
+		if b.registry == nil {
+			b.registry = make(map[string]ledger.Wallet)
+		}
+		if b.walletLimits == nil {
+			b.walletLimits = make(map[string]ledger.Limits)
+		}
+		if b.tierLimits == nil {
+			b.tierLimits = make(map[string]ledger.Limits)
+		}
+		if b.accruals == nil {
+			b.accruals = make(map[string]ledger.InterestAccrual)
+		}
+		if b.idempotency == nil {
+			b.idempotency = make(map[string]IdempotencyRecord)
+		}
+		if b.outbox == nil {
+			b.outbox = []ledger.OutboxMessage{}
+		}
+
+		b.fees = make(map[feeKey]ledger.FeeSchedule)
+		for _, s := range state.Fees {
+			b.applyFeeSchedule(s)
+		}
+
+		b.restoreKeys()
+	}
+}
+
+// rebuildBalances works out every wallet's running balance from the book's transactions, for states saved without them
+// accounts have to be restored first, the side a transaction is posted to decides whether it adds to a balance
+func (b *Book) rebuildBalances() {
+	b.balances = make(map[string]Balances)
+	b.unbalanced = make(map[string]string)
+
+	for _, t := range b.transactions {
+		b.addBalanceEntry(t)
+	}
+}
+
+// Checkpoint returns a copy of the book's current state, calling fn while it's copied so nothing is committed in between
+// the copy shares nothing the book goes on to change, so it can be encoded and saved without holding the book's lock
+func (b *Book) Checkpoint(fn func() error) (State, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if fn != nil {
+		err := fn()
+		if err != nil {
+			return State{}, err
+		}
+	}
+
+	balances := make(map[string]Balances, len(b.balances))
+	for wallet, bs := range b.balances {
+		balances[wallet] = make(Balances, len(bs))
+		for currency, balance := range bs {
+			balances[wallet][currency] = balance
+		}
+	}
+
+	holds := make(map[string]map[string]Hold, len(b.holds))
+	for wallet, hs := range b.holds {
+		holds[wallet] = make(map[string]Hold, len(hs))
+		for hold, h := range hs {
+			holds[wallet][hold] = h
+		}
+	}
+
+	unbalanced := make(map[string]string, len(b.unbalanced))
+	for wallet, reason := range b.unbalanced {
+		unbalanced[wallet] = reason
+	}
+
+	reversals := make(map[string]string, len(b.reversals))
+	for aggregate, reversal := range b.reversals {
+		reversals[aggregate] = reversal
+	}
+
+	refunds := make(map[string]ledger.Money, len(b.refunds))
+	for aggregate, refunded := range b.refunds {
+		refunds[aggregate] = refunded
+	}
+
+	overdrafts := make(map[string]ledger.OverdraftPolicy, len(b.overdrafts))
+	for wallet, policy := range b.overdrafts {
+		overdrafts[wallet] = policy
+	}
+
+	accounts := make(map[string]ledger.Account, len(b.accounts))
+	for id, account := range b.accounts {
+		accounts[id] = account
+	}
+
+	registry := make(map[string]ledger.Wallet, len(b.registry))
+	for id, wallet := range b.registry {
+		registry[id] = wallet
+	}
+
+	walletLimits := make(map[string]ledger.Limits, len(b.walletLimits))
+	for wallet, limits := range b.walletLimits {
+		walletLimits[wallet] = limits
+	}
+
+	tierLimits := make(map[string]ledger.Limits, len(b.tierLimits))
+	for tier, limits := range b.tierLimits {
+		tierLimits[tier] = limits
+	}
+
+	accruals := make(map[string]ledger.InterestAccrual, len(b.accruals))
+	for wallet, accrual := range b.accruals {
+		accruals[wallet] = accrual
+	}
+
+	idempotency := make(map[string]IdempotencyRecord, len(b.idempotency))
+	for key, record := range b.idempotency {
+		idempotency[key] = record
+	}
+
+	return State{
+		// transactions and positions are only ever appended to, so the copy only has to stop at their current length
+		Transactions:   b.transactions[:len(b.transactions):len(b.transactions)],
+		Archived:       b.archived,
+		RecordedAt:     b.recordedAt,
+		Wallets:        copyPositions(b.walletMap),
+		Aggregates:     copyPositions(b.aggregateMap),
+		Balances:       balances,
+		Unbalanced:     unbalanced,
+		Reversals:      reversals,
+		Refunds:        refunds,
+		Holds:          holds,
+		Overdrafts:     overdrafts,
+		Accounts:       accounts,
+		Registry:       registry,
+		WalletLimits:   walletLimits,
+		TierLimits:     tierLimits,
+		Fees:           b.feeSchedules(),
+		Accruals:       accruals,
+		Idempotency:    idempotency,
+		Outbox:         append([]ledger.OutboxMessage{}, b.outbox...),
+		OutboxSequence: b.outboxSequence,
+	}, nil
+}
+
+// copyPositions copies an index of positions, the slices are capped so appending to either copy never writes into the other
+func copyPositions(index map[string][]int) map[string][]int {
+	positions := make(map[string][]int, len(index))
+
+	for key, ps := range index {
+		positions[key] = ps[:len(ps):len(ps)]
+	}
+
+	return positions
+}
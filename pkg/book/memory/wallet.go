@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// OpenWallet adds a wallet to the book, returning its ID, which is generated when the wallet is opened without one
+// a wallet that has only ever been posted to can be opened to give it an owner and metadata, one opened already can't be opened again
+func (b *Book) OpenWallet(wallet ledger.Wallet, idempotencyKey string) (string, error) {
+	fingerprint := fingerprint("open", wallet)
+
+	id, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		if wallet.Status == "" {
+			wallet.Status = ledger.WalletActive
+		}
+
+		if wallet.Status != ledger.WalletActive {
+			return "", Commit{}, &ledger.ValidationError{Field: "status", Value: string(wallet.Status), Reason: "wallets have to be opened active"}
+		}
+
+		if wallet.ID == "" {
+			id, err := genUUID()
+			if err != nil {
+				return "", Commit{}, err
+			}
+
+			wallet.ID = id
+		}
+
+		if _, ok := b.registry[wallet.ID]; ok {
+			return "", Commit{}, &ledger.RejectedError{Subject: wallet.ID, Rejection: ledger.RejectionWalletOpen, Reason: fmt.Sprintf("wallet '%s' is already open", wallet.ID)}
+		}
+
+		return wallet.ID, Commit{Wallet: copyWallet(wallet)}, nil
+	}, func(string) ledger.Event {
+		return ledger.WalletOpened{Wallet: *copyWallet(wallet)}
+	})
+	if err != nil {
+		return "", commandError("problem when opening wallet", err)
+	}
+
+	return id, nil
+}
+
+// SetWalletStatus freezes a wallet, makes a frozen one active again, or closes one
+// a wallet can only be closed once it has nothing in it and nothing on hold, and a closed wallet stays closed
+func (b *Book) SetWalletStatus(wallet string, status ledger.WalletStatus, idempotencyKey string) error {
+	fingerprint := fingerprint("status", wallet, status)
+
+	_, err := b.executeCommit(idempotencyKey, fingerprint, func() (string, Commit, error) {
+		err := status.Validate()
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		w, err := b.wallet(wallet)
+		if err != nil {
+			return "", Commit{}, err
+		}
+
+		if w.Status == ledger.WalletClosed {
+			return "", Commit{}, &ledger.RejectedError{Subject: wallet, Rejection: ledger.RejectionWalletClosed, Reason: fmt.Sprintf("wallet '%s' is closed, it can't be made %s", wallet, status)}
+		}
+
+		if status == ledger.WalletClosed {
+			err := b.checkEmpty(wallet)
+			if err != nil {
+				return "", Commit{}, err
+			}
+		}
+
+		w.Status = status
+
+		return "", Commit{Wallet: &w}, nil
+	}, func(string) ledger.Event {
+		return ledger.WalletStatusSet{Wallet: wallet, Status: status}
+	})
+	if err != nil {
+		return commandError("problem when setting wallet status", err)
+	}
+
+	return nil
+}
+
+// Wallet returns the wallet with the given ID, a wallet that was never opened but has transactions is active
+func (b *Book) Wallet(wallet string) (ledger.Wallet, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.wallet(wallet)
+}
+
+// wallet returns a copy of the wallet, callers must hold the book's lock
+func (b *Book) wallet(wallet string) (ledger.Wallet, error) {
+	if w, ok := b.registry[wallet]; ok {
+		return *copyWallet(w), nil
+	}
+
+	if !b.known(wallet) {
+		return ledger.Wallet{}, &ledger.NotFoundError{Kind: "wallet", ID: wallet}
+	}
+
+	return ledger.Wallet{ID: wallet, Status: ledger.WalletActive}, nil
+}
+
+// known reports whether the wallet has been opened, has transactions, or is in the chart of accounts, callers must hold the book's lock
+func (b *Book) known(wallet string) bool {
+	if _, ok := b.registry[wallet]; ok {
+		return true
+	}
+
+	if _, ok := b.walletMap[wallet]; ok {
+		return true
+	}
+
+	if _, ok := b.accounts[wallet]; ok {
+		return true
+	}
+
+	return wallet == b.cashAccount
+}
+
+// checkDestination returns a NotFoundError unless the wallet funds are being sent to is known to the book
+// so funds can't be sent to a mistyped wallet, it has to be opened first, callers must hold the book's lock
+func (b *Book) checkDestination(wallet string) error {
+	if !b.known(wallet) {
+		return &ledger.NotFoundError{Kind: "wallet", ID: wallet}
+	}
+
+	return nil
+}
+
+// checkStatus returns a WalletStatusError if the wallet's status doesn't let the transaction be posted to it
+// frozen wallets take anything but transactions that take funds out or hold them, callers must hold the book's lock
+func (b *Book) checkStatus(transactionType string, wallet string) error {
+	w, ok := b.registry[wallet]
+	if !ok {
+		return nil
+	}
+
+	switch w.Status {
+	case ledger.WalletClosed:
+		return &ledger.WalletStatusError{Wallet: wallet, Status: w.Status}
+	case ledger.WalletFrozen:
+		if transactionType == ledger.TransactionHold || b.decreases(transactionType, wallet) {
+			return &ledger.WalletStatusError{Wallet: wallet, Status: w.Status}
+		}
+	}
+
+	return nil
+}
+
+// checkEmpty returns an error unless every balance of the wallet is zero and it has no holds, even expired ones that haven't been released
+// callers must hold the book's lock
+func (b *Book) checkEmpty(wallet string) error {
+	if reason, ok := b.unbalanced[wallet]; ok {
+		return errors.New(reason)
+	}
+
+	for _, balance := range b.balances[wallet] {
+		if balance.Amount != 0 {
+			return &ledger.RejectedError{Subject: wallet, Rejection: ledger.RejectionWalletNotEmpty, Reason: fmt.Sprintf("wallet '%s' holds %v, it has to be emptied before it's closed", wallet, balance)}
+		}
+	}
+
+	if len(b.holds[wallet]) > 0 {
+		return &ledger.RejectedError{Subject: wallet, Rejection: ledger.RejectionWalletNotEmpty, Reason: fmt.Sprintf("wallet '%s' has %d holds on it, they have to be captured, voided or released before it's closed", wallet, len(b.holds[wallet]))}
+	}
+
+	return nil
+}
+
+// copyWallet returns a copy of the wallet that doesn't share its metadata
+func copyWallet(wallet ledger.Wallet) *ledger.Wallet {
+	if wallet.Metadata != nil {
+		metadata := make(map[string]string, len(wallet.Metadata))
+		for k, v := range wallet.Metadata {
+			metadata[k] = v
+		}
+
+		wallet.Metadata = metadata
+	}
+
+	return &wallet
+}
@@ -0,0 +1,226 @@
+package memory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestBook_OpenWallet(t *testing.T) {
+	t.Run("should open a wallet with an owner and metadata that has no balance until funds are sent to it", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		wallet := ledger.Wallet{ID: "1", Owner: "alice", Metadata: map[string]string{"tier": "gold"}}
+
+		id, err := book.OpenWallet(wallet, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		got, err := book.Wallet(id)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		want := ledger.Wallet{ID: "1", Owner: "alice", Metadata: map[string]string{"tier": "gold"}, Status: ledger.WalletActive}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got wallet %v, wanted %v", got, want)
+		}
+
+		balance, err := book.WalletBalance("1")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		test.AssertWalletBalance(t, balance, []ledger.Money{})
+
+		ts, err := book.WalletTransactions("1")
+		if err != nil || len(ts) != 0 {
+			t.Errorf("got transactions %v and error %v, wanted none", ts, err)
+		}
+	})
+	t.Run("should generate an ID for a wallet opened without one", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		id, err := book.OpenWallet(ledger.Wallet{Owner: "alice"}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if id == "" {
+			t.Error("no ID generated")
+		}
+	})
+	t.Run("should refuse to open a wallet twice", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+
+		_, err := book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should treat a wallet that was only ever posted to as active", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		got, err := book.Wallet("2")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if got.Status != ledger.WalletActive {
+			t.Errorf("got status %s, wanted %s", got.Status, ledger.WalletActive)
+		}
+
+		_, err = book.Wallet("99")
+		if err == nil {
+			t.Error("no error returned for an unknown wallet")
+		}
+	})
+	t.Run("should refuse transfers, exchanges and captures to an unknown wallet", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		_, err := book.TransferWalletFunds("1", "99", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned for a transfer")
+		}
+
+		_, err = book.ExchangeWalletFunds("1", "99", ledger.NewMoney(1000, ledger.DefaultCurrency), ledger.DefaultCurrency, "")
+		if err == nil {
+			t.Error("no error returned for an exchange")
+		}
+
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(1000, ledger.DefaultCurrency), time.Time{}, "")
+
+		_, err = book.CaptureHold(hold, "99", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err == nil {
+			t.Error("no error returned for a capture")
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "99"}, "")
+
+		_, err = book.TransferWalletFunds("1", "99", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error once the wallet was opened, %v", err)
+		}
+	})
+	t.Run("should restore wallets from earlier commits", func(t *testing.T) {
+		var commits []Commit
+
+		book := NewInMemoryBook(WithCommitHook(func(c Commit) error {
+			commits = append(commits, c)
+			return nil
+		}))
+
+		book.OpenWallet(ledger.Wallet{ID: "1", Owner: "alice"}, "")
+		book.SetWalletStatus("1", ledger.WalletFrozen, "")
+
+		restored := NewInMemoryBook(WithCommits(commits))
+
+		got, _ := restored.Wallet("1")
+		if got.Owner != "alice" || got.Status != ledger.WalletFrozen {
+			t.Errorf("got wallet %v, wanted alice's frozen wallet", got)
+		}
+	})
+}
+
+func TestBook_SetWalletStatus(t *testing.T) {
+	t.Run("should let funds into a frozen wallet but none out, until it's made active again", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(1000, ledger.DefaultCurrency), time.Time{}, "")
+
+		err := book.SetWalletStatus("1", ledger.WalletFrozen, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error for a deposit, %v", err)
+		}
+
+		_, err = book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		assertWalletStatus(t, err, "1", ledger.WalletFrozen)
+
+		_, err = book.WithdrawWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		assertWalletStatus(t, err, "1", ledger.WalletFrozen)
+
+		_, err = book.AuthorizeHold("1", ledger.NewMoney(1000, ledger.DefaultCurrency), time.Time{}, "")
+		assertWalletStatus(t, err, "1", ledger.WalletFrozen)
+
+		_, err = book.CaptureHold(hold, "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		assertWalletStatus(t, err, "1", ledger.WalletFrozen)
+
+		// voiding a hold gives funds back, so it's still allowed
+		_, err = book.VoidHold(hold, "")
+		if err != nil {
+			t.Errorf("returned error voiding a hold, %v", err)
+		}
+
+		book.SetWalletStatus("1", ledger.WalletActive, "")
+
+		_, err = book.TransferWalletFunds("1", "2", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Errorf("returned error once the wallet was made active, %v", err)
+		}
+	})
+	t.Run("should only close an empty wallet, which then takes nothing and stays closed", func(t *testing.T) {
+		book := NewInMemoryBook()
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		hold, _ := book.AuthorizeHold("1", ledger.NewMoney(1000, ledger.DefaultCurrency), time.Time{}, "")
+
+		err := book.SetWalletStatus("1", ledger.WalletClosed, "")
+		if err == nil {
+			t.Error("no error returned closing a wallet with funds in it")
+		}
+
+		book.VoidHold(hold, "")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		err = book.SetWalletStatus("1", ledger.WalletClosed, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		_, err = book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		assertWalletStatus(t, err, "1", ledger.WalletClosed)
+
+		err = book.SetWalletStatus("1", ledger.WalletActive, "")
+		if err == nil {
+			t.Error("no error returned reopening a closed wallet")
+		}
+	})
+	t.Run("should refuse an unknown status or wallet", func(t *testing.T) {
+		book := NewMockInMemoryBook()
+
+		err := book.SetWalletStatus("1", "dormant", "")
+		if err == nil {
+			t.Error("no error returned for an unknown status")
+		}
+
+		err = book.SetWalletStatus("99", ledger.WalletFrozen, "")
+		if err == nil {
+			t.Error("no error returned for an unknown wallet")
+		}
+	})
+}
+
+func assertWalletStatus(t *testing.T, err error, wallet string, status ledger.WalletStatus) {
+	t.Helper()
+
+	e, ok := err.(*ledger.WalletStatusError)
+	if !ok {
+		t.Fatalf("got error %v, wanted a WalletStatusError", err)
+	}
+
+	if e.Wallet != wallet || e.Status != status {
+		t.Errorf("got %s wallet '%s', wanted %s wallet '%s'", e.Status, e.Wallet, status, wallet)
+	}
+}
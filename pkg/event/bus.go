@@ -0,0 +1,269 @@
+// Package event delivers the events a book publishes to the subscribers interested in them, in process
+// every subscriber is handed the events in the order they were published, so the events of each wallet arrive in the order
+// they happened, and an event is handed to a subscriber again until it handles it, so it's delivered at least once
+// events are only kept in memory, those a subscriber hasn't handled when the bus is closed are never delivered to it
+package event
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// DefaultRetryInterval is how long a subscriber waits before being handed an event it failed to handle again
+const DefaultRetryInterval = time.Second
+
+// Envelope is an event as it's handed to a subscriber
+type Envelope struct {
+	Sequence    uint64       `json:"sequence"` // the order the event was published in, starting at 1
+	Name        string       `json:"name"`
+	Wallets     []string     `json:"wallets,omitempty"` // the wallets the command that published it touched
+	PublishedAt int64        `json:"published_at"`      // unix time in nanoseconds the event was published
+	Attempt     int          `json:"attempt"`           // how many times the event has been handed to the subscriber, including this one
+	Event       ledger.Event `json:"event"`
+}
+
+// Handler handles an event handed to a subscriber, the event is handed to it again if it returns an error or panics
+// so it may see an event more than once, the attempt number tells it when it's a retry
+type Handler func(e Envelope) error
+
+// Bus is a ledger.Publisher that hands every event published to it to each subscriber interested in it
+// publishing never waits for subscribers, each one is handed its events on its own goroutine
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]bool
+	sequence    uint64
+	retry       time.Duration
+	clock       func() time.Time
+	closed      bool
+}
+
+// Option configures a Bus as it is created
+type Option func(*Bus)
+
+// WithRetryInterval sets how long a subscriber waits before being handed an event it failed to handle again, the default is DefaultRetryInterval
+func WithRetryInterval(interval time.Duration) Option {
+	return func(b *Bus) {
+		b.retry = interval
+	}
+}
+
+// WithClock sets where the bus gets the time events are published at, the default is time.Now
+func WithClock(clock func() time.Time) Option {
+	return func(b *Bus) {
+		b.clock = clock
+	}
+}
+
+// NewBus returns a Bus with no subscribers
+func NewBus(options ...Option) *Bus {
+	b := &Bus{
+		subscribers: make(map[*Subscription]bool),
+		retry:       DefaultRetryInterval,
+		clock:       time.Now,
+	}
+
+	for _, option := range options {
+		option(b)
+	}
+
+	return b
+}
+
+// Subscribe has the handler handed every event with one of the given names published from now on, or every event if no names are given
+// the names are those returned by the events' EventName, such as ledger.EventWalletFundsTransferred
+func (b *Bus) Subscribe(handler Handler, names ...string) *Subscription {
+	s := &Subscription{
+		bus:     b,
+		handler: handler,
+		names:   make(map[string]bool),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	s.ready = sync.NewCond(&s.mu)
+
+	for _, name := range names {
+		s.names[name] = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// subscribing to a closed bus returns a subscription that's already stopped
+	if b.closed {
+		s.stopOnce.Do(func() { close(s.done) })
+		close(s.stopped)
+		return s
+	}
+
+	b.subscribers[s] = true
+
+	go s.run()
+
+	return s
+}
+
+// Publish queues the event for every subscriber interested in it, events published once the bus is closed are dropped
+func (b *Bus) Publish(event ledger.Event, wallets ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.sequence++
+
+	e := Envelope{
+		Sequence:    b.sequence,
+		Name:        event.EventName(),
+		Wallets:     append([]string{}, wallets...),
+		PublishedAt: b.clock().UnixNano(),
+		Event:       event,
+	}
+
+	for s := range b.subscribers {
+		s.queue(e)
+	}
+}
+
+// Close stops every subscription, waiting for the events being handled to be, and drops anything published after
+func (b *Bus) Close() {
+	b.mu.Lock()
+	b.closed = true
+
+	subscribers := make([]*Subscription, 0, len(b.subscribers))
+	for s := range b.subscribers {
+		subscribers = append(subscribers, s)
+	}
+
+	b.subscribers = make(map[*Subscription]bool)
+	b.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.stop()
+	}
+}
+
+// Subscription is a handler subscribed to a bus, its events are queued until the handler has handled them
+type Subscription struct {
+	bus      *Bus
+	handler  Handler
+	names    map[string]bool
+	mu       sync.Mutex
+	ready    *sync.Cond // signalled when an event is queued or the subscription is stopped
+	pending  []Envelope
+	done     chan struct{} // closed when the subscription is stopped
+	stopped  chan struct{} // closed once the handler has returned for the last time
+	stopOnce sync.Once
+}
+
+// Pending returns how many events are waiting to be handled, including the one being handled
+func (s *Subscription) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.pending)
+}
+
+// Close unsubscribes the handler, waiting for the event it's handling to be handled, the events still queued are dropped
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	delete(s.bus.subscribers, s)
+	s.bus.mu.Unlock()
+
+	s.stop()
+}
+
+func (s *Subscription) stop() {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		close(s.done)
+		s.ready.Broadcast()
+		s.mu.Unlock()
+	})
+
+	<-s.stopped
+}
+
+// queue adds the event to those waiting to be handled, if the subscriber is interested in it
+func (s *Subscription) queue(e Envelope) {
+	if len(s.names) > 0 && !s.names[e.Name] {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, e)
+	s.ready.Signal()
+}
+
+// run hands the queued events to the handler one at a time, an event is only taken off the queue once it has been handled
+func (s *Subscription) run() {
+	defer close(s.stopped)
+
+	for {
+		e, ok := s.next()
+		if !ok {
+			return
+		}
+
+		for e.Attempt = 1; ; e.Attempt++ {
+			err := s.handle(e)
+			if err == nil {
+				break
+			}
+
+			log.Printf("problem handling event %d (%s), attempt %d, %v", e.Sequence, e.Name, e.Attempt, err)
+
+			select {
+			case <-time.After(s.bus.retry):
+			case <-s.done:
+				return
+			}
+		}
+
+		s.mu.Lock()
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+	}
+}
+
+// next waits for an event to be queued, returning the oldest one, or false once the subscription is stopped
+func (s *Subscription) next() (Envelope, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.pending) == 0 {
+		select {
+		case <-s.done:
+			return Envelope{}, false
+		default:
+		}
+
+		s.ready.Wait()
+	}
+
+	select {
+	case <-s.done:
+		return Envelope{}, false
+	default:
+	}
+
+	return s.pending[0], true
+}
+
+// handle hands the event to the handler, a panic is returned as an error so the event is retried
+func (s *Subscription) handle(e Envelope) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked, %v", r)
+		}
+	}()
+
+	return s.handler(e)
+}
@@ -0,0 +1,166 @@
+package event
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// recorder collects what a subscriber was handed, signalling each time it has
+type recorder struct {
+	mu       sync.Mutex
+	handled  []Envelope
+	received chan struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{received: make(chan struct{}, 100)}
+}
+
+func (r *recorder) handle(e Envelope) error {
+	r.mu.Lock()
+	r.handled = append(r.handled, e)
+	r.mu.Unlock()
+
+	r.received <- struct{}{}
+
+	return nil
+}
+
+func (r *recorder) wait(t *testing.T, n int) []Envelope {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		select {
+		case <-r.received:
+		case <-time.After(time.Second):
+			t.Fatalf("only %d of %d events were handled", i, n)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Envelope{}, r.handled...)
+}
+
+func deposited(wallet string) ledger.WalletFundsDeposited {
+	return ledger.WalletFundsDeposited{Wallet: wallet, Deposit: ledger.NewMoney(100, ledger.DefaultCurrency)}
+}
+
+func TestBus_Publish(t *testing.T) {
+	t.Run("should hand every subscriber the events in the order they were published", func(t *testing.T) {
+		bus := NewBus()
+		defer bus.Close()
+
+		first, second := newRecorder(), newRecorder()
+		bus.Subscribe(first.handle)
+		bus.Subscribe(second.handle)
+
+		for _, wallet := range []string{"1", "2", "1"} {
+			bus.Publish(deposited(wallet), wallet)
+		}
+
+		for _, r := range []*recorder{first, second} {
+			got := r.wait(t, 3)
+
+			for i, e := range got {
+				if e.Sequence != uint64(i+1) || e.Name != ledger.EventWalletFundsDeposited || e.Attempt != 1 {
+					t.Errorf("got event %+v at %d, wanted the first attempt at event %d", e, i, i+1)
+				}
+			}
+
+			if !reflect.DeepEqual(got[2].Event, deposited("1")) || !reflect.DeepEqual(got[2].Wallets, []string{"1"}) {
+				t.Errorf("got %+v, wanted the last deposit to wallet 1", got[2])
+			}
+		}
+	})
+	t.Run("should only hand a subscriber the events it subscribed to", func(t *testing.T) {
+		bus := NewBus()
+		defer bus.Close()
+
+		r := newRecorder()
+		bus.Subscribe(r.handle, ledger.EventWalletOpened)
+
+		bus.Publish(deposited("1"), "1")
+		bus.Publish(ledger.WalletOpened{Wallet: ledger.Wallet{ID: "2"}}, "2")
+
+		got := r.wait(t, 1)
+		if got[0].Name != ledger.EventWalletOpened || got[0].Sequence != 2 {
+			t.Errorf("got %+v, wanted only the wallet being opened", got[0])
+		}
+	})
+	t.Run("should hand an event to a subscriber again until it's handled, holding back the ones after it", func(t *testing.T) {
+		bus := NewBus(WithRetryInterval(time.Millisecond))
+		defer bus.Close()
+
+		r := newRecorder()
+		failures := 0
+
+		bus.Subscribe(func(e Envelope) error {
+			if e.Sequence == 1 && failures < 2 {
+				failures++
+				if failures == 2 {
+					panic("handler broke")
+				}
+
+				return errors.New("handler unavailable")
+			}
+
+			return r.handle(e)
+		})
+
+		bus.Publish(deposited("1"), "1")
+		bus.Publish(deposited("1"), "1")
+
+		got := r.wait(t, 2)
+		if got[0].Sequence != 1 || got[0].Attempt != 3 || got[1].Sequence != 2 || got[1].Attempt != 1 {
+			t.Errorf("got %+v, wanted the first event handled on its third attempt then the second on its first", got)
+		}
+	})
+}
+
+func TestSubscription_Close(t *testing.T) {
+	t.Run("should stop handing events to the subscriber", func(t *testing.T) {
+		bus := NewBus()
+		defer bus.Close()
+
+		closed, open := newRecorder(), newRecorder()
+		s := bus.Subscribe(closed.handle)
+		bus.Subscribe(open.handle)
+
+		s.Close()
+
+		bus.Publish(deposited("1"), "1")
+		open.wait(t, 1)
+
+		if len(closed.handled) != 0 {
+			t.Errorf("got %d events handled after the subscription was closed, wanted none", len(closed.handled))
+		}
+	})
+	t.Run("should stop retrying an event that's never handled", func(t *testing.T) {
+		bus := NewBus(WithRetryInterval(time.Hour))
+
+		attempted := make(chan struct{}, 1)
+		s := bus.Subscribe(func(e Envelope) error {
+			attempted <- struct{}{}
+			return errors.New("handler unavailable")
+		})
+
+		bus.Publish(deposited("1"), "1")
+		<-attempted
+
+		if s.Pending() != 1 {
+			t.Errorf("got %d events pending, wanted the one not handled", s.Pending())
+		}
+
+		bus.Close()
+
+		s = bus.Subscribe(func(e Envelope) error { return nil })
+		s.Close()
+	})
+}
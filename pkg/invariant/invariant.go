@@ -0,0 +1,375 @@
+// Package invariant checks that a book is internally consistent, that every aggregate balances, that no wallet went below
+// what its overdraft policy allows and that the book's indexes agree with its transactions
+// it only uses the ledger.Book interface, so it runs against any book
+package invariant
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// Kind is the invariant a violation breaks
+type Kind string
+
+const (
+	// AggregateUnbalanced is an aggregate whose credits and debits don't net to zero in every currency
+	AggregateUnbalanced Kind = "aggregate_unbalanced"
+	// UnknownType is a transaction of a type no balance can be worked out from
+	UnknownType Kind = "unknown_type"
+	// Overdrawn is a transaction that took its wallet below what the wallet's overdraft policy allows
+	Overdrawn Kind = "overdrawn"
+	// WalletIndex is a wallet whose transactions differ from the ones the book holds for it
+	WalletIndex Kind = "wallet_index"
+	// AggregateIndex is an aggregate whose transactions differ from the ones the book holds for it
+	AggregateIndex Kind = "aggregate_index"
+	// BalanceMismatch is a wallet whose balance differs from the sum of its transactions
+	BalanceMismatch Kind = "balance_mismatch"
+)
+
+// Violation is a broken invariant, naming the wallet or aggregate it was found in
+type Violation struct {
+	Invariant Kind   `json:"invariant"`
+	Wallet    string `json:"wallet,omitempty"`
+	Aggregate string `json:"aggregate,omitempty"`
+	Detail    string `json:"detail"`
+}
+
+func (v Violation) String() string {
+	switch {
+	case v.Aggregate != "" && v.Wallet != "":
+		return fmt.Sprintf("%s in aggregate '%s' of wallet '%s': %s", v.Invariant, v.Aggregate, v.Wallet, v.Detail)
+	case v.Aggregate != "":
+		return fmt.Sprintf("%s in aggregate '%s': %s", v.Invariant, v.Aggregate, v.Detail)
+	default:
+		return fmt.Sprintf("%s in wallet '%s': %s", v.Invariant, v.Wallet, v.Detail)
+	}
+}
+
+// Report is the outcome of checking a book, how many transactions were checked and every violation found
+type Report struct {
+	Transactions int         `json:"transactions"`
+	Violations   []Violation `json:"violations"`
+}
+
+// OK reports whether the book broke no invariant
+func (r Report) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// Check checks every invariant against the book's transactions as they were when it started
+// transactions committed while it runs are left out, so it can be run against a book that's in use
+// wallets are judged by their current overdraft policy
+func Check(book ledger.Book) Report {
+	ts := book.Transactions()
+
+	r := Report{Transactions: len(ts), Violations: []Violation{}}
+
+	r.Violations = append(r.Violations, checkAggregates(ts)...)
+	r.Violations = append(r.Violations, checkOverdrafts(book, ts)...)
+	r.Violations = append(r.Violations, checkIndexes(book, ts)...)
+
+	return r
+}
+
+// checkAggregates returns a violation for every aggregate that doesn't balance and every transaction of an unknown type
+// in the order the aggregates were first posted to
+func checkAggregates(ts []ledgerpb.Transaction) []Violation {
+	violations := []Violation{}
+
+	aggregates, order := group(ts, func(t ledgerpb.Transaction) string { return t.Aggregate })
+
+	for _, aggregate := range order {
+		legs := aggregates[aggregate]
+
+		for _, t := range legs {
+			if ledger.Side(t.Type) == "" && t.Type != ledger.TransactionHold && t.Type != ledger.TransactionRelease {
+				violations = append(violations, Violation{Invariant: UnknownType, Wallet: t.Wallet, Aggregate: aggregate, Detail: fmt.Sprintf("transaction '%s' has unknown type '%s'", t.Id, t.Type)})
+			}
+		}
+
+		detail := unbalanced(legs)
+		if detail != "" {
+			violations = append(violations, Violation{Invariant: AggregateUnbalanced, Aggregate: aggregate, Detail: detail})
+		}
+	}
+
+	return violations
+}
+
+// unbalanced describes how the legs of an aggregate fail to net to zero, or returns an empty string if they do
+// cash in and cash out cross the ledger's boundary, they're balanced by the leg on the cash account posted with them
+// the two legs of an exchange are in different currencies, they balance when one converts to the other at their rate
+func unbalanced(legs []ledgerpb.Transaction) string {
+	all := make(map[string]int64)
+
+	rated := []ledgerpb.Transaction{}
+
+	for _, t := range legs {
+		side := ledger.Side(t.Type)
+		if side == "" {
+			continue
+		}
+
+		if t.Rate != "" {
+			rated = append(rated, t)
+			continue
+		}
+
+		amount := t.Amount
+		if side == ledger.TransactionDebit {
+			amount = -amount
+		}
+
+		all[t.Currency] += amount
+	}
+
+	if len(rated) > 0 && !exchanged(rated) {
+		return fmt.Sprintf("%d exchange legs don't convert into each other at their rate", len(rated))
+	}
+
+	if zero(all) {
+		return ""
+	}
+
+	currencies := make([]string, 0, len(all))
+	for currency, amount := range all {
+		if amount != 0 {
+			currencies = append(currencies, currency)
+		}
+	}
+
+	sort.Strings(currencies)
+
+	detail := "credits and debits differ by"
+	for _, currency := range currencies {
+		detail += fmt.Sprintf(" %v", ledger.NewMoney(all[currency], currency))
+	}
+
+	return detail
+}
+
+// exchanged reports whether the legs are the two sides of an exchange, each the other converted at their rate
+func exchanged(legs []ledgerpb.Transaction) bool {
+	if len(legs) != 2 {
+		return false
+	}
+
+	a, b := legs[0], legs[1]
+
+	if a.Rate != b.Rate || a.Currency == b.Currency || ledger.Side(a.Type) == ledger.Side(b.Type) {
+		return false
+	}
+
+	rate, err := ledger.ParseRate(a.Rate)
+	if err != nil {
+		return false
+	}
+
+	// the rate is from the currency funds were exchanged out of, which is either leg once the exchange is reversed
+	for _, pair := range [][2]ledgerpb.Transaction{{a, b}, {b, a}} {
+		from, to := pair[0], pair[1]
+
+		converted, err := ledger.NewMoney(from.Amount, from.Currency).Convert(to.Currency, rate)
+		if err == nil && converted.Amount == to.Amount {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkOverdrafts returns a violation for every transaction that took its wallet below what its overdraft policy allows
+func checkOverdrafts(book ledger.Book, ts []ledgerpb.Transaction) []Violation {
+	violations := []Violation{}
+
+	sides := make(map[string]string)
+	balances := make(map[string]map[string]int64)
+
+	for _, t := range ts {
+		side := ledger.Side(t.Type)
+		if side == "" {
+			continue
+		}
+
+		normal, ok := sides[t.Wallet]
+		if !ok {
+			normal = book.WalletAccountType(t.Wallet).NormalSide()
+			sides[t.Wallet] = normal
+			balances[t.Wallet] = make(map[string]int64)
+		}
+
+		before := balances[t.Wallet][t.Currency]
+
+		if side == normal {
+			balances[t.Wallet][t.Currency] = before + t.Amount
+			continue
+		}
+
+		balances[t.Wallet][t.Currency] = before - t.Amount
+
+		policy := book.OverdraftPolicy(t.Wallet)
+
+		if !policy.Covers(ledger.NewMoney(before, t.Currency), ledger.NewMoney(t.Amount, t.Currency)) {
+			violations = append(violations, Violation{
+				Invariant: Overdrawn,
+				Wallet:    t.Wallet,
+				Aggregate: t.Aggregate,
+				Detail:    fmt.Sprintf("balance of %v can't cover %v with %s overdraft", ledger.NewMoney(before, t.Currency), ledger.NewMoney(t.Amount, t.Currency), policy),
+			})
+		}
+	}
+
+	return violations
+}
+
+// checkIndexes returns a violation for every wallet and aggregate whose transactions differ from the ones in the book's
+// transactions, and every wallet whose balance isn't the sum of them, ordered by wallet then aggregate
+// an index holding more transactions than were checked had some committed since, only the ones that were checked are compared
+func checkIndexes(book ledger.Book, ts []ledgerpb.Transaction) []Violation {
+	violations := []Violation{}
+
+	wallets, walletOrder := group(ts, func(t ledgerpb.Transaction) string { return t.Wallet })
+	sort.Strings(walletOrder)
+
+	for _, wallet := range walletOrder {
+		want := wallets[wallet]
+
+		got, err := book.WalletTransactions(wallet)
+		if err != nil {
+			violations = append(violations, Violation{Invariant: WalletIndex, Wallet: wallet, Detail: err.Error()})
+			continue
+		}
+
+		detail := differs(got, want)
+		if detail != "" {
+			violations = append(violations, Violation{Invariant: WalletIndex, Wallet: wallet, Detail: detail})
+			continue
+		}
+
+		if len(got) != len(want) {
+			continue
+		}
+
+		detail = balanceDiffers(book, wallet, want)
+		if detail != "" {
+			violations = append(violations, Violation{Invariant: BalanceMismatch, Wallet: wallet, Detail: detail})
+		}
+	}
+
+	aggregates, aggregateOrder := group(ts, func(t ledgerpb.Transaction) string { return t.Aggregate })
+	sort.Strings(aggregateOrder)
+
+	for _, aggregate := range aggregateOrder {
+		got, err := book.AggregateTransactions(aggregate)
+		if err != nil {
+			violations = append(violations, Violation{Invariant: AggregateIndex, Aggregate: aggregate, Detail: err.Error()})
+			continue
+		}
+
+		detail := differs(got, aggregates[aggregate])
+		if detail != "" {
+			violations = append(violations, Violation{Invariant: AggregateIndex, Aggregate: aggregate, Detail: detail})
+		}
+	}
+
+	return violations
+}
+
+// differs describes the first difference between an index and the transactions it should hold, or returns an empty string
+func differs(got []*ledgerpb.Transaction, want []ledgerpb.Transaction) string {
+	if len(got) < len(want) {
+		return fmt.Sprintf("index holds %d transactions, the book holds %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if !proto.Equal(got[i], &want[i]) {
+			return fmt.Sprintf("index holds transaction '%s' at %d, the book holds '%s'", got[i].GetId(), i, want[i].Id)
+		}
+	}
+
+	return ""
+}
+
+// balanceDiffers describes how the wallet's balance differs from the sum of its transactions, or returns an empty string
+func balanceDiffers(book ledger.Book, wallet string, ts []ledgerpb.Transaction) string {
+	normal := book.WalletAccountType(wallet).NormalSide()
+
+	want := make(map[string]int64)
+
+	for _, t := range ts {
+		side := ledger.Side(t.Type)
+
+		switch {
+		case side == "":
+			continue
+		case side == normal:
+			want[t.Currency] += t.Amount
+		default:
+			want[t.Currency] -= t.Amount
+		}
+	}
+
+	balances, err := book.WalletBalance(wallet)
+	if err != nil {
+		return fmt.Sprintf("balance can't be worked out, %v", err)
+	}
+
+	got := make(map[string]int64)
+	for _, b := range balances {
+		got[b.Current.Currency] = b.Current.Amount
+	}
+
+	currencies := make([]string, 0, len(want)+len(got))
+	for currency := range want {
+		currencies = append(currencies, currency)
+	}
+	for currency := range got {
+		if _, ok := want[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+	}
+
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		if got[currency] != want[currency] {
+			return fmt.Sprintf("balance is %v, its transactions sum to %v", ledger.NewMoney(got[currency], currency), ledger.NewMoney(want[currency], currency))
+		}
+	}
+
+	return ""
+}
+
+// group returns the transactions grouped by the key, along with the keys in the order they first appear
+func group(ts []ledgerpb.Transaction, key func(t ledgerpb.Transaction) string) (map[string][]ledgerpb.Transaction, []string) {
+	groups := make(map[string][]ledgerpb.Transaction)
+	order := []string{}
+
+	for _, t := range ts {
+		k := key(t)
+
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+
+		groups[k] = append(groups[k], t)
+	}
+
+	return groups, order
+}
+
+// zero reports whether every amount is zero
+func zero(amounts map[string]int64) bool {
+	for _, amount := range amounts {
+		if amount != 0 {
+			return false
+		}
+	}
+
+	return true
+}
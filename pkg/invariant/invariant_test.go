@@ -0,0 +1,217 @@
+package invariant
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+	ratememory "gitlab.com/patchwell/ledger/pkg/rate/memory"
+)
+
+// skewedBook reports a balance one minor unit off from its transactions
+type skewedBook struct {
+	*memory.Book
+}
+
+func (b skewedBook) WalletBalance(wallet string) ([]ledger.Balance, error) {
+	balances, err := b.Book.WalletBalance(wallet)
+	for i := range balances {
+		balances[i].Current.Amount++
+	}
+
+	return balances, err
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("should find no violations in a book only posted to by its commands", func(t *testing.T) {
+		rates := ratememory.NewInMemoryRates()
+		rates.SetRate(ledger.DefaultCurrency, "EUR", big.NewRat(92, 100))
+
+		book := memory.NewInMemoryBook(memory.WithCashAccount("cash"), memory.WithRateProvider(rates))
+		book.OpenWallet(ledger.Wallet{ID: "fees"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.SetFeeSchedule(ledger.FeeSchedule{Command: ledger.FeeTransfer, Currency: ledger.DefaultCurrency, Wallet: "fees", Flat: 10}, "")
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+		transfer, err := book.TransferWalletFunds("1", "2", ledger.NewMoney(2000, ledger.DefaultCurrency), "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		exchange, err := book.ExchangeWalletFunds("1", "2", ledger.NewMoney(1001, ledger.DefaultCurrency), "EUR", "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		book.ReverseAggregate(transfer, "sent to the wrong wallet", "")
+		book.ReverseAggregate(exchange, "sent to the wrong wallet", "")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(500, ledger.DefaultCurrency), "")
+
+		if n := len(book.Transactions()); n != 16 {
+			t.Fatalf("got %d transactions, wanted every command to have been posted", n)
+		}
+
+		r := Check(book)
+		if !r.OK() {
+			t.Errorf("got violations %v, wanted none", r.Violations)
+		}
+
+		if r.Transactions != len(book.Transactions()) {
+			t.Errorf("checked %d transactions, wanted %d", r.Transactions, len(book.Transactions()))
+		}
+	})
+	t.Run("should report an aggregate that doesn't net to zero", func(t *testing.T) {
+		book := memory.NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.AddTransaction(ledger.TransactionCredit, "1", ledger.NewMoney(100, ledger.DefaultCurrency), "3333", "")
+
+		r := Check(book)
+
+		want := []Violation{{Invariant: AggregateUnbalanced, Aggregate: "3333", Detail: "credits and debits differ by 100 USD"}}
+		if !reflect.DeepEqual(r.Violations, want) {
+			t.Errorf("got violations %v, wanted %v", r.Violations, want)
+		}
+	})
+	t.Run("should report a transaction that took its wallet below its overdraft policy", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithTransactions([]ledgerpb.Transaction{
+			{Type: ledger.TransactionDebit, Wallet: "1", Amount: 100, Currency: ledger.DefaultCurrency, Aggregate: "3333"},
+			{Type: ledger.TransactionCredit, Wallet: "2", Amount: 100, Currency: ledger.DefaultCurrency, Aggregate: "3333"},
+		}))
+
+		r := Check(book)
+		if len(r.Violations) != 1 || r.Violations[0].Invariant != Overdrawn || r.Violations[0].Wallet != "1" || r.Violations[0].Aggregate != "3333" {
+			t.Errorf("got violations %v, wanted wallet '1' overdrawn by aggregate '3333'", r.Violations)
+		}
+
+		book.SetOverdraftPolicy("1", ledger.OverdraftLimit(ledger.NewMoney(100, ledger.DefaultCurrency)), "")
+
+		if r := Check(book); !r.OK() {
+			t.Errorf("got violations %v, wanted none once the policy allows it", r.Violations)
+		}
+	})
+	t.Run("should report interest paid out of a source its overdraft policy doesn't cover", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithTransactions([]ledgerpb.Transaction{
+			{Type: ledger.TransactionCredit, Wallet: "1", Amount: 100, Currency: ledger.DefaultCurrency, Aggregate: "3333", Interest: true},
+			{Type: ledger.TransactionDebit, Wallet: "interest", Amount: 100, Currency: ledger.DefaultCurrency, Aggregate: "3333", Interest: true},
+		}))
+
+		r := Check(book)
+		if len(r.Violations) != 1 || r.Violations[0].Invariant != Overdrawn || r.Violations[0].Wallet != "interest" {
+			t.Errorf("got violations %v, wanted wallet 'interest' overdrawn", r.Violations)
+		}
+	})
+	t.Run("should report cash posted without a leg on the cash account, a transaction of an unknown type and the balance it breaks", func(t *testing.T) {
+		r := Check(memory.NewMockInMemoryBook())
+
+		kinds := []Kind{}
+		for _, v := range r.Violations {
+			kinds = append(kinds, v.Invariant)
+		}
+
+		// the mock's cash in and cash out were posted to their wallets alone, in aggregates 1111, 1112 and 1114
+		if !reflect.DeepEqual(kinds, []Kind{AggregateUnbalanced, AggregateUnbalanced, AggregateUnbalanced, UnknownType, BalanceMismatch}) {
+			t.Errorf("got violations %v, wanted three unbalanced aggregates, an unknown type and a balance mismatch", r.Violations)
+		}
+	})
+	t.Run("should report a deposit posted to its wallet alone", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithTransactions([]ledgerpb.Transaction{
+			{Type: ledger.TransactionCashIn, Wallet: "1", Amount: 100, Currency: ledger.DefaultCurrency, Aggregate: "4444"},
+		}))
+
+		r := Check(book)
+
+		want := []Violation{{Invariant: AggregateUnbalanced, Aggregate: "4444", Detail: "credits and debits differ by 100 USD"}}
+		if !reflect.DeepEqual(r.Violations, want) {
+			t.Errorf("got violations %v, wanted %v", r.Violations, want)
+		}
+	})
+	t.Run("should report a balance that isn't the sum of its wallet's transactions", func(t *testing.T) {
+		book := memory.NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(100, ledger.DefaultCurrency), "")
+
+		r := Check(skewedBook{book})
+
+		want := []Violation{
+			{Invariant: BalanceMismatch, Wallet: "1", Detail: "balance is 101 USD, its transactions sum to 100 USD"},
+			{Invariant: BalanceMismatch, Wallet: "cash", Detail: "balance is 101 USD, its transactions sum to 100 USD"},
+		}
+		if !reflect.DeepEqual(r.Violations, want) {
+			t.Errorf("got violations %v, wanted %v", r.Violations, want)
+		}
+	})
+}
+
+func TestNewTrialBalance(t *testing.T) {
+	t.Run("should list each wallet's balance in its column with totals that match", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithCashAccount("cash"))
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, ledger.DefaultCurrency), "")
+		book.TransferWalletFunds("1", "2", ledger.NewMoney(2500, ledger.DefaultCurrency), "")
+
+		got := NewTrialBalance(book)
+
+		want := TrialBalance{
+			Lines: []TrialBalanceLine{
+				{Wallet: "1", Currency: ledger.DefaultCurrency, Credit: 7500},
+				{Wallet: "2", Currency: ledger.DefaultCurrency, Credit: 2500},
+				{Wallet: "cash", Currency: ledger.DefaultCurrency, Debit: 10000},
+			},
+			Totals: []TrialBalanceTotal{{Currency: ledger.DefaultCurrency, Debit: 10000, Credit: 10000}},
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got trial balance %+v, wanted %+v", got, want)
+		}
+
+		if !got.Balanced() {
+			t.Error("trial balance isn't balanced")
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("should raise an alert when a check finds violations, until it's stopped", func(t *testing.T) {
+		book := memory.NewInMemoryBook()
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.AddTransaction(ledger.TransactionCredit, "1", ledger.NewMoney(100, ledger.DefaultCurrency), "3333", "")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		alerts := make(chan Report, 1)
+		done := make(chan struct{})
+
+		go func() {
+			Watch(ctx, book, time.Millisecond, func(r Report) {
+				select {
+				case alerts <- r:
+				default:
+				}
+			})
+			close(done)
+		}()
+
+		select {
+		case r := <-alerts:
+			if r.OK() {
+				t.Error("got an alert with no violations")
+			}
+		case <-time.After(time.Second):
+			t.Error("no alert raised")
+		}
+
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("watch didn't stop once its context was done")
+		}
+	})
+}
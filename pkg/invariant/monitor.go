@@ -0,0 +1,27 @@
+package invariant
+
+import (
+	"context"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// Watch checks the book every interval until the context is done, raising an alert with the report of every check
+// that finds violations, alerts are raised from the goroutine Watch runs on
+func Watch(ctx context.Context, book ledger.Book, interval time.Duration, alert func(Report)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r := Check(book)
+			if !r.OK() {
+				alert(r)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package invariant
+
+import (
+	"sort"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// TrialBalanceLine is a wallet's balance in one currency, in the debit column if its debits outweigh its credits
+// and the credit column otherwise
+type TrialBalanceLine struct {
+	Wallet   string `json:"wallet"`
+	Currency string `json:"currency"`
+	Debit    int64  `json:"debit"`
+	Credit   int64  `json:"credit"`
+}
+
+// TrialBalanceTotal is the sum of each column of the trial balance in one currency
+type TrialBalanceTotal struct {
+	Currency string `json:"currency"`
+	Debit    int64  `json:"debit"`
+	Credit   int64  `json:"credit"`
+}
+
+// Balanced reports whether the debits and credits in the currency are equal
+func (t TrialBalanceTotal) Balanced() bool {
+	return t.Debit == t.Credit
+}
+
+// TrialBalance lists every wallet's balance in debit and credit columns, the columns of a book whose aggregates
+// all balance add up to the same amount in every currency
+type TrialBalance struct {
+	Lines  []TrialBalanceLine  `json:"lines"`
+	Totals []TrialBalanceTotal `json:"totals"`
+}
+
+// Balanced reports whether the debits and credits are equal in every currency
+func (tb TrialBalance) Balanced() bool {
+	for _, t := range tb.Totals {
+		if !t.Balanced() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewTrialBalance returns the trial balance of the book's transactions, lines are ordered by wallet then currency
+// and wallets with nothing left in a currency are left out, cash in counts as a credit and cash out as a debit
+func NewTrialBalance(book ledger.Book) TrialBalance {
+	type key struct {
+		wallet   string
+		currency string
+	}
+
+	nets := make(map[key]int64)
+	totals := make(map[string]*TrialBalanceTotal)
+
+	for _, t := range book.Transactions() {
+		side := ledger.Side(t.Type)
+		if side == "" {
+			continue
+		}
+
+		k := key{wallet: t.Wallet, currency: t.Currency}
+
+		if side == ledger.TransactionCredit {
+			nets[k] += t.Amount
+		} else {
+			nets[k] -= t.Amount
+		}
+
+		if _, ok := totals[t.Currency]; !ok {
+			totals[t.Currency] = &TrialBalanceTotal{Currency: t.Currency}
+		}
+	}
+
+	tb := TrialBalance{Lines: []TrialBalanceLine{}, Totals: []TrialBalanceTotal{}}
+
+	for k, net := range nets {
+		line := TrialBalanceLine{Wallet: k.wallet, Currency: k.currency}
+
+		switch {
+		case net > 0:
+			line.Credit = net
+		case net < 0:
+			line.Debit = -net
+		default:
+			continue
+		}
+
+		totals[k.currency].Debit += line.Debit
+		totals[k.currency].Credit += line.Credit
+
+		tb.Lines = append(tb.Lines, line)
+	}
+
+	sort.Slice(tb.Lines, func(i, j int) bool {
+		if tb.Lines[i].Wallet != tb.Lines[j].Wallet {
+			return tb.Lines[i].Wallet < tb.Lines[j].Wallet
+		}
+
+		return tb.Lines[i].Currency < tb.Lines[j].Currency
+	})
+
+	for _, t := range totals {
+		tb.Totals = append(tb.Totals, *t)
+	}
+
+	sort.Slice(tb.Totals, func(i, j int) bool {
+		return tb.Totals[i].Currency < tb.Totals[j].Currency
+	})
+
+	return tb
+}
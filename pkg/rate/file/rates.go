@@ -0,0 +1,68 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/rate/memory"
+)
+
+// Rates is a ledger.RateProvider loaded from a JSON file, meant for running the ledger locally
+// the file maps each currency to the rates of the currencies it can be exchanged to, e.g. {"USD": {"EUR": "0.92"}}
+type Rates struct {
+	*memory.Rates
+	name string
+}
+
+// NewFileSystemRates returns the Rates held in the named file
+func NewFileSystemRates(name string) (*Rates, error) {
+	r := &Rates{
+		Rates: memory.NewInMemoryRates(),
+		name:  name,
+	}
+
+	err := r.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload reads the file again, replacing every rate with the ones now in it
+// if the file can't be read the rates already loaded are kept
+func (r *Rates) Reload() error {
+	contents, err := ioutil.ReadFile(r.name)
+	if err != nil {
+		return fmt.Errorf("problem reading rates file %s, %v", r.name, err)
+	}
+
+	var table map[string]map[string]string
+
+	err = json.Unmarshal(contents, &table)
+	if err != nil {
+		return fmt.Errorf("problem parsing rates file %s, %v", r.name, err)
+	}
+
+	rates := make(map[string]map[string]*big.Rat)
+
+	for from, tos := range table {
+		rates[from] = make(map[string]*big.Rat)
+
+		for to, s := range tos {
+			rate, err := ledger.ParseRate(s)
+			if err != nil {
+				return fmt.Errorf("problem parsing rate from %s to %s in %s, %v", from, to, r.name, err)
+			}
+
+			rates[from][to] = rate
+		}
+	}
+
+	r.Replace(rates)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package file
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestNewFileSystemRates(t *testing.T) {
+	t.Run("should load the rates held in the file", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, `{"USD": {"EUR": "0.92", "JPY": "150.5"}}`, "rates.json")
+		defer clean()
+
+		rates, err := NewFileSystemRates(file.Name())
+		if err != nil {
+			t.Fatalf("error returned when loading rates, %v", err)
+		}
+
+		assertRate(t, rates, "USD", "JPY", big.NewRat(1505, 10))
+		assertRate(t, rates, "EUR", "USD", big.NewRat(100, 92))
+	})
+	t.Run("should return an error for a file holding an invalid rate", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, `{"USD": {"EUR": "-0.92"}}`, "rates.json")
+		defer clean()
+
+		_, err := NewFileSystemRates(file.Name())
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
+
+func TestRates_Reload(t *testing.T) {
+	t.Run("should pick up rates changed in the file", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, `{"USD": {"EUR": "0.92"}}`, "rates.json")
+		defer clean()
+
+		rates, err := NewFileSystemRates(file.Name())
+		if err != nil {
+			t.Fatalf("error returned when loading rates, %v", err)
+		}
+
+		err = ioutil.WriteFile(file.Name(), []byte(`{"USD": {"EUR": "0.95"}}`), 0644)
+		if err != nil {
+			t.Fatalf("unable to write rates file, %v", err)
+		}
+
+		err = rates.Reload()
+		if err != nil {
+			t.Fatalf("error returned when reloading rates, %v", err)
+		}
+
+		assertRate(t, rates, "USD", "EUR", big.NewRat(95, 100))
+	})
+	t.Run("should keep the loaded rates when the file can't be read", func(t *testing.T) {
+		file, clean := test.CreateTempFile(t, `{"USD": {"EUR": "0.92"}}`, "rates.json")
+		defer clean()
+
+		rates, err := NewFileSystemRates(file.Name())
+		if err != nil {
+			t.Fatalf("error returned when loading rates, %v", err)
+		}
+
+		err = ioutil.WriteFile(file.Name(), []byte(`{"USD": `), 0644)
+		if err != nil {
+			t.Fatalf("unable to write rates file, %v", err)
+		}
+
+		err = rates.Reload()
+		if err == nil {
+			t.Error("no error returned")
+		}
+
+		assertRate(t, rates, "USD", "EUR", big.NewRat(92, 100))
+	})
+}
+
+func assertRate(t *testing.T, rates *Rates, from, to string, want *big.Rat) {
+	t.Helper()
+	got, err := rates.Rate(from, to)
+	if err != nil {
+		t.Fatalf("returned error, %v", err)
+	}
+
+	if got.Cmp(want) != 0 {
+		t.Errorf("got rate %v from %s to %s, wanted %v", got, from, to, want)
+	}
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// pair identifies the rate from one currency to another
+type pair struct {
+	from string
+	to   string
+}
+
+// Rates is a ledger.RateProvider holding a fixed table of exchange rates, it is safe for concurrent use
+// a rate that isn't in the table is worked out from its inverse when that is, and a currency is always worth itself
+type Rates struct {
+	mu    sync.RWMutex
+	rates map[pair]*big.Rat
+}
+
+// NewInMemoryRates returns a Rates with no exchange rates set
+func NewInMemoryRates() *Rates {
+	return &Rates{
+		rates: make(map[pair]*big.Rat),
+	}
+}
+
+// SetRate sets how many major units of the to currency one major unit of the from currency is worth
+func (r *Rates) SetRate(from string, to string, rate *big.Rat) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rates[pair{from: from, to: to}] = new(big.Rat).Set(rate)
+}
+
+// Replace swaps every rate in the table for the given ones
+func (r *Rates) Replace(rates map[string]map[string]*big.Rat) {
+	table := make(map[pair]*big.Rat)
+
+	for from, tos := range rates {
+		for to, rate := range tos {
+			table[pair{from: from, to: to}] = new(big.Rat).Set(rate)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rates = table
+}
+
+func (r *Rates) Rate(from string, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if rate, ok := r.rates[pair{from: from, to: to}]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+
+	if rate, ok := r.rates[pair{from: to, to: from}]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+
+	return nil, &ledger.RejectedError{Subject: from + "/" + to, Rejection: ledger.RejectionNoRate, Reason: fmt.Sprintf("no exchange rate from %s to %s", from, to)}
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRates_Rate(t *testing.T) {
+	rates := NewInMemoryRates()
+	rates.SetRate("USD", "EUR", big.NewRat(92, 100))
+
+	t.Run("should return a rate that was set", func(t *testing.T) {
+		assertRate(t, rates, "USD", "EUR", big.NewRat(92, 100))
+	})
+	t.Run("should work out a rate from its inverse", func(t *testing.T) {
+		assertRate(t, rates, "EUR", "USD", big.NewRat(100, 92))
+	})
+	t.Run("should return one for a currency to itself", func(t *testing.T) {
+		assertRate(t, rates, "GBP", "GBP", big.NewRat(1, 1))
+	})
+	t.Run("should return an error for a rate that isn't known", func(t *testing.T) {
+		_, err := rates.Rate("USD", "GBP")
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should not let callers change the rates held", func(t *testing.T) {
+		rate, _ := rates.Rate("USD", "EUR")
+		rate.SetInt64(5)
+
+		assertRate(t, rates, "USD", "EUR", big.NewRat(92, 100))
+	})
+}
+
+func TestRates_Replace(t *testing.T) {
+	t.Run("should drop every rate not in the replacement", func(t *testing.T) {
+		rates := NewInMemoryRates()
+		rates.SetRate("USD", "EUR", big.NewRat(92, 100))
+
+		rates.Replace(map[string]map[string]*big.Rat{"USD": {"GBP": big.NewRat(79, 100)}})
+
+		assertRate(t, rates, "USD", "GBP", big.NewRat(79, 100))
+
+		_, err := rates.Rate("USD", "EUR")
+		if err == nil {
+			t.Error("no error returned for a replaced rate")
+		}
+	})
+}
+
+func assertRate(t *testing.T, rates *Rates, from, to string, want *big.Rat) {
+	t.Helper()
+	got, err := rates.Rate(from, to)
+	if err != nil {
+		t.Fatalf("returned error, %v", err)
+	}
+
+	if got.Cmp(want) != 0 {
+		t.Errorf("got rate %v from %s to %s, wanted %v", got, from, to, want)
+	}
+}
@@ -0,0 +1,103 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"gitlab.com/patchwell/ledger/pkg/reconcile"
+)
+
+// Store is a reconcile.Store kept in a JSON file, which is rewritten in full every time a run is recorded
+// the file is replaced by renaming a new one over it, so it always holds every run and decision or none of a run's
+type Store struct {
+	*reconcile.MemoryStore
+	name string
+	mu   sync.Mutex
+}
+
+// contents is what the file holds
+type contents struct {
+	Runs      []reconcile.Run      `json:"runs"`
+	Decisions []reconcile.Decision `json:"decisions"`
+}
+
+// NewFileSystemStore returns the Store held in the named file, which is created with the first run recorded if it doesn't exist
+func NewFileSystemStore(name string) (*Store, error) {
+	s := &Store{
+		MemoryStore: reconcile.NewMemoryStore(),
+		name:        name,
+	}
+
+	raw, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("problem reading reconciliation file %s, %v", name, err)
+	}
+
+	var c contents
+
+	err = json.Unmarshal(raw, &c)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing reconciliation file %s, %v", name, err)
+	}
+
+	if c.Runs == nil {
+		c.Runs = []reconcile.Run{}
+	}
+	if c.Decisions == nil {
+		c.Decisions = []reconcile.Decision{}
+	}
+
+	s.Replace(c.Runs, c.Decisions)
+
+	return s, nil
+}
+
+// Record writes the run and its decisions to the file, then adds them to the store
+func (s *Store) Record(run reconcile.Run, decisions []reconcile.Decision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs, _ := s.Runs()
+	recorded, _ := s.Decisions()
+
+	raw, err := json.Marshal(contents{Runs: append(runs, run), Decisions: append(recorded, decisions...)})
+	if err != nil {
+		return fmt.Errorf("problem encoding reconciliation run, %v", err)
+	}
+
+	temp := s.name + ".tmp"
+
+	err = writeFileSynced(temp, raw)
+	if err != nil {
+		return fmt.Errorf("problem writing reconciliation file %s, %v", temp, err)
+	}
+
+	err = os.Rename(temp, s.name)
+	if err != nil {
+		return fmt.Errorf("problem replacing reconciliation file %s, %v", s.name, err)
+	}
+
+	return s.MemoryStore.Record(run, decisions)
+}
+
+// writeFileSynced writes the contents to the named file and flushes it to stable storage before returning
+func writeFileSynced(name string, contents []byte) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(contents)
+	if err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
@@ -0,0 +1,57 @@
+package file
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/reconcile"
+	"gitlab.com/patchwell/ledger/pkg/test"
+)
+
+func TestNewFileSystemStore(t *testing.T) {
+	t.Run("should keep every run and decision once reopened", func(t *testing.T) {
+		f, clean := test.CreateTempFile(t, "", "reconciliation.json")
+		clean()
+		defer clean()
+
+		name := f.Name()
+
+		store, err := NewFileSystemStore(name)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		run := reconcile.Run{ID: 1, Statement: "june.csv", Lines: 1, Matched: []string{"BANK-1"}, Unmatched: []string{}, Duplicates: []string{}}
+		decisions := []reconcile.Decision{{
+			Match: reconcile.Match{
+				Line:        reconcile.Line{ID: "BANK-1", Date: "2019-06-04", Direction: reconcile.Credit, Amount: ledger.NewMoney(100, "USD")},
+				Transaction: "T1",
+				Aggregate:   "A1",
+				Wallet:      "1",
+				Rule:        reconcile.RuleAmountAndDate,
+			},
+			Run: 1,
+		}}
+
+		err = store.Record(run, decisions)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		reopened, err := NewFileSystemStore(name)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		runs, _ := reopened.Runs()
+		if !reflect.DeepEqual(runs, []reconcile.Run{run}) {
+			t.Errorf("got runs %+v, wanted %+v", runs, run)
+		}
+
+		got, _ := reopened.Decisions()
+		if !reflect.DeepEqual(got, decisions) {
+			t.Errorf("got decisions %+v, wanted %+v", got, decisions)
+		}
+	})
+}
@@ -0,0 +1,325 @@
+// Package reconcile matches the cash in and cash out of a book against the lines of bank and processor statements
+// every match is recorded as a decision in a store, so a statement reconciled again only has its unmatched lines looked at
+// and each run can be audited afterwards
+package reconcile
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
+)
+
+// DefaultDateWindow is how many days apart a line and a transaction can be booked and still be matched by amount
+const DefaultDateWindow = 3
+
+// Rule is how a line was matched to a transaction
+type Rule string
+
+const (
+	// RuleReference matches a line whose reference is the aggregate or ID of a transaction for the same amount
+	RuleReference Rule = "reference"
+	// RuleAmountAndDate matches a line to the transaction for the same amount booked closest to it within the date window
+	RuleAmountAndDate Rule = "amount_and_date"
+)
+
+// Match is a statement line matched to the ledger transaction it's for
+type Match struct {
+	Line        Line   `json:"line"`
+	Transaction string `json:"transaction"`
+	Aggregate   string `json:"aggregate"`
+	Wallet      string `json:"wallet"`
+	Rule        Rule   `json:"rule"`
+}
+
+// Decision is a match made by a run, recorded so later runs of the same source leave the line out and every run leaves the transaction out
+type Decision struct {
+	Match
+	Source    string `json:"source,omitempty"` // the source of the statement the line is on, decisions recorded without one are the run's statement's
+	Run       int    `json:"run"`
+	DecidedAt int64  `json:"decided_at"`
+}
+
+// Run records one reconciliation of a statement, by the IDs of the lines it matched, left unmatched or suspected of being duplicates
+type Run struct {
+	ID         int      `json:"id"`
+	Source     string   `json:"source,omitempty"` // the bank account or processor the statement is from
+	Statement  string   `json:"statement"`        // the name the statement was reconciled under, such as its file name
+	At         int64    `json:"at"`
+	Lines      int      `json:"lines"`
+	Skipped    int      `json:"skipped"` // lines an earlier run already matched
+	Matched    []string `json:"matched"`
+	Unmatched  []string `json:"unmatched"`
+	Duplicates []string `json:"duplicates"`
+}
+
+// Result is the outcome of reconciling a statement
+// unmatched transactions are the cash in and cash out booked within the statement's dates, give or take the date window,
+// that no line has been matched to
+type Result struct {
+	Run                   Run                    `json:"run"`
+	Matched               []Match                `json:"matched"`
+	Unmatched             []Line                 `json:"unmatched"`
+	Duplicates            []Line                 `json:"duplicates"`
+	UnmatchedTransactions []ledgerpb.Transaction `json:"unmatched_transactions"`
+}
+
+// Store keeps the runs and the decisions they made
+type Store interface {
+	Runs() ([]Run, error)
+	Decisions() ([]Decision, error)
+	// Record adds a run along with its decisions, either both are kept or neither is
+	Record(run Run, decisions []Decision) error
+}
+
+// Reconciler matches statements against a book, one run at a time
+type Reconciler struct {
+	book   ledger.Book
+	store  Store
+	window int
+	clock  func() time.Time
+	mu     sync.Mutex
+}
+
+// Option configures a Reconciler as it is created
+type Option func(*Reconciler)
+
+// WithDateWindow sets how many days apart a line and a transaction can be booked and still be matched by amount
+func WithDateWindow(days int) Option {
+	return func(r *Reconciler) {
+		r.window = days
+	}
+}
+
+// WithClock sets where the reconciler gets the time runs are recorded at, the default is time.Now
+func WithClock(clock func() time.Time) Option {
+	return func(r *Reconciler) {
+		r.clock = clock
+	}
+}
+
+// NewReconciler returns a Reconciler matching statements against the book, recording its decisions in the store
+func NewReconciler(book ledger.Book, store Store, options ...Option) *Reconciler {
+	r := &Reconciler{
+		book:   book,
+		store:  store,
+		window: DefaultDateWindow,
+		clock:  time.Now,
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	return r
+}
+
+// Reconcile matches the lines of a statement from the source against the book's cash in and cash out, lines paid into the
+// account against cash in and lines paid out of it against cash out, a line is matched by its reference first, then by amount and date
+// line IDs are only unique to the bank or processor that gave them, so lines are told apart per source: lines an earlier run
+// of the source matched and transactions any run matched are left out, and a line repeating the ID of one before it, or
+// whose content is that of a line of the source that was matched when there's nothing left for it to match, is suspected of being a duplicate
+func (r *Reconciler) Reconcile(source string, statement string, lines []Line) (Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	runs, err := r.store.Runs()
+	if err != nil {
+		return Result{}, fmt.Errorf("problem loading reconciliation runs, %v", err)
+	}
+
+	decisions, err := r.store.Decisions()
+	if err != nil {
+		return Result{}, fmt.Errorf("problem loading reconciliation decisions, %v", err)
+	}
+
+	statements := make(map[int]string)
+	for _, run := range runs {
+		statements[run.ID] = run.Statement
+	}
+
+	decided := make(map[string]bool)
+	taken := make(map[string]bool)
+	matchedContent := make(map[string]bool)
+
+	for _, d := range decisions {
+		taken[d.Transaction] = true
+
+		if firstOf(d.Source, statements[d.Run]) != source {
+			continue
+		}
+
+		decided[d.Line.ID] = true
+		matchedContent[d.Line.content()] = true
+	}
+
+	now := r.clock()
+
+	run := Run{ID: len(runs) + 1, Source: source, Statement: statement, At: now.UnixNano(), Lines: len(lines), Matched: []string{}, Unmatched: []string{}, Duplicates: []string{}}
+	result := Result{Matched: []Match{}, Unmatched: []Line{}, Duplicates: []Line{}, UnmatchedTransactions: []ledgerpb.Transaction{}}
+
+	candidates := cashTransactions(r.book.Transactions())
+	seen := make(map[string]bool)
+	newDecisions := []Decision{}
+
+	for _, l := range lines {
+		if decided[l.ID] {
+			run.Skipped++
+			continue
+		}
+
+		if seen[l.ID] {
+			run.Duplicates = append(run.Duplicates, l.ID)
+			result.Duplicates = append(result.Duplicates, l)
+			continue
+		}
+
+		seen[l.ID] = true
+
+		m, ok := r.match(l, candidates, taken)
+		if ok {
+			taken[m.Transaction] = true
+			matchedContent[l.content()] = true
+
+			run.Matched = append(run.Matched, l.ID)
+			result.Matched = append(result.Matched, m)
+			newDecisions = append(newDecisions, Decision{Match: m, Source: source, Run: run.ID, DecidedAt: now.UnixNano()})
+			continue
+		}
+
+		if matchedContent[l.content()] {
+			run.Duplicates = append(run.Duplicates, l.ID)
+			result.Duplicates = append(result.Duplicates, l)
+			continue
+		}
+
+		run.Unmatched = append(run.Unmatched, l.ID)
+		result.Unmatched = append(result.Unmatched, l)
+	}
+
+	from, to, ok := period(lines)
+	if ok {
+		from, to = from.AddDate(0, 0, -r.window), to.AddDate(0, 0, r.window)
+
+		for _, t := range candidates {
+			day, ok := bookedOn(t)
+			if ok && !taken[t.Id] && !day.Before(from) && !day.After(to) {
+				result.UnmatchedTransactions = append(result.UnmatchedTransactions, t)
+			}
+		}
+	}
+
+	err = r.store.Record(run, newDecisions)
+	if err != nil {
+		return Result{}, fmt.Errorf("problem recording reconciliation run, %v", err)
+	}
+
+	result.Run = run
+
+	return result, nil
+}
+
+// match returns the match of the line to one of the transactions not yet taken, or false if there's none
+func (r *Reconciler) match(l Line, candidates []ledgerpb.Transaction, taken map[string]bool) (Match, bool) {
+	transactionType := ledger.TransactionCashIn
+	if l.Direction == Debit {
+		transactionType = ledger.TransactionCashOut
+	}
+
+	lineDay, err := time.Parse(ledger.EffectiveDateLayout, l.Date)
+	if err != nil {
+		return Match{}, false
+	}
+
+	best := -1
+	var bestDistance time.Duration
+
+	for i, t := range candidates {
+		if taken[t.Id] || t.Type != transactionType || t.Amount != l.Amount.Amount || t.Currency != l.Amount.Currency {
+			continue
+		}
+
+		if l.Reference != "" && (l.Reference == t.Aggregate || l.Reference == t.Id) {
+			return Match{Line: l, Transaction: t.Id, Aggregate: t.Aggregate, Wallet: t.Wallet, Rule: RuleReference}, true
+		}
+
+		day, ok := bookedOn(t)
+		if !ok {
+			continue
+		}
+
+		distance := day.Sub(lineDay)
+		if distance < 0 {
+			distance = -distance
+		}
+
+		if distance > time.Duration(r.window)*24*time.Hour {
+			continue
+		}
+
+		if best == -1 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+
+	if best == -1 {
+		return Match{}, false
+	}
+
+	t := candidates[best]
+
+	return Match{Line: l, Transaction: t.Id, Aggregate: t.Aggregate, Wallet: t.Wallet, Rule: RuleAmountAndDate}, true
+}
+
+// cashTransactions returns the cash in and cash out among the transactions, in the order they were recorded
+func cashTransactions(ts []ledgerpb.Transaction) []ledgerpb.Transaction {
+	cash := []ledgerpb.Transaction{}
+
+	for _, t := range ts {
+		if t.Type == ledger.TransactionCashIn || t.Type == ledger.TransactionCashOut {
+			cash = append(cash, t)
+		}
+	}
+
+	return cash
+}
+
+// bookedOn returns the day the transaction took effect on, or the day it was recorded on when it has no effective date
+// or false when it has neither
+func bookedOn(t ledgerpb.Transaction) (time.Time, bool) {
+	if t.EffectiveDate != "" {
+		day, err := time.Parse(ledger.EffectiveDateLayout, t.EffectiveDate)
+		return day, err == nil
+	}
+
+	if t.RecordedAt != 0 {
+		at := time.Unix(0, t.RecordedAt).UTC()
+		return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC), true
+	}
+
+	return time.Time{}, false
+}
+
+// period returns the first and last days the lines were booked on, or false when there are none
+func period(lines []Line) (time.Time, time.Time, bool) {
+	var from, to time.Time
+
+	for _, l := range lines {
+		day, err := time.Parse(ledger.EffectiveDateLayout, l.Date)
+		if err != nil {
+			continue
+		}
+
+		if from.IsZero() || day.Before(from) {
+			from = day
+		}
+
+		if to.IsZero() || day.After(to) {
+			to = day
+		}
+	}
+
+	return from, to, !from.IsZero()
+}
@@ -0,0 +1,173 @@
+package reconcile
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	now := time.Date(2019, 6, 3, 12, 0, 0, 0, time.UTC)
+	book := memory.NewInMemoryBook(memory.WithClock(func() time.Time { return now }))
+
+	book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+	deposit, _ := book.DepositWalletFunds("1", ledger.NewMoney(10000, "USD"), "")
+	book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+	book.DepositWalletFunds("2", ledger.NewMoney(5000, "USD"), "")
+	withdrawal, _ := book.WithdrawWalletFunds("1", ledger.NewMoney(2000, "USD"), "")
+
+	store := NewMemoryStore()
+	reconciler := NewReconciler(book, store, WithClock(func() time.Time { return now }))
+
+	lines := []Line{
+		{ID: "BANK-1", Date: "2019-06-06", Direction: Credit, Amount: ledger.NewMoney(10000, "USD"), Reference: deposit},
+		{ID: "BANK-2", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(5000, "USD")},
+		{ID: "BANK-3", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(5000, "USD")},
+		{ID: "BANK-2", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(5000, "USD")},
+		{ID: "BANK-4", Date: "2019-06-20", Direction: Debit, Amount: ledger.NewMoney(2000, "USD")},
+		{ID: "BANK-5", Date: "2019-06-05", Direction: Credit, Amount: ledger.NewMoney(7500, "USD")},
+	}
+
+	t.Run("should match lines by reference then by amount and date, and set the rest apart", func(t *testing.T) {
+		result, err := reconciler.Reconcile("bank", "june.csv", lines)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if len(result.Matched) != 2 || result.Matched[0].Rule != RuleReference || result.Matched[0].Aggregate != deposit ||
+			result.Matched[1].Rule != RuleAmountAndDate || result.Matched[1].Wallet != "2" {
+			t.Errorf("got matches %+v, wanted BANK-1 matched by reference and BANK-2 by amount and date", result.Matched)
+		}
+
+		assertLineIDs(t, "duplicates", result.Duplicates, "BANK-3", "BANK-2")
+		assertLineIDs(t, "unmatched", result.Unmatched, "BANK-4", "BANK-5")
+
+		if len(result.UnmatchedTransactions) != 1 || result.UnmatchedTransactions[0].Aggregate != withdrawal {
+			t.Errorf("got unmatched transactions %v, wanted the withdrawal", result.UnmatchedTransactions)
+		}
+
+		if result.Run.ID != 1 || result.Run.Statement != "june.csv" || result.Run.Lines != 6 {
+			t.Errorf("got run %+v, wanted the first run of june.csv", result.Run)
+		}
+	})
+	t.Run("should only look at lines no earlier run matched", func(t *testing.T) {
+		book.OpenWallet(ledger.Wallet{ID: "3"}, "")
+		aggregate, _ := book.DepositWalletFunds("3", ledger.NewMoney(7500, "USD"), "")
+
+		result, err := reconciler.Reconcile("bank", "june.csv", lines)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if result.Run.ID != 2 || result.Run.Skipped != 3 {
+			t.Errorf("got run %+v, wanted the second run to skip the 3 lines already matched", result.Run)
+		}
+
+		if len(result.Matched) != 1 || result.Matched[0].Line.ID != "BANK-5" || result.Matched[0].Aggregate != aggregate {
+			t.Errorf("got matches %+v, wanted BANK-5 matched to the new deposit", result.Matched)
+		}
+
+		assertLineIDs(t, "unmatched", result.Unmatched, "BANK-4")
+
+		decisions, _ := store.Decisions()
+		if len(decisions) != 3 || decisions[2].Run != 2 || decisions[2].DecidedAt != now.UnixNano() {
+			t.Errorf("got decisions %+v, wanted 3 with the last made by the second run", decisions)
+		}
+	})
+}
+
+func TestReconciler_ReconcileSources(t *testing.T) {
+	now := time.Date(2019, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should match two alike payments on a statement without IDs to a transaction each", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, "USD"), "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.DepositWalletFunds("2", ledger.NewMoney(10000, "USD"), "")
+
+		lines, _ := ParseCSV(strings.NewReader("date,amount,currency\n2019-06-04,100,USD\n2019-06-04,100,USD\n"))
+
+		result, err := NewReconciler(book, NewMemoryStore()).Reconcile("bank", "june.csv", lines)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if len(result.Matched) != 2 || len(result.Duplicates) != 0 {
+			t.Errorf("got matches %+v and duplicates %+v, wanted both lines matched", result.Matched, result.Duplicates)
+		}
+	})
+	t.Run("should keep the decisions of each source apart, even for lines with the same ID", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(10000, "USD"), "")
+		book.OpenWallet(ledger.Wallet{ID: "2"}, "")
+		book.DepositWalletFunds("2", ledger.NewMoney(2500, "USD"), "")
+
+		store := NewMemoryStore()
+		reconciler := NewReconciler(book, store, WithClock(func() time.Time { return now }))
+
+		reconciler.Reconcile("bank", "june.csv", []Line{{ID: "1", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(10000, "USD")}})
+
+		result, err := reconciler.Reconcile("processor", "payouts.csv", []Line{{ID: "1", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(2500, "USD")}})
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if result.Run.Skipped != 0 || len(result.Matched) != 1 || result.Matched[0].Wallet != "2" {
+			t.Errorf("got run %+v and matches %+v, wanted the processor's line matched to the deposit into wallet 2", result.Run, result.Matched)
+		}
+
+		decisions, _ := store.Decisions()
+		if len(decisions) != 2 || decisions[0].Source != "bank" || decisions[1].Source != "processor" {
+			t.Errorf("got decisions %+v, wanted one for each source", decisions)
+		}
+	})
+	t.Run("should take decisions recorded without a source to be of their run's statement", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithClock(func() time.Time { return now }))
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		deposit, _ := book.DepositWalletFunds("1", ledger.NewMoney(10000, "USD"), "")
+
+		ts, _ := book.AggregateTransactions(deposit)
+		line := Line{ID: "BANK-1", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(10000, "USD")}
+
+		store := NewMemoryStore()
+		store.Replace(
+			[]Run{{ID: 1, Statement: "june.csv", Matched: []string{"BANK-1"}}},
+			[]Decision{{Match: Match{Line: line, Transaction: ts[0].Id, Aggregate: deposit, Wallet: "1", Rule: RuleAmountAndDate}, Run: 1}},
+		)
+
+		result, err := NewReconciler(book, store).Reconcile("june.csv", "june.csv", []Line{line})
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if result.Run.Skipped != 1 {
+			t.Errorf("got run %+v, wanted the line the earlier run matched skipped", result.Run)
+		}
+	})
+}
+
+func assertLineIDs(t *testing.T, set string, lines []Line, ids ...string) {
+	t.Helper()
+
+	got := []string{}
+	for _, l := range lines {
+		got = append(got, l.ID)
+	}
+
+	if len(got) != len(ids) {
+		t.Errorf("got %s %v, wanted %v", set, got, ids)
+		return
+	}
+
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Errorf("got %s %v, wanted %v", set, got, ids)
+			return
+		}
+	}
+}
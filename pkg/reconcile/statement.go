@@ -0,0 +1,249 @@
+package reconcile
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// Direction is which way funds moved through the bank account a statement is for
+type Direction string
+
+const (
+	// Credit is funds paid into the account, matched against cash in
+	Credit Direction = "credit"
+	// Debit is funds paid out of the account, matched against cash out
+	Debit Direction = "debit"
+)
+
+// Line is one entry of a bank or processor statement
+type Line struct {
+	ID          string       `json:"id"`   // the bank's reference for the entry, or a fingerprint of the line when it has none, unique to the statement's source
+	Date        string       `json:"date"` // the day the entry was booked, laid out like ledger.EffectiveDateLayout
+	Direction   Direction    `json:"direction"`
+	Amount      ledger.Money `json:"amount"`
+	Reference   string       `json:"reference,omitempty"` // matched against the aggregates and IDs of ledger transactions
+	Description string       `json:"description,omitempty"`
+}
+
+// content identifies what the line says happened, lines with the same content are the same payment seen twice or two
+// payments that look alike
+func (l Line) content() string {
+	return fmt.Sprintf("%s|%s|%d|%s|%s", l.Date, l.Direction, l.Amount.Amount, l.Amount.Currency, l.Reference)
+}
+
+// fingerprint returns an ID for a line the statement gave none, made of its fields and how many lines before it in the
+// statement have the same ones, so two alike payments get different IDs while reading the statement again gives the same
+func fingerprint(l Line, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d", l.content(), l.Description, occurrence)))
+
+	return fmt.Sprintf("line-%x", sum[:16])
+}
+
+// identify gives every line without an ID its fingerprint
+func identify(lines []Line) {
+	occurrences := make(map[string]int)
+
+	for i, l := range lines {
+		if l.ID != "" {
+			continue
+		}
+
+		fields := l.content() + "|" + l.Description
+
+		lines[i].ID = fingerprint(l, occurrences[fields])
+		occurrences[fields]++
+	}
+}
+
+// ParseCSV reads a statement with a header row naming its columns, date, amount and currency are required
+// and id, reference and description are optional, dates are laid out like ledger.EffectiveDateLayout and amounts are
+// decimals in major units, positive for funds paid in and negative for funds paid out
+func ParseCSV(r io.Reader) ([]Line, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("problem reading CSV statement, %v", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV statement has no header row")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"date", "amount", "currency"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV statement has no %s column", name)
+		}
+	}
+
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+
+		return strings.TrimSpace(row[i])
+	}
+
+	lines := make([]Line, 0, len(rows)-1)
+
+	for n, row := range rows[1:] {
+		l := Line{
+			ID:          field(row, "id"),
+			Date:        field(row, "date"),
+			Reference:   field(row, "reference"),
+			Description: field(row, "description"),
+		}
+
+		_, err := time.Parse(ledger.EffectiveDateLayout, l.Date)
+		if err != nil {
+			return nil, fmt.Errorf("problem reading date of CSV statement row %d, %v", n+2, err)
+		}
+
+		amount := field(row, "amount")
+		l.Direction = Credit
+
+		if strings.HasPrefix(amount, "-") {
+			amount = amount[1:]
+			l.Direction = Debit
+		}
+
+		l.Amount, err = parseAmount(amount, field(row, "currency"))
+		if err != nil {
+			return nil, fmt.Errorf("problem reading amount of CSV statement row %d, %v", n+2, err)
+		}
+
+		lines = append(lines, l)
+	}
+
+	identify(lines)
+
+	return lines, nil
+}
+
+// camtDocument is the part of an ISO 20022 camt.053 bank to customer statement the entries are read from
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	EntryReference    string `xml:"NtryRef"`
+	ServicerReference string `xml:"AcctSvcrRef"`
+	Amount            struct {
+		Value    string `xml:",chardata"`
+		Currency string `xml:"Ccy,attr"`
+	} `xml:"Amt"`
+	Indicator       string `xml:"CdtDbtInd"`
+	BookingDate     string `xml:"BookgDt>Dt"`
+	BookingDateTime string `xml:"BookgDt>DtTm"`
+	EndToEndID      string `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+	Remittance      string `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+	AdditionalInfo  string `xml:"AddtlNtryInf"`
+}
+
+// ParseCamt053 reads the entries of every statement in an ISO 20022 camt.053 document
+// an entry's ID is the bank's reference for it, and its reference is the end to end ID of its transaction
+func ParseCamt053(r io.Reader) ([]Line, error) {
+	var doc camtDocument
+
+	err := xml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("problem reading camt.053 statement, %v", err)
+	}
+
+	lines := []Line{}
+
+	for _, s := range doc.Statements {
+		for n, e := range s.Entries {
+			l := Line{
+				ID:          firstOf(e.ServicerReference, e.EntryReference),
+				Date:        firstOf(e.BookingDate, prefix(e.BookingDateTime, len(ledger.EffectiveDateLayout))),
+				Description: strings.TrimSpace(firstOf(e.Remittance, e.AdditionalInfo)),
+			}
+
+			if e.EndToEndID != "NOTPROVIDED" {
+				l.Reference = strings.TrimSpace(e.EndToEndID)
+			}
+
+			switch e.Indicator {
+			case "CRDT":
+				l.Direction = Credit
+			case "DBIT":
+				l.Direction = Debit
+			default:
+				return nil, fmt.Errorf("camt.053 entry %d has unknown credit or debit indicator '%s'", n+1, e.Indicator)
+			}
+
+			_, err := time.Parse(ledger.EffectiveDateLayout, l.Date)
+			if err != nil {
+				return nil, fmt.Errorf("problem reading booking date of camt.053 entry %d, %v", n+1, err)
+			}
+
+			l.Amount, err = parseAmount(strings.TrimSpace(e.Amount.Value), e.Amount.Currency)
+			if err != nil {
+				return nil, fmt.Errorf("problem reading amount of camt.053 entry %d, %v", n+1, err)
+			}
+
+			lines = append(lines, l)
+		}
+	}
+
+	identify(lines)
+
+	return lines, nil
+}
+
+// parseAmount reads a decimal amount in major units of the currency, it must be positive and a whole number of minor units
+func parseAmount(s string, currency string) (ledger.Money, error) {
+	if currency == "" {
+		return ledger.Money{}, fmt.Errorf("amount '%s' has no currency", s)
+	}
+
+	amount, ok := new(big.Rat).SetString(s)
+	if !ok || amount.Sign() <= 0 {
+		return ledger.Money{}, fmt.Errorf("invalid amount '%s'", s)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(ledger.CurrencyExponent(currency))), nil)
+
+	amount.Mul(amount, new(big.Rat).SetInt(scale))
+
+	if !amount.IsInt() || !amount.Num().IsInt64() {
+		return ledger.Money{}, fmt.Errorf("amount '%s' isn't a whole number of minor units of %s", s, currency)
+	}
+
+	return ledger.NewMoney(amount.Num().Int64(), currency), nil
+}
+
+// firstOf returns the first of the strings that isn't empty
+func firstOf(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+
+	return ""
+}
+
+// prefix returns the first n bytes of the string, or all of it when it's shorter
+func prefix(s string, n int) string {
+	if len(s) < n {
+		return s
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,112 @@
+package reconcile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("should read each row into a line, paid in or out by the sign of its amount", func(t *testing.T) {
+		statement := "Date,Amount,Currency,Reference,Description,ID\n" +
+			"2019-06-04,100.50,USD,AGG-1,deposit,BANK-1\n" +
+			"2019-06-05,-20,USD,,withdrawal,BANK-2\n" +
+			"2019-06-05,1500,JPY,,,BANK-3\n"
+
+		got, err := ParseCSV(strings.NewReader(statement))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		want := []Line{
+			{ID: "BANK-1", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(10050, "USD"), Reference: "AGG-1", Description: "deposit"},
+			{ID: "BANK-2", Date: "2019-06-05", Direction: Debit, Amount: ledger.NewMoney(2000, "USD"), Description: "withdrawal"},
+			{ID: "BANK-3", Date: "2019-06-05", Direction: Credit, Amount: ledger.NewMoney(1500, "JPY")},
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got lines %+v, wanted %+v", got, want)
+		}
+	})
+	t.Run("should give lines without an ID one made of their fields, different for alike lines and the same when read again", func(t *testing.T) {
+		statement := "date,amount,currency\n2019-06-04,1,USD\n2019-06-04,1,USD\n2019-06-04,2,USD\n"
+
+		got, err := ParseCSV(strings.NewReader(statement))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if got[0].ID == "" || got[0].ID == got[1].ID || got[0].ID == got[2].ID || got[1].ID == got[2].ID {
+			t.Errorf("got IDs %s, %s and %s, wanted each line to have its own", got[0].ID, got[1].ID, got[2].ID)
+		}
+
+		again, _ := ParseCSV(strings.NewReader(statement))
+		if !reflect.DeepEqual(again, got) {
+			t.Errorf("got lines %+v reading the statement again, wanted %+v", again, got)
+		}
+	})
+	t.Run("should refuse a statement it can't read every line of", func(t *testing.T) {
+		for _, statement := range []string{
+			"date,amount\n2019-06-04,1\n",
+			"date,amount,currency\n06/04/2019,1,USD\n",
+			"date,amount,currency\n2019-06-04,1.005,USD\n",
+			"date,amount,currency\n2019-06-04,0,USD\n",
+		} {
+			_, err := ParseCSV(strings.NewReader(statement))
+			if err == nil {
+				t.Errorf("no error returned for %q", statement)
+			}
+		}
+	})
+}
+
+func TestParseCamt053(t *testing.T) {
+	t.Run("should read every entry of the statement", func(t *testing.T) {
+		statement := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="EUR">250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2019-06-04</Dt></BookgDt>
+        <AcctSvcrRef>BANK-1</AcctSvcrRef>
+        <NtryDtls><TxDtls><Refs><EndToEndId>AGG-1</EndToEndId></Refs><RmtInf><Ustrd>invoice 42</Ustrd></RmtInf></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>ENTRY-2</NtryRef>
+        <Amt Ccy="EUR">10.5</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <BookgDt><DtTm>2019-06-05T10:00:00</DtTm></BookgDt>
+        <NtryDtls><TxDtls><Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs></TxDtls></NtryDtls>
+        <AddtlNtryInf>card fees</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+		got, err := ParseCamt053(strings.NewReader(statement))
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		want := []Line{
+			{ID: "BANK-1", Date: "2019-06-04", Direction: Credit, Amount: ledger.NewMoney(25000, "EUR"), Reference: "AGG-1", Description: "invoice 42"},
+			{ID: "ENTRY-2", Date: "2019-06-05", Direction: Debit, Amount: ledger.NewMoney(1050, "EUR"), Description: "card fees"},
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got lines %+v, wanted %+v", got, want)
+		}
+	})
+	t.Run("should refuse an entry that's neither a credit nor a debit", func(t *testing.T) {
+		statement := `<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy="EUR">1</Amt><CdtDbtInd>X</CdtDbtInd><BookgDt><Dt>2019-06-04</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`
+
+		_, err := ParseCamt053(strings.NewReader(statement))
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package reconcile
+
+import "sync"
+
+// MemoryStore is a Store that keeps runs and decisions in memory, they're lost when the process exits
+type MemoryStore struct {
+	mu        sync.RWMutex
+	runs      []Run
+	decisions []Decision
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		runs:      []Run{},
+		decisions: []Decision{},
+	}
+}
+
+// Runs returns every run recorded, in the order they ran
+func (s *MemoryStore) Runs() ([]Run, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]Run{}, s.runs...), nil
+}
+
+// Decisions returns every decision recorded, in the order they were made
+func (s *MemoryStore) Decisions() ([]Decision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]Decision{}, s.decisions...), nil
+}
+
+// Record adds the run and its decisions
+func (s *MemoryStore) Record(run Run, decisions []Decision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = append(s.runs, run)
+	s.decisions = append(s.decisions, decisions...)
+
+	return nil
+}
+
+// Replace swaps every run and decision for the given ones
+func (s *MemoryStore) Replace(runs []Run, decisions []Decision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = runs
+	s.decisions = decisions
+}
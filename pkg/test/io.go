@@ -3,10 +3,11 @@ package test
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
-func CreateTempFile(t *testing.T, data string, name string) (*os.File, func()) {
+func CreateTempFile(t testing.TB, data string, name string) (*os.File, func()) {
 	t.Helper()
 
 	file, err := ioutil.TempFile("", name)
@@ -18,8 +19,14 @@ func CreateTempFile(t *testing.T, data string, name string) (*os.File, func()) {
 		file.Write([]byte(data))
 	}
 
+	// also removes anything written next to the file, such as backups
 	cleanUp := func() {
+		file.Close()
 		os.Remove(file.Name())
+		siblings, _ := filepath.Glob(file.Name() + ".*")
+		for _, s := range siblings {
+			os.Remove(s)
+		}
 	}
 
 	return file, cleanUp
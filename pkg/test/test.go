@@ -1,12 +1,49 @@
 package test
 
 import (
+	"reflect"
 	"testing"
+
+	"gitlab.com/patchwell/ledger"
 )
 
-func AssertWalletBalance(t *testing.T, got, want int32) {
+// AssertWalletBalance checks the current balances of a wallet, in the order they were returned
+func AssertWalletBalance(t *testing.T, got []ledger.Balance, want []ledger.Money) {
+	t.Helper()
+	if !reflect.DeepEqual(balances(got, false), want) {
+		t.Errorf("got incorrect wallet balance, got %v, wanted %v", got, want)
+	}
+}
+
+// AssertAvailableBalance checks the available balances of a wallet, in the order they were returned
+func AssertAvailableBalance(t *testing.T, got []ledger.Balance, want []ledger.Money) {
+	t.Helper()
+	if !reflect.DeepEqual(balances(got, true), want) {
+		t.Errorf("got incorrect available wallet balance, got %v, wanted %v", got, want)
+	}
+}
+
+func balances(bs []ledger.Balance, available bool) []ledger.Money {
+	if bs == nil {
+		return nil
+	}
+
+	ms := make([]ledger.Money, len(bs))
+
+	for i, b := range bs {
+		ms[i] = b.Current
+		if available {
+			ms[i] = b.Available
+		}
+	}
+
+	return ms
+}
+
+// AssertBalance checks balances worked out without holds, such as the balance of an account
+func AssertBalance(t *testing.T, got, want []ledger.Money) {
 	t.Helper()
-	if got != want {
-		t.Errorf("got incorrect wallet balance, got %d, wanted %d", got, want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got incorrect balance, got %v, wanted %v", got, want)
 	}
 }
@@ -1,9 +1,11 @@
 package test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
+	"gitlab.com/patchwell/ledger"
 	ledgerpb "gitlab.com/patchwell/ledger/gen/api/protobuf"
 )
 
@@ -44,7 +46,7 @@ func AssertTransactionWallet(t *testing.T, transaction *ledgerpb.Transaction, wa
 	}
 }
 
-func AssertTransactionAmount(t *testing.T, transaction *ledgerpb.Transaction, want int32) {
+func AssertTransactionAmount(t *testing.T, transaction *ledgerpb.Transaction, want int64) {
 	t.Helper()
 	ta := transaction.GetAmount()
 	if ta != want {
@@ -67,3 +69,36 @@ func AssertTransactionCount(t *testing.T, transactions []*ledgerpb.Transaction,
 		t.Errorf("transaction slices have different lengths, got %d, wanted %d", tl, want)
 	}
 }
+
+// AssertRecordedTransactions compares transactions ignoring the ID, sequence number and timestamps the book gave them when they were recorded
+func AssertRecordedTransactions(t *testing.T, transactions, want []ledgerpb.Transaction) {
+	t.Helper()
+	got := make([]ledgerpb.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		got[i] = withoutRecording(transaction)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("transaction slices are different, got '%v', wanted '%v'", got, want)
+	}
+}
+
+func withoutRecording(transaction ledgerpb.Transaction) ledgerpb.Transaction {
+	transaction.Id = ""
+	transaction.Sequence = 0
+	transaction.RecordedAt = 0
+	transaction.EffectiveDate = ""
+	return transaction
+}
+
+// WalletHistory returns n cash in transactions of 1 in the default currency to the wallet, each under its own aggregate
+// for benchmarks that need a wallet with a long history without committing it one command at a time
+func WalletHistory(wallet string, n int) []ledgerpb.Transaction {
+	ts := make([]ledgerpb.Transaction, n)
+
+	for i := range ts {
+		ts[i] = ledgerpb.Transaction{Type: ledger.TransactionCashIn, Wallet: wallet, Amount: 1, Currency: ledger.DefaultCurrency, Aggregate: fmt.Sprintf("history-%d", i)}
+	}
+
+	return ts
+}
@@ -0,0 +1,488 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+const (
+	// DefaultBackoff is how long the first retry of a delivery waits, each one after waits twice as long as the last
+	DefaultBackoff = 5 * time.Second
+	// DefaultMaxBackoff is the longest a retry waits, however many attempts came before it
+	DefaultMaxBackoff = time.Hour
+	// DefaultMaxAttempts is how many times a delivery is tried before it's set aside as a dead letter
+	DefaultMaxAttempts = 10
+	// DefaultTimeout is how long an endpoint has to respond to a delivery
+	DefaultTimeout = 10 * time.Second
+	// DefaultBatchSize is how many outbox messages are taken at a time
+	DefaultBatchSize = 100
+
+	// EventHeader is the request header carrying the name of the event delivered
+	EventHeader = "X-Ledger-Event"
+	// DeliveryHeader is the request header carrying the ID of the delivery, the same on every attempt at it
+	DeliveryHeader = "X-Ledger-Delivery"
+)
+
+// Payload is the body of a delivery
+type Payload struct {
+	ID        string          `json:"id"` // the delivery's ID, a receiver seeing it twice is seeing a retry
+	Event     string          `json:"event"`
+	Sequence  uint64          `json:"sequence"` // the order the event was written to the outbox in
+	Wallets   []string        `json:"wallets,omitempty"`
+	CreatedAt int64           `json:"created_at"` // unix time in nanoseconds the command was committed
+	Data      json.RawMessage `json:"data"`       // the event
+}
+
+// Dispatcher moves messages from a book's outbox into deliveries to the webhooks that want them, and delivers them
+// webhooks are registered and dead letters redelivered through it, so nothing changes the store while it's queueing deliveries
+// or recording their results, deliveries are sent without holding its lock, so a slow endpoint doesn't hold anything else up
+type Dispatcher struct {
+	outbox      ledger.Outbox
+	store       Store
+	client      *http.Client
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+	batch       int
+	clock       func() time.Time
+	mu          sync.Mutex // serializes changes to the store
+	polling     sync.Mutex // serializes polls, so a delivery isn't tried by two at once
+}
+
+// attempt is a delivery that's due along with the webhook it's sent to
+type attempt struct {
+	webhook  Webhook
+	delivery Delivery
+}
+
+// Option configures a Dispatcher as it is created
+type Option func(*Dispatcher)
+
+// WithHTTPClient sets the client deliveries are POSTed with, the default times out after DefaultTimeout
+func WithHTTPClient(client *http.Client) Option {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// WithBackoff sets how long the first retry of a delivery waits and the longest any retry waits
+// the defaults are DefaultBackoff and DefaultMaxBackoff
+func WithBackoff(backoff time.Duration, max time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.backoff = backoff
+		d.maxBackoff = max
+	}
+}
+
+// WithMaxAttempts sets how many times a delivery is tried before it's set aside as a dead letter, the default is DefaultMaxAttempts
+func WithMaxAttempts(attempts int) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithClock sets where the dispatcher gets the time, the default is time.Now
+func WithClock(clock func() time.Time) Option {
+	return func(d *Dispatcher) {
+		d.clock = clock
+	}
+}
+
+// NewDispatcher returns a Dispatcher delivering the messages in the outbox to the webhooks in the store
+func NewDispatcher(outbox ledger.Outbox, store Store, options ...Option) *Dispatcher {
+	d := &Dispatcher{
+		outbox:      outbox,
+		store:       store,
+		client:      &http.Client{Timeout: DefaultTimeout},
+		backoff:     DefaultBackoff,
+		maxBackoff:  DefaultMaxBackoff,
+		maxAttempts: DefaultMaxAttempts,
+		batch:       DefaultBatchSize,
+		clock:       time.Now,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// Subscribe registers an endpoint to be sent the events with the given names, or every event when there are none
+// a secret is generated for the webhook when it's given none, either way it's returned so the receiver can verify deliveries
+func (d *Dispatcher) Subscribe(endpoint string, events []string, secret string) (Webhook, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Webhook{}, &ledger.ValidationError{Field: "url", Value: endpoint, Reason: "must be an absolute http or https URL"}
+	}
+
+	for _, e := range events {
+		if !ledger.KnownEvent(e) {
+			return Webhook{}, &ledger.ValidationError{Field: "events", Value: e, Reason: "isn't the name of an event"}
+		}
+	}
+
+	id, err := newID(16)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	if secret == "" {
+		secret, err = newID(32)
+		if err != nil {
+			return Webhook{}, err
+		}
+	}
+
+	w := Webhook{ID: id, URL: endpoint, Events: append([]string{}, events...), Secret: secret, CreatedAt: d.clock().UnixNano()}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err = d.store.SaveWebhook(w)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("problem saving webhook, %v", err)
+	}
+
+	return w, nil
+}
+
+// Unsubscribe deletes the webhook along with its deliveries, dead letters included
+func (d *Dispatcher) Unsubscribe(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := d.store.DeleteWebhook(id)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := d.store.Deliveries()
+	if err != nil {
+		return fmt.Errorf("problem loading deliveries, %v", err)
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.Webhook != id {
+			continue
+		}
+
+		err := d.store.DeleteDelivery(delivery.ID)
+		if err != nil {
+			return fmt.Errorf("problem deleting delivery %s, %v", delivery.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Webhooks returns every registered webhook
+func (d *Dispatcher) Webhooks() ([]Webhook, error) {
+	return d.store.Webhooks()
+}
+
+// DeadLetters returns the deliveries that ran out of attempts, in the order they were queued
+func (d *Dispatcher) DeadLetters() ([]Delivery, error) {
+	deliveries, err := d.store.Deliveries()
+	if err != nil {
+		return nil, err
+	}
+
+	dead := []Delivery{}
+	for _, delivery := range deliveries {
+		if delivery.Dead() {
+			dead = append(dead, delivery)
+		}
+	}
+
+	return dead, nil
+}
+
+// Redeliver queues a delivery to be tried again on the next poll with all its attempts, whether it's a dead letter or still being retried
+func (d *Dispatcher) Redeliver(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveries, err := d.store.Deliveries()
+	if err != nil {
+		return fmt.Errorf("problem loading deliveries, %v", err)
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.ID != id {
+			continue
+		}
+
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = d.clock().UnixNano()
+		delivery.DeadAt = 0
+
+		return d.store.SaveDeliveries(delivery)
+	}
+
+	return &NotFoundError{Kind: "delivery", ID: id}
+}
+
+// Run polls every interval until the context is done
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := d.Poll(ctx)
+			if err != nil {
+				log.Printf("problem dispatching webhooks, %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Poll queues deliveries of the messages in the outbox, then tries every delivery that's due, in the order they were queued
+// messages are only acknowledged once their deliveries are saved, so one that's in the outbox after a crash is queued again
+// under the same delivery IDs
+func (d *Dispatcher) Poll(ctx context.Context) error {
+	d.polling.Lock()
+	defer d.polling.Unlock()
+
+	due, err := d.takeDue()
+	if err != nil {
+		return err
+	}
+
+	for _, a := range due {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := d.record(a.delivery, d.deliver(ctx, a.webhook, a.delivery))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// takeDue queues the messages in the outbox then returns every delivery that's due, along with its webhook
+func (d *Dispatcher) takeDue() ([]attempt, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := d.queue()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := d.store.Deliveries()
+	if err != nil {
+		return nil, fmt.Errorf("problem loading deliveries, %v", err)
+	}
+
+	webhooks, err := d.store.Webhooks()
+	if err != nil {
+		return nil, fmt.Errorf("problem loading webhooks, %v", err)
+	}
+
+	byID := make(map[string]Webhook, len(webhooks))
+	for _, w := range webhooks {
+		byID[w.ID] = w
+	}
+
+	now := d.clock().UnixNano()
+	due := []attempt{}
+
+	for _, delivery := range deliveries {
+		w, ok := byID[delivery.Webhook]
+		if delivery.Dead() || !ok || delivery.NextAttemptAt > now {
+			continue
+		}
+
+		due = append(due, attempt{webhook: w, delivery: delivery})
+	}
+
+	return due, nil
+}
+
+// queue turns the messages in the outbox into deliveries to every webhook that wants them, then acknowledges them
+func (d *Dispatcher) queue() error {
+	for {
+		messages := d.outbox.PendingOutbox(d.batch)
+		if len(messages) == 0 {
+			return nil
+		}
+
+		webhooks, err := d.store.Webhooks()
+		if err != nil {
+			return fmt.Errorf("problem loading webhooks, %v", err)
+		}
+
+		deliveries, err := d.store.Deliveries()
+		if err != nil {
+			return fmt.Errorf("problem loading deliveries, %v", err)
+		}
+
+		queued := make(map[string]bool, len(deliveries))
+		for _, delivery := range deliveries {
+			queued[delivery.ID] = true
+		}
+
+		now := d.clock().UnixNano()
+		added := []Delivery{}
+		ids := make([]string, len(messages))
+
+		for i, m := range messages {
+			ids[i] = m.ID
+
+			for _, w := range webhooks {
+				id := m.ID + "-" + w.ID
+				if !w.Wants(m.Event) || queued[id] {
+					continue
+				}
+
+				added = append(added, Delivery{ID: id, Webhook: w.ID, Message: m, NextAttemptAt: now})
+			}
+		}
+
+		if len(added) > 0 {
+			err = d.store.SaveDeliveries(added...)
+			if err != nil {
+				return fmt.Errorf("problem saving deliveries, %v", err)
+			}
+		}
+
+		err = d.outbox.AcknowledgeOutbox(ids...)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// record saves the result of an attempt at the delivery, deleting it once it's delivered or scheduling its retry
+// a delivery unsubscribed or redelivered while it was being tried is left as it is, the result is of an attempt nobody wants anymore
+func (d *Dispatcher) record(delivery Delivery, result error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveries, err := d.store.Deliveries()
+	if err != nil {
+		return fmt.Errorf("problem loading deliveries, %v", err)
+	}
+
+	changed := true
+	for _, current := range deliveries {
+		if current.ID == delivery.ID {
+			changed = current.Attempts != delivery.Attempts || current.NextAttemptAt != delivery.NextAttemptAt || current.DeadAt != delivery.DeadAt
+			break
+		}
+	}
+
+	if changed {
+		return nil
+	}
+
+	delivery.Attempts++
+
+	if result == nil {
+		err = d.store.DeleteDelivery(delivery.ID)
+		if err != nil {
+			return fmt.Errorf("problem deleting delivery %s, %v", delivery.ID, err)
+		}
+
+		return nil
+	}
+
+	now := d.clock()
+	delivery.LastError = result.Error()
+
+	if delivery.Attempts >= d.maxAttempts {
+		delivery.DeadAt = now.UnixNano()
+	} else {
+		delivery.NextAttemptAt = now.Add(d.retryAfter(delivery.Attempts)).UnixNano()
+	}
+
+	err = d.store.SaveDeliveries(delivery)
+	if err != nil {
+		return fmt.Errorf("problem saving delivery %s, %v", delivery.ID, err)
+	}
+
+	return nil
+}
+
+// deliver POSTs the delivery to the webhook, signed with its secret, anything but a 2xx response is a failure
+func (d *Dispatcher) deliver(ctx context.Context, w Webhook, delivery Delivery) error {
+	m := delivery.Message
+
+	body, err := json.Marshal(Payload{ID: delivery.ID, Event: m.Event, Sequence: m.Sequence, Wallets: m.Wallets, CreatedAt: m.CreatedAt, Data: m.Payload})
+	if err != nil {
+		return fmt.Errorf("problem encoding delivery, %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, m.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(w.Secret, d.clock().Unix(), body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// the body is read so the connection can be reused
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded %s", res.Status)
+	}
+
+	return nil
+}
+
+// retryAfter returns how long to wait before the next attempt at a delivery tried the given number of times
+func (d *Dispatcher) retryAfter(attempts int) time.Duration {
+	wait := d.backoff
+
+	for i := 1; i < attempts && wait < d.maxBackoff; i++ {
+		wait *= 2
+	}
+
+	if wait > d.maxBackoff {
+		wait = d.maxBackoff
+	}
+
+	return wait
+}
+
+// newID returns n random bytes as hex
+func newID(n int) (string, error) {
+	b := make([]byte, n)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("error when generating ID: %v", err)
+	}
+
+	return fmt.Sprintf("%x", b), nil
+}
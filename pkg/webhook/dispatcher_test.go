@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/book/memory"
+)
+
+// endpoint records the deliveries it's sent, responding with the status it's set to
+type endpoint struct {
+	mu       sync.Mutex
+	status   int
+	received []*http.Request
+	bodies   [][]byte
+}
+
+func (e *endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.received = append(e.received, r)
+	e.bodies = append(e.bodies, body)
+	w.WriteHeader(e.status)
+}
+
+func (e *endpoint) respondWith(status int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.status = status
+}
+
+func (e *endpoint) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return len(e.received)
+}
+
+func TestDispatcher_Poll(t *testing.T) {
+	t.Run("should POST every event a webhook wants to it, signed with its secret, and empty the outbox", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithOutbox())
+		receiver := &endpoint{status: http.StatusOK}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		dispatcher := NewDispatcher(book, NewMemoryStore())
+
+		w, err := dispatcher.Subscribe(server.URL, []string{ledger.EventWalletFundsDeposited}, "")
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		deposit, _ := book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		book.WithdrawWalletFunds("1", ledger.NewMoney(400, ledger.DefaultCurrency), "")
+
+		err = dispatcher.Poll(context.Background())
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		if receiver.count() != 1 {
+			t.Fatalf("got %d deliveries, wanted only the deposit", receiver.count())
+		}
+
+		r, body := receiver.received[0], receiver.bodies[0]
+
+		err = Verify(w.Secret, r.Header.Get(SignatureHeader), body, time.Minute, time.Now())
+		if err != nil {
+			t.Errorf("got signature that doesn't verify, %v", err)
+		}
+
+		var p Payload
+		json.Unmarshal(body, &p)
+
+		var event ledger.WalletFundsDeposited
+		json.Unmarshal(p.Data, &event)
+
+		if p.Event != ledger.EventWalletFundsDeposited || r.Header.Get(EventHeader) != p.Event || r.Header.Get(DeliveryHeader) != p.ID || event.Aggregate != deposit {
+			t.Errorf("got delivery %+v of %+v, wanted the deposit", p, event)
+		}
+
+		if pending := book.PendingOutbox(0); len(pending) != 0 {
+			t.Errorf("got %d messages left in the outbox, wanted none", len(pending))
+		}
+	})
+	t.Run("should retry with exponential backoff then set the delivery aside as a dead letter", func(t *testing.T) {
+		now := time.Unix(1560000000, 0)
+		book := memory.NewInMemoryBook(memory.WithOutbox())
+		receiver := &endpoint{status: http.StatusInternalServerError}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		dispatcher := NewDispatcher(book, NewMemoryStore(), WithBackoff(time.Second, 3*time.Second), WithMaxAttempts(4), WithClock(func() time.Time { return now }))
+		dispatcher.Subscribe(server.URL, []string{ledger.EventWalletFundsDeposited}, "secret")
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		// attempts are due 1, 2 then 3 seconds after the one before, the backoff stops doubling at its maximum
+		for _, wait := range []time.Duration{0, 999 * time.Millisecond, time.Millisecond, 2 * time.Second, 3 * time.Second} {
+			now = now.Add(wait)
+			dispatcher.Poll(context.Background())
+		}
+
+		if receiver.count() != 4 {
+			t.Fatalf("got %d attempts, wanted 4", receiver.count())
+		}
+
+		dead, _ := dispatcher.DeadLetters()
+		if len(dead) != 1 || dead[0].Attempts != 4 || dead[0].DeadAt != now.UnixNano() || dead[0].LastError == "" {
+			t.Fatalf("got dead letters %+v, wanted the deposit after 4 attempts", dead)
+		}
+
+		now = now.Add(time.Hour)
+		dispatcher.Poll(context.Background())
+
+		if receiver.count() != 4 {
+			t.Errorf("got %d attempts, wanted a dead letter not to be tried again", receiver.count())
+		}
+	})
+	t.Run("should deliver a dead letter again once it's redelivered", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithOutbox())
+		receiver := &endpoint{status: http.StatusBadGateway}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		dispatcher := NewDispatcher(book, NewMemoryStore(), WithMaxAttempts(1))
+		dispatcher.Subscribe(server.URL, []string{ledger.EventWalletFundsDeposited}, "secret")
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		dispatcher.Poll(context.Background())
+
+		dead, _ := dispatcher.DeadLetters()
+		if len(dead) != 1 {
+			t.Fatalf("got dead letters %+v, wanted the deposit", dead)
+		}
+
+		receiver.respondWith(http.StatusNoContent)
+
+		err := dispatcher.Redeliver(dead[0].ID)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		dispatcher.Poll(context.Background())
+
+		if receiver.count() != 2 || receiver.received[1].Header.Get(DeliveryHeader) != dead[0].ID {
+			t.Errorf("got %d attempts, wanted the dead letter delivered again", receiver.count())
+		}
+
+		dead, _ = dispatcher.DeadLetters()
+		if len(dead) != 0 {
+			t.Errorf("got dead letters %+v, wanted none", dead)
+		}
+
+		err = dispatcher.Redeliver("unknown")
+		if _, ok := err.(*NotFoundError); !ok {
+			t.Errorf("got error %v, wanted a NotFoundError", err)
+		}
+	})
+	t.Run("should let webhooks change while delivering without bringing back the delivery of one unsubscribed", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithOutbox())
+		arrived := make(chan struct{})
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(arrived)
+			<-release
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		store := NewMemoryStore()
+		dispatcher := NewDispatcher(book, store)
+		w, _ := dispatcher.Subscribe(server.URL, []string{ledger.EventWalletFundsDeposited}, "secret")
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+
+		polled := make(chan error)
+		go func() {
+			polled <- dispatcher.Poll(context.Background())
+		}()
+
+		<-arrived
+
+		changed := make(chan error)
+		go func() {
+			changed <- dispatcher.Unsubscribe(w.ID)
+		}()
+
+		select {
+		case err := <-changed:
+			if err != nil {
+				t.Fatalf("returned error, %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("unsubscribing waited for the delivery to finish")
+		}
+
+		close(release)
+
+		err := <-polled
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		deliveries, _ := store.Deliveries()
+		if len(deliveries) != 0 {
+			t.Errorf("got deliveries %+v, wanted none once the webhook was unsubscribed", deliveries)
+		}
+	})
+}
+
+func TestDispatcher_Subscribe(t *testing.T) {
+	t.Run("should refuse an endpoint that isn't an http URL or an event it doesn't know", func(t *testing.T) {
+		dispatcher := NewDispatcher(memory.NewInMemoryBook(), NewMemoryStore())
+
+		for _, c := range []struct {
+			url    string
+			events []string
+			field  string
+		}{
+			{"ftp://example.com/hook", nil, "url"},
+			{"/hook", nil, "url"},
+			{"https://example.com/hook", []string{"wallet_funds_stolen"}, "events"},
+		} {
+			_, err := dispatcher.Subscribe(c.url, c.events, "")
+			if e, ok := err.(*ledger.ValidationError); !ok || e.Field != c.field {
+				t.Errorf("got error %v for %s %v, wanted a ValidationError of %s", err, c.url, c.events, c.field)
+			}
+		}
+	})
+	t.Run("should drop the deliveries of a webhook once it's unsubscribed", func(t *testing.T) {
+		book := memory.NewInMemoryBook(memory.WithOutbox())
+		dispatcher := NewDispatcher(book, NewMemoryStore(), WithMaxAttempts(1))
+
+		w, _ := dispatcher.Subscribe("http://127.0.0.1:0/hook", nil, "")
+
+		book.OpenWallet(ledger.Wallet{ID: "1"}, "")
+		book.DepositWalletFunds("1", ledger.NewMoney(1000, ledger.DefaultCurrency), "")
+		dispatcher.Poll(context.Background())
+
+		err := dispatcher.Unsubscribe(w.ID)
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		webhooks, _ := dispatcher.Webhooks()
+		dead, _ := dispatcher.DeadLetters()
+
+		if len(webhooks) != 0 || len(dead) != 0 {
+			t.Errorf("got webhooks %+v and dead letters %+v, wanted none", webhooks, dead)
+		}
+	})
+}
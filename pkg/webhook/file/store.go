@@ -0,0 +1,140 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"gitlab.com/patchwell/ledger/pkg/webhook"
+)
+
+// Store is a webhook.Store kept in a JSON file, which is rewritten in full on every change
+// the file is replaced by renaming a new one over it, so a change is either all in it or not at all
+type Store struct {
+	*webhook.MemoryStore
+	name string
+	mu   sync.Mutex
+}
+
+// contents is what the file holds
+type contents struct {
+	Webhooks   []webhook.Webhook  `json:"webhooks"`
+	Deliveries []webhook.Delivery `json:"deliveries"`
+}
+
+// NewFileSystemStore returns the Store held in the named file, which is created with the first change if it doesn't exist
+func NewFileSystemStore(name string) (*Store, error) {
+	s := &Store{
+		MemoryStore: webhook.NewMemoryStore(),
+		name:        name,
+	}
+
+	raw, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("problem reading webhook file %s, %v", name, err)
+	}
+
+	var c contents
+
+	err = json.Unmarshal(raw, &c)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing webhook file %s, %v", name, err)
+	}
+
+	if c.Webhooks == nil {
+		c.Webhooks = []webhook.Webhook{}
+	}
+	if c.Deliveries == nil {
+		c.Deliveries = []webhook.Delivery{}
+	}
+
+	s.Replace(c.Webhooks, c.Deliveries)
+
+	return s, nil
+}
+
+func (s *Store) SaveWebhook(w webhook.Webhook) error {
+	return s.update(func(next *webhook.MemoryStore) error {
+		return next.SaveWebhook(w)
+	})
+}
+
+func (s *Store) DeleteWebhook(id string) error {
+	return s.update(func(next *webhook.MemoryStore) error {
+		return next.DeleteWebhook(id)
+	})
+}
+
+func (s *Store) SaveDeliveries(deliveries ...webhook.Delivery) error {
+	return s.update(func(next *webhook.MemoryStore) error {
+		return next.SaveDeliveries(deliveries...)
+	})
+}
+
+func (s *Store) DeleteDelivery(id string) error {
+	return s.update(func(next *webhook.MemoryStore) error {
+		return next.DeleteDelivery(id)
+	})
+}
+
+// update applies the change to a copy of the store, writes the copy to the file, then has the store take it on
+func (s *Store) update(change func(next *webhook.MemoryStore) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhooks, _ := s.Webhooks()
+	deliveries, _ := s.Deliveries()
+
+	next := webhook.NewMemoryStore()
+	next.Replace(webhooks, deliveries)
+
+	err := change(next)
+	if err != nil {
+		return err
+	}
+
+	webhooks, _ = next.Webhooks()
+	deliveries, _ = next.Deliveries()
+
+	raw, err := json.Marshal(contents{Webhooks: webhooks, Deliveries: deliveries})
+	if err != nil {
+		return fmt.Errorf("problem encoding webhooks, %v", err)
+	}
+
+	temp := s.name + ".tmp"
+
+	err = writeFileSynced(temp, raw)
+	if err != nil {
+		return fmt.Errorf("problem writing webhook file %s, %v", temp, err)
+	}
+
+	err = os.Rename(temp, s.name)
+	if err != nil {
+		return fmt.Errorf("problem replacing webhook file %s, %v", s.name, err)
+	}
+
+	s.Replace(webhooks, deliveries)
+
+	return nil
+}
+
+// writeFileSynced writes the contents to the named file and flushes it to stable storage before returning
+func writeFileSynced(name string, contents []byte) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(contents)
+	if err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
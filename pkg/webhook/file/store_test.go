@@ -0,0 +1,69 @@
+package file
+
+import (
+	"reflect"
+	"testing"
+
+	"gitlab.com/patchwell/ledger"
+	"gitlab.com/patchwell/ledger/pkg/test"
+	"gitlab.com/patchwell/ledger/pkg/webhook"
+)
+
+func TestNewFileSystemStore(t *testing.T) {
+	t.Run("should keep webhooks and deliveries once reopened", func(t *testing.T) {
+		f, clean := test.CreateTempFile(t, "", "webhooks.json")
+		clean()
+		defer clean()
+
+		store, err := NewFileSystemStore(f.Name())
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		kept := webhook.Webhook{ID: "W1", URL: "https://example.com/hook", Events: []string{ledger.EventWalletFundsDeposited}, Secret: "secret"}
+		deleted := webhook.Webhook{ID: "W2", URL: "https://example.com/other"}
+		delivery := webhook.Delivery{ID: "M1-W1", Webhook: "W1", Message: ledger.OutboxMessage{ID: "M1", Sequence: 1, Event: ledger.EventWalletFundsDeposited, Wallets: []string{"1"}, Payload: []byte(`{"wallet":"1"}`)}}
+
+		store.SaveWebhook(kept)
+		store.SaveWebhook(deleted)
+		store.DeleteWebhook(deleted.ID)
+		store.SaveDeliveries(delivery, webhook.Delivery{ID: "M2-W1", Webhook: "W1"})
+		store.DeleteDelivery("M2-W1")
+
+		delivery.Attempts = 2
+		store.SaveDeliveries(delivery)
+
+		reopened, err := NewFileSystemStore(f.Name())
+		if err != nil {
+			t.Fatalf("returned error, %v", err)
+		}
+
+		webhooks, _ := reopened.Webhooks()
+		if !reflect.DeepEqual(webhooks, []webhook.Webhook{kept}) {
+			t.Errorf("got webhooks %+v, wanted %+v", webhooks, kept)
+		}
+
+		deliveries, _ := reopened.Deliveries()
+		if !reflect.DeepEqual(deliveries, []webhook.Delivery{delivery}) {
+			t.Errorf("got deliveries %+v, wanted %+v", deliveries, delivery)
+		}
+	})
+	t.Run("should leave the file as it was when a change fails", func(t *testing.T) {
+		f, clean := test.CreateTempFile(t, "", "webhooks.json")
+		clean()
+		defer clean()
+
+		store, _ := NewFileSystemStore(f.Name())
+		store.SaveWebhook(webhook.Webhook{ID: "W1"})
+
+		err := store.DeleteWebhook("unknown")
+		if err == nil {
+			t.Error("no error returned")
+		}
+
+		reopened, _ := NewFileSystemStore(f.Name())
+		if webhooks, _ := reopened.Webhooks(); len(webhooks) != 1 {
+			t.Errorf("got webhooks %+v, wanted the one saved", webhooks)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the request header a delivery's signature is sent in, laid out as t=<unix seconds>,v1=<hex HMAC-SHA256>
+// the HMAC is of the timestamp, a dot and the request body, keyed with the webhook's secret
+const SignatureHeader = "X-Ledger-Signature"
+
+// Sign returns the signature of a body sent at the given unix time in seconds, as it's sent in the SignatureHeader
+func Sign(secret string, timestamp int64, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac(secret, timestamp, body)))
+}
+
+// Verify checks the signature was made with the secret for the body, and if tolerance isn't zero
+// that it was made no more than tolerance from now, so a delivery captured on the way can't be replayed later
+// receivers should use it with the body exactly as it was received
+func Verify(secret string, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signed []byte
+
+	for _, part := range strings.Split(signature, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			t, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid signature timestamp '%s'", kv[1])
+			}
+
+			timestamp = t
+		case "v1":
+			s, err := hex.DecodeString(kv[1])
+			if err != nil {
+				return fmt.Errorf("invalid signature '%s'", kv[1])
+			}
+
+			signed = s
+		}
+	}
+
+	if timestamp == 0 || signed == nil {
+		return errors.New("signature has no timestamp or no v1 signature")
+	}
+
+	if !hmac.Equal(signed, mac(secret, timestamp, body)) {
+		return errors.New("signature doesn't match the body")
+	}
+
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(timestamp, 0))
+		if age > tolerance || age < -tolerance {
+			return fmt.Errorf("signature was made at %v, more than %v from now", time.Unix(timestamp, 0).UTC(), tolerance)
+		}
+	}
+
+	return nil
+}
+
+func mac(secret string, timestamp int64, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(h, "%d.", timestamp)
+	h.Write(body)
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"wallet_funds_deposited"}`)
+	now := time.Unix(1560000000, 0)
+	signature := Sign("secret", now.Unix(), body)
+
+	t.Run("should accept a signature made with the secret for the body", func(t *testing.T) {
+		err := Verify("secret", signature, body, 5*time.Minute, now.Add(time.Minute))
+		if err != nil {
+			t.Errorf("returned error, %v", err)
+		}
+	})
+	t.Run("should refuse a signature for another body or made with another secret", func(t *testing.T) {
+		err := Verify("secret", signature, []byte(`{"event":"wallet_funds_withdrawn"}`), 0, now)
+		if err == nil {
+			t.Error("no error returned for a tampered body")
+		}
+
+		err = Verify("other", signature, body, 0, now)
+		if err == nil {
+			t.Error("no error returned for another secret")
+		}
+	})
+	t.Run("should refuse a signature made too long ago", func(t *testing.T) {
+		err := Verify("secret", signature, body, 5*time.Minute, now.Add(time.Hour))
+		if err == nil {
+			t.Error("no error returned")
+		}
+	})
+	t.Run("should refuse a signature it can't read", func(t *testing.T) {
+		for _, s := range []string{"", "t=1560000000", "v1=abc", "t=x,v1=00", "t=1560000000,v1=zz"} {
+			err := Verify("secret", s, body, 0, now)
+			if err == nil {
+				t.Errorf("no error returned for %q", s)
+			}
+		}
+	})
+}
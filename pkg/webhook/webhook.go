@@ -0,0 +1,179 @@
+// Package webhook forwards the events in a book's outbox to the endpoints registered for them, as signed JSON POSTs
+// an event is taken out of the outbox once a delivery of it has been queued for every webhook that wants it, and a delivery
+// is retried with exponential backoff until the endpoint accepts it or it runs out of attempts and is set aside as a dead letter
+// dead letters are kept until they're redelivered by hand or their webhook is deleted
+package webhook
+
+import (
+	"fmt"
+	"sync"
+
+	"gitlab.com/patchwell/ledger"
+)
+
+// Webhook is an endpoint events are POSTed to
+type Webhook struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events,omitempty"` // names of the events it's sent, every event when there are none
+	Secret    string   `json:"secret"`           // the key its deliveries are signed with
+	CreatedAt int64    `json:"created_at"`       // unix time in nanoseconds it was registered at
+}
+
+// Wants reports whether the webhook is sent the event with the given name
+func (w Webhook) Wants(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delivery is an outbox message on its way to a webhook
+type Delivery struct {
+	ID            string               `json:"id"`
+	Webhook       string               `json:"webhook"`
+	Message       ledger.OutboxMessage `json:"message"`
+	Attempts      int                  `json:"attempts"`
+	NextAttemptAt int64                `json:"next_attempt_at"` // unix time in nanoseconds it's due to be tried again at
+	LastError     string               `json:"last_error,omitempty"`
+	DeadAt        int64                `json:"dead_at,omitempty"` // unix time in nanoseconds it was given up on, zero while it's still being tried
+}
+
+// Dead reports whether the delivery has been given up on and set aside as a dead letter
+func (d Delivery) Dead() bool {
+	return d.DeadAt != 0
+}
+
+// NotFoundError is returned for a webhook or delivery that doesn't exist
+type NotFoundError struct {
+	Kind string // webhook or delivery
+	ID   string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no %s (%s)", e.Kind, e.ID)
+}
+
+// Store keeps the registered webhooks and the deliveries that haven't been accepted yet, dead letters included
+type Store interface {
+	Webhooks() ([]Webhook, error)
+	// SaveWebhook adds the webhook, or replaces the one with its ID
+	SaveWebhook(w Webhook) error
+	DeleteWebhook(id string) error
+	// Deliveries returns every delivery still held, in the order they were first saved
+	Deliveries() ([]Delivery, error)
+	// SaveDeliveries adds the deliveries, or replaces the ones with their IDs, either all of them are saved or none are
+	SaveDeliveries(deliveries ...Delivery) error
+	DeleteDelivery(id string) error
+}
+
+// MemoryStore is a Store that keeps webhooks and deliveries in memory, they're lost when the process exits
+type MemoryStore struct {
+	mu         sync.RWMutex
+	webhooks   []Webhook
+	deliveries []Delivery
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		webhooks:   []Webhook{},
+		deliveries: []Delivery{},
+	}
+}
+
+// Webhooks returns every webhook, in the order they were registered
+func (s *MemoryStore) Webhooks() ([]Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]Webhook{}, s.webhooks...), nil
+}
+
+func (s *MemoryStore) SaveWebhook(w Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.webhooks {
+		if s.webhooks[i].ID == w.ID {
+			s.webhooks[i] = w
+			return nil
+		}
+	}
+
+	s.webhooks = append(s.webhooks, w)
+
+	return nil
+}
+
+func (s *MemoryStore) DeleteWebhook(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.webhooks {
+		if s.webhooks[i].ID == id {
+			s.webhooks = append(s.webhooks[:i:i], s.webhooks[i+1:]...)
+			return nil
+		}
+	}
+
+	return &NotFoundError{Kind: "webhook", ID: id}
+}
+
+func (s *MemoryStore) Deliveries() ([]Delivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]Delivery{}, s.deliveries...), nil
+}
+
+func (s *MemoryStore) SaveDeliveries(deliveries ...Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	positions := make(map[string]int, len(s.deliveries))
+	for i, d := range s.deliveries {
+		positions[d.ID] = i
+	}
+
+	for _, d := range deliveries {
+		if i, ok := positions[d.ID]; ok {
+			s.deliveries[i] = d
+			continue
+		}
+
+		positions[d.ID] = len(s.deliveries)
+		s.deliveries = append(s.deliveries, d)
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) DeleteDelivery(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.deliveries {
+		if s.deliveries[i].ID == id {
+			s.deliveries = append(s.deliveries[:i:i], s.deliveries[i+1:]...)
+			return nil
+		}
+	}
+
+	return &NotFoundError{Kind: "delivery", ID: id}
+}
+
+// Replace swaps every webhook and delivery for the given ones
+func (s *MemoryStore) Replace(webhooks []Webhook, deliveries []Delivery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks = webhooks
+	s.deliveries = deliveries
+}
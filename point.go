@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"fmt"
+	"time"
+)
+
+// Point is a place in the book's history, either a time or the sequence number of a transaction
+// the zero value is no point at all, queries treat it as the start or the end of the history
+type Point struct {
+	Time     time.Time
+	Sequence uint64
+}
+
+// AtTime is the point in the book's history at the given time, it takes in every transaction recorded by then
+func AtTime(t time.Time) Point {
+	return Point{Time: t}
+}
+
+// AtSequence is the point in the book's history just after the transaction with the sequence number was recorded
+func AtSequence(sequence uint64) Point {
+	return Point{Sequence: sequence}
+}
+
+// IsZero reports whether the point is neither a time nor a sequence number
+func (p Point) IsZero() bool {
+	return p.Time.IsZero() && p.Sequence == 0
+}
+
+// Validate returns an error if the point is both a time and a sequence number
+func (p Point) Validate() error {
+	if !p.Time.IsZero() && p.Sequence != 0 {
+		return fmt.Errorf("point can be a time or a sequence number, not both")
+	}
+
+	return nil
+}
+
+// String describes the point, e.g. "sequence 42" or "2019-03-31T23:59:59Z"
+func (p Point) String() string {
+	if p.Sequence != 0 {
+		return fmt.Sprintf("sequence %d", p.Sequence)
+	}
+
+	return p.Time.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,42 @@
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RateProvider looks up exchange rates for transfers between wallets in different currencies
+type RateProvider interface {
+	// Rate returns how many major units of the to currency one major unit of the from currency is worth
+	Rate(from string, to string) (*big.Rat, error)
+}
+
+// maxRateDigits is the most decimal places a rate is written with before falling back to a fraction
+const maxRateDigits = 18
+
+// FormatRate writes a rate as a decimal, e.g. "0.92", or as an exact fraction, e.g. "25/23", when no short decimal is exact
+func FormatRate(rate *big.Rat) string {
+	for digits := 0; digits <= maxRateDigits; digits++ {
+		s := rate.FloatString(digits)
+
+		if r, ok := new(big.Rat).SetString(s); ok && r.Cmp(rate) == 0 {
+			return s
+		}
+	}
+
+	return rate.RatString()
+}
+
+// ParseRate reads a rate written by FormatRate, it must be greater than zero
+func ParseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid rate '%s'", s)
+	}
+
+	if rate.Sign() <= 0 {
+		return nil, fmt.Errorf("rate '%s' must be greater than zero", s)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatRate(t *testing.T) {
+	cases := []struct {
+		rate *big.Rat
+		want string
+	}{
+		{big.NewRat(92, 100), "0.92"},
+		{big.NewRat(3, 1), "3"},
+		{big.NewRat(25, 23), "25/23"},
+	}
+
+	for _, c := range cases {
+		t.Run("should write "+c.want, func(t *testing.T) {
+			got := FormatRate(c.rate)
+			if got != c.want {
+				t.Errorf("got '%s', wanted '%s'", got, c.want)
+			}
+
+			parsed, err := ParseRate(got)
+			if err != nil {
+				t.Fatalf("returned error parsing rate back, %v", err)
+			}
+
+			if parsed.Cmp(c.rate) != 0 {
+				t.Errorf("rate didn't survive being written and parsed, got %v, wanted %v", parsed, c.rate)
+			}
+		})
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	for _, s := range []string{"", "abc", "0", "-1.5"} {
+		t.Run("should return an error for '"+s+"'", func(t *testing.T) {
+			_, err := ParseRate(s)
+			if err == nil {
+				t.Error("no error returned")
+			}
+		})
+	}
+}
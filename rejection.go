@@ -0,0 +1,47 @@
+package ledger
+
+import "fmt"
+
+// Rejection names what about the state of the book kept a command from being carried out
+type Rejection string
+
+const (
+	RejectionAlreadyReversed  Rejection = "already_reversed"
+	RejectionPartlyRefunded   Rejection = "partly_refunded"
+	RejectionRefundExceeded   Rejection = "refund_exceeded"
+	RejectionNotCompensable   Rejection = "not_compensable"
+	RejectionCurrencyMismatch Rejection = "currency_mismatch"
+	RejectionHoldExpired      Rejection = "hold_expired"
+	RejectionHoldSettled      Rejection = "hold_settled"
+	RejectionCaptureExceeded  Rejection = "capture_exceeded"
+	RejectionWalletOpen       Rejection = "wallet_open"
+	RejectionWalletClosed     Rejection = "wallet_closed"
+	RejectionWalletNotEmpty   Rejection = "wallet_not_empty"
+	RejectionAccountInUse     Rejection = "account_in_use"
+	RejectionChartConflict    Rejection = "chart_conflict"
+	RejectionNoRateProvider   Rejection = "no_rate_provider"
+	RejectionNoRate           Rejection = "no_rate"
+	RejectionNoFeeWallet      Rejection = "no_fee_wallet"
+)
+
+// RejectedError is returned when the state of the book doesn't allow a command, such as reversing an aggregate twice
+// the command fails the same way until something else changes the book, so retrying it as it is doesn't help
+type RejectedError struct {
+	Subject   string // the wallet, aggregate, hold, account or currency pair the command was refused for
+	Rejection Rejection
+	Reason    string
+}
+
+func (e *RejectedError) Error() string {
+	return e.Reason
+}
+
+// NotFoundError is returned when a command names a wallet, aggregate, hold or account the book doesn't have
+type NotFoundError struct {
+	Kind string // wallet, aggregate, hold or account
+	ID   string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no %s (%s)", e.Kind, e.ID)
+}
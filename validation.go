@@ -0,0 +1,81 @@
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ValidationError is returned when a command is given input that could never be recorded, such as an unknown transaction type
+// it's returned before anything is looked up in the book, so the same input fails the same way on every backend
+type ValidationError struct {
+	Field  string // the input that was rejected, such as "type", "amount" or "wallet"
+	Value  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s '%s', %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidateTransaction returns a ValidationError for the first part of a single transaction that can't be added to a book
+func ValidateTransaction(transactionType string, wallet string, amount Money, aggregate string) error {
+	err := ValidateTransactionType(transactionType)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateID("wallet", wallet)
+	if err != nil {
+		return err
+	}
+
+	err = ValidateAmount(amount)
+	if err != nil {
+		return err
+	}
+
+	return ValidateID("aggregate", aggregate)
+}
+
+// ValidateTransactionType returns a ValidationError unless a transaction of the type can be added on its own
+// holds and releases aren't, they're only ever posted by the hold commands
+func ValidateTransactionType(transactionType string) error {
+	switch transactionType {
+	case TransactionCredit, TransactionDebit, TransactionCashIn, TransactionCashOut:
+		return nil
+	}
+
+	return &ValidationError{Field: "type", Value: transactionType, Reason: "must be one of credit, debit, cash in or cash out"}
+}
+
+// ValidateAmount returns a ValidationError unless the amount is greater than zero and in a currency
+func ValidateAmount(amount Money) error {
+	if amount.Amount <= 0 {
+		return &ValidationError{Field: "amount", Value: strconv.FormatInt(amount.Amount, 10), Reason: "must be greater than zero"}
+	}
+
+	if amount.Currency == "" {
+		return &ValidationError{Field: "currency", Value: amount.Currency, Reason: "must be given"}
+	}
+
+	return nil
+}
+
+// ValidateID returns a ValidationError unless the identifier is given, has no whitespace around it and no control characters
+func ValidateID(field string, id string) error {
+	if id == "" {
+		return &ValidationError{Field: field, Value: id, Reason: "must be given"}
+	}
+
+	if strings.TrimSpace(id) != id {
+		return &ValidationError{Field: field, Value: id, Reason: "can't start or end with whitespace"}
+	}
+
+	if strings.IndexFunc(id, unicode.IsControl) >= 0 {
+		return &ValidationError{Field: field, Value: id, Reason: "can't hold control characters"}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package ledger
+
+import "testing"
+
+func TestValidateTransaction(t *testing.T) {
+	usd := NewMoney(100, "USD")
+
+	cases := []struct {
+		name            string
+		transactionType string
+		wallet          string
+		amount          Money
+		aggregate       string
+		field           string
+	}{
+		{"a credit", TransactionCredit, "1", usd, "1111", ""},
+		{"a cash out", TransactionCashOut, "1", usd, "1111", ""},
+		{"an unknown type", "invalid", "1", usd, "1111", "type"},
+		{"a hold added on its own", TransactionHold, "1", usd, "1111", "type"},
+		{"no wallet", TransactionCredit, "", usd, "1111", "wallet"},
+		{"a wallet padded with whitespace", TransactionCredit, " 1", usd, "1111", "wallet"},
+		{"a wallet with a control character", TransactionCredit, "1\n", usd, "1111", "wallet"},
+		{"a zero amount", TransactionCredit, "1", NewMoney(0, "USD"), "1111", "amount"},
+		{"a negative amount", TransactionDebit, "1", NewMoney(-100, "USD"), "1111", "amount"},
+		{"no currency", TransactionDebit, "1", NewMoney(100, ""), "1111", "currency"},
+		{"no aggregate", TransactionCashIn, "1", usd, "", "aggregate"},
+	}
+
+	for _, c := range cases {
+		t.Run("should check "+c.name, func(t *testing.T) {
+			err := ValidateTransaction(c.transactionType, c.wallet, c.amount, c.aggregate)
+
+			if c.field == "" {
+				if err != nil {
+					t.Errorf("returned error, %v", err)
+				}
+				return
+			}
+
+			e, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("got error %v, wanted a ValidationError", err)
+			}
+
+			if e.Field != c.field {
+				t.Errorf("got error for field '%s', wanted '%s'", e.Field, c.field)
+			}
+		})
+	}
+}
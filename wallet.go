@@ -0,0 +1,48 @@
+package ledger
+
+import "fmt"
+
+// WalletStatus is where a wallet is in its lifecycle, which decides what can be posted to it
+type WalletStatus string
+
+const (
+	// WalletActive takes any transaction
+	WalletActive WalletStatus = "active"
+	// WalletFrozen takes funds in but doesn't let any out, until it's made active again
+	WalletFrozen WalletStatus = "frozen"
+	// WalletClosed takes no transactions at all, a wallet can only be closed once it's empty and it can't be reopened
+	WalletClosed WalletStatus = "closed"
+)
+
+// Wallet is a wallet opened in the book, wallets that were only ever posted to are active and have no owner or metadata
+type Wallet struct {
+	ID       string            `json:"id"`
+	Owner    string            `json:"owner"`
+	Tier     string            `json:"tier,omitempty"` // the tier whose limits apply to the wallet, unless it has limits of its own
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Status   WalletStatus      `json:"status"`
+}
+
+// Validate returns an error if the status isn't a known one
+func (s WalletStatus) Validate() error {
+	switch s {
+	case WalletActive, WalletFrozen, WalletClosed:
+		return nil
+	default:
+		return fmt.Errorf("unknown wallet status '%s'", s)
+	}
+}
+
+// WalletStatusError is returned when a transaction is posted to a wallet whose status doesn't allow it
+type WalletStatusError struct {
+	Wallet string
+	Status WalletStatus
+}
+
+func (e *WalletStatusError) Error() string {
+	if e.Status == WalletFrozen {
+		return fmt.Sprintf("wallet '%s' is frozen, no funds can be taken out of it", e.Wallet)
+	}
+
+	return fmt.Sprintf("wallet '%s' is %s, nothing can be posted to it", e.Wallet, e.Status)
+}